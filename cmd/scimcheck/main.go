@@ -0,0 +1,614 @@
+// Command scimcheck runs a SCIM 2.0 compliance suite against a running
+// server. It provisions, reads, filters, patches and deletes users and
+// groups the way Entra ID and other provisioning clients do, and checks the
+// responses against RFC 7643 and RFC 7644.
+//
+// Usage:
+//
+//	SCIM_TOKEN=itsm_... go run ./cmd/scimcheck -url http://localhost:8080/scim/v2
+//
+// The token must be an API key of a service principal holding the scim.*
+// permissions. Every resource the suite creates is deleted again.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"yet-another-itsm/internal/constants"
+)
+
+// resource is a decoded SCIM response body.
+type resource map[string]interface{}
+
+func (r resource) str(key string) string {
+	value, _ := r[key].(string)
+	return value
+}
+
+func (r resource) list(key string) []interface{} {
+	value, _ := r[key].([]interface{})
+	return value
+}
+
+func (r resource) number(key string) int {
+	value, _ := r[key].(float64)
+	return int(value)
+}
+
+type suite struct {
+	client  *http.Client
+	baseURL string
+	token   string
+	passed  int
+	failed  int
+}
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:8080/scim/v2", "SCIM base URL")
+	flag.Parse()
+
+	token := os.Getenv("SCIM_TOKEN")
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "SCIM_TOKEN must hold an API key with the scim.* permissions")
+		os.Exit(2)
+	}
+
+	s := &suite{
+		client:  &http.Client{Timeout: 30 * time.Second},
+		baseURL: strings.TrimSuffix(*baseURL, "/"),
+		token:   token,
+	}
+	s.run()
+
+	fmt.Printf("\n%d passed, %d failed\n", s.passed, s.failed)
+	if s.failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func (s *suite) run() {
+	s.checkDiscovery()
+
+	s.check("requests without a token are rejected", func() error {
+		status, body, err := s.request(http.MethodGet, "/Users", nil, false)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusUnauthorized, "")
+	})
+
+	suffix := uuid.NewString()[:8]
+	userName := "scimcheck." + suffix + "@example.com"
+	var userID, otherUserID, groupID string
+
+	if !s.check("create user", func() error {
+		created, err := s.expect(http.MethodPost, "/Users", newUser(userName, uuid.NewString()), http.StatusCreated)
+		if err != nil {
+			return err
+		}
+		userID = created.str("id")
+		switch {
+		case userID == "":
+			return errors.New("id is missing")
+		case created.str("userName") != userName:
+			return fmt.Errorf("userName is %q", created.str("userName"))
+		case created["active"] != true:
+			return fmt.Errorf("active is %v", created["active"])
+		}
+		return expectMeta(created, "User", s.baseURL+"/Users/"+userID)
+	}) {
+		return
+	}
+	defer s.cleanup("/Users/" + userID)
+
+	s.check("create user with a taken userName conflicts", func() error {
+		status, body, err := s.request(http.MethodPost, "/Users", newUser(strings.ToUpper(userName), ""), true)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusConflict, constants.SCIMTypeUniqueness)
+	})
+
+	s.check("get user", func() error {
+		user, err := s.expect(http.MethodGet, "/Users/"+userID, nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if user.str("userName") != userName {
+			return fmt.Errorf("userName is %q", user.str("userName"))
+		}
+		return nil
+	})
+
+	s.check("get unknown user returns 404", func() error {
+		status, body, err := s.request(http.MethodGet, "/Users/"+uuid.NewString(), nil, true)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusNotFound, "")
+	})
+
+	s.check("filter users by userName", func() error {
+		return s.expectTotal("/Users?filter="+url.QueryEscape(`userName eq "`+userName+`"`), 1)
+	})
+
+	s.check("filter users by unknown userName returns none", func() error {
+		return s.expectTotal("/Users?filter="+url.QueryEscape(`userName eq "nobody.`+suffix+`@example.com"`), 0)
+	})
+
+	s.check("unsupported filter is rejected", func() error {
+		status, body, err := s.request(http.MethodGet, "/Users?filter="+url.QueryEscape(`userName co "scim"`), nil, true)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusBadRequest, constants.SCIMTypeInvalidFilter)
+	})
+
+	s.check("list users honours count", func() error {
+		list, err := s.expect(http.MethodGet, "/Users?startIndex=1&count=1", nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if len(list.list("Resources")) > 1 || list.number("itemsPerPage") > 1 {
+			return fmt.Errorf("got %d resources for count=1", len(list.list("Resources")))
+		}
+		return nil
+	})
+
+	s.check("replace user", func() error {
+		replacement := newUser(userName, "")
+		replacement["displayName"] = "Replaced " + suffix
+		replacement["title"] = "Analyst"
+		user, err := s.expect(http.MethodPut, "/Users/"+userID, replacement, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if user.str("displayName") != "Replaced "+suffix || user.str("title") != "Analyst" {
+			return fmt.Errorf("displayName %q, title %q", user.str("displayName"), user.str("title"))
+		}
+		return nil
+	})
+
+	s.check("patch user attributes", func() error {
+		user, err := s.expect(http.MethodPatch, "/Users/"+userID, patch(
+			operation("replace", "displayName", "Patched "+suffix),
+			operation("replace", "name.givenName", "Patched"),
+			operation("add", constants.SCIMSchemaEnterpriseUser+":department", "SCIM Check"),
+		), http.StatusOK)
+		if err != nil {
+			return err
+		}
+		name, _ := user["name"].(map[string]interface{})
+		enterprise, _ := user[constants.SCIMSchemaEnterpriseUser].(map[string]interface{})
+		switch {
+		case user.str("displayName") != "Patched "+suffix:
+			return fmt.Errorf("displayName is %q", user.str("displayName"))
+		case name["givenName"] != "Patched":
+			return fmt.Errorf("name.givenName is %v", name["givenName"])
+		case enterprise["department"] != "SCIM Check":
+			return fmt.Errorf("department is %v", enterprise["department"])
+		}
+		return nil
+	})
+
+	s.check("patch user without path", func() error {
+		user, err := s.expect(http.MethodPatch, "/Users/"+userID, patch(
+			operation("replace", "", map[string]interface{}{"title": "Lead", "displayName": "Pathless " + suffix}),
+		), http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if user.str("title") != "Lead" || user.str("displayName") != "Pathless "+suffix {
+			return fmt.Errorf("title %q, displayName %q", user.str("title"), user.str("displayName"))
+		}
+		return nil
+	})
+
+	s.check("deactivate user with a string boolean", func() error {
+		user, err := s.expect(http.MethodPatch, "/Users/"+userID, patch(operation("Replace", "active", "False")), http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if user["active"] != false {
+			return fmt.Errorf("active is %v", user["active"])
+		}
+		return nil
+	})
+
+	s.check("reactivate user", func() error {
+		user, err := s.expect(http.MethodPatch, "/Users/"+userID, patch(operation("replace", "active", true)), http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if user["active"] != true {
+			return fmt.Errorf("active is %v", user["active"])
+		}
+		return nil
+	})
+
+	s.check("patch unknown attribute is rejected", func() error {
+		status, body, err := s.request(http.MethodPatch, "/Users/"+userID, patch(operation("replace", "favouriteColour", "blue")), true)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusBadRequest, constants.SCIMTypeInvalidPath)
+	})
+
+	s.check("malformed body is rejected", func() error {
+		status, body, err := s.request(http.MethodPost, "/Users", json.RawMessage(`{"userName":`), true)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusBadRequest, constants.SCIMTypeInvalidSyntax)
+	})
+
+	if !s.check("create second user", func() error {
+		created, err := s.expect(http.MethodPost, "/Users", newUser("scimcheck.other."+suffix+"@example.com", ""), http.StatusCreated)
+		if err != nil {
+			return err
+		}
+		otherUserID = created.str("id")
+		if created.str("externalId") != "" {
+			return fmt.Errorf("externalId is %q without one being sent", created.str("externalId"))
+		}
+		return nil
+	}) {
+		return
+	}
+	defer s.cleanup("/Users/" + otherUserID)
+
+	s.check("set manager", func() error {
+		user, err := s.expect(http.MethodPatch, "/Users/"+otherUserID, patch(
+			operation("add", constants.SCIMSchemaEnterpriseUser+":manager", map[string]string{"value": userID}),
+		), http.StatusOK)
+		if err != nil {
+			return err
+		}
+		enterprise, _ := user[constants.SCIMSchemaEnterpriseUser].(map[string]interface{})
+		manager, _ := enterprise["manager"].(map[string]interface{})
+		if manager["value"] != userID {
+			return fmt.Errorf("manager is %v", manager["value"])
+		}
+		return nil
+	})
+
+	groupName := "SCIM Check " + suffix
+	if !s.check("create group with a member", func() error {
+		created, err := s.expect(http.MethodPost, "/Groups", map[string]interface{}{
+			"schemas":     []string{constants.SCIMSchemaGroup},
+			"displayName": groupName,
+			"externalId":  uuid.NewString(),
+			"members":     []map[string]string{{"value": userID}},
+		}, http.StatusCreated)
+		if err != nil {
+			return err
+		}
+		groupID = created.str("id")
+		if len(created.list("members")) != 1 {
+			return fmt.Errorf("got %d members", len(created.list("members")))
+		}
+		return expectMeta(created, "Group", s.baseURL+"/Groups/"+groupID)
+	}) {
+		return
+	}
+	defer s.cleanup("/Groups/" + groupID)
+
+	s.check("create group with a taken displayName conflicts", func() error {
+		status, body, err := s.request(http.MethodPost, "/Groups", map[string]interface{}{
+			"schemas":     []string{constants.SCIMSchemaGroup},
+			"displayName": groupName,
+		}, true)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusConflict, constants.SCIMTypeUniqueness)
+	})
+
+	s.check("filter groups by displayName", func() error {
+		return s.expectTotal("/Groups?filter="+url.QueryEscape(`displayName eq "`+groupName+`"`), 1)
+	})
+
+	s.check("get group without members", func() error {
+		group, err := s.expect(http.MethodGet, "/Groups/"+groupID+"?excludedAttributes=members", nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if _, ok := group["members"]; ok {
+			return errors.New("members is returned although excluded")
+		}
+		return nil
+	})
+
+	s.check("user lists its group", func() error {
+		user, err := s.expect(http.MethodGet, "/Users/"+userID, nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if len(user.list("groups")) != 1 {
+			return fmt.Errorf("got %d groups", len(user.list("groups")))
+		}
+		return nil
+	})
+
+	s.check("patch group adds a member", func() error {
+		group, err := s.expect(http.MethodPatch, "/Groups/"+groupID, patch(
+			operation("Add", "members", []map[string]string{{"value": otherUserID}}),
+		), http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if len(group.list("members")) != 2 {
+			return fmt.Errorf("got %d members", len(group.list("members")))
+		}
+		return nil
+	})
+
+	s.check("filter groups by member", func() error {
+		return s.expectTotal("/Groups?filter="+url.QueryEscape(`members.value eq "`+otherUserID+`"`), 1)
+	})
+
+	s.check("patch group removes a member by filter", func() error {
+		group, err := s.expect(http.MethodPatch, "/Groups/"+groupID, patch(
+			operation("Remove", `members[value eq "`+userID+`"]`, nil),
+		), http.StatusOK)
+		if err != nil {
+			return err
+		}
+		members := group.list("members")
+		if len(members) != 1 || members[0].(map[string]interface{})["value"] != otherUserID {
+			return fmt.Errorf("members are %v", members)
+		}
+		return nil
+	})
+
+	s.check("patch group with unknown member is rejected", func() error {
+		status, body, err := s.request(http.MethodPatch, "/Groups/"+groupID, patch(
+			operation("add", "members", []map[string]string{{"value": uuid.NewString()}}),
+		), true)
+		if err != nil {
+			return err
+		}
+		return expectError(status, body, http.StatusBadRequest, constants.SCIMTypeInvalidValue)
+	})
+
+	s.check("replace group", func() error {
+		group, err := s.expect(http.MethodPut, "/Groups/"+groupID, map[string]interface{}{
+			"schemas":     []string{constants.SCIMSchemaGroup},
+			"displayName": groupName + " renamed",
+			"members":     []map[string]string{},
+		}, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if group.str("displayName") != groupName+" renamed" || len(group.list("members")) != 0 {
+			return fmt.Errorf("displayName %q with %d members", group.str("displayName"), len(group.list("members")))
+		}
+		return nil
+	})
+
+	s.check("delete group", func() error {
+		return s.expectDeleted("/Groups/" + groupID)
+	})
+
+	s.check("delete user", func() error {
+		return s.expectDeleted("/Users/" + otherUserID)
+	})
+}
+
+func (s *suite) checkDiscovery() {
+	s.check("service provider configuration", func() error {
+		config, err := s.expect(http.MethodGet, "/ServiceProviderConfig", nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		patch, _ := config["patch"].(map[string]interface{})
+		switch {
+		case !hasSchema(config, constants.SCIMSchemaServiceProviderConfig):
+			return errors.New("schemas does not list the ServiceProviderConfig schema")
+		case patch["supported"] != true:
+			return errors.New("patch is not supported")
+		case len(config.list("authenticationSchemes")) == 0:
+			return errors.New("authenticationSchemes is empty")
+		}
+		return nil
+	})
+
+	for path, want := range map[string]int{"/ResourceTypes": 2, "/Schemas": 3} {
+		s.check("list "+strings.TrimPrefix(path, "/"), func() error {
+			list, err := s.expect(http.MethodGet, path, nil, http.StatusOK)
+			if err != nil {
+				return err
+			}
+			if !hasSchema(list, constants.SCIMSchemaListResponse) || len(list.list("Resources")) != want {
+				return fmt.Errorf("expected a ListResponse of %d resources", want)
+			}
+			return nil
+		})
+	}
+}
+
+// check runs one test case and reports whether it passed.
+func (s *suite) check(name string, test func() error) bool {
+	if err := test(); err != nil {
+		s.failed++
+		fmt.Printf("FAIL  %s: %v\n", name, err)
+		return false
+	}
+	s.passed++
+	fmt.Printf("PASS  %s\n", name)
+	return true
+}
+
+// cleanup deletes a resource the suite created, ignoring whether it is
+// already gone.
+func (s *suite) cleanup(path string) {
+	if _, _, err := s.request(http.MethodDelete, path, nil, true); err != nil {
+		fmt.Printf("WARN  cleanup of %s: %v\n", path, err)
+	}
+}
+
+func (s *suite) request(method, path string, body interface{}, authenticate bool) (int, resource, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", constants.SCIMContentType)
+	}
+	req.Header.Set("Accept", constants.SCIMContentType)
+	if authenticate {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	if len(payload) == 0 {
+		return resp.StatusCode, nil, nil
+	}
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, constants.SCIMContentType) {
+		return resp.StatusCode, nil, fmt.Errorf("%s %s: Content-Type is %q", method, path, contentType)
+	}
+
+	var decoded resource
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	return resp.StatusCode, decoded, nil
+}
+
+// expect sends an authenticated request and fails unless it answers status.
+func (s *suite) expect(method, path string, body interface{}, status int) (resource, error) {
+	got, decoded, err := s.request(method, path, body, true)
+	if err != nil {
+		return nil, err
+	}
+	if got != status {
+		return nil, fmt.Errorf("%s %s: expected %d, got %d: %v", method, path, status, got, decoded)
+	}
+	return decoded, nil
+}
+
+func (s *suite) expectTotal(path string, total int) error {
+	list, err := s.expect(http.MethodGet, path, nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	if !hasSchema(list, constants.SCIMSchemaListResponse) {
+		return errors.New("schemas does not list the ListResponse schema")
+	}
+	if list.number("totalResults") != total || len(list.list("Resources")) != total {
+		return fmt.Errorf("expected %d results, got totalResults %d with %d resources",
+			total, list.number("totalResults"), len(list.list("Resources")))
+	}
+	return nil
+}
+
+func (s *suite) expectDeleted(path string) error {
+	if _, err := s.expect(http.MethodDelete, path, nil, http.StatusNoContent); err != nil {
+		return err
+	}
+	status, body, err := s.request(http.MethodGet, path, nil, true)
+	if err != nil {
+		return err
+	}
+	return expectError(status, body, http.StatusNotFound, "")
+}
+
+// expectError checks a response in the SCIM error schema.
+func expectError(status int, body resource, wantStatus int, wantType string) error {
+	switch {
+	case status != wantStatus:
+		return fmt.Errorf("expected %d, got %d: %v", wantStatus, status, body)
+	case !hasSchema(body, constants.SCIMSchemaError):
+		return fmt.Errorf("error does not use the SCIM error schema: %v", body)
+	case body.str("status") != fmt.Sprint(wantStatus):
+		return fmt.Errorf("error status is %q", body.str("status"))
+	case wantType != "" && body.str("scimType") != wantType:
+		return fmt.Errorf("expected scimType %q, got %q", wantType, body.str("scimType"))
+	}
+	return nil
+}
+
+func expectMeta(body resource, resourceType, location string) error {
+	meta, _ := body["meta"].(map[string]interface{})
+	switch {
+	case meta["resourceType"] != resourceType:
+		return fmt.Errorf("meta.resourceType is %v", meta["resourceType"])
+	case meta["location"] != location:
+		return fmt.Errorf("meta.location is %v, expected %s", meta["location"], location)
+	case meta["created"] == nil || meta["lastModified"] == nil:
+		return errors.New("meta.created or meta.lastModified is missing")
+	}
+	return nil
+}
+
+func hasSchema(body resource, schema string) bool {
+	for _, value := range body.list("schemas") {
+		if value == schema {
+			return true
+		}
+	}
+	return false
+}
+
+func newUser(userName, externalID string) map[string]interface{} {
+	user := map[string]interface{}{
+		"schemas":     []string{constants.SCIMSchemaUser, constants.SCIMSchemaEnterpriseUser},
+		"userName":    userName,
+		"active":      true,
+		"displayName": "SCIM Check",
+		"name":        map[string]string{"givenName": "SCIM", "familyName": "Check"},
+		"emails":      []map[string]interface{}{{"value": userName, "type": "work", "primary": true}},
+	}
+	if externalID != "" {
+		user["externalId"] = externalID
+	}
+	return user
+}
+
+func patch(operations ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":    []string{constants.SCIMSchemaPatchOp},
+		"Operations": operations,
+	}
+}
+
+func operation(op, path string, value interface{}) map[string]interface{} {
+	operation := map[string]interface{}{"op": op}
+	if path != "" {
+		operation["path"] = path
+	}
+	if value != nil {
+		operation["value"] = value
+	}
+	return operation
+}
@@ -11,6 +11,7 @@ import (
 	"yet-another-itsm/internal/config"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/database"
+	"yet-another-itsm/internal/jobs"
 	"yet-another-itsm/internal/middleware"
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/router"
@@ -46,11 +47,27 @@ func main() {
 	// Initialize services
 	services := service.NewServices(db, repository, cfg)
 
+	// API key, session and account status checks need the database, so they are wired in after the services
+	cfg.OAuth.APIKeys = services.ServicePrincipal
+	cfg.OAuth.Sessions = services.Session
+	cfg.OAuth.Accounts = services.User
+
+	// Start background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobs.Schedule(jobsCtx, jobs.NewRoleAssignmentExpiryJob(services), cfg.Jobs.RoleAssignmentExpiryInterval)
+	jobs.Schedule(jobsCtx, jobs.NewAccessReviewCloseJob(services), cfg.Jobs.AccessReviewCloseInterval)
+	jobs.Schedule(jobsCtx, jobs.NewElevationExpiryJob(services), cfg.Jobs.ElevationExpiryInterval)
+	jobs.Schedule(jobsCtx, jobs.NewSessionCleanupJob(services), cfg.Jobs.SessionCleanupInterval)
+	if services.DirectorySync != nil {
+		jobs.Schedule(jobsCtx, jobs.NewDirectorySyncJob(services), cfg.Jobs.DirectorySyncInterval)
+	}
+
 	// Initialize controllers
-	controllers := controller.NewControllers(services)
+	controllers := controller.NewControllers(services, cfg)
 
-	// Setup Gin router
-	if cfg.Logger.Level != "debug" {
+	// Setup Gin router; development deployments keep debug mode, which the dev issuer needs
+	if cfg.Logger.Level != "debug" && !cfg.Server.IsDevelopment() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
@@ -64,7 +81,7 @@ func main() {
 	ginEngine.Use(middleware.SecurityMiddleware())
 
 	// Setup routes
-	routers := router.NewRouters(controllers, cfg)
+	routers := router.NewRouters(controllers, services, cfg)
 	routers.SetupRoutes(ginEngine)
 
 	// Create HTTP server
@@ -98,6 +115,7 @@ func main() {
 	<-quit
 
 	log.Info().Msg("Shutting down server...")
+	stopJobs()
 
 	// Give outstanding requests 30 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -1,14 +1,16 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"yet-another-itsm/internal/constants"
 
-	"github.com/MicahParks/keyfunc"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -21,15 +23,28 @@ type Config struct {
 	Database DatabaseConfig
 	Logger   LoggerConfig
 	OAuth    OAuthConfig
+	Session  SessionConfig
+	Jobs     JobsConfig
 }
 
+// EnvironmentDevelopment is the APP_ENV value that marks a non-production
+// deployment. Only there may the dev issuer run.
+const EnvironmentDevelopment = "development"
+
 type ServerConfig struct {
+	// Environment is APP_ENV; anything but development counts as production.
+	Environment  string
 	Port         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
 }
 
+// IsDevelopment reports whether APP_ENV marks this as a development deployment.
+func (s ServerConfig) IsDevelopment() bool {
+	return s.Environment == EnvironmentDevelopment
+}
+
 type DatabaseConfig struct {
 	Host     string
 	Port     string
@@ -46,14 +61,81 @@ type LoggerConfig struct {
 	Format string // "json" or "console"
 }
 
+type JobsConfig struct {
+	RoleAssignmentExpiryInterval time.Duration
+	AccessReviewCloseInterval    time.Duration
+	ElevationExpiryInterval      time.Duration
+	SessionCleanupInterval       time.Duration
+	DirectorySyncInterval        time.Duration
+}
+
 type OAuthConfig struct {
+	// ProviderType selects the identity provider: entra (default), oidc or dev.
+	ProviderType string
+	// Provider verifies access tokens; it is built from ProviderType on load.
+	Provider IdentityProvider
+	// DevIssuer is set when ProviderType is dev and serves the /dev routes.
+	DevIssuer *DevIssuer
+	// OIDC configures the oidc provider and the dev issuer.
+	OIDC OIDCConfig
+	// DevKeyFile persists the dev issuer's signing key across restarts.
+	DevKeyFile string
+
 	EntraConfig  *oauth2.Config
-	JWKSEntra    *keyfunc.JWKS
 	ClientID     string
 	ClientSecret string
 	TenantID     string
 	RedirectURI  string
 	GraphScope   string
+	// AllowedTenants lists the Entra tenants whose tokens are accepted; the
+	// home tenant is always included. Each tenant's users and business units
+	// are isolated under its own tenant ID.
+	AllowedTenants []string
+	// TokenVersions lists the accepted access token versions, "1" and/or "2".
+	TokenVersions []string
+	// LogoutURL ends the Entra session after a browser logout.
+	LogoutURL string
+	// APIKeys verifies locally issued API keys. Verification needs the
+	// database, so it is set once the services are built; API keys are
+	// rejected while it is nil.
+	APIKeys APIKeyVerifier
+	// Sessions resolves the session cookie of the browser login flow. Like
+	// APIKeys it needs the database and is set once the services are built.
+	Sessions SessionResolver
+	// Accounts rejects suspended and locked users. It is set once the
+	// services are built; account status is not enforced while it is nil.
+	Accounts AccountStatusChecker
+	// AccountStatusCacheTTL bounds how long a cached account status is
+	// trusted, and so how long a suspension takes to reach other instances.
+	AccountStatusCacheTTL time.Duration
+}
+
+// OIDCConfig describes a generic OpenID Connect issuer. Its tokens carry no
+// tid claim, so all of them belong to TenantID.
+type OIDCConfig struct {
+	IssuerURL string
+	Audience  string
+	TenantID  string
+}
+
+// APIKeyVerifier resolves a raw API key to the service principal it was issued to.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, rawKey, clientIP string) (*APIKeyIdentity, error)
+}
+
+// APIKeyIdentity is the caller established by an API key. ObjectID matches the
+// azure_ad_object_id of the service principal's backing user.
+type APIKeyIdentity struct {
+	TenantID    string
+	ObjectID    string
+	DisplayName string
+}
+
+// AccountStatusChecker returns constants.ErrAccountSuspended or
+// constants.ErrAccountLocked for callers who must not be authenticated.
+// Callers that are not provisioned yet pass.
+type AccountStatusChecker interface {
+	CheckAccountStatus(ctx context.Context, tenantID, objectID string) error
 }
 
 func Load() (*Config, error) {
@@ -64,6 +146,7 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
+			Environment:  getEnv("APP_ENV", "production"),
 			Port:         getEnv("PORT", "8080"),
 			ReadTimeout:  getDurationEnv("READ_TIMEOUT", 10*time.Second),
 			WriteTimeout: getDurationEnv("WRITE_TIMEOUT", 10*time.Second),
@@ -84,21 +167,58 @@ func Load() (*Config, error) {
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
 		OAuth: OAuthConfig{
-			ClientID:     getEnv("ENTRA_CLIENT_ID", ""),
-			ClientSecret: getEnv("ENTRA_CLIENT_SECRET", ""),
-			TenantID:     getEnv("ENTRA_TENANT_ID", ""),
-			RedirectURI:  getEnv("REDIRECT_URI", "http://localhost:8080/callback"),
-			GraphScope:   getEnv("APPLICATION_GRAPH_API_SCOPE", "https://graph.microsoft.com/.default"),
+			ProviderType: getEnv("AUTH_PROVIDER", ProviderEntra),
+			OIDC: OIDCConfig{
+				IssuerURL: getEnv("OIDC_ISSUER_URL", ""),
+				Audience:  getEnv("OIDC_AUDIENCE", "api://yet-another-itsm"),
+				TenantID:  getEnv("OIDC_TENANT_ID", ""),
+			},
+			DevKeyFile:     getEnv("DEV_ISSUER_KEY_FILE", ""),
+			ClientID:       getEnv("ENTRA_CLIENT_ID", ""),
+			ClientSecret:   getEnv("ENTRA_CLIENT_SECRET", ""),
+			TenantID:       getEnv("ENTRA_TENANT_ID", ""),
+			RedirectURI:    getEnv("REDIRECT_URI", "http://localhost:8080/callback"),
+			GraphScope:     getEnv("APPLICATION_GRAPH_API_SCOPE", "https://graph.microsoft.com/.default"),
+			AllowedTenants: getListEnv("ENTRA_ALLOWED_TENANTS", nil),
+			TokenVersions:  getListEnv("ENTRA_TOKEN_VERSIONS", []string{tokenVersionV1, tokenVersionV2}),
+
+			AccountStatusCacheTTL: getDurationEnv("ACCOUNT_STATUS_CACHE_TTL", 30*time.Second),
+		},
+		Session: SessionConfig{
+			Lifetime:          getDurationEnv("SESSION_LIFETIME", 8*time.Hour),
+			CookieSecure:      getBoolEnv("SESSION_COOKIE_SECURE", true),
+			PostLoginRedirect: getEnv("POST_LOGIN_REDIRECT_URL", "/"),
+		},
+		Jobs: JobsConfig{
+			RoleAssignmentExpiryInterval: getDurationEnv("ROLE_ASSIGNMENT_EXPIRY_INTERVAL", time.Minute),
+			AccessReviewCloseInterval:    getDurationEnv("ACCESS_REVIEW_CLOSE_INTERVAL", 5*time.Minute),
+			ElevationExpiryInterval:      getDurationEnv("ELEVATION_EXPIRY_INTERVAL", time.Minute),
+			SessionCleanupInterval:       getDurationEnv("SESSION_CLEANUP_INTERVAL", time.Hour),
+			DirectorySyncInterval:        getDurationEnv("DIRECTORY_SYNC_INTERVAL", time.Hour),
 		},
 	}
 
 	// Configure zerolog
 	configureLogger(config.Logger)
 
-	if err := initOAuth(&config.OAuth); err != nil {
+	// Tenants are stored as UUIDs, so a token of any other tenant could never
+	// be resolved to a user.
+	if config.OAuth.OIDC.TenantID != "" {
+		if _, err := uuid.Parse(config.OAuth.OIDC.TenantID); err != nil {
+			return nil, constants.ErrInvalidOIDCTenantIDMsg
+		}
+	}
+
+	if err := initOAuth(&config.OAuth, config.Server); err != nil {
 		return nil, fmt.Errorf(constants.ErrFailedToInitializeOAuthMsg, err)
 	}
 
+	sessionKey, err := decodeSessionKey(os.Getenv("SESSION_ENCRYPTION_KEY"))
+	if err != nil {
+		return nil, err
+	}
+	config.Session.EncryptionKey = sessionKey
+
 	return config, nil
 }
 
@@ -132,6 +252,22 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getListEnv splits a comma-separated variable, dropping blank entries.
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
 func getIntEnv(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
@@ -141,6 +277,15 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,264 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"yet-another-itsm/internal/constants"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// devTenantID is the tenant of dev issuer tokens when OIDC_TENANT_ID is unset.
+const devTenantID = "00000000-0000-0000-0000-000000000001"
+
+// DevIssuer is a local OpenID issuer for development and CI. It signs tokens
+// with its own RSA key and publishes the public key as a JWKS, so any user,
+// tenant, app role or group can be impersonated without Entra ID. It must
+// never be enabled in production.
+type DevIssuer struct {
+	issuer   string
+	audience string
+	tenantID string
+	keyID    string
+	key      *rsa.PrivateKey
+}
+
+// DevTokenClaims describes the caller a dev token is minted for. ObjectID
+// defaults to a UUID derived from Email, and TenantID to the issuer's tenant.
+type DevTokenClaims struct {
+	ObjectID       string
+	TenantID       string
+	Name           string
+	Email          string
+	GivenName      string
+	FamilyName     string
+	JobTitle       string
+	Department     string
+	OfficeLocation string
+	Roles          []string
+	Groups         []string
+	Scopes         string
+	AppOnly        bool
+}
+
+// JWK is a JSON Web Key for an RSA signing key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKSet is the document served at the dev issuer's jwks_uri.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// newDevIssuer loads the signing key from keyFile, creating the file when it
+// does not exist. Without a key file a new key is generated on every start,
+// invalidating previously minted tokens.
+func newDevIssuer(cfg OIDCConfig, keyFile string) (*DevIssuer, error) {
+	key, err := loadOrCreateDevKey(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFailedToLoadDevIssuerKeyMsg, err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFailedToLoadDevIssuerKeyMsg, err)
+	}
+	thumbprint := sha256.Sum256(der)
+
+	tenantID := cfg.TenantID
+	if tenantID == "" {
+		tenantID = devTenantID
+	}
+
+	return &DevIssuer{
+		issuer:   strings.TrimSuffix(cfg.IssuerURL, "/"),
+		audience: cfg.Audience,
+		tenantID: tenantID,
+		keyID:    base64.RawURLEncoding.EncodeToString(thumbprint[:12]),
+		key:      key,
+	}, nil
+}
+
+func loadOrCreateDevKey(keyFile string) (*rsa.PrivateKey, error) {
+	if keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err == nil {
+			return parseDevKey(data)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyFile != "" {
+		block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+		if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+func parseDevKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not an RSA private key")
+	}
+	return key, nil
+}
+
+// Issuer returns the iss claim of minted tokens.
+func (d *DevIssuer) Issuer() string { return d.issuer }
+
+// JWKS returns the public signing key.
+func (d *DevIssuer) JWKS() JWKSet {
+	return JWKSet{Keys: []JWK{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: jwt.SigningMethodRS256.Alg(),
+		Kid: d.keyID,
+		N:   base64.RawURLEncoding.EncodeToString(d.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(d.key.E)).Bytes()),
+	}}}
+}
+
+// Mint signs an access token for claims that is valid for ttl. The token
+// carries the same claims as an Entra access token, plus the profile claims
+// used to provision the user without Graph.
+func (d *DevIssuer) Mint(claims DevTokenClaims, ttl time.Duration) (string, time.Time, error) {
+	objectID := claims.ObjectID
+	if objectID == "" {
+		if claims.Email == "" {
+			return "", time.Time{}, constants.ErrDevTokenSubjectRequired
+		}
+		objectID = devObjectID(claims.Email)
+	}
+
+	tenantID := claims.TenantID
+	if tenantID == "" {
+		tenantID = d.tenantID
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	mapClaims := jwt.MapClaims{
+		"iss": d.issuer,
+		"aud": d.audience,
+		"sub": objectID,
+		"oid": objectID,
+		"tid": tenantID,
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": expiresAt.Unix(),
+	}
+	setClaim(mapClaims, "name", claims.Name)
+	setClaim(mapClaims, "email", claims.Email)
+	setClaim(mapClaims, "upn", claims.Email)
+	setClaim(mapClaims, "given_name", claims.GivenName)
+	setClaim(mapClaims, "family_name", claims.FamilyName)
+	setClaim(mapClaims, "job_title", claims.JobTitle)
+	setClaim(mapClaims, "department", claims.Department)
+	setClaim(mapClaims, "office_location", claims.OfficeLocation)
+	if len(claims.Roles) > 0 {
+		mapClaims["roles"] = claims.Roles
+	}
+	if len(claims.Groups) > 0 {
+		mapClaims["groups"] = claims.Groups
+	}
+	if claims.AppOnly {
+		mapClaims["idtyp"] = "app"
+		setClaim(mapClaims, "app_displayname", claims.Name)
+	} else {
+		scopes := claims.Scopes
+		if scopes == "" {
+			scopes = "user_impersonation"
+		}
+		mapClaims["scp"] = scopes
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mapClaims)
+	token.Header["kid"] = d.keyID
+
+	signed, err := token.SignedString(d.key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+func setClaim(claims jwt.MapClaims, name, value string) {
+	if value != "" {
+		claims[name] = value
+	}
+}
+
+// devObjectID derives a stable object ID from an email address, so repeated
+// tokens for the same email resolve to the same provisioned user.
+func devObjectID(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	b := sum[:16]
+	b[6] = (b[6] & 0x0f) | 0x50
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (d *DevIssuer) Name() string { return ProviderDev }
+
+// Keyfunc only accepts RS256 tokens signed with the issuer's own key.
+func (d *DevIssuer) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method != jwt.SigningMethodRS256 {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+	if kid, _ := token.Header["kid"].(string); kid != d.keyID {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return &d.key.PublicKey, nil
+}
+
+// IsTenantAllowed accepts any tenant, so multi-tenant isolation can be
+// exercised locally.
+func (d *DevIssuer) IsTenantAllowed(tenantID string) bool { return tenantID != "" }
+
+func (d *DevIssuer) AcceptedIssuers(string) []string { return []string{d.issuer} }
+
+func (d *DevIssuer) Audiences() []string { return []string{d.audience} }
+
+func (d *DevIssuer) DefaultTenantID() string { return d.tenantID }
+
+func (d *DevIssuer) GraphEnabled() bool { return false }
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"yet-another-itsm/internal/constants"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// Identity provider types selected with AUTH_PROVIDER.
+const (
+	ProviderEntra = "entra"
+	ProviderOIDC  = "oidc"
+	ProviderDev   = "dev"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+// IdentityProvider verifies the access tokens accepted by AuthMiddleWare.
+type IdentityProvider interface {
+	// Name identifies the provider in logs.
+	Name() string
+	// Keyfunc returns the key that verifies a token's signature.
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	// IsTenantAllowed reports whether tokens for tenantID are accepted.
+	IsTenantAllowed(tenantID string) bool
+	// AcceptedIssuers returns the iss values accepted for tokens from tenantID.
+	AcceptedIssuers(tenantID string) []string
+	// Audiences returns the accepted aud values.
+	Audiences() []string
+	// DefaultTenantID is the tenant of tokens without a tid claim, or empty
+	// when the claim is required.
+	DefaultTenantID() string
+	// GraphEnabled reports whether Microsoft Graph can be called on behalf of
+	// the token. Without Graph, profiles are read from the token claims.
+	GraphEnabled() bool
+}
+
+// entraProvider accepts v1 and v2 access tokens issued by Entra ID to the
+// app registration, from the home tenant and any allowed guest tenant.
+type entraProvider struct {
+	jwks           *keyfunc.JWKS
+	clientID       string
+	allowedTenants []string
+	tokenVersions  []string
+}
+
+func (p *entraProvider) Name() string { return ProviderEntra }
+
+func (p *entraProvider) Keyfunc(token *jwt.Token) (interface{}, error) {
+	return p.jwks.Keyfunc(token)
+}
+
+func (p *entraProvider) IsTenantAllowed(tenantID string) bool {
+	return tenantID != "" && slices.Contains(p.allowedTenants, tenantID)
+}
+
+func (p *entraProvider) AcceptedIssuers(tenantID string) []string {
+	issuers := make([]string, 0, len(p.tokenVersions))
+	for _, version := range p.tokenVersions {
+		switch version {
+		case tokenVersionV1:
+			issuers = append(issuers, "https://sts.windows.net/"+tenantID+"/")
+		case tokenVersionV2:
+			issuers = append(issuers, microsoftLoginBaseURL+tenantID+"/v2.0")
+		}
+	}
+	return issuers
+}
+
+// Audiences accepts the client ID and, for client-credential tokens requested
+// for api://<client id>/.default, the application ID URI.
+func (p *entraProvider) Audiences() []string {
+	return []string{p.clientID, "api://" + p.clientID}
+}
+
+func (p *entraProvider) DefaultTenantID() string { return "" }
+
+func (p *entraProvider) GraphEnabled() bool { return true }
+
+// oidcProvider accepts tokens from any OpenID Connect issuer, loading its
+// signing keys through discovery. Every token belongs to a single tenant.
+type oidcProvider struct {
+	issuer   string
+	audience string
+	tenantID string
+	jwks     *keyfunc.JWKS
+}
+
+// oidcDiscovery is the subset of the OpenID provider metadata that is used.
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// newOIDCProvider reads the issuer's discovery document and loads its JWKS.
+func newOIDCProvider(cfg OIDCConfig) (*oidcProvider, error) {
+	if cfg.IssuerURL == "" {
+		return nil, constants.ErrOIDCIssuerRequiredMsg
+	}
+	if cfg.TenantID == "" {
+		return nil, constants.ErrOIDCTenantIDRequiredMsg
+	}
+
+	issuer := strings.TrimSuffix(cfg.IssuerURL, "/")
+	discovery, err := fetchDiscovery(issuer)
+	if err != nil {
+		return nil, err
+	}
+	// The discovery document must describe the configured issuer, otherwise
+	// tokens would be checked against keys of another issuer.
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf(constants.ErrOIDCIssuerMismatchMsg, discovery.Issuer, issuer)
+	}
+
+	jwks, err := keyfunc.Get(discovery.JWKSURI, keyfunc.Options{
+		RefreshInterval:   time.Hour,
+		RefreshUnknownKID: true,
+		RefreshErrorHandler: func(err error) {
+			log.Printf(constants.ErrJWKSRefreshErrorMsg, err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFailedToLoadJWKSMsg, err)
+	}
+
+	return &oidcProvider{
+		issuer:   discovery.Issuer,
+		audience: cfg.Audience,
+		tenantID: cfg.TenantID,
+		jwks:     jwks,
+	}, nil
+}
+
+func fetchDiscovery(issuer string) (*oidcDiscovery, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(issuer + discoveryPath)
+	if err != nil {
+		return nil, fmt.Errorf(constants.ErrFailedToFetchDiscoveryMsg, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(constants.ErrFailedToFetchDiscoveryMsg, fmt.Errorf("unexpected status %d", resp.StatusCode))
+	}
+
+	var discovery oidcDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf(constants.ErrFailedToFetchDiscoveryMsg, err)
+	}
+	if discovery.Issuer == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf(constants.ErrFailedToFetchDiscoveryMsg, fmt.Errorf("issuer and jwks_uri are required"))
+	}
+
+	return &discovery, nil
+}
+
+func (p *oidcProvider) Name() string { return ProviderOIDC }
+
+func (p *oidcProvider) Keyfunc(token *jwt.Token) (interface{}, error) {
+	return p.jwks.Keyfunc(token)
+}
+
+func (p *oidcProvider) IsTenantAllowed(tenantID string) bool { return tenantID == p.tenantID }
+
+func (p *oidcProvider) AcceptedIssuers(string) []string { return []string{p.issuer} }
+
+func (p *oidcProvider) Audiences() []string { return []string{p.audience} }
+
+func (p *oidcProvider) DefaultTenantID() string { return p.tenantID }
+
+func (p *oidcProvider) GraphEnabled() bool { return false }
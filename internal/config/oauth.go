@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"crypto/rand"
@@ -16,9 +17,59 @@ import (
 	"golang.org/x/oauth2"
 )
 
-const microsoftLoginBaseURL = "https://login.microsoftonline.com/"
+const (
+	microsoftLoginBaseURL = "https://login.microsoftonline.com/"
 
-func initOAuth(oauthConfig *OAuthConfig) error {
+	// commonTenant serves the signing keys shared by every Entra tenant and is
+	// used for JWKS when tokens from more than one tenant are accepted.
+	commonTenant = "common"
+
+	tokenVersionV1 = "1"
+	tokenVersionV2 = "2"
+)
+
+// initOAuth builds the identity provider selected by AUTH_PROVIDER. The dev
+// issuer is refused unless APP_ENV is development; the server port is used
+// for its default issuer URL.
+func initOAuth(oauthConfig *OAuthConfig, server ServerConfig) error {
+	var err error
+	switch oauthConfig.ProviderType {
+	case ProviderEntra:
+		err = initEntra(oauthConfig)
+	case ProviderOIDC:
+		oauthConfig.Provider, err = newOIDCProvider(oauthConfig.OIDC)
+	case ProviderDev:
+		if !server.IsDevelopment() {
+			return constants.ErrDevIssuerRequiresDevelopmentMsg
+		}
+		if oauthConfig.OIDC.IssuerURL == "" {
+			oauthConfig.OIDC.IssuerURL = "http://localhost:" + server.Port + "/dev"
+		}
+		oauthConfig.DevIssuer, err = newDevIssuer(oauthConfig.OIDC, oauthConfig.DevKeyFile)
+		if err == nil {
+			oauthConfig.Provider = oauthConfig.DevIssuer
+			log.Warn().
+				Str("issuer", oauthConfig.DevIssuer.Issuer()).
+				Msg(constants.WarnDevIssuerEnabledMsg)
+		}
+	default:
+		err = fmt.Errorf(constants.ErrUnknownIdentityProviderMsg, oauthConfig.ProviderType)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("provider", oauthConfig.Provider.Name()).
+		Strs("audiences", oauthConfig.Provider.Audiences()).
+		Msg(constants.ErrIdentityProviderInitializedMsg)
+
+	return nil
+}
+
+// initEntra validates the app registration settings and loads the Entra ID
+// signing keys.
+func initEntra(oauthConfig *OAuthConfig) error {
 	if oauthConfig.ClientID == "" {
 		return constants.ErrEntraClientIDRequiredMsg
 	}
@@ -28,6 +79,17 @@ func initOAuth(oauthConfig *OAuthConfig) error {
 	if oauthConfig.TenantID == "" {
 		return constants.ErrEntraTenantIDRequiredMsg
 	}
+	if !slices.Contains(oauthConfig.AllowedTenants, oauthConfig.TenantID) {
+		oauthConfig.AllowedTenants = append([]string{oauthConfig.TenantID}, oauthConfig.AllowedTenants...)
+	}
+	if len(oauthConfig.TokenVersions) == 0 {
+		return constants.ErrInvalidTokenVersionsMsg
+	}
+	for _, version := range oauthConfig.TokenVersions {
+		if version != tokenVersionV1 && version != tokenVersionV2 {
+			return constants.ErrInvalidTokenVersionsMsg
+		}
+	}
 
 	oauthConfig.EntraConfig = &oauth2.Config{
 		ClientID:     oauthConfig.ClientID,
@@ -42,9 +104,12 @@ func initOAuth(oauthConfig *OAuthConfig) error {
 		},
 	}
 
-	jwksURLEntra := microsoftLoginBaseURL + oauthConfig.TenantID + "/discovery/v2.0/keys"
-	var err error
-	oauthConfig.JWKSEntra, err = keyfunc.Get(jwksURLEntra, keyfunc.Options{
+	jwksTenant := oauthConfig.TenantID
+	if len(oauthConfig.AllowedTenants) > 1 {
+		jwksTenant = commonTenant
+	}
+	jwksURLEntra := microsoftLoginBaseURL + jwksTenant + "/discovery/v2.0/keys"
+	jwks, err := keyfunc.Get(jwksURLEntra, keyfunc.Options{
 		RefreshInterval: time.Hour,
 		RefreshErrorHandler: func(err error) {
 			log.Printf(constants.ErrJWKSRefreshErrorMsg, err)
@@ -54,9 +119,19 @@ func initOAuth(oauthConfig *OAuthConfig) error {
 		return fmt.Errorf(constants.ErrFailedToLoadJWKSMsg, err)
 	}
 
+	oauthConfig.LogoutURL = microsoftLoginBaseURL + oauthConfig.TenantID + "/oauth2/v2.0/logout"
+	oauthConfig.Provider = &entraProvider{
+		jwks:           jwks,
+		clientID:       oauthConfig.ClientID,
+		allowedTenants: oauthConfig.AllowedTenants,
+		tokenVersions:  oauthConfig.TokenVersions,
+	}
+
 	log.Info().
 		Str("client_id", oauthConfig.ClientID).
 		Str("tenant_id", oauthConfig.TenantID).
+		Strs("allowed_tenants", oauthConfig.AllowedTenants).
+		Strs("token_versions", oauthConfig.TokenVersions).
 		Str("redirect_uri", oauthConfig.RedirectURI).
 		Msg(constants.ErrOAuthConfigInitializedMsg)
 
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"yet-another-itsm/internal/constants"
+)
+
+// Cookies and header of the browser login flow.
+const (
+	// SessionCookieName holds the encrypted session ID.
+	SessionCookieName = "itsm_session"
+	// CSRFCookieName holds the session's CSRF token. It is readable by the
+	// frontend, which echoes it in CSRFHeader on state-changing requests.
+	CSRFCookieName = "itsm_csrf"
+	// LoginCookieName holds the encrypted state and PKCE verifier between
+	// /login and /callback.
+	LoginCookieName = "itsm_login"
+	CSRFHeader      = "X-CSRF-Token"
+)
+
+// sessionKeySize is the AES-256 key size required for SESSION_ENCRYPTION_KEY.
+const sessionKeySize = 32
+
+type SessionConfig struct {
+	// EncryptionKey seals the session cookies and the tokens stored with each
+	// session. The browser login flow is disabled while it is empty.
+	EncryptionKey []byte
+	Lifetime      time.Duration
+	CookieSecure  bool
+	// PostLoginRedirect is where /callback sends the browser when /login was
+	// called without return_to, and where Entra returns after logout.
+	PostLoginRedirect string
+}
+
+// SessionResolver resolves a session cookie to the session's current access
+// token, refreshing it when needed.
+type SessionResolver interface {
+	ResolveSession(ctx context.Context, cookieValue string) (*SessionIdentity, error)
+}
+
+// SessionIdentity is what AuthMiddleWare needs from a browser session.
+type SessionIdentity struct {
+	AccessToken string
+	CSRFToken   string
+}
+
+// decodeSessionKey parses SESSION_ENCRYPTION_KEY, a base64 encoded 32 byte key.
+func decodeSessionKey(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(key) != sessionKeySize {
+		return nil, constants.ErrInvalidSessionKeyMsg
+	}
+	return key, nil
+}
@@ -17,10 +17,11 @@ const (
 	ErrCodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
 
 	// Common errors
-	ErrTenantIDNotFound    = "tenant ID not found in context"
-	ErrUserIDNotFound      = "user ID not found in context"
-	ErrUserNameNotFound    = "user name not found in context"
-	ErrAccessTokenNotFound = "access token not found in context"
+	ErrTenantIDNotFound       = "tenant ID not found in context"
+	ErrUserIDNotFound         = "user ID not found in context"
+	ErrUserNameNotFound       = "user name not found in context"
+	ErrAccessTokenNotFound    = "access token not found in context"
+	ErrInternalUserIDNotFound = "internal user ID not found in context"
 
 	// Health Service errors
 	ErrDatabaseHealthCheckFailed = "Database health check failed"
@@ -50,6 +51,9 @@ const (
 	ErrFailedToGetDepartment         = "failed to get department from repository"
 	ErrInvalidBusinessUnitUUIDFormat = "invalid business unit UUID format"
 	ErrFailedToCreateDepartment      = "failed to create department"
+	ErrFailedToUpdateDepartment      = "failed to update department"
+	ErrFailedToMoveDepartment        = "failed to move department"
+	ErrFailedToDeleteDepartment      = "failed to delete department"
 
 	// User Service errors
 	ErrFailedToGetUsers            = "failed to get users from repository"
@@ -87,13 +91,251 @@ const (
 	ErrFailedToCreateRolePermission = "failed to create role permission in repository"
 	ErrFailedToUpdateRolePermission = "failed to update role permission in repository"
 	ErrFailedToDeleteRolePermission = "failed to delete role permission in repository"
+
+	// SodConstraint Service errors
+	ErrFailedToGetSodConstraints   = "failed to get separation-of-duties constraints from repository"
+	ErrFailedToGetSodConstraint    = "failed to get separation-of-duties constraint from repository"
+	ErrFailedToCreateSodConstraint = "failed to create separation-of-duties constraint in repository"
+	ErrFailedToDeleteSodConstraint = "failed to delete separation-of-duties constraint in repository"
+	ErrFailedToGetSodConflicts     = "failed to check separation-of-duties conflicts"
+	ErrFailedToGetSodViolations    = "failed to get separation-of-duties violations from repository"
+
+	// AccessReview Service errors
+	ErrFailedToGetAccessReviewCampaigns     = "failed to get access review campaigns from repository"
+	ErrFailedToGetAccessReviewCampaign      = "failed to get access review campaign from repository"
+	ErrFailedToCreateAccessReviewCampaign   = "failed to create access review campaign in repository"
+	ErrFailedToCreateAccessReviewItems      = "failed to create access review items in repository"
+	ErrFailedToGetAccessReviewItems         = "failed to get access review items from repository"
+	ErrFailedToGetAccessReviewItem          = "failed to get access review item from repository"
+	ErrFailedToDecideAccessReviewItem       = "failed to record access review decision in repository"
+	ErrFailedToCompleteAccessReviewCampaign = "failed to complete access review campaign in repository"
+	ErrFailedToWriteAccessReviewExport      = "failed to write access review export"
+
+	// DirectoryRoleMapping Service errors
+	ErrFailedToGetDirectoryRoleMappings   = "failed to get directory role mappings from repository"
+	ErrFailedToGetDirectoryRoleMapping    = "failed to get directory role mapping from repository"
+	ErrFailedToCreateDirectoryRoleMapping = "failed to create directory role mapping in repository"
+	ErrFailedToDeleteDirectoryRoleMapping = "failed to delete directory role mapping in repository"
+	ErrFailedToGetDirectoryRoleGrants     = "failed to get directory role grants from repository"
+	ErrFailedToSyncDirectoryRoles         = "failed to sync directory role assignments"
+
+	// ElevationRequest Service errors
+	ErrFailedToGetElevationRequests    = "failed to get elevation requests from repository"
+	ErrFailedToCreateElevationRequest  = "failed to create elevation request in repository"
+	ErrFailedToDecideElevationRequest  = "failed to record elevation decision in repository"
+	ErrFailedToCancelElevationRequest  = "failed to cancel elevation request in repository"
+	ErrFailedToExpireElevationRequests = "failed to expire elevation requests in repository"
+	ErrFailedToGrantElevation          = "failed to create elevation role assignments in repository"
+
+	// ServicePrincipal Service errors
+	ErrFailedToGetServicePrincipals   = "failed to get service principals from repository"
+	ErrFailedToCreateServicePrincipal = "failed to create service principal in repository"
+	ErrFailedToDeleteServicePrincipal = "failed to delete service principal in repository"
+	ErrFailedToGetAPIKeys             = "failed to get API keys from repository"
+	ErrFailedToCreateAPIKey           = "failed to create API key in repository"
+	ErrFailedToRevokeAPIKey           = "failed to revoke API key in repository"
+	ErrFailedToGenerateAPIKey         = "failed to generate API key"
+	ErrFailedToVerifyAPIKey           = "failed to verify API key"
+
+	// User account status errors
+	ErrFailedToGetAccountStatus    = "failed to get account status from repository"
+	ErrFailedToUpdateAccountStatus = "failed to update account status in repository"
+	ErrFailedToGetStatusChanges    = "failed to get user status changes from repository"
+
+	// User profile sync errors
+	ErrFailedToSyncUserProfile    = "failed to sync user profile in repository"
+	ErrFailedToGetProfileChanges  = "failed to get user profile changes from repository"
+	ErrFailedToResolveUserManager = "failed to resolve user manager"
+
+	// Org chart service errors
+	ErrFailedToGetManagementChain = "failed to get management chain from repository"
+	ErrFailedToGetReports         = "failed to get reports from repository"
+	ErrFailedToGetOrgChart        = "failed to get org chart from repository"
+
+	// User search errors
+	ErrFailedToSearchUsers = "failed to search users in repository"
+
+	// Directory sync service errors
+	ErrFailedToQueryDirectory          = "failed to query directory changes"
+	ErrFailedToGetDirectorySyncState   = "failed to get directory sync state from repository"
+	ErrFailedToSaveDirectorySyncState  = "failed to save directory sync state in repository"
+	ErrFailedToSyncDirectoryUser       = "failed to sync directory user in repository"
+	ErrCouldNotCreateAppOnlyCredential = "could not create app-only credential"
+
+	// SCIM service errors
+	ErrFailedToListSCIMResources  = "failed to list SCIM resources from repository"
+	ErrFailedToGetSCIMGroup       = "failed to get SCIM group from repository"
+	ErrFailedToSaveSCIMUser       = "failed to save SCIM user in repository"
+	ErrFailedToSaveSCIMGroup      = "failed to save SCIM group in repository"
+	ErrFailedToDeleteSCIMResource = "failed to delete SCIM resource in repository"
+	ErrFailedToSyncSCIMGroupRoles = "failed to sync role assignments of SCIM group members"
+
+	// Session service errors
+	ErrFailedToBeginLogin     = "failed to begin login"
+	ErrFailedToExchangeCode   = "failed to redeem authorization code"
+	ErrFailedToCreateSession  = "failed to create session in repository"
+	ErrFailedToGetSession     = "failed to get session from repository"
+	ErrFailedToRefreshSession = "failed to refresh session tokens"
+	ErrFailedToRevokeSession  = "failed to revoke session in repository"
+	ErrFailedToDeleteSessions = "failed to delete stale sessions in repository"
 )
 
 // Error variables
 var (
-	ErrEntraClientIDRequiredMsg     = fmt.Errorf("ENTRA_CLIENT_ID is required")
-	ErrEntraClientSecretRequiredMsg = fmt.Errorf("ENTRA_CLIENT_SECRET is required")
-	ErrEntraTenantIDRequiredMsg     = fmt.Errorf("ENTRA_TENANT_ID is required")
+	ErrEntraClientIDRequiredMsg        = fmt.Errorf("ENTRA_CLIENT_ID is required")
+	ErrEntraClientSecretRequiredMsg    = fmt.Errorf("ENTRA_CLIENT_SECRET is required")
+	ErrEntraTenantIDRequiredMsg        = fmt.Errorf("ENTRA_TENANT_ID is required")
+	ErrInvalidTokenVersionsMsg         = fmt.Errorf("ENTRA_TOKEN_VERSIONS must list 1, 2 or both")
+	ErrOIDCIssuerRequiredMsg           = fmt.Errorf("OIDC_ISSUER_URL is required")
+	ErrOIDCTenantIDRequiredMsg         = fmt.Errorf("OIDC_TENANT_ID is required")
+	ErrInvalidOIDCTenantIDMsg          = fmt.Errorf("OIDC_TENANT_ID must be a UUID")
+	ErrDevTokenSubjectRequired         = fmt.Errorf("oid or email is required")
+	ErrDevIssuerRequiresDevelopmentMsg = fmt.Errorf("AUTH_PROVIDER=dev requires APP_ENV=development")
+	ErrInvalidSessionKeyMsg            = fmt.Errorf("SESSION_ENCRYPTION_KEY must be 32 bytes, base64 encoded")
+
+	// Role assignment validation errors
+	ErrRoleAssignmentNotFound      = fmt.Errorf("role assignment not found")
+	ErrRoleAssignmentAlreadyExists = fmt.Errorf("role assignment already exists")
+	ErrRolePermissionNotActive     = fmt.Errorf("role permission does not exist or is not active")
+	ErrAssigneeNotFound            = fmt.Errorf("assignee not found")
+	ErrInvalidExpiresAt            = fmt.Errorf("expires_at must be an RFC 3339 timestamp in the future")
+
+	// Role hierarchy validation errors
+	ErrRoleNotFound       = fmt.Errorf("role not found")
+	ErrParentRoleNotFound = fmt.Errorf("parent role not found")
+	ErrRoleHierarchyCycle = fmt.Errorf("parent role would create a cycle in the role hierarchy")
+
+	// Separation-of-duties validation errors
+	ErrSodConstraintNotFound      = fmt.Errorf("separation-of-duties constraint not found")
+	ErrSodConstraintAlreadyExists = fmt.Errorf("a separation-of-duties constraint already exists for this role pair")
+	ErrSodConstraintViolation     = fmt.Errorf("role assignment violates separation-of-duties constraint")
+
+	// Access review validation errors
+	ErrAccessReviewCampaignNotFound = fmt.Errorf("access review campaign not found")
+	ErrAccessReviewCampaignClosed   = fmt.Errorf("access review campaign is not active")
+	ErrAccessReviewItemNotFound     = fmt.Errorf("access review item not found")
+	ErrAccessReviewItemDecided      = fmt.Errorf("access review item has already been decided")
+	ErrNotAccessReviewer            = fmt.Errorf("only the assigned reviewer can decide this access review item")
+	ErrAccessReviewSelfReview       = fmt.Errorf("reviewers cannot decide the review of their own role assignment")
+	ErrAccessReviewCampaignEnded    = fmt.Errorf("access review campaign has ended")
+	ErrInvalidEndsAt                = fmt.Errorf("ends_at must be an RFC 3339 timestamp in the future")
+
+	// Directory role mapping validation errors
+	ErrDirectoryRoleMappingNotFound      = fmt.Errorf("directory role mapping not found")
+	ErrDirectoryRoleMappingAlreadyExists = fmt.Errorf("a directory role mapping already exists for this claim and role")
+	ErrRoleAssignmentExternallyManaged   = fmt.Errorf("role assignment is managed by a directory role mapping and cannot be changed manually")
+	ErrBusinessUnitNotFound              = fmt.Errorf("business unit not found")
+	ErrBusinessUnitTenantMismatch        = fmt.Errorf("business unit must belong to the caller's tenant")
+
+	// Department validation errors
+	ErrDepartmentNotFound             = fmt.Errorf("department not found")
+	ErrParentDepartmentNotFound       = fmt.Errorf("parent department not found")
+	ErrDepartmentHierarchyCycle       = fmt.Errorf("department cannot be placed below itself or one of its descendants")
+	ErrDepartmentNameTaken            = fmt.Errorf("a department with this name already exists in the business unit")
+	ErrDepartmentHasChildren          = fmt.Errorf("department has child departments; move or delete them first")
+	ErrDepartmentHasRoleAssignments   = fmt.Errorf("role assignments are scoped to the department or its descendants; revoke them before moving it to another business unit")
+	ErrDepartmentBusinessUnitMismatch = fmt.Errorf("parent department belongs to a different business unit")
+	ErrDepartmentBusinessUnitRequired = fmt.Errorf("business_unit_id is required for a department without a business unit")
+	ErrInvalidDepartmentReference     = fmt.Errorf("business_unit_id and parent_id must be UUIDs")
+	ErrInvalidDepartmentStatus        = fmt.Errorf("status must be one of active, inactive")
+
+	// Form field validation errors
+	ErrFormFieldNotFound           = fmt.Errorf("form field not found")
+	ErrFormFieldTemplateNotFound   = fmt.Errorf("form template not found")
+	ErrFormFieldSectionNotFound    = fmt.Errorf("form section does not belong to the form template")
+	ErrFormFieldTypeNotFound       = fmt.Errorf("field_type is not a registered field type")
+	ErrInvalidFormFieldConfig      = fmt.Errorf("config does not satisfy the field type's validation schema")
+	ErrFormFieldAlreadyExists      = fmt.Errorf("a field with this name already exists in the form or one with this order in the section")
+	ErrInvalidFormSectionReference = fmt.Errorf("form_section_id must be a UUID")
+
+	// Form section validation errors
+	ErrFormSectionMissing = fmt.Errorf("form section not found")
+
+	// Form template lifecycle validation errors
+	ErrFormTemplateMissing           = fmt.Errorf("form template not found")
+	ErrFormTemplateNotEditable       = fmt.Errorf("only draft form templates can be changed; create a new version to change a published one")
+	ErrFormTemplateInvalidTransition = fmt.Errorf("form template state does not allow this transition")
+	ErrFormTemplateDraftExists       = fmt.Errorf("the form already has a version in draft or review")
+	ErrFormTemplateNotDeletable      = fmt.Errorf("published and retired form templates cannot be deleted; retire them instead")
+	ErrFormTemplateHasNoFields       = fmt.Errorf("a form template needs at least one field to be reviewed")
+	ErrFormTemplateVersionNotFound   = fmt.Errorf("form template version not found")
+	ErrFormTemplateNotPublished      = fmt.Errorf("the form has no published version")
+	ErrInvalidFormTemplateVersion    = fmt.Errorf("version must be a positive integer or latest")
+
+	// Field type validation errors
+	ErrFieldTypeNotFound      = fmt.Errorf("field type not found")
+	ErrFieldTypeAlreadyExists = fmt.Errorf("a field type with this name already exists")
+	ErrFieldTypeBuiltIn       = fmt.Errorf("built-in field types cannot be changed or deleted")
+	ErrFieldTypeInUse         = fmt.Errorf("field type is used by form fields")
+	ErrInvalidFieldTypeName   = fmt.Errorf("type_name must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	ErrInvalidFieldTypeSchema = fmt.Errorf("validation_schema is not a supported JSON Schema")
+	ErrFieldTypeSchemaEmpty   = fmt.Errorf("validation_schema must be a non-empty JSON Schema")
+
+	// Form submission validation errors
+	ErrFormSubmissionNotFound             = fmt.Errorf("form submission not found")
+	ErrFormSubmissionForbidden            = fmt.Errorf("you do not have permission to perform this action on the form submission")
+	ErrFormSubmissionTemplateNotPublished = fmt.Errorf("submissions are only accepted for the published version of a form")
+	ErrFormSubmissionNotEditable          = fmt.Errorf("only drafts can be changed, and only by their submitter")
+	ErrInvalidFormSubmissionAnswers       = fmt.Errorf("answers do not satisfy the form")
+	ErrInvalidFormSubmissionFilter        = fmt.Errorf("template_id and submitted_by must be UUIDs or me, state one of draft, submitted, and from and to dates (YYYY-MM-DD) or RFC 3339 timestamps")
+	ErrInvalidFormSubmissionPage          = fmt.Errorf("page must be a positive number and size a number between 1 and 100")
+
+	// User account status validation errors
+	ErrAccountSuspended   = fmt.Errorf("user account is suspended")
+	ErrAccountLocked      = fmt.Errorf("user account is locked")
+	ErrUserNotFound       = fmt.Errorf("user not found")
+	ErrCannotSuspendSelf  = fmt.Errorf("users cannot suspend or lock their own account")
+	ErrInvalidLockedUntil = fmt.Errorf("locked_until must be an RFC 3339 time in the future")
+
+	// Org chart validation errors
+	ErrUserHasNoManager      = fmt.Errorf("user has no manager")
+	ErrInvalidOrgChartDepth  = fmt.Errorf("depth must be a number between 1 and 10")
+	ErrOrgChartScopeRequired = fmt.Errorf("department_id or business_unit_id is required")
+
+	// User search validation errors
+	ErrInvalidUserSort   = fmt.Errorf("sort must be one of display_name, mail, created_at, last_login with an optional + or - prefix")
+	ErrInvalidUserStatus = fmt.Errorf("status must be one of active, inactive, deleted")
+	ErrInvalidPageLimit  = fmt.Errorf("limit must be a number between 1 and 100")
+	ErrInvalidCursor     = fmt.Errorf("cursor is invalid or does not match the requested sort")
+	ErrInvalidUserFilter = fmt.Errorf("department_id, business_unit_id and manager_id must be UUIDs")
+
+	// Paged list errors
+	ErrInvalidPage = fmt.Errorf("page must be a positive number and size a number between 1 and 100")
+
+	// Directory sync errors
+	ErrDirectoryDeltaExpired = fmt.Errorf("directory delta link expired, a full sync is required")
+
+	// SCIM request errors; the SCIM controller maps each to a scimType
+	ErrSCIMInvalidFilter = fmt.Errorf("filter is not supported")
+	ErrSCIMInvalidPath   = fmt.Errorf("path is not supported")
+	ErrSCIMInvalidValue  = fmt.Errorf("value is invalid")
+	ErrSCIMInvalidSyntax = fmt.Errorf("request is not a valid SCIM message")
+	ErrSCIMNoTarget      = fmt.Errorf("path does not match any value")
+	ErrSCIMUniqueness    = fmt.Errorf("value is already in use")
+	ErrSCIMGroupNotFound = fmt.Errorf("group not found")
+
+	// Session validation errors
+	ErrLoginNotConfigured    = fmt.Errorf("browser login is not configured")
+	ErrInvalidLoginState     = fmt.Errorf("login state is missing, expired or does not match")
+	ErrLoginTenantNotAllowed = fmt.Errorf("the signed-in tenant is not allowed")
+	ErrSessionNotFound       = fmt.Errorf("session not found or expired")
+	ErrInvalidCSRFToken      = fmt.Errorf("missing or invalid CSRF token")
+
+	// Elevation request validation errors
+	ErrElevationRequestNotFound   = fmt.Errorf("elevation request not found")
+	ErrElevationRequestNotPending = fmt.Errorf("elevation request is no longer pending")
+	ErrElevationSelfApproval      = fmt.Errorf("an elevation request cannot be decided by its requester")
+	ErrElevationRoleEmpty         = fmt.Errorf("role has no active permissions to elevate to")
+	ErrInvalidElevationStatus     = fmt.Errorf("status must be one of pending, approved, rejected, cancelled, expired")
+
+	// Service principal and API key validation errors
+	ErrServicePrincipalNotFound      = fmt.Errorf("service principal not found")
+	ErrServicePrincipalAlreadyExists = fmt.Errorf("a service principal or user already exists for this Entra application or object ID")
+	ErrServicePrincipalEntraIDs      = fmt.Errorf("app_id and object_id must be provided together")
+	ErrAPIKeyNotFound                = fmt.Errorf("API key not found")
+	ErrAPIKeyRevoked                 = fmt.Errorf("API key has already been revoked")
+	ErrInvalidAPIKey                 = fmt.Errorf("invalid or expired API key")
+	ErrInvalidAPIKeyExpiresAt        = fmt.Errorf("expires_at must be an RFC 3339 timestamp in the future")
 )
 
 // Error messages
@@ -111,9 +353,16 @@ const (
 	ErrRouteNotFoundMsg    = "Route not found"
 
 	// Config OAuth error messages
-	ErrFailedToLoadJWKSMsg       = "failed to load JWKS for Entra ID: %w"
-	ErrJWKSRefreshErrorMsg       = "[Entra JWKS] Error refreshing JWKS: %v"
-	ErrOAuthConfigInitializedMsg = "OAuth config initialized successfully"
+	ErrFailedToLoadJWKSMsg            = "failed to load JWKS for Entra ID: %w"
+	ErrJWKSRefreshErrorMsg            = "[Entra JWKS] Error refreshing JWKS: %v"
+	ErrOAuthConfigInitializedMsg      = "OAuth config initialized successfully"
+	ErrIdentityProviderInitializedMsg = "Identity provider initialized"
+	ErrUnknownIdentityProviderMsg     = "unknown AUTH_PROVIDER %q, expected entra, oidc or dev"
+	ErrFailedToFetchDiscoveryMsg      = "failed to fetch OpenID discovery document: %w"
+	ErrOIDCIssuerMismatchMsg          = "discovery document issuer %q does not match OIDC_ISSUER_URL %q"
+	ErrFailedToLoadDevIssuerKeyMsg    = "failed to load dev issuer signing key: %w"
+	WarnDevIssuerEnabledMsg           = "Dev issuer enabled: anyone can mint tokens at /dev/token, never use this in production"
+	ErrDevIssuerReleaseModeMsg        = "Dev issuer routes are not served in release mode"
 
 	// Logger error messages
 	ErrFailedToInitializeOAuthMsg = "failed to initialize OAuth: %w"
@@ -131,6 +380,15 @@ const (
 	ErrInvalidTokenAudienceMsg          = "Invalid token audience"
 	ErrInvalidTenantIDMsg               = "Invalid tenant ID"
 	ErrUserAuthenticatedSuccessfullyMsg = "User authenticated successfully"
+	ErrInvalidAPIKeyMsg                 = "Invalid or expired API key"
+	ErrFailedToMintDevTokenMsg          = "Failed to mint dev token"
+	ErrSessionExpiredMsg                = "Session expired, sign in again"
+	ErrAccountSuspendedMsg              = "User account is suspended"
+	ErrAccountLockedMsg                 = "User account is locked"
+	ErrInvalidCSRFTokenMsg              = "Missing or invalid CSRF token"
+	ErrUserNotProvisionedMsg            = "User is not provisioned"
+	ErrPermissionDeniedMsg              = "You do not have permission to perform this action"
+	ErrFailedToCheckPermissionsMsg      = "Failed to check permissions"
 	ErrTokenExpiryNotSetMsg             = "token expiry not set"
 	ErrTokenExpiredAtMsg                = "token expired at %v, current time %v"
 
@@ -153,18 +411,31 @@ const (
 	ErrFailedToRetrieveBusinessUnitsMsg       = "failed to retrieve business units"
 
 	// Department Controller error messages
-	ErrFailedToRetrieveDepartmentsMsg = "Failed to retrieve departments"
-	ErrDepartmentNotFoundMsg          = "Department not found"
-	ErrDepartmentIDRequiredMsg        = "Department ID is required"
-	ErrDepartmentNameRequiredMsg      = "Department name is required"
-	ErrFailedToGetDepartmentByIDMsg   = "Failed to get department by ID"
-	ErrFailedToGetDepartmentByNameMsg = "Failed to get department by name"
+	ErrFailedToRetrieveDepartmentsMsg  = "Failed to retrieve departments"
+	ErrDepartmentNotFoundMsg           = "Department not found"
+	ErrDepartmentIDRequiredMsg         = "Department ID is required"
+	ErrDepartmentNameRequiredMsg       = "Department name is required"
+	ErrFailedToGetDepartmentByIDMsg    = "Failed to get department by ID"
+	ErrFailedToGetDepartmentByNameMsg  = "Failed to get department by name"
+	ErrFailedToGetDepartmentSubtreeMsg = "Failed to get department subtree"
+	ErrFailedToCreateDepartmentMsg     = "Failed to create department"
+	ErrFailedToUpdateDepartmentMsg     = "Failed to update department"
+	ErrFailedToMoveDepartmentMsg       = "Failed to move department"
+	ErrFailedToDeleteDepartmentMsg     = "Failed to delete department"
 
 	// User Controller error messages
 	ErrFailedToRetrieveUsersMsg = "Failed to retrieve users"
 	ErrUserNotFoundMsg          = "User not found"
 	ErrInvalidRequestBodyMsg    = "Invalid request body"
 	ErrFailedToCreateUserMsg    = "Failed to create user"
+	ErrFailedToSuspendUserMsg   = "Failed to suspend user"
+	ErrFailedToUnlockUserMsg    = "Failed to unlock user"
+	ErrFailedToGetUserStatusMsg = "Failed to retrieve user status changes"
+	ErrFailedToGetProfileMsg    = "Failed to retrieve user profile changes"
+	ErrFailedToSyncProfileMsg   = "Failed to sync user profile"
+	ErrFailedToGetManagerMsg    = "Failed to retrieve management chain"
+	ErrFailedToGetReportsMsg    = "Failed to retrieve reports"
+	ErrFailedToGetOrgChartMsg   = "Failed to retrieve org chart"
 
 	// Permission Controller error messages
 	ErrFailedToRetrievePermissionsMsg = "Failed to retrieve permissions"
@@ -187,6 +458,7 @@ const (
 	ErrFailedToCreateRoleMsg    = "Failed to create role"
 	ErrFailedToUpdateRoleMsg    = "Failed to update role"
 	ErrFailedToDeleteRoleMsg    = "Failed to delete role"
+	ErrFailedToSetRoleParentMsg = "Failed to set parent role"
 
 	// Scope Controller error messages
 	ErrFailedToRetrieveScopesMsg = "Failed to retrieve scopes"
@@ -204,13 +476,72 @@ const (
 	ErrFailedToUpdateRolePermissionMsg    = "Failed to update role permission"
 	ErrFailedToDeleteRolePermissionMsg    = "Failed to delete role permission"
 
+	// SodConstraint Controller error messages
+	ErrFailedToRetrieveSodConstraintsMsg = "Failed to retrieve separation-of-duties constraints"
+	ErrSodConstraintIDRequiredMsg        = "Separation-of-duties constraint ID is required"
+	ErrFailedToCreateSodConstraintMsg    = "Failed to create separation-of-duties constraint"
+	ErrFailedToDeleteSodConstraintMsg    = "Failed to delete separation-of-duties constraint"
+	ErrFailedToGetSodViolationsMsg       = "Failed to get separation-of-duties violations"
+
+	// AccessReview Controller error messages
+	ErrFailedToRetrieveAccessReviewsMsg     = "Failed to retrieve access review campaigns"
+	ErrFailedToRetrieveAccessReviewItemsMsg = "Failed to retrieve access review items"
+	ErrAccessReviewCampaignIDRequiredMsg    = "Access review campaign ID is required"
+	ErrAccessReviewItemIDRequiredMsg        = "Access review item ID is required"
+	ErrFailedToCreateAccessReviewMsg        = "Failed to create access review campaign"
+	ErrFailedToDecideAccessReviewItemMsg    = "Failed to record access review decision"
+	ErrFailedToCompleteAccessReviewMsg      = "Failed to complete access review campaign"
+	ErrFailedToExportAccessReviewMsg        = "Failed to export access review campaign"
+
+	// DirectoryRoleMapping Controller error messages
+	ErrFailedToRetrieveDirectoryRoleMappingsMsg = "Failed to retrieve directory role mappings"
+	ErrDirectoryRoleMappingIDRequiredMsg        = "Directory role mapping ID is required"
+	ErrFailedToCreateDirectoryRoleMappingMsg    = "Failed to create directory role mapping"
+	ErrFailedToDeleteDirectoryRoleMappingMsg    = "Failed to delete directory role mapping"
+
+	// ElevationRequest Controller error messages
+	ErrFailedToRetrieveElevationRequestsMsg = "Failed to retrieve elevation requests"
+	ErrElevationRequestIDRequiredMsg        = "Elevation request ID is required"
+	ErrFailedToCreateElevationRequestMsg    = "Failed to create elevation request"
+	ErrFailedToApproveElevationRequestMsg   = "Failed to approve elevation request"
+	ErrFailedToRejectElevationRequestMsg    = "Failed to reject elevation request"
+	ErrFailedToCancelElevationRequestMsg    = "Failed to cancel elevation request"
+
+	// ServicePrincipal Controller error messages
+	ErrFailedToRetrieveServicePrincipalsMsg = "Failed to retrieve service principals"
+	ErrServicePrincipalIDRequiredMsg        = "Service principal ID is required"
+	ErrFailedToCreateServicePrincipalMsg    = "Failed to create service principal"
+	ErrFailedToDeleteServicePrincipalMsg    = "Failed to delete service principal"
+	ErrFailedToRetrieveAPIKeysMsg           = "Failed to retrieve API keys"
+	ErrAPIKeyIDRequiredMsg                  = "API key ID is required"
+	ErrFailedToCreateAPIKeyMsg              = "Failed to create API key"
+	ErrFailedToRotateAPIKeyMsg              = "Failed to rotate API key"
+	ErrFailedToRevokeAPIKeyMsg              = "Failed to revoke API key"
+
+	// Login Controller error messages
+	ErrLoginNotAvailableMsg  = "Browser login is not configured"
+	ErrFailedToBeginLoginMsg = "Failed to start login"
+	ErrLoginFailedMsg        = "Login failed"
+	ErrFailedToLogoutMsg     = "Failed to log out"
+
+	// SCIM Controller error messages
+	ErrFailedToListSCIMResourcesMsg  = "Failed to list SCIM resources"
+	ErrFailedToGetSCIMResourceMsg    = "Failed to get SCIM resource"
+	ErrFailedToSaveSCIMResourceMsg   = "Failed to save SCIM resource"
+	ErrFailedToDeleteSCIMResourceMsg = "Failed to delete SCIM resource"
+
 	// RoleAssignment Service error messages
-	ErrFailedToGetRoleAssignments   = "failed to get role assignments from repository"
-	ErrFailedToGetRoleAssignment    = "failed to get role assignment from repository"
-	ErrFailedToCreateRoleAssignment = "failed to create role assignment in repository"
-	ErrFailedToUpdateRoleAssignment = "failed to update role assignment in repository"
-	ErrFailedToDeleteRoleAssignment = "failed to delete role assignment in repository"
-	ErrFailedToCheckUserPermission  = "failed to check user permission from repository"
+	ErrFailedToGetRoleAssignments    = "failed to get role assignments from repository"
+	ErrFailedToGetRoleAssignment     = "failed to get role assignment from repository"
+	ErrFailedToCreateRoleAssignment  = "failed to create role assignment in repository"
+	ErrFailedToUpdateRoleAssignment  = "failed to update role assignment in repository"
+	ErrFailedToDeleteRoleAssignment  = "failed to delete role assignment in repository"
+	ErrFailedToRevokeRoleAssignment  = "failed to revoke role assignment in repository"
+	ErrFailedToExpireRoleAssignments = "failed to expire role assignments in repository"
+	ErrFailedToBeginTransaction      = "failed to begin database transaction"
+	ErrFailedToCommitTransaction     = "failed to commit database transaction"
+	ErrFailedToCheckUserPermission   = "failed to check user permission from repository"
+	ErrFailedToGetPermissionGrants   = "failed to get permission grants from repository"
 
 	// RoleAssignment Controller error messages
 	ErrFailedToRetrieveRoleAssignmentsMsg = "Failed to retrieve role assignments"
@@ -220,6 +551,8 @@ const (
 	ErrFailedToCreateRoleAssignmentMsg    = "Failed to create role assignment"
 	ErrFailedToUpdateRoleAssignmentMsg    = "Failed to update role assignment"
 	ErrFailedToDeleteRoleAssignmentMsg    = "Failed to delete role assignment"
+	ErrFailedToGetEffectivePermissionsMsg = "Failed to get effective permissions"
+	ErrFailedToRevokeRoleAssignmentMsg    = "Failed to revoke role assignment"
 	ErrFailedToCheckPermissionMsg         = "Failed to check user permission"
 	ErrFailedToGetUsersInDepartmentMsg    = "Failed to get users in department"
 	ErrFailedToGetUserByIDMsg             = "Failed to get user by ID"
@@ -243,6 +576,12 @@ const (
 	ErrFailedToUpdateFormTemplate  = "Failed to update form template"
 	ErrFailedToDeleteFormTemplate  = "Failed to delete form template"
 	ErrFailedToPublishFormTemplate = "Failed to publish form template"
+	ErrFailedToSubmitFormTemplate  = "Failed to submit form template for review"
+	ErrFailedToRejectFormTemplate  = "Failed to reject form template"
+	ErrFailedToRetireFormTemplate  = "Failed to retire form template"
+	ErrFailedToGetTemplateVersions = "Failed to get form template versions"
+	ErrFailedToCreateFormVersion   = "Failed to create form template version"
+	ErrFailedToGetFormDefinition   = "Failed to get form definition"
 	ErrFormTemplateNotFound        = "Form template not found"
 	ErrFormTemplateNameRequired    = "Form template name is required"
 	ErrFormTemplateIDRequired      = "Form template ID is required"
@@ -257,6 +596,29 @@ const (
 	ErrFormSectionNameRequired   = "Form section name is required"
 	ErrFormSectionIDRequired     = "Form section ID is required"
 
+	// Form Field errors
+	ErrFailedToGetFormFields   = "Failed to get form fields"
+	ErrFailedToGetFormField    = "Failed to get form field"
+	ErrFailedToCreateFormField = "Failed to create form field"
+	ErrFailedToUpdateFormField = "Failed to update form field"
+	ErrFailedToDeleteFormField = "Failed to delete form field"
+	ErrFailedToGetFieldType    = "Failed to get field type"
+	ErrFormFieldIDRequired     = "Form field ID is required"
+
+	// Field Type errors
+	ErrFailedToGetFieldTypes   = "Failed to get field types"
+	ErrFailedToCreateFieldType = "Failed to create field type"
+	ErrFailedToUpdateFieldType = "Failed to update field type"
+	ErrFailedToDeleteFieldType = "Failed to delete field type"
+	ErrFieldTypeIDRequired     = "Field type ID is required"
+
+	// Form Submission errors
+	ErrFailedToGetFormSubmissions   = "Failed to get form submissions"
+	ErrFailedToGetFormSubmission    = "Failed to get form submission"
+	ErrFailedToCreateFormSubmission = "Failed to create form submission"
+	ErrFailedToUpdateFormSubmission = "Failed to update form submission"
+	ErrFailedToDeleteFormSubmission = "Failed to delete form submission"
+
 	// Request error messages
 	ErrInvalidRequestBody = "invalid request body"
 )
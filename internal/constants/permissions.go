@@ -0,0 +1,54 @@
+package constants
+
+// Permission resources, matching permissions.resource
+const (
+	ResourceBusinessUnits         = "business_units"
+	ResourceDepartments           = "departments"
+	ResourceUsers                 = "users"
+	ResourceRoles                 = "roles"
+	ResourcePermissions           = "permissions"
+	ResourceScopes                = "scopes"
+	ResourceRolePermissions       = "role_permissions"
+	ResourceRoleAssignments       = "role_assignments"
+	ResourceFormCategories        = "form_categories"
+	ResourceFormTemplates         = "form_templates"
+	ResourceFormSections          = "form_sections"
+	ResourceFormFields            = "form_fields"
+	ResourceFieldTypes            = "field_types"
+	ResourceFormSubmissions       = "form_submissions"
+	ResourceSodConstraints        = "sod_constraints"
+	ResourceAccessReviews         = "access_reviews"
+	ResourceDirectoryRoleMappings = "directory_role_mappings"
+	ResourceElevationRequests     = "elevation_requests"
+	ResourceServicePrincipals     = "service_principals"
+	ResourceSCIM                  = "scim"
+)
+
+// Permission actions, matching permissions.action
+const (
+	ActionRead   = "read"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+
+	// ActionApprove is used for resources that go through an approval step,
+	// such as elevation requests.
+	ActionApprove = "approve"
+)
+
+// Permission scopes, matching scopes.id. A role permission without a scope is
+// treated as tenant-wide.
+const (
+	ScopeOwn          = "own"
+	ScopeDepartment   = "department"
+	ScopeBusinessUnit = "business_unit"
+	ScopeTenant       = "tenant"
+)
+
+// Authorization check reasons
+const (
+	ReasonPermissionGranted   = "granted by role %s via assignment %s with %s scope"
+	ReasonPermissionInherited = "inherited from role %s by role %s via assignment %s with %s scope"
+	ReasonNoActiveGrant       = "no active role assignment grants %s.%s"
+	ReasonScopeNotCovered     = "role assignments grant %s.%s but none of their scopes cover the target"
+)
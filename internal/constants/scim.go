@@ -0,0 +1,34 @@
+package constants
+
+// SCIM schema URNs (RFC 7643, RFC 7644)
+const (
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SCIMSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SCIMSchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+// SCIM error types, the scimType of an error response
+const (
+	SCIMTypeInvalidFilter = "invalidFilter"
+	SCIMTypeInvalidPath   = "invalidPath"
+	SCIMTypeInvalidValue  = "invalidValue"
+	SCIMTypeInvalidSyntax = "invalidSyntax"
+	SCIMTypeNoTarget      = "noTarget"
+	SCIMTypeUniqueness    = "uniqueness"
+)
+
+// SCIMContentType is the media type of SCIM requests and responses.
+const SCIMContentType = "application/scim+json"
+
+// SCIMLocalObjectIDPrefix marks the generated object ID of a user created
+// without an externalId; such IDs are not reported as externalId.
+const SCIMLocalObjectIDPrefix = "scim:"
+
+// SCIMMaxResults is the largest page a SCIM list request returns.
+const SCIMMaxResults = 200
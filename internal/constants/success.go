@@ -13,26 +13,128 @@ const (
 	SuccessMsgGetBusinessUnitByID         = "Successfully retrieved business unit by ID"
 
 	// Department Controller success messages
-	SuccessMsgGetAllDepartments   = "Successfully retrieved all departments"
-	SuccessMsgGetDepartmentByID   = "Successfully retrieved department by ID"
-	SuccessMsgGetDepartmentByName = "Successfully retrieved department by name"
+	SuccessMsgGetAllDepartments    = "Successfully retrieved all departments"
+	SuccessMsgGetDepartmentByID    = "Successfully retrieved department by ID"
+	SuccessMsgGetDepartmentByName  = "Successfully retrieved department by name"
+	SuccessMsgGetDepartmentSubtree = "Successfully retrieved department subtree"
+	SuccessMsgCreateDepartment     = "Successfully created department"
+	SuccessMsgUpdateDepartment     = "Successfully updated department"
+	SuccessMsgMoveDepartment       = "Successfully moved department"
+	SuccessMsgDeleteDepartment     = "Successfully deleted department"
 
 	// User Controller success messages
 	SuccessMsgGetCurrentUser          = "Successfully retrieved current user"
 	SuccessMsgGetAllUsersInDepartment = "Successfully retrieved all users in department"
 	SuccessMsgGetUserByID             = "Successfully retrieved user by ID"
+	SuccessMsgSearchUsers             = "Successfully retrieved users"
 	SuccessMsgGetUserByEmail          = "Successfully retrieved user by email"
 	SuccessMsgCreateUser              = "Successfully created user"
+	SuccessMsgSuspendUser             = "Successfully suspended user"
+	SuccessMsgUnlockUser              = "Successfully unlocked user"
+	SuccessMsgGetUserStatusChanges    = "Successfully retrieved user status changes"
+	SuccessMsgGetUserProfileChanges   = "Successfully retrieved user profile changes"
+	SuccessMsgGetManager              = "Successfully retrieved manager"
+	SuccessMsgGetManagementChain      = "Successfully retrieved management chain"
+	SuccessMsgGetReports              = "Successfully retrieved reports"
+	SuccessMsgGetOrgChart             = "Successfully retrieved org chart"
+
+	// Role Assignment Controller success messages
+	SuccessMsgGetRoleAssignmentByID     = "Successfully retrieved role assignment by ID"
+	SuccessMsgCreateRoleAssignment      = "Successfully created role assignment"
+	SuccessMsgBulkCreateRoleAssignments = "Successfully created role assignments"
+	SuccessMsgUpdateRoleAssignment      = "Successfully updated role assignment"
+	SuccessMsgRevokeRoleAssignment      = "Successfully revoked role assignment"
+
+	// Role Controller success messages
+	SuccessMsgSetRoleParent = "Successfully set parent role"
+
+	// SodConstraint Controller success messages
+	SuccessMsgGetSodConstraints    = "Successfully retrieved separation-of-duties constraints"
+	SuccessMsgGetSodConstraintByID = "Successfully retrieved separation-of-duties constraint"
+	SuccessMsgCreateSodConstraint  = "Successfully created separation-of-duties constraint"
+	SuccessMsgDeleteSodConstraint  = "Successfully deleted separation-of-duties constraint"
+	SuccessMsgGetSodViolations     = "Successfully retrieved separation-of-duties violations"
+
+	// AccessReview Controller success messages
+	SuccessMsgGetAccessReviewCampaigns    = "Successfully retrieved access review campaigns"
+	SuccessMsgGetAccessReviewCampaignByID = "Successfully retrieved access review campaign"
+	SuccessMsgCreateAccessReviewCampaign  = "Successfully started access review campaign"
+	SuccessMsgCompleteAccessReview        = "Successfully completed access review campaign"
+	SuccessMsgGetAccessReviewItems        = "Successfully retrieved access review items"
+	SuccessMsgDecideAccessReviewItem      = "Successfully recorded access review decision"
+
+	// DirectoryRoleMapping Controller success messages
+	SuccessMsgGetDirectoryRoleMappings    = "Successfully retrieved directory role mappings"
+	SuccessMsgGetDirectoryRoleMappingByID = "Successfully retrieved directory role mapping"
+	SuccessMsgCreateDirectoryRoleMapping  = "Successfully created directory role mapping"
+	SuccessMsgDeleteDirectoryRoleMapping  = "Successfully deleted directory role mapping"
+
+	// ElevationRequest Controller success messages
+	SuccessMsgGetElevationRequests    = "Successfully retrieved elevation requests"
+	SuccessMsgGetElevationRequestByID = "Successfully retrieved elevation request"
+	SuccessMsgCreateElevationRequest  = "Successfully submitted elevation request"
+	SuccessMsgApproveElevationRequest = "Successfully approved elevation request"
+	SuccessMsgRejectElevationRequest  = "Successfully rejected elevation request"
+	SuccessMsgCancelElevationRequest  = "Successfully cancelled elevation request"
+
+	// ServicePrincipal Controller success messages
+	SuccessMsgGetServicePrincipals    = "Successfully retrieved service principals"
+	SuccessMsgGetServicePrincipalByID = "Successfully retrieved service principal"
+	SuccessMsgCreateServicePrincipal  = "Successfully created service principal"
+	SuccessMsgDeleteServicePrincipal  = "Successfully deleted service principal"
+	SuccessMsgGetAPIKeys              = "Successfully retrieved API keys"
+	SuccessMsgCreateAPIKey            = "Successfully created API key"
+	SuccessMsgRotateAPIKey            = "Successfully rotated API key"
+	SuccessMsgRevokeAPIKey            = "Successfully revoked API key"
+
+	// Login Controller success messages
+	SuccessMsgLogout = "Successfully logged out"
+
+	// DevIssuer Controller success messages
+	SuccessMsgMintDevToken = "Successfully minted dev token"
+
+	// Authorization Controller success messages
+	SuccessMsgGetEffectivePermissions = "Successfully retrieved effective permissions"
+	SuccessMsgCheckAuthorization      = "Successfully evaluated authorization checks"
 
 	// Form Template Controller success messages
-	SuccessGetFormTemplates   = "Successfully retrieved all form templates"
-	SuccessCreateFormTemplate = "Successfully created form template"
-	SuccessUpdateFormTemplate = "Successfully updated form template"
-	SuccessDeleteFormTemplate = "Successfully deleted form template"
+	SuccessGetFormTemplates          = "Successfully retrieved all form templates"
+	SuccessCreateFormTemplate        = "Successfully created form template"
+	SuccessUpdateFormTemplate        = "Successfully updated form template"
+	SuccessDeleteFormTemplate        = "Successfully deleted form template"
+	SuccessSubmitFormTemplate        = "Successfully submitted form template for review"
+	SuccessRejectFormTemplate        = "Successfully returned form template to draft"
+	SuccessPublishFormTemplate       = "Successfully published form template"
+	SuccessRetireFormTemplate        = "Successfully retired form template"
+	SuccessGetFormTemplateVersions   = "Successfully retrieved form template versions"
+	SuccessGetFormTemplateVersion    = "Successfully retrieved form template version"
+	SuccessCreateFormTemplateVersion = "Successfully created form template version"
+	SuccessGetFormDefinition         = "Successfully retrieved form definition"
 
 	// Form Section Controller success messages
 	SuccessGetFormSections   = "Successfully retrieved all form sections"
 	SuccessCreateFormSection = "Successfully created form section"
 	SuccessUpdateFormSection = "Successfully updated form section"
 	SuccessDeleteFormSection = "Successfully deleted form section"
+
+	// Form Field Controller success messages
+	SuccessGetFormFields   = "Successfully retrieved form fields"
+	SuccessGetFormField    = "Successfully retrieved form field"
+	SuccessCreateFormField = "Successfully created form field"
+	SuccessUpdateFormField = "Successfully updated form field"
+	SuccessDeleteFormField = "Successfully deleted form field"
+
+	// Field Type Controller success messages
+	SuccessGetFieldTypes   = "Successfully retrieved field types"
+	SuccessGetFieldType    = "Successfully retrieved field type"
+	SuccessCreateFieldType = "Successfully created field type"
+	SuccessUpdateFieldType = "Successfully updated field type"
+	SuccessDeleteFieldType = "Successfully deleted field type"
+
+	// Form Submission Controller success messages
+	SuccessGetFormSubmissions      = "Successfully retrieved form submissions"
+	SuccessGetFormSubmission       = "Successfully retrieved form submission"
+	SuccessCreateFormSubmission    = "Successfully submitted form"
+	SuccessSaveFormSubmissionDraft = "Successfully saved form submission draft"
+	SuccessDeleteFormSubmission    = "Successfully deleted form submission draft"
 )
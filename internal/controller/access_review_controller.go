@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AccessReviewController struct {
+	services *service.Services
+}
+
+func NewAccessReviewController(services *service.Services) *AccessReviewController {
+	return &AccessReviewController{
+		services: services,
+	}
+}
+
+// GetCampaigns godoc
+// @Summary List access review campaigns
+// @Description List access review campaigns with their decision progress
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.AccessReviewCampaignsListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-reviews [get]
+// @Security BearerAuth
+func (c *AccessReviewController) GetCampaigns(ctx *gin.Context) {
+	campaigns, err := c.services.AccessReview.GetCampaigns(ctx.Request.Context())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToRetrieveAccessReviewsMsg)
+		utils.SendInternalServerError(ctx, constants.ErrFailedToRetrieveAccessReviewsMsg)
+		return
+	}
+
+	responses := make([]dtos.AccessReviewCampaignResponse, len(campaigns))
+	for i, campaign := range campaigns {
+		responses[i] = *campaign
+	}
+
+	response := dtos.NewAccessReviewCampaignsListResponse(responses, 1, len(responses), int64(len(responses)))
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetAccessReviewCampaigns, response)
+}
+
+// GetCampaignByID godoc
+// @Summary Get access review campaign
+// @Description Retrieve a single access review campaign with its decision progress
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {object} dtos.AccessReviewCampaignResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-reviews/{campaignId} [get]
+// @Security BearerAuth
+func (c *AccessReviewController) GetCampaignByID(ctx *gin.Context) {
+	id := ctx.Param("campaignId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrAccessReviewCampaignIDRequiredMsg)
+		return
+	}
+
+	campaign, err := c.services.AccessReview.GetCampaignByID(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendAccessReviewError(ctx, err, constants.ErrFailedToRetrieveAccessReviewsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetAccessReviewCampaignByID, campaign)
+}
+
+// CreateCampaign godoc
+// @Summary Start access review campaign
+// @Description Start a recertification campaign over the active role assignments matching the given roles and business units (all when empty). Each assignment is reviewed by the assignee's manager, or by the campaign creator when the assignee has none; nobody reviews their own assignment. Items still pending at ends_at are revoked.
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateAccessReviewCampaignRequest true "Create campaign request"
+// @Success 201 {object} dtos.AccessReviewCampaignResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-reviews [post]
+// @Security BearerAuth
+func (c *AccessReviewController) CreateCampaign(ctx *gin.Context) {
+	var req dtos.CreateAccessReviewCampaignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	campaign, err := c.services.AccessReview.CreateCampaign(ctx.Request.Context(), &req)
+	if err != nil {
+		c.sendAccessReviewError(ctx, err, constants.ErrFailedToCreateAccessReviewMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgCreateAccessReviewCampaign, campaign)
+}
+
+// CompleteCampaign godoc
+// @Summary Complete access review campaign
+// @Description Close an active campaign before its end date. Items still pending are revoked.
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {object} dtos.AccessReviewCampaignResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-reviews/{campaignId}/complete [post]
+// @Security BearerAuth
+func (c *AccessReviewController) CompleteCampaign(ctx *gin.Context) {
+	id := ctx.Param("campaignId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrAccessReviewCampaignIDRequiredMsg)
+		return
+	}
+
+	campaign, err := c.services.AccessReview.CompleteCampaign(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendAccessReviewError(ctx, err, constants.ErrFailedToCompleteAccessReviewMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgCompleteAccessReview, campaign)
+}
+
+// GetCampaignItems godoc
+// @Summary List access review items
+// @Description List every item of a campaign with its reviewer and decision
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
+// @Success 200 {object} dtos.AccessReviewItemsListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-reviews/{campaignId}/items [get]
+// @Security BearerAuth
+func (c *AccessReviewController) GetCampaignItems(ctx *gin.Context) {
+	id := ctx.Param("campaignId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrAccessReviewCampaignIDRequiredMsg)
+		return
+	}
+	page, ok := bindPage(ctx)
+	if !ok {
+		return
+	}
+
+	items, total, err := c.services.AccessReview.GetCampaignItems(ctx.Request.Context(), id, page)
+	if err != nil {
+		c.sendAccessReviewError(ctx, err, constants.ErrFailedToRetrieveAccessReviewItemsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetAccessReviewItems, newAccessReviewItemsList(items, page, total))
+}
+
+// ExportCampaign godoc
+// @Summary Export access review campaign
+// @Description Download every item of a campaign, with its decision, as CSV
+// @Tags access-reviews
+// @Produce text/csv
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {file} file
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-reviews/{campaignId}/export [get]
+// @Security BearerAuth
+func (c *AccessReviewController) ExportCampaign(ctx *gin.Context) {
+	id := ctx.Param("campaignId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrAccessReviewCampaignIDRequiredMsg)
+		return
+	}
+
+	data, err := c.services.AccessReview.ExportCampaignCSV(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendAccessReviewError(ctx, err, constants.ErrFailedToExportAccessReviewMsg)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=access-review-%s.csv", id))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// GetMyPendingItems godoc
+// @Summary List my pending access reviews
+// @Description List the undecided items assigned to the authenticated user across active campaigns
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
+// @Success 200 {object} dtos.AccessReviewItemsListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-review-items [get]
+// @Security BearerAuth
+func (c *AccessReviewController) GetMyPendingItems(ctx *gin.Context) {
+	page, ok := bindPage(ctx)
+	if !ok {
+		return
+	}
+
+	items, total, err := c.services.AccessReview.GetMyPendingItems(ctx.Request.Context(), page)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToRetrieveAccessReviewItemsMsg)
+		utils.SendInternalServerError(ctx, constants.ErrFailedToRetrieveAccessReviewItemsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetAccessReviewItems, newAccessReviewItemsList(items, page, total))
+}
+
+// DecideItem godoc
+// @Summary Decide access review item
+// @Description Approve or revoke a role assignment under review before the campaign's ends_at. Only the assigned reviewer may decide, never for their own assignment, and a revoke takes effect immediately.
+// @Tags access-reviews
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Item ID"
+// @Param request body dtos.DecideAccessReviewItemRequest true "Decision request"
+// @Success 200 {object} dtos.AccessReviewItemResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/access-review-items/{itemId}/decision [post]
+// @Security BearerAuth
+func (c *AccessReviewController) DecideItem(ctx *gin.Context) {
+	id := ctx.Param("itemId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrAccessReviewItemIDRequiredMsg)
+		return
+	}
+
+	var req dtos.DecideAccessReviewItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	item, err := c.services.AccessReview.DecideItem(ctx.Request.Context(), id, &req)
+	if err != nil {
+		c.sendAccessReviewError(ctx, err, constants.ErrFailedToDecideAccessReviewItemMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgDecideAccessReviewItem, item)
+}
+
+// sendAccessReviewError maps service validation errors to client responses and everything else to a 500.
+func (c *AccessReviewController) sendAccessReviewError(ctx *gin.Context, err error, fallback string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrAccessReviewCampaignNotFound), errors.Is(err, constants.ErrAccessReviewItemNotFound):
+		utils.SendNotFound(ctx, err.Error())
+	case errors.Is(err, constants.ErrNotAccessReviewer), errors.Is(err, constants.ErrAccessReviewSelfReview):
+		utils.SendForbidden(ctx, err.Error())
+	case errors.Is(err, constants.ErrAccessReviewCampaignClosed), errors.Is(err, constants.ErrAccessReviewItemDecided),
+		errors.Is(err, constants.ErrAccessReviewCampaignEnded):
+		utils.SendConflict(ctx, err.Error())
+	case errors.Is(err, constants.ErrInvalidEndsAt):
+		utils.SendValidationError(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallback)
+	}
+}
+
+func newAccessReviewItemsList(items []*dtos.AccessReviewItemResponse, page dtos.PageRequest, total int64) *dtos.AccessReviewItemsListResponse {
+	responses := make([]dtos.AccessReviewItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = *item
+	}
+
+	return dtos.NewAccessReviewItemsListResponse(responses, page.Page, page.PageSize, total)
+}
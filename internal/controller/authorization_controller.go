@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"net/http"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	responseModel "yet-another-itsm/internal/dtos"
+)
+
+type AuthorizationController struct {
+	services *service.Services
+}
+
+func NewAuthorizationController(services *service.Services) *AuthorizationController {
+	return &AuthorizationController{
+		services: services,
+	}
+}
+
+// GetMyEffectivePermissions godoc
+// @Summary Get effective permissions of the current user
+// @Description Flatten the caller's active, non-expired role assignments into resource/action/scope tuples
+// @Tags authorization
+// @Accept json
+// @Produce json
+// @Success 200 {object} responseModel.EffectivePermissionsResponse
+// @Failure 401 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/users/me/effective-permissions [get]
+func (ac *AuthorizationController) GetMyEffectivePermissions(c *gin.Context) {
+	log.Info().
+		Str("controller", "AuthorizationController").
+		Str("endpoint", "GetMyEffectivePermissions").
+		Str("method", c.Request.Method).
+		Msg("Get effective permissions endpoint called")
+
+	ctx := c.Request.Context()
+
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToGetUserID)
+		utils.SendUnauthorized(c, constants.ErrUserIDNotFound)
+		return
+	}
+
+	permissions, err := ac.services.Authorization.GetEffectivePermissions(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg(constants.ErrFailedToGetEffectivePermissionsMsg)
+		utils.SendInternalServerError(c, constants.ErrFailedToGetEffectivePermissionsMsg)
+		return
+	}
+
+	response := responseModel.EffectivePermissionsResponse{
+		UserID:      userID,
+		Permissions: make([]responseModel.EffectivePermission, len(permissions)),
+	}
+	for i, permission := range permissions {
+		response.Permissions[i] = *permission
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetEffectivePermissions, response)
+}
+
+// CheckAuthorization godoc
+// @Summary Check a batch of permissions for the current user
+// @Description Evaluate (resource, action, target) tuples and return allow/deny with the granting role and assignment
+// @Tags authorization
+// @Accept json
+// @Produce json
+// @Param request body responseModel.AuthorizationCheckRequest true "Authorization check request"
+// @Success 200 {object} responseModel.AuthorizationCheckResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 401 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/authz/check [post]
+func (ac *AuthorizationController) CheckAuthorization(c *gin.Context) {
+	log.Info().
+		Str("controller", "AuthorizationController").
+		Str("endpoint", "CheckAuthorization").
+		Str("method", c.Request.Method).
+		Msg("Authorization check endpoint called")
+
+	var req responseModel.AuthorizationCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to bind request")
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	results, err := ac.services.Authorization.Check(ctx, req.Checks)
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToCheckPermissionsMsg)
+		utils.SendInternalServerError(c, constants.ErrFailedToCheckPermissionsMsg)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgCheckAuthorization, responseModel.AuthorizationCheckResponse{Results: results})
+}
@@ -1,37 +1,70 @@
 package controller
 
 import (
+	"yet-another-itsm/internal/config"
 	"yet-another-itsm/internal/service"
 )
 
 type Controllers struct {
-	Health         *HealthController
-	BusinessUnit   *BusinessUnitController
-	Department     *DepartmentController
-	User           *UserController
-	Role           *RoleController
-	Permission     *PermissionController
-	Scope          *ScopeController
-	RolePermission *RolePermissionController
-	RoleAssignment *RoleAssignmentController
-	FormCategory   *FormCategoryController
-	FormTemplate   *FormTemplateController
-	FormSection    *FormSectionController
+	Health               *HealthController
+	BusinessUnit         *BusinessUnitController
+	Department           *DepartmentController
+	User                 *UserController
+	Role                 *RoleController
+	Permission           *PermissionController
+	Scope                *ScopeController
+	RolePermission       *RolePermissionController
+	RoleAssignment       *RoleAssignmentController
+	FormCategory         *FormCategoryController
+	FormTemplate         *FormTemplateController
+	FormSection          *FormSectionController
+	FormField            *FormFieldController
+	FormSubmission       *FormSubmissionController
+	FieldType            *FieldTypeController
+	Authorization        *AuthorizationController
+	SodConstraint        *SodConstraintController
+	AccessReview         *AccessReviewController
+	DirectoryRoleMapping *DirectoryRoleMappingController
+	ElevationRequest     *ElevationRequestController
+	ServicePrincipal     *ServicePrincipalController
+	OrgChart             *OrgChartController
+	SCIM                 *SCIMController
+	Login                *LoginController
+	// DevIssuer is nil unless AUTH_PROVIDER is dev.
+	DevIssuer *DevIssuerController
 }
 
-func NewControllers(services *service.Services) *Controllers {
-	return &Controllers{
-		Health:         NewHealthController(services),
-		BusinessUnit:   NewBusinessUnitController(services),
-		Department:     NewDepartmentController(services),
-		User:           NewUserController(services),
-		Role:           NewRoleController(services),
-		Permission:     NewPermissionController(services),
-		Scope:          NewScopeController(services),
-		RolePermission: NewRolePermissionController(services),
-		RoleAssignment: NewRoleAssignmentController(services),
-		FormCategory:   NewFormCategoryController(services),
-		FormTemplate:   NewFormTemplateController(services),
-		FormSection:    NewFormSectionController(services),
+func NewControllers(services *service.Services, cfg *config.Config) *Controllers {
+	controllers := &Controllers{
+		Health:               NewHealthController(services),
+		BusinessUnit:         NewBusinessUnitController(services),
+		Department:           NewDepartmentController(services),
+		User:                 NewUserController(services),
+		Role:                 NewRoleController(services),
+		Permission:           NewPermissionController(services),
+		Scope:                NewScopeController(services),
+		RolePermission:       NewRolePermissionController(services),
+		RoleAssignment:       NewRoleAssignmentController(services),
+		FormCategory:         NewFormCategoryController(services),
+		FormTemplate:         NewFormTemplateController(services),
+		FormSection:          NewFormSectionController(services),
+		FormField:            NewFormFieldController(services),
+		FormSubmission:       NewFormSubmissionController(services),
+		FieldType:            NewFieldTypeController(services),
+		Authorization:        NewAuthorizationController(services),
+		SodConstraint:        NewSodConstraintController(services),
+		AccessReview:         NewAccessReviewController(services),
+		DirectoryRoleMapping: NewDirectoryRoleMappingController(services),
+		ElevationRequest:     NewElevationRequestController(services),
+		ServicePrincipal:     NewServicePrincipalController(services),
+		OrgChart:             NewOrgChartController(services),
+		SCIM:                 NewSCIMController(services),
+		Login:                NewLoginController(services, cfg.Session),
 	}
+
+	if cfg.OAuth.DevIssuer != nil {
+		controllers.DevIssuer = NewDevIssuerController(cfg.OAuth.DevIssuer)
+	}
+
+	return controllers
 }
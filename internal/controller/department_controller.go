@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"yet-another-itsm/internal/constants"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+
+	responseModel "yet-another-itsm/internal/dtos"
 )
 
 type DepartmentController struct {
@@ -21,6 +24,42 @@ func NewDepartmentController(services *service.Services) *DepartmentController {
 	}
 }
 
+// GetDepartments godoc
+// @Summary List departments
+// @Description List the departments of the caller's tenant, optionally narrowed to a business unit and to the direct children of a department
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param business_unit_id query string false "Business Unit ID"
+// @Param parent_id query string false "Parent Department ID"
+// @Success 200 {object} responseModel.DepartmentsListResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/departments [get]
+func (dc *DepartmentController) GetDepartments(c *gin.Context) {
+	log.Info().
+		Str("controller", "DepartmentController").
+		Str("endpoint", "GetDepartments").
+		Str("method", c.Request.Method).
+		Msg("Get departments endpoint called")
+
+	departments, err := dc.services.Department.ListDepartments(c.Request.Context(), c.Query("business_unit_id"), c.Query("parent_id"))
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToRetrieveDepartmentsMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToRetrieveDepartmentsMsg)
+		return
+	}
+
+	responses := make([]responseModel.DepartmentResponse, len(departments))
+	for i, department := range departments {
+		responses[i] = *department.ToResponse()
+	}
+
+	response := responseModel.NewDepartmentsListResponse(responses, 1, len(responses), int64(len(responses)))
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetAllDepartments, response)
+}
+
 // GetDepartmentByID godoc
 // @Summary Get department by ID
 // @Description Get department by ID
@@ -51,7 +90,7 @@ func (dc *DepartmentController) GetDepartmentByID(c *gin.Context) {
 	department, err := dc.services.Department.GetDepartmentByID(ctx, id)
 	if err != nil {
 		log.Error().Err(err).Str("id", id).Msg(constants.ErrFailedToGetDepartmentByIDMsg)
-		utils.SendNotFound(c, constants.ErrDepartmentNotFoundMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToGetDepartmentByIDMsg)
 		return
 	}
 
@@ -98,10 +137,10 @@ func (dc *DepartmentController) GetDepartmentByName(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	department, err := dc.services.Department.GetDepartmentByName(ctx, name)
+	department, err := dc.services.Department.GetDepartmentByName(ctx, businessUnitID, name)
 	if err != nil {
 		log.Error().Err(err).Str("name", name).Str("business_unit_id", businessUnitID).Msg(constants.ErrFailedToGetDepartmentByNameMsg)
-		utils.SendNotFound(c, constants.ErrDepartmentNotFoundMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToGetDepartmentByNameMsg)
 		return
 	}
 
@@ -113,3 +152,226 @@ func (dc *DepartmentController) GetDepartmentByName(c *gin.Context) {
 
 	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetDepartmentByName, department.ToResponse())
 }
+
+// GetDepartmentSubtree godoc
+// @Summary Get department subtree
+// @Description List a department followed by its descendants, depth first; depth 0 is the department itself
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param departmentId path string true "Department ID"
+// @Success 200 {object} responseModel.DepartmentSubtreeListResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/departments/{departmentId}/subtree [get]
+func (dc *DepartmentController) GetDepartmentSubtree(c *gin.Context) {
+	log.Info().
+		Str("controller", "DepartmentController").
+		Str("endpoint", "GetDepartmentSubtree").
+		Str("method", c.Request.Method).
+		Msg("Get department subtree endpoint called")
+
+	id := c.Param("departmentId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrDepartmentIDRequiredMsg)
+		return
+	}
+
+	departments, err := dc.services.Department.GetDepartmentSubtree(c.Request.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg(constants.ErrFailedToGetDepartmentSubtreeMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToGetDepartmentSubtreeMsg)
+		return
+	}
+
+	responses := make([]responseModel.DepartmentSubtreeResponse, len(departments))
+	for i, department := range departments {
+		responses[i] = *department.ToSubtreeResponse()
+	}
+
+	response := responseModel.NewDepartmentSubtreeListResponse(responses, 1, len(responses), int64(len(responses)))
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetDepartmentSubtree, response)
+}
+
+// CreateDepartment godoc
+// @Summary Create department
+// @Description Create a department in a business unit of the caller's tenant, optionally below a parent department of the same business unit
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param request body responseModel.CreateDepartmentRequest true "Create department request"
+// @Success 201 {object} responseModel.DepartmentResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/departments [post]
+func (dc *DepartmentController) CreateDepartment(c *gin.Context) {
+	log.Info().
+		Str("controller", "DepartmentController").
+		Str("endpoint", "CreateDepartment").
+		Str("method", c.Request.Method).
+		Msg("Create department endpoint called")
+
+	var req responseModel.CreateDepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	department, err := dc.services.Department.CreateDepartment(c.Request.Context(), &req)
+	if err != nil {
+		log.Error().Err(err).Str("name", req.Name).Msg(constants.ErrFailedToCreateDepartmentMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToCreateDepartmentMsg)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, constants.SuccessMsgCreateDepartment, department.ToResponse())
+}
+
+// UpdateDepartment godoc
+// @Summary Update department
+// @Description Rename a department or change its status
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param departmentId path string true "Department ID"
+// @Param request body responseModel.UpdateDepartmentRequest true "Update department request"
+// @Success 200 {object} responseModel.DepartmentResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/departments/{departmentId} [put]
+func (dc *DepartmentController) UpdateDepartment(c *gin.Context) {
+	log.Info().
+		Str("controller", "DepartmentController").
+		Str("endpoint", "UpdateDepartment").
+		Str("method", c.Request.Method).
+		Msg("Update department endpoint called")
+
+	id := c.Param("departmentId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrDepartmentIDRequiredMsg)
+		return
+	}
+
+	var req responseModel.UpdateDepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	department, err := dc.services.Department.UpdateDepartment(c.Request.Context(), id, &req)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg(constants.ErrFailedToUpdateDepartmentMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToUpdateDepartmentMsg)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgUpdateDepartment, department.ToResponse())
+}
+
+// MoveDepartment godoc
+// @Summary Move department
+// @Description Move a department and its subtree below another department, or to the root of a business unit. The subtree moves into the business unit of its new parent.
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param departmentId path string true "Department ID"
+// @Param request body responseModel.MoveDepartmentRequest true "Move department request"
+// @Success 200 {object} responseModel.DepartmentResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/departments/{departmentId}/parent [put]
+func (dc *DepartmentController) MoveDepartment(c *gin.Context) {
+	log.Info().
+		Str("controller", "DepartmentController").
+		Str("endpoint", "MoveDepartment").
+		Str("method", c.Request.Method).
+		Msg("Move department endpoint called")
+
+	id := c.Param("departmentId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrDepartmentIDRequiredMsg)
+		return
+	}
+
+	var req responseModel.MoveDepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	department, err := dc.services.Department.MoveDepartment(c.Request.Context(), id, &req)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg(constants.ErrFailedToMoveDepartmentMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToMoveDepartmentMsg)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgMoveDepartment, department.ToResponse())
+}
+
+// DeleteDepartment godoc
+// @Summary Delete department
+// @Description Soft delete a department without active child departments
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param departmentId path string true "Department ID"
+// @Success 200 {object} responseModel.DepartmentResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/departments/{departmentId} [delete]
+func (dc *DepartmentController) DeleteDepartment(c *gin.Context) {
+	log.Info().
+		Str("controller", "DepartmentController").
+		Str("endpoint", "DeleteDepartment").
+		Str("method", c.Request.Method).
+		Msg("Delete department endpoint called")
+
+	id := c.Param("departmentId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrDepartmentIDRequiredMsg)
+		return
+	}
+
+	department, err := dc.services.Department.DeleteDepartment(c.Request.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg(constants.ErrFailedToDeleteDepartmentMsg)
+		sendDepartmentError(c, err, constants.ErrFailedToDeleteDepartmentMsg)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgDeleteDepartment, department.ToResponse())
+}
+
+// sendDepartmentError maps department validation errors to client responses
+// and everything else to a 500 with the fallback message.
+func sendDepartmentError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, constants.ErrDepartmentNotFound), errors.Is(err, constants.ErrParentDepartmentNotFound),
+		errors.Is(err, constants.ErrBusinessUnitNotFound):
+		utils.SendNotFound(c, err.Error())
+	case errors.Is(err, constants.ErrDepartmentHierarchyCycle), errors.Is(err, constants.ErrDepartmentNameTaken),
+		errors.Is(err, constants.ErrDepartmentHasChildren), errors.Is(err, constants.ErrDepartmentHasRoleAssignments):
+		utils.SendConflict(c, err.Error())
+	case errors.Is(err, constants.ErrDepartmentBusinessUnitMismatch), errors.Is(err, constants.ErrDepartmentBusinessUnitRequired),
+		errors.Is(err, constants.ErrInvalidDepartmentReference), errors.Is(err, constants.ErrInvalidDepartmentStatus):
+		utils.SendValidationError(c, err.Error())
+	default:
+		utils.SendInternalServerError(c, fallback)
+	}
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// devTokenTTL is the lifetime of dev tokens minted without ttl_minutes.
+const devTokenTTL = time.Hour
+
+// DevIssuerController serves the local development issuer. Its routes are only
+// registered when AUTH_PROVIDER is dev.
+type DevIssuerController struct {
+	issuer *config.DevIssuer
+}
+
+func NewDevIssuerController(issuer *config.DevIssuer) *DevIssuerController {
+	return &DevIssuerController{
+		issuer: issuer,
+	}
+}
+
+// Discovery serves the OpenID provider metadata of the dev issuer.
+func (c *DevIssuerController) Discovery(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, dtos.DevDiscoveryResponse{
+		Issuer:                           c.issuer.Issuer(),
+		JWKSURI:                          c.issuer.Issuer() + "/jwks",
+		TokenEndpoint:                    c.issuer.Issuer() + "/token",
+		IDTokenSigningAlgValuesSupported: []string{jwt.SigningMethodRS256.Alg()},
+	})
+}
+
+// JWKS serves the public key that verifies dev tokens.
+func (c *DevIssuerController) JWKS(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.issuer.JWKS())
+}
+
+// MintToken godoc
+// @Summary Mint dev token
+// @Description Sign an access token for any user, tenant, app roles and groups. Only available when AUTH_PROVIDER is dev.
+// @Tags dev
+// @Accept json
+// @Produce json
+// @Param request body dtos.MintDevTokenRequest true "Token claims"
+// @Success 200 {object} dtos.DevTokenResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Router /dev/token [post]
+func (c *DevIssuerController) MintToken(ctx *gin.Context) {
+	var req dtos.MintDevTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	ttl := devTokenTTL
+	if req.TTLMinutes > 0 {
+		ttl = time.Duration(req.TTLMinutes) * time.Minute
+	}
+
+	token, expiresAt, err := c.issuer.Mint(config.DevTokenClaims{
+		ObjectID:       req.ObjectID,
+		TenantID:       req.TenantID,
+		Name:           req.Name,
+		Email:          req.Email,
+		GivenName:      req.GivenName,
+		FamilyName:     req.FamilyName,
+		JobTitle:       req.JobTitle,
+		Department:     req.Department,
+		OfficeLocation: req.OfficeLocation,
+		Roles:          req.Roles,
+		Groups:         req.Groups,
+		Scopes:         req.Scopes,
+		AppOnly:        req.AppOnly,
+	}, ttl)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToMintDevTokenMsg)
+		if errors.Is(err, constants.ErrDevTokenSubjectRequired) {
+			utils.SendValidationError(ctx, err.Error())
+			return
+		}
+		utils.SendInternalServerError(ctx, constants.ErrFailedToMintDevTokenMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgMintDevToken, dtos.DevTokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Seconds()),
+		ExpiresAt:   utils.FormatTime(expiresAt),
+	})
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+type DirectoryRoleMappingController struct {
+	services *service.Services
+}
+
+func NewDirectoryRoleMappingController(services *service.Services) *DirectoryRoleMappingController {
+	return &DirectoryRoleMappingController{
+		services: services,
+	}
+}
+
+// GetDirectoryRoleMappings godoc
+// @Summary List directory role mappings
+// @Description List the Entra app roles and groups that grant internal roles at login
+// @Tags directory-role-mappings
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.DirectoryRoleMappingsListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/directory-role-mappings [get]
+// @Security BearerAuth
+func (c *DirectoryRoleMappingController) GetDirectoryRoleMappings(ctx *gin.Context) {
+	mappings, err := c.services.DirectoryRoleMapping.GetMappings(ctx.Request.Context())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToRetrieveDirectoryRoleMappingsMsg)
+		utils.SendInternalServerError(ctx, constants.ErrFailedToRetrieveDirectoryRoleMappingsMsg)
+		return
+	}
+
+	responses := make([]dtos.DirectoryRoleMappingResponse, len(mappings))
+	for i, mapping := range mappings {
+		responses[i] = *mapping
+	}
+
+	response := dtos.NewDirectoryRoleMappingsListResponse(responses, 1, len(responses), int64(len(responses)))
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetDirectoryRoleMappings, response)
+}
+
+// GetDirectoryRoleMappingByID godoc
+// @Summary Get directory role mapping
+// @Description Retrieve a single directory role mapping
+// @Tags directory-role-mappings
+// @Accept json
+// @Produce json
+// @Param mappingId path string true "Mapping ID"
+// @Success 200 {object} dtos.DirectoryRoleMappingResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/directory-role-mappings/{mappingId} [get]
+// @Security BearerAuth
+func (c *DirectoryRoleMappingController) GetDirectoryRoleMappingByID(ctx *gin.Context) {
+	id := ctx.Param("mappingId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrDirectoryRoleMappingIDRequiredMsg)
+		return
+	}
+
+	mapping, err := c.services.DirectoryRoleMapping.GetMappingByID(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendDirectoryRoleMappingError(ctx, err, constants.ErrFailedToRetrieveDirectoryRoleMappingsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetDirectoryRoleMappingByID, mapping)
+}
+
+// CreateDirectoryRoleMapping godoc
+// @Summary Create directory role mapping
+// @Description Map an Entra app role value or group object ID to an internal role, optionally scoped to a business unit. Users carrying the claim receive the role's permissions the next time they call /v1/users/me.
+// @Tags directory-role-mappings
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateDirectoryRoleMappingRequest true "Create mapping request"
+// @Success 201 {object} dtos.DirectoryRoleMappingResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/directory-role-mappings [post]
+// @Security BearerAuth
+func (c *DirectoryRoleMappingController) CreateDirectoryRoleMapping(ctx *gin.Context) {
+	var req dtos.CreateDirectoryRoleMappingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	mapping, err := c.services.DirectoryRoleMapping.CreateMapping(ctx.Request.Context(), &req)
+	if err != nil {
+		c.sendDirectoryRoleMappingError(ctx, err, constants.ErrFailedToCreateDirectoryRoleMappingMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgCreateDirectoryRoleMapping, mapping)
+}
+
+// DeleteDirectoryRoleMapping godoc
+// @Summary Delete directory role mapping
+// @Description Remove a directory role mapping and revoke the role assignments it created
+// @Tags directory-role-mappings
+// @Accept json
+// @Produce json
+// @Param mappingId path string true "Mapping ID"
+// @Success 200 {object} dtos.DirectoryRoleMappingResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/directory-role-mappings/{mappingId} [delete]
+// @Security BearerAuth
+func (c *DirectoryRoleMappingController) DeleteDirectoryRoleMapping(ctx *gin.Context) {
+	id := ctx.Param("mappingId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrDirectoryRoleMappingIDRequiredMsg)
+		return
+	}
+
+	mapping, err := c.services.DirectoryRoleMapping.DeleteMapping(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendDirectoryRoleMappingError(ctx, err, constants.ErrFailedToDeleteDirectoryRoleMappingMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgDeleteDirectoryRoleMapping, mapping)
+}
+
+// sendDirectoryRoleMappingError maps service validation errors to client responses and everything else to a 500.
+func (c *DirectoryRoleMappingController) sendDirectoryRoleMappingError(ctx *gin.Context, err error, fallback string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrDirectoryRoleMappingNotFound), errors.Is(err, constants.ErrRoleNotFound),
+		errors.Is(err, constants.ErrBusinessUnitNotFound), errors.Is(err, constants.ErrSCIMGroupNotFound):
+		utils.SendNotFound(ctx, err.Error())
+	case errors.Is(err, constants.ErrDirectoryRoleMappingAlreadyExists):
+		utils.SendConflict(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallback)
+	}
+}
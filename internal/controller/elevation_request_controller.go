@@ -0,0 +1,292 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ElevationRequestController struct {
+	services *service.Services
+}
+
+func NewElevationRequestController(services *service.Services) *ElevationRequestController {
+	return &ElevationRequestController{
+		services: services,
+	}
+}
+
+// GetElevationRequests godoc
+// @Summary List elevation requests
+// @Description Audit listing of elevation requests and their decisions, newest first
+// @Tags elevation-requests
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled, expired)"
+// @Param requester_id query string false "Filter by requester ID"
+// @Param role_id query string false "Filter by role ID"
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
+// @Success 200 {object} dtos.ElevationRequestsListResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/elevation-requests [get]
+// @Security BearerAuth
+func (c *ElevationRequestController) GetElevationRequests(ctx *gin.Context) {
+	filter := dtos.ElevationRequestFilter{
+		Status:      ctx.Query("status"),
+		RequesterID: ctx.Query("requester_id"),
+		RoleID:      ctx.Query("role_id"),
+	}
+	page, ok := bindPage(ctx)
+	if !ok {
+		return
+	}
+
+	requests, total, err := c.services.ElevationRequest.GetRequests(ctx.Request.Context(), filter, page)
+	if err != nil {
+		c.sendElevationRequestError(ctx, err, constants.ErrFailedToRetrieveElevationRequestsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetElevationRequests, newElevationRequestsList(requests, page, total))
+}
+
+// GetMyElevationRequests godoc
+// @Summary List my elevation requests
+// @Description List the elevation requests submitted by the authenticated user
+// @Tags elevation-requests
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
+// @Success 200 {object} dtos.ElevationRequestsListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/elevation-requests/mine [get]
+// @Security BearerAuth
+func (c *ElevationRequestController) GetMyElevationRequests(ctx *gin.Context) {
+	page, ok := bindPage(ctx)
+	if !ok {
+		return
+	}
+
+	requests, total, err := c.services.ElevationRequest.GetMyRequests(ctx.Request.Context(), page)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToRetrieveElevationRequestsMsg)
+		utils.SendInternalServerError(ctx, constants.ErrFailedToRetrieveElevationRequestsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetElevationRequests, newElevationRequestsList(requests, page, total))
+}
+
+// GetElevationRequestByID godoc
+// @Summary Get elevation request
+// @Description Retrieve a single elevation request with its decision
+// @Tags elevation-requests
+// @Accept json
+// @Produce json
+// @Param requestId path string true "Elevation request ID"
+// @Success 200 {object} dtos.ElevationRequestResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/elevation-requests/{requestId} [get]
+// @Security BearerAuth
+func (c *ElevationRequestController) GetElevationRequestByID(ctx *gin.Context) {
+	id := ctx.Param("requestId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrElevationRequestIDRequiredMsg)
+		return
+	}
+
+	request, err := c.services.ElevationRequest.GetRequestByID(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendElevationRequestError(ctx, err, constants.ErrFailedToRetrieveElevationRequestsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetElevationRequestByID, request)
+}
+
+// CreateElevationRequest godoc
+// @Summary Request role elevation
+// @Description Request a role for a bounded duration (at most 480 minutes) with a justification. Once approved the role's permissions are assigned until the elevation expires.
+// @Tags elevation-requests
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateElevationRequest true "Elevation request"
+// @Success 201 {object} dtos.ElevationRequestResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/elevation-requests [post]
+// @Security BearerAuth
+func (c *ElevationRequestController) CreateElevationRequest(ctx *gin.Context) {
+	var req dtos.CreateElevationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	request, err := c.services.ElevationRequest.CreateRequest(ctx.Request.Context(), &req)
+	if err != nil {
+		c.sendElevationRequestError(ctx, err, constants.ErrFailedToCreateElevationRequestMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgCreateElevationRequest, request)
+}
+
+// ApproveElevationRequest godoc
+// @Summary Approve elevation request
+// @Description Approve a pending elevation request and assign the role until the elevation expires. Requesters cannot approve their own requests.
+// @Tags elevation-requests
+// @Accept json
+// @Produce json
+// @Param requestId path string true "Elevation request ID"
+// @Param request body dtos.DecideElevationRequest false "Decision comment"
+// @Success 200 {object} dtos.ElevationRequestResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/elevation-requests/{requestId}/approve [post]
+// @Security BearerAuth
+func (c *ElevationRequestController) ApproveElevationRequest(ctx *gin.Context) {
+	id, req, ok := c.bindDecision(ctx)
+	if !ok {
+		return
+	}
+
+	request, err := c.services.ElevationRequest.ApproveRequest(ctx.Request.Context(), id, req)
+	if err != nil {
+		c.sendElevationRequestError(ctx, err, constants.ErrFailedToApproveElevationRequestMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgApproveElevationRequest, request)
+}
+
+// RejectElevationRequest godoc
+// @Summary Reject elevation request
+// @Description Reject a pending elevation request. Requesters cannot reject their own requests.
+// @Tags elevation-requests
+// @Accept json
+// @Produce json
+// @Param requestId path string true "Elevation request ID"
+// @Param request body dtos.DecideElevationRequest false "Decision comment"
+// @Success 200 {object} dtos.ElevationRequestResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/elevation-requests/{requestId}/reject [post]
+// @Security BearerAuth
+func (c *ElevationRequestController) RejectElevationRequest(ctx *gin.Context) {
+	id, req, ok := c.bindDecision(ctx)
+	if !ok {
+		return
+	}
+
+	request, err := c.services.ElevationRequest.RejectRequest(ctx.Request.Context(), id, req)
+	if err != nil {
+		c.sendElevationRequestError(ctx, err, constants.ErrFailedToRejectElevationRequestMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgRejectElevationRequest, request)
+}
+
+// CancelElevationRequest godoc
+// @Summary Cancel elevation request
+// @Description Withdraw one of the authenticated user's pending elevation requests
+// @Tags elevation-requests
+// @Accept json
+// @Produce json
+// @Param requestId path string true "Elevation request ID"
+// @Success 200 {object} dtos.ElevationRequestResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/elevation-requests/{requestId}/cancel [post]
+// @Security BearerAuth
+func (c *ElevationRequestController) CancelElevationRequest(ctx *gin.Context) {
+	id := ctx.Param("requestId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrElevationRequestIDRequiredMsg)
+		return
+	}
+
+	request, err := c.services.ElevationRequest.CancelRequest(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendElevationRequestError(ctx, err, constants.ErrFailedToCancelElevationRequestMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgCancelElevationRequest, request)
+}
+
+// bindDecision reads the request ID and the optional decision body; it writes
+// the error response itself and reports false when the request is malformed.
+func (c *ElevationRequestController) bindDecision(ctx *gin.Context) (string, *dtos.DecideElevationRequest, bool) {
+	id := ctx.Param("requestId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrElevationRequestIDRequiredMsg)
+		return "", nil, false
+	}
+
+	var req dtos.DecideElevationRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+			utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+			return "", nil, false
+		}
+	}
+
+	return id, &req, true
+}
+
+// sendElevationRequestError maps service validation errors to client responses and everything else to a 500.
+func (c *ElevationRequestController) sendElevationRequestError(ctx *gin.Context, err error, fallback string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrElevationRequestNotFound), errors.Is(err, constants.ErrRoleNotFound),
+		errors.Is(err, constants.ErrBusinessUnitNotFound):
+		utils.SendNotFound(ctx, err.Error())
+	case errors.Is(err, constants.ErrElevationSelfApproval):
+		utils.SendForbidden(ctx, err.Error())
+	case errors.Is(err, constants.ErrElevationRequestNotPending), errors.Is(err, constants.ErrSodConstraintViolation):
+		utils.SendConflict(ctx, err.Error())
+	case errors.Is(err, constants.ErrElevationRoleEmpty), errors.Is(err, constants.ErrInvalidElevationStatus):
+		utils.SendValidationError(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallback)
+	}
+}
+
+func newElevationRequestsList(requests []*dtos.ElevationRequestResponse, page dtos.PageRequest, total int64) *dtos.ElevationRequestsListResponse {
+	responses := make([]dtos.ElevationRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = *request
+	}
+
+	return dtos.NewElevationRequestsListResponse(responses, page.Page, page.PageSize, total)
+}
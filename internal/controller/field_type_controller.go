@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	responseModel "yet-another-itsm/internal/dtos"
+)
+
+type FieldTypeController struct {
+	services *service.Services
+}
+
+func NewFieldTypeController(services *service.Services) *FieldTypeController {
+	return &FieldTypeController{
+		services: services,
+	}
+}
+
+// GetFieldTypes godoc
+// @Summary Get field types
+// @Description Get the registered field types, built-in and custom, ordered by name
+// @Tags field-types
+// @Accept json
+// @Produce json
+// @Success 200 {object} responseModel.FieldTypesListResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/field-types [get]
+func (ft *FieldTypeController) GetFieldTypes(c *gin.Context) {
+	log.Info().
+		Str("controller", "FieldTypeController").
+		Str("endpoint", "GetFieldTypes").
+		Str("method", c.Request.Method).
+		Msg("Get field types endpoint called")
+
+	fieldTypes, err := ft.services.FieldType.GetFieldTypes(c.Request.Context())
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToGetFieldTypes)
+		sendFieldTypeError(c, err, constants.ErrFailedToGetFieldTypes)
+		return
+	}
+
+	items := make([]responseModel.FieldTypeResponse, len(fieldTypes))
+	for i, fieldType := range fieldTypes {
+		items[i] = *fieldType.ToResponse()
+	}
+
+	response := responseModel.NewFieldTypesListResponse(items, 1, len(items), int64(len(items)))
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFieldTypes, response)
+}
+
+// GetFieldTypeByID godoc
+// @Summary Get field type by ID
+// @Description Get a field type with its validation schema
+// @Tags field-types
+// @Accept json
+// @Produce json
+// @Param fieldTypeId path string true "Field type ID"
+// @Success 200 {object} responseModel.FieldTypeResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/field-types/{fieldTypeId} [get]
+func (ft *FieldTypeController) GetFieldTypeByID(c *gin.Context) {
+	log.Info().
+		Str("controller", "FieldTypeController").
+		Str("endpoint", "GetFieldTypeByID").
+		Str("method", c.Request.Method).
+		Msg("Get field type by ID endpoint called")
+
+	id := c.Param("fieldTypeId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrFieldTypeIDRequired)
+		return
+	}
+
+	fieldType, err := ft.services.FieldType.GetFieldTypeByID(c.Request.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Str("fieldTypeId", id).Msg(constants.ErrFailedToGetFieldType)
+		sendFieldTypeError(c, err, constants.ErrFailedToGetFieldType)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFieldType, fieldType.ToResponse())
+}
+
+// CreateFieldType godoc
+// @Summary Create field type
+// @Description Register a custom field type. validation_schema is the JSON Schema every field config of this type must satisfy; unsupported keywords are rejected with their paths.
+// @Tags field-types
+// @Accept json
+// @Produce json
+// @Param fieldType body responseModel.CreateFieldTypeRequest true "Field type to create"
+// @Success 201 {object} responseModel.FieldTypeResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/field-types [post]
+func (ft *FieldTypeController) CreateFieldType(c *gin.Context) {
+	log.Info().
+		Str("controller", "FieldTypeController").
+		Str("endpoint", "CreateFieldType").
+		Str("method", c.Request.Method).
+		Msg("Create field type endpoint called")
+
+	var req responseModel.CreateFieldTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	fieldType, err := ft.services.FieldType.CreateFieldType(c.Request.Context(), &req)
+	if err != nil {
+		log.Error().Err(err).Str("typeName", req.TypeName).Msg(constants.ErrFailedToCreateFieldType)
+		sendFieldTypeError(c, err, constants.ErrFailedToCreateFieldType)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, constants.SuccessCreateFieldType, fieldType.ToResponse())
+}
+
+// UpdateFieldType godoc
+// @Summary Update field type
+// @Description Replace the description and validation schema of a field type; its name cannot change
+// @Tags field-types
+// @Accept json
+// @Produce json
+// @Param fieldTypeId path string true "Field type ID"
+// @Param fieldType body responseModel.UpdateFieldTypeRequest true "Field type to update"
+// @Success 200 {object} responseModel.FieldTypeResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/field-types/{fieldTypeId} [put]
+func (ft *FieldTypeController) UpdateFieldType(c *gin.Context) {
+	log.Info().
+		Str("controller", "FieldTypeController").
+		Str("endpoint", "UpdateFieldType").
+		Str("method", c.Request.Method).
+		Msg("Update field type endpoint called")
+
+	id := c.Param("fieldTypeId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrFieldTypeIDRequired)
+		return
+	}
+
+	var req responseModel.UpdateFieldTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	fieldType, err := ft.services.FieldType.UpdateFieldType(c.Request.Context(), id, &req)
+	if err != nil {
+		log.Error().Err(err).Str("fieldTypeId", id).Msg(constants.ErrFailedToUpdateFieldType)
+		sendFieldTypeError(c, err, constants.ErrFailedToUpdateFieldType)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessUpdateFieldType, fieldType.ToResponse())
+}
+
+// DeleteFieldType godoc
+// @Summary Delete field type
+// @Description Delete a custom field type that no form field uses
+// @Tags field-types
+// @Accept json
+// @Produce json
+// @Param fieldTypeId path string true "Field type ID"
+// @Success 200 {object} responseModel.SuccessResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/field-types/{fieldTypeId} [delete]
+func (ft *FieldTypeController) DeleteFieldType(c *gin.Context) {
+	log.Info().
+		Str("controller", "FieldTypeController").
+		Str("endpoint", "DeleteFieldType").
+		Str("method", c.Request.Method).
+		Msg("Delete field type endpoint called")
+
+	id := c.Param("fieldTypeId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrFieldTypeIDRequired)
+		return
+	}
+
+	if err := ft.services.FieldType.DeleteFieldType(c.Request.Context(), id); err != nil {
+		log.Error().Err(err).Str("fieldTypeId", id).Msg(constants.ErrFailedToDeleteFieldType)
+		sendFieldTypeError(c, err, constants.ErrFailedToDeleteFieldType)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessDeleteFieldType, nil)
+}
+
+// sendFieldTypeError maps field type validation errors to client responses
+// and everything else to a 500 with the fallback message.
+func sendFieldTypeError(c *gin.Context, err error, fallback string) {
+	var schemaErr *utils.SchemaValidationError
+	switch {
+	case errors.As(err, &schemaErr):
+		utils.SendValidationErrors(c, err.Error(), schemaErr.Errors)
+	case errors.Is(err, constants.ErrFieldTypeNotFound):
+		utils.SendNotFound(c, err.Error())
+	case errors.Is(err, constants.ErrFieldTypeAlreadyExists), errors.Is(err, constants.ErrFieldTypeBuiltIn),
+		errors.Is(err, constants.ErrFieldTypeInUse):
+		utils.SendConflict(c, err.Error())
+	case errors.Is(err, constants.ErrInvalidFieldTypeName), errors.Is(err, constants.ErrInvalidFieldTypeSchema),
+		errors.Is(err, constants.ErrFieldTypeSchemaEmpty):
+		utils.SendValidationError(c, err.Error())
+	default:
+		utils.SendInternalServerError(c, fallback)
+	}
+}
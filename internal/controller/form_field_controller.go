@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	responseModel "yet-another-itsm/internal/dtos"
+)
+
+type FormFieldController struct {
+	services *service.Services
+}
+
+func NewFormFieldController(services *service.Services) *FormFieldController {
+	return &FormFieldController{
+		services: services,
+	}
+}
+
+// GetFormFields godoc
+// @Summary Get form fields
+// @Description Get the fields of a form template ordered by field_order, optionally only those of one section
+// @Tags form-fields
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param section_id query string false "Section ID"
+// @Success 200 {object} responseModel.FormFieldsListResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/fields [get]
+func (ff *FormFieldController) GetFormFields(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormFieldController").
+		Str("endpoint", "GetFormFields").
+		Str("method", c.Request.Method).
+		Msg("Get form fields endpoint called")
+
+	templateID := c.Param("templateId")
+	if templateID == "" {
+		utils.SendBadRequest(c, constants.ErrFormTemplateIDRequired)
+		return
+	}
+
+	if _, ok := authorizeTemplate(c, ff.services, templateID, constants.ActionRead); !ok {
+		return
+	}
+
+	fields, err := ff.services.FormField.GetFormFields(c.Request.Context(), templateID, c.Query("section_id"))
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToGetFormFields)
+		sendFormFieldError(c, err, constants.ErrFailedToGetFormFields)
+		return
+	}
+
+	items := make([]responseModel.FormFieldResponse, len(fields))
+	for i, field := range fields {
+		items[i] = *field.ToResponse()
+	}
+
+	response := responseModel.NewFormFieldsListResponse(items, 1, len(items), int64(len(items)))
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFormFields, response)
+}
+
+// GetFormFieldByID godoc
+// @Summary Get form field by ID
+// @Description Get a field of a form template
+// @Tags form-fields
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param fieldId path string true "Field ID"
+// @Success 200 {object} responseModel.FormFieldResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/fields/{fieldId} [get]
+func (ff *FormFieldController) GetFormFieldByID(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormFieldController").
+		Str("endpoint", "GetFormFieldByID").
+		Str("method", c.Request.Method).
+		Msg("Get form field by ID endpoint called")
+
+	templateID, fieldID, ok := formFieldParams(c)
+	if !ok {
+		return
+	}
+	if _, ok := authorizeTemplate(c, ff.services, templateID, constants.ActionRead); !ok {
+		return
+	}
+
+	field, err := ff.services.FormField.GetFormFieldByID(c.Request.Context(), templateID, fieldID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Str("fieldId", fieldID).Msg(constants.ErrFailedToGetFormField)
+		sendFormFieldError(c, err, constants.ErrFailedToGetFormField)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFormField, field.ToResponse())
+}
+
+// CreateFormField godoc
+// @Summary Create form field
+// @Description Add a field to a form template. The section must belong to the template, field_type must be a registered field type and config must satisfy its validation schema.
+// @Tags form-fields
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param formField body responseModel.CreateFormFieldRequest true "Form field to create"
+// @Success 201 {object} responseModel.FormFieldResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/fields [post]
+func (ff *FormFieldController) CreateFormField(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormFieldController").
+		Str("endpoint", "CreateFormField").
+		Str("method", c.Request.Method).
+		Msg("Create form field endpoint called")
+
+	templateID := c.Param("templateId")
+	if templateID == "" {
+		utils.SendBadRequest(c, constants.ErrFormTemplateIDRequired)
+		return
+	}
+	if _, ok := authorizeTemplate(c, ff.services, templateID, constants.ActionUpdate); !ok {
+		return
+	}
+
+	var req responseModel.CreateFormFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	field, err := ff.services.FormField.CreateFormField(c.Request.Context(), templateID, &req)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToCreateFormField)
+		sendFormFieldError(c, err, constants.ErrFailedToCreateFormField)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, constants.SuccessCreateFormField, field.ToResponse())
+}
+
+// UpdateFormField godoc
+// @Summary Update form field
+// @Description Replace a field of a form template; it is validated like a new field
+// @Tags form-fields
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param fieldId path string true "Field ID"
+// @Param formField body responseModel.UpdateFormFieldRequest true "Form field to update"
+// @Success 200 {object} responseModel.FormFieldResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/fields/{fieldId} [put]
+func (ff *FormFieldController) UpdateFormField(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormFieldController").
+		Str("endpoint", "UpdateFormField").
+		Str("method", c.Request.Method).
+		Msg("Update form field endpoint called")
+
+	templateID, fieldID, ok := formFieldParams(c)
+	if !ok {
+		return
+	}
+	if _, ok := authorizeTemplate(c, ff.services, templateID, constants.ActionUpdate); !ok {
+		return
+	}
+
+	var req responseModel.UpdateFormFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	field, err := ff.services.FormField.UpdateFormField(c.Request.Context(), templateID, fieldID, &req)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Str("fieldId", fieldID).Msg(constants.ErrFailedToUpdateFormField)
+		sendFormFieldError(c, err, constants.ErrFailedToUpdateFormField)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessUpdateFormField, field.ToResponse())
+}
+
+// DeleteFormField godoc
+// @Summary Delete form field
+// @Description Delete a field of a form template
+// @Tags form-fields
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param fieldId path string true "Field ID"
+// @Success 200 {object} responseModel.SuccessResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/fields/{fieldId} [delete]
+func (ff *FormFieldController) DeleteFormField(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormFieldController").
+		Str("endpoint", "DeleteFormField").
+		Str("method", c.Request.Method).
+		Msg("Delete form field endpoint called")
+
+	templateID, fieldID, ok := formFieldParams(c)
+	if !ok {
+		return
+	}
+	if _, ok := authorizeTemplate(c, ff.services, templateID, constants.ActionUpdate); !ok {
+		return
+	}
+
+	if err := ff.services.FormField.DeleteFormField(c.Request.Context(), templateID, fieldID); err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Str("fieldId", fieldID).Msg(constants.ErrFailedToDeleteFormField)
+		sendFormFieldError(c, err, constants.ErrFailedToDeleteFormField)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessDeleteFormField, nil)
+}
+
+// formFieldParams reads the template and field path parameters, answering 400
+// when one is missing.
+func formFieldParams(c *gin.Context) (string, string, bool) {
+	templateID := c.Param("templateId")
+	if templateID == "" {
+		utils.SendBadRequest(c, constants.ErrFormTemplateIDRequired)
+		return "", "", false
+	}
+
+	fieldID := c.Param("fieldId")
+	if fieldID == "" {
+		utils.SendBadRequest(c, constants.ErrFormFieldIDRequired)
+		return "", "", false
+	}
+
+	return templateID, fieldID, true
+}
+
+// sendFormFieldError maps form field validation errors to client responses
+// and everything else to a 500 with the fallback message. Config schema
+// violations are listed with their paths.
+func sendFormFieldError(c *gin.Context, err error, fallback string) {
+	var schemaErr *utils.SchemaValidationError
+	switch {
+	case errors.As(err, &schemaErr):
+		utils.SendValidationErrors(c, err.Error(), schemaErr.Errors)
+	case errors.Is(err, constants.ErrFormFieldNotFound), errors.Is(err, constants.ErrFormFieldTemplateNotFound):
+		utils.SendNotFound(c, err.Error())
+	case errors.Is(err, constants.ErrFormFieldAlreadyExists), errors.Is(err, constants.ErrFormTemplateNotEditable):
+		utils.SendConflict(c, err.Error())
+	case errors.Is(err, constants.ErrFormFieldSectionNotFound), errors.Is(err, constants.ErrFormFieldTypeNotFound),
+		errors.Is(err, constants.ErrInvalidFormFieldConfig), errors.Is(err, constants.ErrInvalidFormSectionReference):
+		utils.SendValidationError(c, err.Error())
+	default:
+		utils.SendInternalServerError(c, fallback)
+	}
+}
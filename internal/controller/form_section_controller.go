@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"yet-another-itsm/internal/constants"
@@ -25,11 +26,15 @@ func NewFormSectionController(services *service.Services) *FormSectionController
 
 // GetFormSections godoc
 // @Summary Get all form sections
-// @Description Get all form sections
+// @Description Get the sections of a form template
 // @Tags form-sections
 // @Accept json
 // @Produce json
+// @Param templateId query string true "Template ID"
 // @Success 200 {object} responseModel.FormSectionsListResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-sections [get]
 func (fs *FormSectionController) GetFormSections(c *gin.Context) {
@@ -41,6 +46,13 @@ func (fs *FormSectionController) GetFormSections(c *gin.Context) {
 
 	ctx := c.Request.Context()
 	templateID := c.Query("templateId")
+	if templateID == "" {
+		utils.SendBadRequest(c, constants.ErrFormTemplateIDRequired)
+		return
+	}
+	if _, ok := authorizeTemplate(c, fs.services, templateID, constants.ActionRead); !ok {
+		return
+	}
 
 	sections, err := fs.services.FormSection.GetFormSections(ctx, templateID)
 	if err != nil {
@@ -69,6 +81,7 @@ func (fs *FormSectionController) GetFormSections(c *gin.Context) {
 // @Param sectionId path string true "Section ID"
 // @Success 200 {object} responseModel.FormSectionResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 404 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-sections/{sectionId} [get]
@@ -79,13 +92,8 @@ func (fs *FormSectionController) GetFormSectionByID(c *gin.Context) {
 		Str("method", c.Request.Method).
 		Msg("Get form section by ID endpoint called")
 
-	sectionID := c.Param("sectionId")
-	ctx := c.Request.Context()
-
-	section, err := fs.services.FormSection.GetFormSectionByID(ctx, sectionID)
-	if err != nil {
-		log.Error().Err(err).Str("sectionId", sectionID).Msg(constants.ErrFailedToGetFormSection)
-		utils.SendInternalServerError(c, constants.ErrFailedToGetFormSection)
+	section, ok := fs.authorizeSection(c, c.Param("sectionId"), constants.ActionRead)
+	if !ok {
 		return
 	}
 
@@ -101,6 +109,9 @@ func (fs *FormSectionController) GetFormSectionByID(c *gin.Context) {
 // @Param request body responseModel.CreateFormSectionRequest true "Create form section request"
 // @Success 201 {object} responseModel.FormSectionResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-sections [post]
 func (fs *FormSectionController) CreateFormSection(c *gin.Context) {
@@ -117,12 +128,16 @@ func (fs *FormSectionController) CreateFormSection(c *gin.Context) {
 		return
 	}
 
+	if _, ok := authorizeTemplate(c, fs.services, req.FormTemplateID, constants.ActionUpdate); !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	section, err := fs.services.FormSection.CreateFormSection(ctx, &req)
 	if err != nil {
 		log.Error().Err(err).Msg(constants.ErrFailedToCreateFormSection)
-		utils.SendInternalServerError(c, constants.ErrFailedToCreateFormSection)
+		sendFormSectionError(c, err, constants.ErrFailedToCreateFormSection)
 		return
 	}
 
@@ -139,7 +154,9 @@ func (fs *FormSectionController) CreateFormSection(c *gin.Context) {
 // @Param request body responseModel.UpdateFormSectionRequest true "Update form section request"
 // @Success 200 {object} responseModel.FormSectionResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-sections/{sectionId} [put]
 func (fs *FormSectionController) UpdateFormSection(c *gin.Context) {
@@ -150,6 +167,9 @@ func (fs *FormSectionController) UpdateFormSection(c *gin.Context) {
 		Msg("Update form section endpoint called")
 
 	sectionID := c.Param("sectionId")
+	if _, ok := fs.authorizeSection(c, sectionID, constants.ActionUpdate); !ok {
+		return
+	}
 
 	var req responseModel.UpdateFormSectionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -163,7 +183,7 @@ func (fs *FormSectionController) UpdateFormSection(c *gin.Context) {
 	section, err := fs.services.FormSection.UpdateFormSection(ctx, sectionID, &req)
 	if err != nil {
 		log.Error().Err(err).Str("sectionId", sectionID).Msg(constants.ErrFailedToUpdateFormSection)
-		utils.SendInternalServerError(c, constants.ErrFailedToUpdateFormSection)
+		sendFormSectionError(c, err, constants.ErrFailedToUpdateFormSection)
 		return
 	}
 
@@ -179,7 +199,9 @@ func (fs *FormSectionController) UpdateFormSection(c *gin.Context) {
 // @Param sectionId path string true "Section ID"
 // @Success 200 {object} responseModel.SuccessResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-sections/{sectionId} [delete]
 func (fs *FormSectionController) DeleteFormSection(c *gin.Context) {
@@ -190,14 +212,47 @@ func (fs *FormSectionController) DeleteFormSection(c *gin.Context) {
 		Msg("Delete form section endpoint called")
 
 	sectionID := c.Param("sectionId")
+	if _, ok := fs.authorizeSection(c, sectionID, constants.ActionUpdate); !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	err := fs.services.FormSection.DeleteFormSection(ctx, sectionID)
 	if err != nil {
 		log.Error().Err(err).Str("sectionId", sectionID).Msg(constants.ErrFailedToDeleteFormSection)
-		utils.SendInternalServerError(c, constants.ErrFailedToDeleteFormSection)
+		sendFormSectionError(c, err, constants.ErrFailedToDeleteFormSection)
 		return
 	}
 
 	utils.SendSuccess(c, http.StatusOK, constants.SuccessDeleteFormSection, nil)
 }
+
+// authorizeSection loads the section and checks the caller's scoped permission
+// for action on the template it belongs to.
+func (fs *FormSectionController) authorizeSection(c *gin.Context, sectionID, action string) (*responseModel.FormSection, bool) {
+	section, err := fs.services.FormSection.GetFormSectionByID(c.Request.Context(), sectionID)
+	if err != nil {
+		log.Error().Err(err).Str("sectionId", sectionID).Msg(constants.ErrFailedToGetFormSection)
+		utils.SendNotFound(c, constants.ErrFormSectionMissing.Error())
+		return nil, false
+	}
+
+	if _, ok := authorizeTemplate(c, fs.services, section.FormTemplateID, action); !ok {
+		return nil, false
+	}
+
+	return section, true
+}
+
+// sendFormSectionError maps form section errors to HTTP responses, falling back to a 500 with fallback.
+func sendFormSectionError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, constants.ErrFormSectionMissing), errors.Is(err, constants.ErrFormFieldTemplateNotFound):
+		utils.SendNotFound(c, err.Error())
+	case errors.Is(err, constants.ErrFormTemplateNotEditable):
+		utils.SendConflict(c, err.Error())
+	default:
+		utils.SendInternalServerError(c, fallback)
+	}
+}
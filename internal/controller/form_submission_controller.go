@@ -0,0 +1,269 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	responseModel "yet-another-itsm/internal/dtos"
+)
+
+type FormSubmissionController struct {
+	services *service.Services
+}
+
+func NewFormSubmissionController(services *service.Services) *FormSubmissionController {
+	return &FormSubmissionController{
+		services: services,
+	}
+}
+
+// GetFormSubmissions godoc
+// @Summary List form submissions
+// @Description List the submissions the caller may read, newest first. Drafts are only listed for their submitter. Under a template the submissions to every version of its form are listed.
+// @Tags form-submissions
+// @Accept json
+// @Produce json
+// @Param templateId path string false "Template ID"
+// @Param template_id query string false "Template ID, when not given in the path"
+// @Param submitted_by query string false "Submitter user ID, or me"
+// @Param state query string false "draft or submitted"
+// @Param from query string false "Created on or after, a date (YYYY-MM-DD) or RFC 3339 timestamp"
+// @Param to query string false "Created on or before, a date (YYYY-MM-DD) or RFC 3339 timestamp"
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
+// @Success 200 {object} responseModel.FormSubmissionsListResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-submissions [get]
+// @Router /v1/form-templates/{templateId}/submissions [get]
+func (fs *FormSubmissionController) GetFormSubmissions(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormSubmissionController").
+		Str("endpoint", "GetFormSubmissions").
+		Str("method", c.Request.Method).
+		Msg("Get form submissions endpoint called")
+
+	templateID := c.Param("templateId")
+	if templateID == "" {
+		templateID = c.Query("template_id")
+	}
+
+	query := responseModel.FormSubmissionQuery{
+		TemplateID:  templateID,
+		SubmittedBy: c.Query("submitted_by"),
+		State:       c.Query("state"),
+		From:        c.Query("from"),
+		To:          c.Query("to"),
+		Page:        c.Query("page"),
+		Size:        c.Query("size"),
+	}
+
+	ctx := c.Request.Context()
+
+	result, err := fs.services.FormSubmission.GetFormSubmissions(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToGetFormSubmissions)
+		sendFormSubmissionError(c, err, constants.ErrFailedToGetFormSubmissions)
+		return
+	}
+
+	items := make([]responseModel.FormSubmissionResponse, len(result.Submissions))
+	for i, submission := range result.Submissions {
+		items[i] = *submission.ToResponse()
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFormSubmissions,
+		responseModel.NewFormSubmissionsListResponse(items, result.Page, result.Size, result.TotalItems))
+}
+
+// GetFormSubmissionByID godoc
+// @Summary Get form submission by ID
+// @Description Get a submission; another user's draft is not found
+// @Tags form-submissions
+// @Accept json
+// @Produce json
+// @Param submissionId path string true "Submission ID"
+// @Success 200 {object} responseModel.FormSubmissionResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-submissions/{submissionId} [get]
+func (fs *FormSubmissionController) GetFormSubmissionByID(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormSubmissionController").
+		Str("endpoint", "GetFormSubmissionByID").
+		Str("method", c.Request.Method).
+		Msg("Get form submission by ID endpoint called")
+
+	submissionID := c.Param("submissionId")
+	ctx := c.Request.Context()
+
+	submission, err := fs.services.FormSubmission.GetFormSubmissionByID(ctx, submissionID)
+	if err != nil {
+		log.Error().Err(err).Str("submissionId", submissionID).Msg(constants.ErrFailedToGetFormSubmission)
+		sendFormSubmissionError(c, err, constants.ErrFailedToGetFormSubmission)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFormSubmission, submission.ToResponse())
+}
+
+// CreateFormSubmission godoc
+// @Summary Submit form
+// @Description Answer the published version of a form template, keyed by field name. The answers are validated against each field's type and config; with draft set required fields may be left out and the submission is kept for its submitter to finish.
+// @Tags form-submissions
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param request body responseModel.CreateFormSubmissionRequest true "Create form submission request"
+// @Success 201 {object} responseModel.FormSubmissionResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/submissions [post]
+func (fs *FormSubmissionController) CreateFormSubmission(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormSubmissionController").
+		Str("endpoint", "CreateFormSubmission").
+		Str("method", c.Request.Method).
+		Msg("Create form submission endpoint called")
+
+	templateID := c.Param("templateId")
+
+	var req responseModel.CreateFormSubmissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to bind request")
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	submission, err := fs.services.FormSubmission.CreateFormSubmission(ctx, templateID, &req)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToCreateFormSubmission)
+		sendFormSubmissionError(c, err, constants.ErrFailedToCreateFormSubmission)
+		return
+	}
+
+	message := constants.SuccessCreateFormSubmission
+	if req.Draft {
+		message = constants.SuccessSaveFormSubmissionDraft
+	}
+	utils.SendSuccess(c, http.StatusCreated, message, submission.ToResponse())
+}
+
+// UpdateFormSubmission godoc
+// @Summary Update form submission draft
+// @Description Replace the answers of the caller's draft. Without draft set the answers are validated in full and the draft is submitted.
+// @Tags form-submissions
+// @Accept json
+// @Produce json
+// @Param submissionId path string true "Submission ID"
+// @Param request body responseModel.UpdateFormSubmissionRequest true "Update form submission request"
+// @Success 200 {object} responseModel.FormSubmissionResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-submissions/{submissionId} [put]
+func (fs *FormSubmissionController) UpdateFormSubmission(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormSubmissionController").
+		Str("endpoint", "UpdateFormSubmission").
+		Str("method", c.Request.Method).
+		Msg("Update form submission endpoint called")
+
+	submissionID := c.Param("submissionId")
+
+	var req responseModel.UpdateFormSubmissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to bind request")
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	submission, err := fs.services.FormSubmission.UpdateFormSubmission(ctx, submissionID, &req)
+	if err != nil {
+		log.Error().Err(err).Str("submissionId", submissionID).Msg(constants.ErrFailedToUpdateFormSubmission)
+		sendFormSubmissionError(c, err, constants.ErrFailedToUpdateFormSubmission)
+		return
+	}
+
+	message := constants.SuccessCreateFormSubmission
+	if req.Draft {
+		message = constants.SuccessSaveFormSubmissionDraft
+	}
+	utils.SendSuccess(c, http.StatusOK, message, submission.ToResponse())
+}
+
+// DeleteFormSubmission godoc
+// @Summary Delete form submission draft
+// @Description Discard the caller's draft; submitted answers cannot be deleted
+// @Tags form-submissions
+// @Accept json
+// @Produce json
+// @Param submissionId path string true "Submission ID"
+// @Success 200 {object} responseModel.SuccessResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-submissions/{submissionId} [delete]
+func (fs *FormSubmissionController) DeleteFormSubmission(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormSubmissionController").
+		Str("endpoint", "DeleteFormSubmission").
+		Str("method", c.Request.Method).
+		Msg("Delete form submission endpoint called")
+
+	submissionID := c.Param("submissionId")
+	ctx := c.Request.Context()
+
+	err := fs.services.FormSubmission.DeleteFormSubmission(ctx, submissionID)
+	if err != nil {
+		log.Error().Err(err).Str("submissionId", submissionID).Msg(constants.ErrFailedToDeleteFormSubmission)
+		sendFormSubmissionError(c, err, constants.ErrFailedToDeleteFormSubmission)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessDeleteFormSubmission, nil)
+}
+
+// sendFormSubmissionError maps form submission errors to client responses
+// and everything else to a 500 with the fallback message. Answer violations
+// are listed with their paths.
+func sendFormSubmissionError(c *gin.Context, err error, fallback string) {
+	var schemaErr *utils.SchemaValidationError
+	switch {
+	case errors.As(err, &schemaErr):
+		utils.SendValidationErrors(c, err.Error(), schemaErr.Errors)
+	case errors.Is(err, constants.ErrFormSubmissionNotFound), errors.Is(err, constants.ErrFormFieldTemplateNotFound):
+		utils.SendNotFound(c, err.Error())
+	case errors.Is(err, constants.ErrFormSubmissionForbidden):
+		utils.SendForbidden(c, err.Error())
+	case errors.Is(err, constants.ErrFormSubmissionTemplateNotPublished), errors.Is(err, constants.ErrFormSubmissionNotEditable):
+		utils.SendConflict(c, err.Error())
+	case errors.Is(err, constants.ErrInvalidFormSubmissionFilter), errors.Is(err, constants.ErrInvalidFormSubmissionPage):
+		utils.SendBadRequest(c, err.Error())
+	default:
+		utils.SendInternalServerError(c, fallback)
+	}
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"yet-another-itsm/internal/constants"
@@ -68,6 +69,7 @@ func (ft *FormTemplateController) GetFormTemplates(c *gin.Context) {
 // @Param templateId path string true "Template ID"
 // @Success 200 {object} responseModel.FormTemplateResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 404 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-templates/{templateId} [get]
@@ -79,12 +81,9 @@ func (ft *FormTemplateController) GetFormTemplateByID(c *gin.Context) {
 		Msg("Get form template by ID endpoint called")
 
 	templateID := c.Param("templateId")
-	ctx := c.Request.Context()
 
-	template, err := ft.services.FormTemplate.GetFormTemplateByID(ctx, templateID)
-	if err != nil {
-		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToGetFormTemplate)
-		utils.SendInternalServerError(c, constants.ErrFailedToGetFormTemplate)
+	template, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionRead)
+	if !ok {
 		return
 	}
 
@@ -139,6 +138,7 @@ func (ft *FormTemplateController) GetFormTemplatesByCategory(c *gin.Context) {
 // @Param request body responseModel.CreateFormTemplateRequest true "Create form template request"
 // @Success 201 {object} responseModel.FormTemplateResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-templates [post]
 func (ft *FormTemplateController) CreateFormTemplate(c *gin.Context) {
@@ -157,6 +157,30 @@ func (ft *FormTemplateController) CreateFormTemplate(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
+	ownerID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToGetUserID)
+		utils.SendInternalServerError(c, constants.ErrFailedToCreateFormTemplate)
+		return
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToGetTenantID)
+		utils.SendInternalServerError(c, constants.ErrFailedToCreateFormTemplate)
+		return
+	}
+
+	target := &responseModel.AuthorizationTarget{
+		OwnerID:        ownerID,
+		DepartmentID:   req.DepartmentID,
+		BusinessUnitID: req.BusinessUnitID,
+		TenantID:       tenantID.String(),
+	}
+	if !authorizeTarget(c, ft.services, constants.ActionCreate, target) {
+		return
+	}
+
 	template, err := ft.services.FormTemplate.CreateFormTemplate(ctx, &req)
 	if err != nil {
 		log.Error().Err(err).Msg(constants.ErrFailedToCreateFormTemplate)
@@ -169,15 +193,18 @@ func (ft *FormTemplateController) CreateFormTemplate(c *gin.Context) {
 
 // UpdateFormTemplate godoc
 // @Summary Update form template
-// @Description Update an existing form template
+// @Description Update a draft form template in place. Updating a published or retired version creates the next draft version with the changes.
 // @Tags form-templates
 // @Accept json
 // @Produce json
 // @Param templateId path string true "Template ID"
 // @Param request body responseModel.UpdateFormTemplateRequest true "Update form template request"
 // @Success 200 {object} responseModel.FormTemplateResponse
+// @Success 201 {object} responseModel.FormTemplateResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-templates/{templateId} [put]
 func (ft *FormTemplateController) UpdateFormTemplate(c *gin.Context) {
@@ -196,58 +223,399 @@ func (ft *FormTemplateController) UpdateFormTemplate(c *gin.Context) {
 		return
 	}
 
+	current, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionUpdate)
+	if !ok {
+		return
+	}
+
+	// Moving the template must not take it out of the caller's scope either.
+	target := current.AuthorizationTarget()
+	if req.BusinessUnitID != "" {
+		target.BusinessUnitID = req.BusinessUnitID
+	}
+	if req.DepartmentID != "" {
+		target.DepartmentID = req.DepartmentID
+	}
+	if !authorizeTarget(c, ft.services, constants.ActionUpdate, target) {
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	template, err := ft.services.FormTemplate.UpdateFormTemplate(ctx, templateID, &req)
 	if err != nil {
 		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToUpdateFormTemplate)
-		utils.SendInternalServerError(c, constants.ErrFailedToUpdateFormTemplate)
+		sendFormTemplateError(c, err, constants.ErrFailedToUpdateFormTemplate)
+		return
+	}
+
+	if template.ID != current.ID {
+		utils.SendSuccess(c, http.StatusCreated, constants.SuccessCreateFormTemplateVersion, template.ToResponse())
 		return
 	}
 
 	utils.SendSuccess(c, http.StatusOK, constants.SuccessUpdateFormTemplate, template.ToResponse())
 }
 
+// SubmitFormTemplateForReview godoc
+// @Summary Submit form template for review
+// @Description Freeze a draft form template with at least one field for review
+// @Tags form-templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} responseModel.FormTemplateResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/submit [post]
+func (ft *FormTemplateController) SubmitFormTemplateForReview(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "SubmitFormTemplateForReview").
+		Str("method", c.Request.Method).
+		Msg("Submit form template for review endpoint called")
+
+	templateID := c.Param("templateId")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionUpdate); !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	template, err := ft.services.FormTemplate.SubmitFormTemplateForReview(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToSubmitFormTemplate)
+		sendFormTemplateError(c, err, constants.ErrFailedToSubmitFormTemplate)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessSubmitFormTemplate, template.ToResponse())
+}
+
+// RejectFormTemplate godoc
+// @Summary Reject form template
+// @Description Send a form template in review back to draft
+// @Tags form-templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} responseModel.FormTemplateResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/reject [post]
+func (ft *FormTemplateController) RejectFormTemplate(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "RejectFormTemplate").
+		Str("method", c.Request.Method).
+		Msg("Reject form template endpoint called")
+
+	templateID := c.Param("templateId")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionApprove); !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	template, err := ft.services.FormTemplate.RejectFormTemplate(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToRejectFormTemplate)
+		sendFormTemplateError(c, err, constants.ErrFailedToRejectFormTemplate)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessRejectFormTemplate, template.ToResponse())
+}
+
 // PublishFormTemplate godoc
 // @Summary Publish form template
-// @Description Publish a form template with version
+// @Description Approve a form template in review as the current user and publish it, retiring the previously published version
 // @Tags form-templates
 // @Accept json
 // @Produce json
 // @Param templateId path string true "Template ID"
-// @Param request body responseModel.PublishFormTemplateRequest true "Publish form template request"
 // @Success 200 {object} responseModel.FormTemplateResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-templates/{templateId}/publish [post]
-// func (ft *FormTemplateController) PublishFormTemplate(c *gin.Context) {
-// 	log.Info().
-// 		Str("controller", "FormTemplateController").
-// 		Str("endpoint", "PublishFormTemplate").
-// 		Str("method", c.Request.Method).
-// 		Msg("Publish form template endpoint called")
-
-// 	templateID := c.Param("templateId")
-
-// 	var req responseModel.PublishFormTemplateRequest
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		log.Error().Err(err).Msg("Failed to bind request")
-// 		utils.SendBadRequestError(c, constants.ErrInvalidRequestBody)
-// 		return
-// 	}
-
-// 	ctx := c.Request.Context()
-
-// 	template, err := ft.services.FormTemplate.PublishFormTemplate(ctx, templateID, &req)
-// 	if err != nil {
-// 		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToPublishFormTemplate)
-// 		utils.SendInternalServerError(c, constants.ErrFailedToPublishFormTemplate)
-// 		return
-// 	}
-
-// 	utils.SendSuccessResponse(c, http.StatusOK, constants.SuccessPublishFormTemplate, template.ToResponse())
-// }
+func (ft *FormTemplateController) PublishFormTemplate(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "PublishFormTemplate").
+		Str("method", c.Request.Method).
+		Msg("Publish form template endpoint called")
+
+	templateID := c.Param("templateId")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionApprove); !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	template, err := ft.services.FormTemplate.PublishFormTemplate(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToPublishFormTemplate)
+		sendFormTemplateError(c, err, constants.ErrFailedToPublishFormTemplate)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessPublishFormTemplate, template.ToResponse())
+}
+
+// RetireFormTemplate godoc
+// @Summary Retire form template
+// @Description Withdraw the published version of a form template
+// @Tags form-templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} responseModel.FormTemplateResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/retire [post]
+func (ft *FormTemplateController) RetireFormTemplate(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "RetireFormTemplate").
+		Str("method", c.Request.Method).
+		Msg("Retire form template endpoint called")
+
+	templateID := c.Param("templateId")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionApprove); !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	template, err := ft.services.FormTemplate.RetireFormTemplate(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToRetireFormTemplate)
+		sendFormTemplateError(c, err, constants.ErrFailedToRetireFormTemplate)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessRetireFormTemplate, template.ToResponse())
+}
+
+// GetFormTemplateVersions godoc
+// @Summary Get form template versions
+// @Description Get every version of the form the template belongs to, newest first
+// @Tags form-templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} responseModel.FormTemplatesListResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/versions [get]
+func (ft *FormTemplateController) GetFormTemplateVersions(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "GetFormTemplateVersions").
+		Str("method", c.Request.Method).
+		Msg("Get form template versions endpoint called")
+
+	templateID := c.Param("templateId")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionRead); !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	templates, err := ft.services.FormTemplate.GetFormTemplateVersions(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToGetTemplateVersions)
+		sendFormTemplateError(c, err, constants.ErrFailedToGetTemplateVersions)
+		return
+	}
+
+	response := &responseModel.FormTemplatesListResponse{
+		Items: make([]responseModel.FormTemplateResponse, len(templates)),
+	}
+
+	for i, template := range templates {
+		response.Items[i] = *template.ToResponse()
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFormTemplateVersions, response)
+}
+
+// GetFormTemplateVersion godoc
+// @Summary Get form template version
+// @Description Get one version of the form the template belongs to; use latest for the published version
+// @Tags form-templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param version path string true "Version number or latest"
+// @Success 200 {object} responseModel.FormTemplateResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/versions/{version} [get]
+func (ft *FormTemplateController) GetFormTemplateVersion(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "GetFormTemplateVersion").
+		Str("method", c.Request.Method).
+		Msg("Get form template version endpoint called")
+
+	templateID := c.Param("templateId")
+	version := c.Param("version")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionRead); !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	template, err := ft.services.FormTemplate.GetFormTemplateVersion(ctx, templateID, version)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Str("version", version).Msg(constants.ErrFailedToGetFormTemplate)
+		sendFormTemplateError(c, err, constants.ErrFailedToGetFormTemplate)
+		return
+	}
+
+	if !authorizeTarget(c, ft.services, constants.ActionRead, template.AuthorizationTarget()) {
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFormTemplateVersion, template.ToResponse())
+}
+
+// CreateFormTemplateVersion godoc
+// @Summary Create form template version
+// @Description Start the next draft version of the form from the template, copying its sections and fields
+// @Tags form-templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 201 {object} responseModel.FormTemplateResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/versions [post]
+func (ft *FormTemplateController) CreateFormTemplateVersion(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "CreateFormTemplateVersion").
+		Str("method", c.Request.Method).
+		Msg("Create form template version endpoint called")
+
+	templateID := c.Param("templateId")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionUpdate); !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	template, err := ft.services.FormTemplate.CreateFormTemplateVersion(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToCreateFormVersion)
+		sendFormTemplateError(c, err, constants.ErrFailedToCreateFormVersion)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, constants.SuccessCreateFormTemplateVersion, template.ToResponse())
+}
+
+// GetFormDefinition godoc
+// @Summary Get form definition
+// @Description Get the template with its sections ordered by section_order, their fields ordered by field_order and the resolved field types as one document. The ETag header covers the data of the response; a matching If-None-Match is answered with 304.
+// @Tags form-templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param version query string false "Another version of the same form by number, or latest for the published one"
+// @Param If-None-Match header string false "ETag of a cached definition"
+// @Success 200 {object} responseModel.FormDefinitionResponse
+// @Success 304
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 422 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/form-templates/{templateId}/definition [get]
+func (ft *FormTemplateController) GetFormDefinition(c *gin.Context) {
+	log.Info().
+		Str("controller", "FormTemplateController").
+		Str("endpoint", "GetFormDefinition").
+		Str("method", c.Request.Method).
+		Msg("Get form definition endpoint called")
+
+	templateID := c.Param("templateId")
+	version := c.Query("version")
+
+	template, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionRead)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Versions of a form can have different owners, so the one served is
+	// authorized on its own.
+	if version != "" {
+		var err error
+		template, err = ft.services.FormTemplate.GetFormTemplateVersion(ctx, templateID, version)
+		if err != nil {
+			log.Error().Err(err).Str("templateId", templateID).Str("version", version).Msg(constants.ErrFailedToGetFormDefinition)
+			sendFormTemplateError(c, err, constants.ErrFailedToGetFormDefinition)
+			return
+		}
+		if !authorizeTarget(c, ft.services, constants.ActionRead, template.AuthorizationTarget()) {
+			return
+		}
+	}
+
+	definition, err := ft.services.FormTemplate.GetFormDefinition(ctx, template.ID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Str("version", version).Msg(constants.ErrFailedToGetFormDefinition)
+		sendFormTemplateError(c, err, constants.ErrFailedToGetFormDefinition)
+		return
+	}
+
+	etag, err := utils.ComputeETag(definition)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToGetFormDefinition)
+		utils.SendInternalServerError(c, constants.ErrFailedToGetFormDefinition)
+		return
+	}
+
+	// Drafts change in place, so caches have to revalidate every time.
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	if utils.ETagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessGetFormDefinition, definition)
+}
 
 // DeleteFormTemplate godoc
 // @Summary Delete form template
@@ -258,7 +626,9 @@ func (ft *FormTemplateController) UpdateFormTemplate(c *gin.Context) {
 // @Param templateId path string true "Template ID"
 // @Success 200 {object} responseModel.SuccessResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 403 {object} responseModel.ErrorResponse
 // @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/form-templates/{templateId} [delete]
 func (ft *FormTemplateController) DeleteFormTemplate(c *gin.Context) {
@@ -269,14 +639,74 @@ func (ft *FormTemplateController) DeleteFormTemplate(c *gin.Context) {
 		Msg("Delete form template endpoint called")
 
 	templateID := c.Param("templateId")
+
+	if _, ok := authorizeTemplate(c, ft.services, templateID, constants.ActionDelete); !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	err := ft.services.FormTemplate.DeleteFormTemplate(ctx, templateID)
 	if err != nil {
 		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToDeleteFormTemplate)
-		utils.SendInternalServerError(c, constants.ErrFailedToDeleteFormTemplate)
+		sendFormTemplateError(c, err, constants.ErrFailedToDeleteFormTemplate)
 		return
 	}
 
 	utils.SendSuccess(c, http.StatusOK, constants.SuccessDeleteFormTemplate, nil)
 }
+
+// authorizeTemplate loads the template and checks the caller's scoped permission for action on it.
+// The field and section controllers use it for the template their resource belongs to.
+// It writes the error response and returns false when the request must not proceed.
+func authorizeTemplate(c *gin.Context, services *service.Services, templateID, action string) (*responseModel.FormTemplate, bool) {
+	ctx := c.Request.Context()
+
+	template, err := services.FormTemplate.GetFormTemplateByID(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateId", templateID).Msg(constants.ErrFailedToGetFormTemplate)
+		utils.SendNotFound(c, constants.ErrFormTemplateNotFound)
+		return nil, false
+	}
+
+	if !authorizeTarget(c, services, action, template.AuthorizationTarget()) {
+		return nil, false
+	}
+
+	return template, true
+}
+
+// authorizeTarget checks the caller's scoped permission for action on a template owned by target.
+// It writes the error response and returns false when the request must not proceed.
+func authorizeTarget(c *gin.Context, services *service.Services, action string, target *responseModel.AuthorizationTarget) bool {
+	allowed, err := services.Authorization.Authorize(c.Request.Context(), constants.ResourceFormTemplates, action, target)
+	if err != nil {
+		log.Error().Err(err).Msg(constants.ErrFailedToCheckPermissionsMsg)
+		utils.SendInternalServerError(c, constants.ErrFailedToCheckPermissionsMsg)
+		return false
+	}
+	if !allowed {
+		utils.SendForbidden(c, constants.ErrPermissionDeniedMsg)
+		return false
+	}
+
+	return true
+}
+
+// sendFormTemplateError maps form template lifecycle errors to HTTP responses, falling back to a 500 with fallback.
+func sendFormTemplateError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, constants.ErrFormTemplateMissing), errors.Is(err, constants.ErrFormFieldTemplateNotFound),
+		errors.Is(err, constants.ErrFormTemplateVersionNotFound),
+		errors.Is(err, constants.ErrFormTemplateNotPublished):
+		utils.SendNotFound(c, err.Error())
+	case errors.Is(err, constants.ErrFormTemplateNotEditable), errors.Is(err, constants.ErrFormTemplateInvalidTransition),
+		errors.Is(err, constants.ErrFormTemplateDraftExists), errors.Is(err, constants.ErrFormTemplateNotDeletable),
+		errors.Is(err, constants.ErrFormTemplateHasNoFields):
+		utils.SendConflict(c, err.Error())
+	case errors.Is(err, constants.ErrInvalidFormTemplateVersion):
+		utils.SendValidationError(c, err.Error())
+	default:
+		utils.SendInternalServerError(c, fallback)
+	}
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LoginController runs the browser login flow. Tokens never reach the
+// browser: it only holds the encrypted session cookie and the CSRF token.
+type LoginController struct {
+	services *service.Services
+	session  config.SessionConfig
+}
+
+func NewLoginController(services *service.Services, session config.SessionConfig) *LoginController {
+	return &LoginController{
+		services: services,
+		session:  session,
+	}
+}
+
+// Login godoc
+// @Summary Start browser login
+// @Description Redirect the browser to the identity provider using the authorization code flow with PKCE
+// @Tags auth
+// @Param return_to query string false "Local path to return to after login"
+// @Success 302
+// @Failure 503 {object} dtos.ErrorResponse
+// @Router /login [get]
+func (c *LoginController) Login(ctx *gin.Context) {
+	redirect, err := c.services.Session.BeginLogin(ctx.Request.Context(), ctx.Query("return_to"))
+	if err != nil {
+		sendLoginError(ctx, err, constants.ErrFailedToBeginLoginMsg)
+		return
+	}
+
+	c.setCookie(ctx, config.LoginCookieName, redirect.LoginCookie, redirect.MaxAge, true)
+	ctx.Redirect(http.StatusFound, redirect.AuthURL)
+}
+
+// Callback godoc
+// @Summary Complete browser login
+// @Description Redeem the authorization code, start a session and redirect back to the application
+// @Tags auth
+// @Param code query string true "Authorization code"
+// @Param state query string true "State issued by /login"
+// @Success 302
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Router /callback [get]
+func (c *LoginController) Callback(ctx *gin.Context) {
+	// The login cookie is single use, whatever the outcome.
+	loginCookie, _ := ctx.Cookie(config.LoginCookieName)
+	c.clearCookie(ctx, config.LoginCookieName, true)
+
+	if errorCode := ctx.Query("error"); errorCode != "" {
+		log.Ctx(ctx).Warn().
+			Str("error", errorCode).
+			Str("error_description", ctx.Query("error_description")).
+			Msg(constants.ErrLoginFailedMsg)
+		utils.SendUnauthorized(ctx, constants.ErrLoginFailedMsg)
+		return
+	}
+
+	session, err := c.services.Session.CompleteLogin(ctx.Request.Context(), &dtos.CompleteLoginRequest{
+		Code:        ctx.Query("code"),
+		State:       ctx.Query("state"),
+		LoginCookie: loginCookie,
+		IPAddress:   ctx.ClientIP(),
+		UserAgent:   ctx.Request.UserAgent(),
+	})
+	if err != nil {
+		sendLoginError(ctx, err, constants.ErrLoginFailedMsg)
+		return
+	}
+
+	c.setCookie(ctx, config.SessionCookieName, session.SessionCookie, session.MaxAge, true)
+	// The CSRF cookie is readable by the frontend, which echoes it in the
+	// X-CSRF-Token header.
+	c.setCookie(ctx, config.CSRFCookieName, session.CSRFToken, session.MaxAge, false)
+	ctx.Redirect(http.StatusFound, session.ReturnTo)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke the browser session and return the identity provider's logout URL
+// @Tags auth
+// @Produce json
+// @Param X-CSRF-Token header string true "CSRF token from the itsm_csrf cookie"
+// @Success 200 {object} dtos.LogoutResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /logout [post]
+func (c *LoginController) Logout(ctx *gin.Context) {
+	sessionCookie, err := ctx.Cookie(config.SessionCookieName)
+	if err != nil {
+		c.clearSessionCookies(ctx)
+		utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgLogout, dtos.LogoutResponse{})
+		return
+	}
+
+	response, err := c.services.Session.Logout(ctx.Request.Context(), sessionCookie, ctx.GetHeader(config.CSRFHeader))
+	if err != nil {
+		// An unknown or expired session is already logged out.
+		if !errors.Is(err, constants.ErrSessionNotFound) {
+			sendLoginError(ctx, err, constants.ErrFailedToLogoutMsg)
+			return
+		}
+		response = &dtos.LogoutResponse{}
+	}
+
+	c.clearSessionCookies(ctx)
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgLogout, response)
+}
+
+func (c *LoginController) clearSessionCookies(ctx *gin.Context) {
+	c.clearCookie(ctx, config.SessionCookieName, true)
+	c.clearCookie(ctx, config.CSRFCookieName, false)
+}
+
+// setCookie sets a host-only cookie. SameSite=Lax lets the cookies accompany
+// the top-level redirect back from the identity provider.
+func (c *LoginController) setCookie(ctx *gin.Context, name, value string, maxAge int, httpOnly bool) {
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   c.session.CookieSecure,
+		HttpOnly: httpOnly,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (c *LoginController) clearCookie(ctx *gin.Context, name string, httpOnly bool) {
+	c.setCookie(ctx, name, "", -1, httpOnly)
+}
+
+func sendLoginError(ctx *gin.Context, err error, fallbackMsg string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallbackMsg)
+	switch {
+	case errors.Is(err, constants.ErrLoginNotConfigured):
+		utils.SendServiceUnavailable(ctx, constants.ErrLoginNotAvailableMsg)
+	case errors.Is(err, constants.ErrInvalidLoginState):
+		utils.SendBadRequest(ctx, err.Error())
+	case errors.Is(err, constants.ErrLoginTenantNotAllowed), errors.Is(err, constants.ErrInvalidCSRFToken):
+		utils.SendForbidden(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallbackMsg)
+	}
+}
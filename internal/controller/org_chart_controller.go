@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+type OrgChartController struct {
+	services *service.Services
+}
+
+func NewOrgChartController(services *service.Services) *OrgChartController {
+	return &OrgChartController{
+		services: services,
+	}
+}
+
+// GetManager godoc
+// @Summary Get a user's manager
+// @Description Get the direct manager of a user
+// @Tags org-chart
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} dtos.UserResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/manager [get]
+// @Security BearerAuth
+func (c *OrgChartController) GetManager(ctx *gin.Context) {
+	manager, err := c.services.OrgChart.GetManager(ctx.Request.Context(), ctx.Param("userId"))
+	if err != nil {
+		c.sendOrgChartError(ctx, err, constants.ErrFailedToGetManagerMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetManager, manager.ToResponse())
+}
+
+// GetManagementChain godoc
+// @Summary Get a user's management chain
+// @Description List the managers of a user from the direct manager (depth 1) to the top of the hierarchy
+// @Tags org-chart
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} dtos.OrgChartUsersListResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/management-chain [get]
+// @Security BearerAuth
+func (c *OrgChartController) GetManagementChain(ctx *gin.Context) {
+	chain, err := c.services.OrgChart.GetManagementChain(ctx.Request.Context(), ctx.Param("userId"))
+	if err != nil {
+		c.sendOrgChartError(ctx, err, constants.ErrFailedToGetManagerMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetManagementChain, newOrgChartUsersList(chain))
+}
+
+// GetDirectReports godoc
+// @Summary Get a user's direct reports
+// @Description List the users whose manager is the given user
+// @Tags org-chart
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} dtos.OrgChartUsersListResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/direct-reports [get]
+// @Security BearerAuth
+func (c *OrgChartController) GetDirectReports(ctx *gin.Context) {
+	reports, err := c.services.OrgChart.GetDirectReports(ctx.Request.Context(), ctx.Param("userId"))
+	if err != nil {
+		c.sendOrgChartError(ctx, err, constants.ErrFailedToGetReportsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetReports, newOrgChartUsersList(reports))
+}
+
+// GetReports godoc
+// @Summary Get a user's transitive reports
+// @Description List the direct and indirect reports of a user, ordered by depth
+// @Tags org-chart
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param depth query int false "Levels to include, 1-10 (default 3)"
+// @Success 200 {object} dtos.OrgChartUsersListResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/reports [get]
+// @Security BearerAuth
+func (c *OrgChartController) GetReports(ctx *gin.Context) {
+	reports, err := c.services.OrgChart.GetReports(ctx.Request.Context(), ctx.Param("userId"), ctx.Query("depth"))
+	if err != nil {
+		c.sendOrgChartError(ctx, err, constants.ErrFailedToGetReportsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetReports, newOrgChartUsersList(reports))
+}
+
+// GetOrgChart godoc
+// @Summary Get an org chart
+// @Description Reporting tree of a department and/or business unit. Members whose manager is outside the selection are the roots.
+// @Tags org-chart
+// @Accept json
+// @Produce json
+// @Param department_id query string false "Department ID"
+// @Param business_unit_id query string false "Business unit ID"
+// @Param depth query int false "Levels to include, 1-10 (default 3)"
+// @Success 200 {object} dtos.OrgChartResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/org-chart [get]
+// @Security BearerAuth
+func (c *OrgChartController) GetOrgChart(ctx *gin.Context) {
+	filter := dtos.OrgChartFilter{
+		DepartmentID:   ctx.Query("department_id"),
+		BusinessUnitID: ctx.Query("business_unit_id"),
+		Depth:          ctx.Query("depth"),
+	}
+
+	chart, err := c.services.OrgChart.GetOrgChart(ctx.Request.Context(), filter)
+	if err != nil {
+		c.sendOrgChartError(ctx, err, constants.ErrFailedToGetOrgChartMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetOrgChart, chart)
+}
+
+func (c *OrgChartController) sendOrgChartError(ctx *gin.Context, err error, fallback string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrUserNotFound), errors.Is(err, constants.ErrUserHasNoManager):
+		utils.SendNotFound(ctx, err.Error())
+	case errors.Is(err, constants.ErrInvalidOrgChartDepth), errors.Is(err, constants.ErrOrgChartScopeRequired):
+		utils.SendValidationError(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallback)
+	}
+}
+
+func newOrgChartUsersList(users []*dtos.OrgChartUser) *dtos.OrgChartUsersListResponse {
+	responses := make([]dtos.OrgChartUser, len(users))
+	for i, user := range users {
+		responses[i] = *user
+	}
+
+	return dtos.NewOrgChartUsersListResponse(responses, 1, len(responses), int64(len(responses)))
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"math"
+	"strconv"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// bindPage reads the page and size query parameters of a paged list and
+// answers 400 when they are invalid.
+func bindPage(c *gin.Context) (dtos.PageRequest, bool) {
+	page, err := parsePage(c.Query("page"), c.Query("size"))
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return dtos.PageRequest{}, false
+	}
+	return page, true
+}
+
+func parsePage(pageValue, sizeValue string) (dtos.PageRequest, error) {
+	page := dtos.PageRequest{Page: 1, PageSize: defaultPageSize}
+	var err error
+	if pageValue != "" {
+		if page.Page, err = strconv.Atoi(pageValue); err != nil || page.Page < 1 {
+			return dtos.PageRequest{}, constants.ErrInvalidPage
+		}
+	}
+	if sizeValue != "" {
+		if page.PageSize, err = strconv.Atoi(sizeValue); err != nil || page.PageSize < 1 || page.PageSize > maxPageSize {
+			return dtos.PageRequest{}, constants.ErrInvalidPage
+		}
+	}
+	if (page.Page-1)*page.PageSize > math.MaxInt32 {
+		return dtos.PageRequest{}, constants.ErrInvalidPage
+	}
+	return page, nil
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,6 +31,8 @@ func NewRoleAssignmentController(services *service.Services) *RoleAssignmentCont
 // @Accept json
 // @Produce json
 // @Param userId path string true "User ID"
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
 // @Success 200 {object} dtos.RoleAssignmentsListResponse
 // @Failure 400 {object} dtos.ErrorResponse
 // @Failure 404 {object} dtos.ErrorResponse
@@ -44,9 +47,14 @@ func (c *RoleAssignmentController) GetUserRoleAssignments(ctx *gin.Context) {
 		return
 	}
 
+	page, ok := bindPage(ctx)
+	if !ok {
+		return
+	}
+
 	log.Ctx(ctx).Info().Str("user_id", userID).Msg("Getting user role assignments")
 
-	roleAssignments, err := c.services.RoleAssignment.GetUserRoleAssignments(ctx.Request.Context(), userID)
+	roleAssignments, total, err := c.services.RoleAssignment.GetUserRoleAssignments(ctx.Request.Context(), userID, page)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToRetrieveRoleAssignmentsMsg)
 		utils.SendNotFound(ctx, constants.ErrFailedToRetrieveRoleAssignmentsMsg)
@@ -56,29 +64,219 @@ func (c *RoleAssignmentController) GetUserRoleAssignments(ctx *gin.Context) {
 	var detailResponses []dtos.RoleAssignmentDetailResponse
 	for _, assignment := range roleAssignments {
 		detailResponses = append(detailResponses, dtos.RoleAssignmentDetailResponse{
-			ID:                assignment.ID.String(),
-			RolePermissionsID: assignment.RolePermissionsID,
-			AssigneeID:        assignment.AssigneeID,
-			AssigneeName:      assignment.AssigneeName,
-			AssigneeEmail:     assignment.AssigneeEmail,
-			BusinessUnitID:    assignment.BusinessUnitID,
-			BusinessUnitName:  assignment.BusinessUnitName,
-			DepartmentID:      assignment.DepartmentID,
-			DepartmentName:    assignment.DepartmentName,
-			AssignedBy:        assignment.AssignedBy,
-			AssignedAt:        assignment.AssignedAt,
-			ExpiresAt:         assignment.ExpiresAt,
-			RoleName:          assignment.RoleName,
-			PermissionName:    assignment.PermissionName,
-			Resource:          assignment.Resource,
-			Action:            assignment.Action,
-			ScopeName:         assignment.ScopeName,
-			Status:            assignment.Status,
-			UpdatedAt:         assignment.UpdatedAt,
-			DeletedAt:         assignment.DeletedAt,
+			ID:                 assignment.ID.String(),
+			RolePermissionsID:  assignment.RolePermissionsID,
+			AssigneeID:         assignment.AssigneeID,
+			AssigneeName:       assignment.AssigneeName,
+			AssigneeEmail:      assignment.AssigneeEmail,
+			BusinessUnitID:     assignment.BusinessUnitID,
+			BusinessUnitName:   assignment.BusinessUnitName,
+			DepartmentID:       assignment.DepartmentID,
+			DepartmentName:     assignment.DepartmentName,
+			AssignedBy:         assignment.AssignedBy,
+			AssignedAt:         assignment.AssignedAt,
+			ExpiresAt:          assignment.ExpiresAt,
+			RoleName:           assignment.RoleName,
+			PermissionName:     assignment.PermissionName,
+			Resource:           assignment.Resource,
+			Action:             assignment.Action,
+			ScopeName:          assignment.ScopeName,
+			Status:             assignment.Status,
+			UpdatedAt:          assignment.UpdatedAt,
+			DeletedAt:          assignment.DeletedAt,
+			ExternallyManaged:  assignment.DirectoryMappingID != "",
+			DirectoryMappingID: assignment.DirectoryMappingID,
+			ElevationRequestID: assignment.ElevationRequestID,
 		})
 	}
 
-	response := dtos.NewRoleAssignmentsListResponse(detailResponses, 1, len(detailResponses), int64(len(detailResponses)))
+	response := dtos.NewRoleAssignmentsListResponse(detailResponses, page.Page, page.PageSize, total)
 	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsg, response)
 }
+
+// GetRoleAssignmentByID godoc
+// @Summary Get role assignment by ID
+// @Description Retrieve a single role assignment
+// @Tags role-assignments
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Role assignment ID"
+// @Success 200 {object} dtos.RoleAssignmentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/role-assignments/{assignmentId} [get]
+// @Security BearerAuth
+func (c *RoleAssignmentController) GetRoleAssignmentByID(ctx *gin.Context) {
+	id := ctx.Param("assignmentId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrRoleAssignmentIDRequiredMsg)
+		return
+	}
+
+	roleAssignment, err := c.services.RoleAssignment.GetRoleAssignmentByID(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendRoleAssignmentError(ctx, err, constants.ErrFailedToRetrieveRoleAssignmentsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetRoleAssignmentByID, roleAssignment.ToResponse())
+}
+
+// CreateRoleAssignment godoc
+// @Summary Create role assignment
+// @Description Assign a role permission to a user. assigned_by is taken from the access token.
+// @Tags role-assignments
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateRoleAssignmentRequest true "Create role assignment request"
+// @Success 201 {object} dtos.RoleAssignmentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "Duplicate assignment or separation-of-duties conflict"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/role-assignments [post]
+// @Security BearerAuth
+func (c *RoleAssignmentController) CreateRoleAssignment(ctx *gin.Context) {
+	var req dtos.CreateRoleAssignmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	roleAssignment, err := c.services.RoleAssignment.CreateRoleAssignment(ctx.Request.Context(), &req)
+	if err != nil {
+		c.sendRoleAssignmentError(ctx, err, constants.ErrFailedToCreateRoleAssignmentMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgCreateRoleAssignment, roleAssignment.ToResponse())
+}
+
+// BulkCreateRoleAssignments godoc
+// @Summary Bulk create role assignments
+// @Description Create up to 100 role assignments atomically. If any assignment is rejected, none are created.
+// @Tags role-assignments
+// @Accept json
+// @Produce json
+// @Param request body dtos.BulkCreateRoleAssignmentRequest true "Bulk create role assignments request"
+// @Success 201 {object} dtos.BulkRoleAssignmentsResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/role-assignments/bulk [post]
+// @Security BearerAuth
+func (c *RoleAssignmentController) BulkCreateRoleAssignments(ctx *gin.Context) {
+	var req dtos.BulkCreateRoleAssignmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	roleAssignments, err := c.services.RoleAssignment.BulkCreateRoleAssignments(ctx.Request.Context(), &req)
+	if err != nil {
+		c.sendRoleAssignmentError(ctx, err, constants.ErrFailedToCreateRoleAssignmentMsg)
+		return
+	}
+
+	response := dtos.BulkRoleAssignmentsResponse{
+		RoleAssignments: make([]dtos.RoleAssignmentResponse, len(roleAssignments)),
+	}
+	for i, roleAssignment := range roleAssignments {
+		response.RoleAssignments[i] = *roleAssignment.ToResponse()
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgBulkCreateRoleAssignments, response)
+}
+
+// UpdateRoleAssignment godoc
+// @Summary Update role assignment
+// @Description Change the expiry or status of a role assignment
+// @Tags role-assignments
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Role assignment ID"
+// @Param request body dtos.UpdateRoleAssignmentRequest true "Update role assignment request"
+// @Success 200 {object} dtos.RoleAssignmentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "Assignment is managed by a directory role mapping"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/role-assignments/{assignmentId} [put]
+// @Security BearerAuth
+func (c *RoleAssignmentController) UpdateRoleAssignment(ctx *gin.Context) {
+	id := ctx.Param("assignmentId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrRoleAssignmentIDRequiredMsg)
+		return
+	}
+
+	var req dtos.UpdateRoleAssignmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	roleAssignment, err := c.services.RoleAssignment.UpdateRoleAssignment(ctx.Request.Context(), id, &req)
+	if err != nil {
+		c.sendRoleAssignmentError(ctx, err, constants.ErrFailedToUpdateRoleAssignmentMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgUpdateRoleAssignment, roleAssignment.ToResponse())
+}
+
+// RevokeRoleAssignment godoc
+// @Summary Revoke role assignment
+// @Description Revoke a role assignment so it no longer grants permissions
+// @Tags role-assignments
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Role assignment ID"
+// @Success 200 {object} dtos.RoleAssignmentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "Assignment is managed by a directory role mapping"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/role-assignments/{assignmentId} [delete]
+// @Security BearerAuth
+func (c *RoleAssignmentController) RevokeRoleAssignment(ctx *gin.Context) {
+	id := ctx.Param("assignmentId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrRoleAssignmentIDRequiredMsg)
+		return
+	}
+
+	roleAssignment, err := c.services.RoleAssignment.RevokeRoleAssignment(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendRoleAssignmentError(ctx, err, constants.ErrFailedToRevokeRoleAssignmentMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgRevokeRoleAssignment, roleAssignment.ToResponse())
+}
+
+// sendRoleAssignmentError maps service validation errors to client responses and everything else to a 500.
+func (c *RoleAssignmentController) sendRoleAssignmentError(ctx *gin.Context, err error, fallback string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrRoleAssignmentNotFound), errors.Is(err, constants.ErrAssigneeNotFound),
+		errors.Is(err, constants.ErrBusinessUnitNotFound):
+		utils.SendNotFound(ctx, err.Error())
+	case errors.Is(err, constants.ErrRoleAssignmentAlreadyExists), errors.Is(err, constants.ErrSodConstraintViolation),
+		errors.Is(err, constants.ErrRoleAssignmentExternallyManaged):
+		utils.SendConflict(ctx, err.Error())
+	case errors.Is(err, constants.ErrRolePermissionNotActive), errors.Is(err, constants.ErrInvalidExpiresAt):
+		utils.SendValidationError(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallback)
+	}
+}
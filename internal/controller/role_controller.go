@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"yet-another-itsm/internal/constants"
@@ -162,6 +163,7 @@ func (rc *RoleController) GetSystemRoles(c *gin.Context) {
 // @Param role body responseModel.CreateRoleRequest true "Role data"
 // @Success 201 {object} responseModel.RoleResponse
 // @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
 // @Failure 500 {object} responseModel.ErrorResponse
 // @Router /v1/roles [post]
 func (rc *RoleController) CreateRole(c *gin.Context) {
@@ -184,6 +186,10 @@ func (rc *RoleController) CreateRole(c *gin.Context) {
 	role, err := rc.services.Role.CreateRole(ctx, &req)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create role")
+		if errors.Is(err, constants.ErrParentRoleNotFound) {
+			utils.SendNotFound(c, err.Error())
+			return
+		}
 		utils.SendInternalServerError(c, constants.ErrFailedToCreateRoleMsg)
 		return
 	}
@@ -195,3 +201,54 @@ func (rc *RoleController) CreateRole(c *gin.Context) {
 
 	utils.SendSuccess(c, http.StatusCreated, "Successfully created role", role.ToResponse())
 }
+
+// SetRoleParent godoc
+// @Summary Set parent role
+// @Description Make a role inherit all permissions of a parent role. An empty parent_role_id removes the parent.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param roleId path string true "Role ID"
+// @Param request body responseModel.SetRoleParentRequest true "Parent role"
+// @Success 200 {object} responseModel.RoleResponse
+// @Failure 400 {object} responseModel.ErrorResponse
+// @Failure 404 {object} responseModel.ErrorResponse
+// @Failure 409 {object} responseModel.ErrorResponse
+// @Failure 500 {object} responseModel.ErrorResponse
+// @Router /v1/roles/{roleId}/parent [put]
+func (rc *RoleController) SetRoleParent(c *gin.Context) {
+	log.Info().
+		Str("controller", "RoleController").
+		Str("endpoint", "SetRoleParent").
+		Str("method", c.Request.Method).
+		Msg("Set parent role endpoint called")
+
+	id := c.Param("roleId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrRoleIDRequiredMsg)
+		return
+	}
+
+	var req responseModel.SetRoleParentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request body")
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBodyMsg)
+		return
+	}
+
+	role, err := rc.services.Role.SetRoleParent(c.Request.Context(), id, &req)
+	if err != nil {
+		log.Error().Err(err).Str("role_id", id).Msg(constants.ErrFailedToSetRoleParentMsg)
+		switch {
+		case errors.Is(err, constants.ErrRoleNotFound), errors.Is(err, constants.ErrParentRoleNotFound):
+			utils.SendNotFound(c, err.Error())
+		case errors.Is(err, constants.ErrRoleHierarchyCycle):
+			utils.SendConflict(c, err.Error())
+		default:
+			utils.SendInternalServerError(c, constants.ErrFailedToSetRoleParentMsg)
+		}
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgSetRoleParent, role.ToResponse())
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"yet-another-itsm/internal/constants"
@@ -25,7 +26,7 @@ func NewRolePermissionController(services *service.Services) *RolePermissionCont
 
 // GetPermissionsByRole godoc
 // @Summary Get role permissions
-// @Description Get all permissions assigned to a specific role
+// @Description Get all permissions of a specific role, including those inherited from its parent roles
 // @Tags role-permissions
 // @Accept json
 // @Produce json
@@ -49,7 +50,7 @@ func (rpc *RolePermissionController) GetPermissionsByRole(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	rolePermissions, err := rpc.services.RolePermission.GetPermissionsByRole(ctx, roleID)
+	rolePermissions, err := rpc.services.RolePermission.GetResolvedPermissionsByRole(ctx, roleID)
 	if err != nil {
 		log.Error().Err(err).Str("role_id", roleID).Msg("Failed to get role permissions")
 		utils.SendInternalServerError(c, constants.ErrFailedToRetrieveRolePermissionsMsg)
@@ -148,6 +149,10 @@ func (rpc *RolePermissionController) CreateRolePermission(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	rolePermission, err := rpc.services.RolePermission.CreateRolePermission(ctx, &req)
+	if errors.Is(err, constants.ErrRoleNotFound) {
+		utils.SendNotFound(c, constants.ErrRoleNotFoundMsg)
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create role permission")
 		utils.SendInternalServerError(c, constants.ErrFailedToCreateRolePermissionMsg)
@@ -0,0 +1,453 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SCIMController serves the SCIM 2.0 protocol (RFC 7644). Responses use the
+// SCIM schemas rather than the API's response envelope.
+type SCIMController struct {
+	services *service.Services
+}
+
+func NewSCIMController(services *service.Services) *SCIMController {
+	return &SCIMController{
+		services: services,
+	}
+}
+
+// GetServiceProviderConfig godoc
+// @Summary Get the SCIM service provider configuration
+// @Description Describe the SCIM features the endpoints support
+// @Tags scim
+// @Produce json
+// @Success 200 {object} dtos.SCIMServiceProviderConfig
+// @Router /scim/v2/ServiceProviderConfig [get]
+func (c *SCIMController) GetServiceProviderConfig(ctx *gin.Context) {
+	config := dtos.NewSCIMServiceProviderConfig(constants.SCIMMaxResults)
+	config.Meta.Location = scimBaseURL(ctx) + config.Meta.Location
+	utils.SendSCIM(ctx, http.StatusOK, config)
+}
+
+// ListResourceTypes godoc
+// @Summary List SCIM resource types
+// @Description Describe the User and Group endpoints
+// @Tags scim
+// @Produce json
+// @Success 200 {object} dtos.SCIMDiscoveryListResponse
+// @Router /scim/v2/ResourceTypes [get]
+func (c *SCIMController) ListResourceTypes(ctx *gin.Context) {
+	resourceTypes := dtos.NewSCIMResourceTypes()
+	for i := range resourceTypes {
+		resourceTypes[i].Meta.Location = scimBaseURL(ctx) + resourceTypes[i].Meta.Location
+	}
+	utils.SendSCIM(ctx, http.StatusOK, newSCIMDiscoveryList(resourceTypes, len(resourceTypes)))
+}
+
+// ListSchemas godoc
+// @Summary List SCIM schemas
+// @Description Describe the attributes of the User, enterprise User and Group schemas
+// @Tags scim
+// @Produce json
+// @Success 200 {object} dtos.SCIMDiscoveryListResponse
+// @Router /scim/v2/Schemas [get]
+func (c *SCIMController) ListSchemas(ctx *gin.Context) {
+	schemas := dtos.NewSCIMSchemaDefinitions()
+	for i := range schemas {
+		schemas[i].Meta.Location = scimBaseURL(ctx) + schemas[i].Meta.Location
+	}
+	utils.SendSCIM(ctx, http.StatusOK, newSCIMDiscoveryList(schemas, len(schemas)))
+}
+
+// ListUsers godoc
+// @Summary List SCIM users
+// @Description List provisioned users. The filter supports eq comparisons on id, userName, emails.value, externalId and displayName joined by and.
+// @Tags scim
+// @Produce json
+// @Param filter query string false "SCIM filter, e.g. userName eq \"jane@contoso.com\""
+// @Param startIndex query int false "1-based index of the first result (default 1)"
+// @Param count query int false "Page size, 0-200 (default 100)"
+// @Success 200 {object} dtos.SCIMUserListResponse
+// @Failure 400 {object} utils.SCIMError
+// @Failure 401 {object} utils.SCIMError
+// @Failure 403 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Users [get]
+// @Security BearerAuth
+func (c *SCIMController) ListUsers(ctx *gin.Context) {
+	users, err := c.services.SCIM.ListUsers(ctx.Request.Context(), newSCIMListQuery(ctx))
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToListSCIMResourcesMsg)
+		return
+	}
+
+	for _, user := range users.Resources {
+		user.ResolveReferences(scimBaseURL(ctx))
+	}
+	utils.SendSCIM(ctx, http.StatusOK, users)
+}
+
+// GetUser godoc
+// @Summary Get a SCIM user
+// @Tags scim
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dtos.SCIMUser
+// @Failure 404 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Users/{id} [get]
+// @Security BearerAuth
+func (c *SCIMController) GetUser(ctx *gin.Context) {
+	user, err := c.services.SCIM.GetUser(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToGetSCIMResourceMsg)
+		return
+	}
+
+	user.ResolveReferences(scimBaseURL(ctx))
+	utils.SendSCIM(ctx, http.StatusOK, user)
+}
+
+// CreateUser godoc
+// @Summary Provision a SCIM user
+// @Description Create a user. externalId should be the user's directory object ID so their sign-in links to the provisioned user.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param user body dtos.SCIMUser true "User"
+// @Success 201 {object} dtos.SCIMUser
+// @Failure 400 {object} utils.SCIMError
+// @Failure 409 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Users [post]
+// @Security BearerAuth
+func (c *SCIMController) CreateUser(ctx *gin.Context) {
+	var request dtos.SCIMUser
+	if !bindSCIMRequest(ctx, &request) {
+		return
+	}
+
+	user, err := c.services.SCIM.CreateUser(ctx.Request.Context(), &request)
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToSaveSCIMResourceMsg)
+		return
+	}
+
+	user.ResolveReferences(scimBaseURL(ctx))
+	ctx.Header("Location", user.Meta.Location)
+	utils.SendSCIM(ctx, http.StatusCreated, user)
+}
+
+// ReplaceUser godoc
+// @Summary Replace a SCIM user
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param user body dtos.SCIMUser true "User"
+// @Success 200 {object} dtos.SCIMUser
+// @Failure 400 {object} utils.SCIMError
+// @Failure 404 {object} utils.SCIMError
+// @Failure 409 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Users/{id} [put]
+// @Security BearerAuth
+func (c *SCIMController) ReplaceUser(ctx *gin.Context) {
+	var request dtos.SCIMUser
+	if !bindSCIMRequest(ctx, &request) {
+		return
+	}
+
+	user, err := c.services.SCIM.ReplaceUser(ctx.Request.Context(), ctx.Param("id"), &request)
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToSaveSCIMResourceMsg)
+		return
+	}
+
+	user.ResolveReferences(scimBaseURL(ctx))
+	utils.SendSCIM(ctx, http.StatusOK, user)
+}
+
+// PatchUser godoc
+// @Summary Patch a SCIM user
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param patch body dtos.SCIMPatchRequest true "PATCH operations"
+// @Success 200 {object} dtos.SCIMUser
+// @Failure 400 {object} utils.SCIMError
+// @Failure 404 {object} utils.SCIMError
+// @Failure 409 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Users/{id} [patch]
+// @Security BearerAuth
+func (c *SCIMController) PatchUser(ctx *gin.Context) {
+	var request dtos.SCIMPatchRequest
+	if !bindSCIMRequest(ctx, &request) {
+		return
+	}
+
+	user, err := c.services.SCIM.PatchUser(ctx.Request.Context(), ctx.Param("id"), &request)
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToSaveSCIMResourceMsg)
+		return
+	}
+
+	user.ResolveReferences(scimBaseURL(ctx))
+	utils.SendSCIM(ctx, http.StatusOK, user)
+}
+
+// DeleteUser godoc
+// @Summary Deprovision a SCIM user
+// @Description Soft-delete a user and remove them from all groups
+// @Tags scim
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 404 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Users/{id} [delete]
+// @Security BearerAuth
+func (c *SCIMController) DeleteUser(ctx *gin.Context) {
+	if err := c.services.SCIM.DeleteUser(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToDeleteSCIMResourceMsg)
+		return
+	}
+
+	utils.SendSCIMNoContent(ctx)
+}
+
+// ListGroups godoc
+// @Summary List SCIM groups
+// @Description List provisioned groups. The filter supports eq comparisons on id, displayName, externalId and members.value joined by and.
+// @Tags scim
+// @Produce json
+// @Param filter query string false "SCIM filter, e.g. displayName eq \"Service Desk\""
+// @Param startIndex query int false "1-based index of the first result (default 1)"
+// @Param count query int false "Page size, 0-200 (default 100)"
+// @Param excludedAttributes query string false "members to leave members out"
+// @Success 200 {object} dtos.SCIMGroupListResponse
+// @Failure 400 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Groups [get]
+// @Security BearerAuth
+func (c *SCIMController) ListGroups(ctx *gin.Context) {
+	groups, err := c.services.SCIM.ListGroups(ctx.Request.Context(), newSCIMListQuery(ctx))
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToListSCIMResourcesMsg)
+		return
+	}
+
+	for _, group := range groups.Resources {
+		group.ResolveReferences(scimBaseURL(ctx))
+	}
+	utils.SendSCIM(ctx, http.StatusOK, groups)
+}
+
+// GetGroup godoc
+// @Summary Get a SCIM group
+// @Tags scim
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param excludedAttributes query string false "members to leave members out"
+// @Success 200 {object} dtos.SCIMGroup
+// @Failure 404 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Groups/{id} [get]
+// @Security BearerAuth
+func (c *SCIMController) GetGroup(ctx *gin.Context) {
+	excludeMembers := newSCIMListQuery(ctx).ExcludesMembers()
+	group, err := c.services.SCIM.GetGroup(ctx.Request.Context(), ctx.Param("id"), excludeMembers)
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToGetSCIMResourceMsg)
+		return
+	}
+
+	group.ResolveReferences(scimBaseURL(ctx))
+	utils.SendSCIM(ctx, http.StatusOK, group)
+}
+
+// CreateGroup godoc
+// @Summary Provision a SCIM group
+// @Description Create a group. Members get the roles that group-type directory role mappings grant for the group's externalId.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param group body dtos.SCIMGroup true "Group"
+// @Success 201 {object} dtos.SCIMGroup
+// @Failure 400 {object} utils.SCIMError
+// @Failure 409 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Groups [post]
+// @Security BearerAuth
+func (c *SCIMController) CreateGroup(ctx *gin.Context) {
+	var request dtos.SCIMGroup
+	if !bindSCIMRequest(ctx, &request) {
+		return
+	}
+
+	group, err := c.services.SCIM.CreateGroup(ctx.Request.Context(), &request)
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToSaveSCIMResourceMsg)
+		return
+	}
+
+	group.ResolveReferences(scimBaseURL(ctx))
+	ctx.Header("Location", group.Meta.Location)
+	utils.SendSCIM(ctx, http.StatusCreated, group)
+}
+
+// ReplaceGroup godoc
+// @Summary Replace a SCIM group
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param group body dtos.SCIMGroup true "Group"
+// @Success 200 {object} dtos.SCIMGroup
+// @Failure 400 {object} utils.SCIMError
+// @Failure 404 {object} utils.SCIMError
+// @Failure 409 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Groups/{id} [put]
+// @Security BearerAuth
+func (c *SCIMController) ReplaceGroup(ctx *gin.Context) {
+	var request dtos.SCIMGroup
+	if !bindSCIMRequest(ctx, &request) {
+		return
+	}
+
+	group, err := c.services.SCIM.ReplaceGroup(ctx.Request.Context(), ctx.Param("id"), &request)
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToSaveSCIMResourceMsg)
+		return
+	}
+
+	group.ResolveReferences(scimBaseURL(ctx))
+	utils.SendSCIM(ctx, http.StatusOK, group)
+}
+
+// PatchGroup godoc
+// @Summary Patch a SCIM group
+// @Description Apply PATCH operations, most often adding or removing members
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param patch body dtos.SCIMPatchRequest true "PATCH operations"
+// @Success 200 {object} dtos.SCIMGroup
+// @Failure 400 {object} utils.SCIMError
+// @Failure 404 {object} utils.SCIMError
+// @Failure 409 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Groups/{id} [patch]
+// @Security BearerAuth
+func (c *SCIMController) PatchGroup(ctx *gin.Context) {
+	var request dtos.SCIMPatchRequest
+	if !bindSCIMRequest(ctx, &request) {
+		return
+	}
+
+	group, err := c.services.SCIM.PatchGroup(ctx.Request.Context(), ctx.Param("id"), &request)
+	if err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToSaveSCIMResourceMsg)
+		return
+	}
+
+	group.ResolveReferences(scimBaseURL(ctx))
+	utils.SendSCIM(ctx, http.StatusOK, group)
+}
+
+// DeleteGroup godoc
+// @Summary Deprovision a SCIM group
+// @Description Delete a group and revoke the roles its members had through it
+// @Tags scim
+// @Param id path string true "Group ID"
+// @Success 204
+// @Failure 404 {object} utils.SCIMError
+// @Failure 500 {object} utils.SCIMError
+// @Router /scim/v2/Groups/{id} [delete]
+// @Security BearerAuth
+func (c *SCIMController) DeleteGroup(ctx *gin.Context) {
+	if err := c.services.SCIM.DeleteGroup(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		c.sendSCIMError(ctx, err, constants.ErrFailedToDeleteSCIMResourceMsg)
+		return
+	}
+
+	utils.SendSCIMNoContent(ctx)
+}
+
+func (c *SCIMController) sendSCIMError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, constants.ErrUserNotFound), errors.Is(err, constants.ErrSCIMGroupNotFound):
+		utils.SendSCIMError(ctx, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, constants.ErrSCIMInvalidFilter):
+		utils.SendSCIMError(ctx, http.StatusBadRequest, constants.SCIMTypeInvalidFilter, err.Error())
+	case errors.Is(err, constants.ErrSCIMInvalidPath):
+		utils.SendSCIMError(ctx, http.StatusBadRequest, constants.SCIMTypeInvalidPath, err.Error())
+	case errors.Is(err, constants.ErrSCIMInvalidValue):
+		utils.SendSCIMError(ctx, http.StatusBadRequest, constants.SCIMTypeInvalidValue, err.Error())
+	case errors.Is(err, constants.ErrSCIMInvalidSyntax):
+		utils.SendSCIMError(ctx, http.StatusBadRequest, constants.SCIMTypeInvalidSyntax, err.Error())
+	case errors.Is(err, constants.ErrSCIMNoTarget):
+		utils.SendSCIMError(ctx, http.StatusBadRequest, constants.SCIMTypeNoTarget, err.Error())
+	case errors.Is(err, constants.ErrSCIMUniqueness):
+		utils.SendSCIMError(ctx, http.StatusConflict, constants.SCIMTypeUniqueness, err.Error())
+	default:
+		log.Ctx(ctx).Error().Err(err).Msg(fallback)
+		utils.SendSCIMError(ctx, http.StatusInternalServerError, "", fallback)
+	}
+}
+
+// bindSCIMRequest decodes the request body, answering invalidSyntax when it
+// is not valid JSON for the resource.
+func bindSCIMRequest(ctx *gin.Context, request interface{}) bool {
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendSCIMError(ctx, http.StatusBadRequest, constants.SCIMTypeInvalidSyntax, err.Error())
+		return false
+	}
+	return true
+}
+
+func newSCIMListQuery(ctx *gin.Context) dtos.SCIMListQuery {
+	return dtos.SCIMListQuery{
+		Filter:             ctx.Query("filter"),
+		StartIndex:         ctx.Query("startIndex"),
+		Count:              ctx.Query("count"),
+		ExcludedAttributes: ctx.Query("excludedAttributes"),
+	}
+}
+
+func newSCIMDiscoveryList(resources interface{}, count int) *dtos.SCIMDiscoveryListResponse {
+	return &dtos.SCIMDiscoveryListResponse{
+		Schemas:      []string{constants.SCIMSchemaListResponse},
+		TotalResults: int64(count),
+		StartIndex:   1,
+		ItemsPerPage: count,
+		Resources:    resources,
+	}
+}
+
+// scimBaseURL is the absolute URL of the SCIM root as the client reached it,
+// which resource locations and references are relative to.
+func scimBaseURL(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := ctx.GetHeader("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	return scheme + "://" + ctx.Request.Host + "/scim/v2"
+}
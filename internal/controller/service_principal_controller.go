@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ServicePrincipalController struct {
+	services *service.Services
+}
+
+func NewServicePrincipalController(services *service.Services) *ServicePrincipalController {
+	return &ServicePrincipalController{
+		services: services,
+	}
+}
+
+// GetServicePrincipals godoc
+// @Summary List service principals
+// @Description List the machine callers that authenticate with Entra client-credential tokens or API keys
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.ServicePrincipalsListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals [get]
+// @Security BearerAuth
+func (c *ServicePrincipalController) GetServicePrincipals(ctx *gin.Context) {
+	principals, err := c.services.ServicePrincipal.GetServicePrincipals(ctx.Request.Context())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToRetrieveServicePrincipalsMsg)
+		utils.SendInternalServerError(ctx, constants.ErrFailedToRetrieveServicePrincipalsMsg)
+		return
+	}
+
+	responses := make([]dtos.ServicePrincipalResponse, len(principals))
+	for i, principal := range principals {
+		responses[i] = *principal
+	}
+
+	response := dtos.NewServicePrincipalsListResponse(responses, 1, len(responses), int64(len(responses)))
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetServicePrincipals, response)
+}
+
+// GetServicePrincipalByID godoc
+// @Summary Get service principal
+// @Description Retrieve a single service principal
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Param servicePrincipalId path string true "Service principal ID"
+// @Success 200 {object} dtos.ServicePrincipalResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals/{servicePrincipalId} [get]
+// @Security BearerAuth
+func (c *ServicePrincipalController) GetServicePrincipalByID(ctx *gin.Context) {
+	id := ctx.Param("servicePrincipalId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrServicePrincipalIDRequiredMsg)
+		return
+	}
+
+	principal, err := c.services.ServicePrincipal.GetServicePrincipalByID(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendServicePrincipalError(ctx, err, constants.ErrFailedToRetrieveServicePrincipalsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetServicePrincipalByID, principal)
+}
+
+// CreateServicePrincipal godoc
+// @Summary Create service principal
+// @Description Register a machine caller. Set app_id and object_id (the Entra service principal object ID) to accept the application's client-credential tokens; leave both empty for a principal that only uses API keys. Grant permissions by creating role assignments for the returned user_id.
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateServicePrincipalRequest true "Create service principal request"
+// @Success 201 {object} dtos.ServicePrincipalResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals [post]
+// @Security BearerAuth
+func (c *ServicePrincipalController) CreateServicePrincipal(ctx *gin.Context) {
+	var req dtos.CreateServicePrincipalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	principal, err := c.services.ServicePrincipal.CreateServicePrincipal(ctx.Request.Context(), &req)
+	if err != nil {
+		c.sendServicePrincipalError(ctx, err, constants.ErrFailedToCreateServicePrincipalMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgCreateServicePrincipal, principal)
+}
+
+// DeleteServicePrincipal godoc
+// @Summary Delete service principal
+// @Description Delete a service principal, revoking its API keys and role assignments
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Param servicePrincipalId path string true "Service principal ID"
+// @Success 200 {object} dtos.ServicePrincipalResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals/{servicePrincipalId} [delete]
+// @Security BearerAuth
+func (c *ServicePrincipalController) DeleteServicePrincipal(ctx *gin.Context) {
+	id := ctx.Param("servicePrincipalId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrServicePrincipalIDRequiredMsg)
+		return
+	}
+
+	principal, err := c.services.ServicePrincipal.DeleteServicePrincipal(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendServicePrincipalError(ctx, err, constants.ErrFailedToDeleteServicePrincipalMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgDeleteServicePrincipal, principal)
+}
+
+// GetAPIKeys godoc
+// @Summary List API keys
+// @Description List the API keys of a service principal with their status and last use. Secrets are never returned.
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Param servicePrincipalId path string true "Service principal ID"
+// @Success 200 {object} dtos.APIKeysListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals/{servicePrincipalId}/api-keys [get]
+// @Security BearerAuth
+func (c *ServicePrincipalController) GetAPIKeys(ctx *gin.Context) {
+	id := ctx.Param("servicePrincipalId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrServicePrincipalIDRequiredMsg)
+		return
+	}
+
+	keys, err := c.services.ServicePrincipal.GetAPIKeys(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendServicePrincipalError(ctx, err, constants.ErrFailedToRetrieveAPIKeysMsg)
+		return
+	}
+
+	responses := make([]dtos.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = *key
+	}
+
+	response := dtos.NewAPIKeysListResponse(responses, 1, len(responses), int64(len(responses)))
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetAPIKeys, response)
+}
+
+// CreateAPIKey godoc
+// @Summary Create API key
+// @Description Issue an API key for a service principal. The key is only returned in this response; send it in the X-API-Key header.
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Param servicePrincipalId path string true "Service principal ID"
+// @Param request body dtos.CreateAPIKeyRequest true "Create API key request"
+// @Success 201 {object} dtos.APIKeySecretResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals/{servicePrincipalId}/api-keys [post]
+// @Security BearerAuth
+func (c *ServicePrincipalController) CreateAPIKey(ctx *gin.Context) {
+	id := ctx.Param("servicePrincipalId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrServicePrincipalIDRequiredMsg)
+		return
+	}
+
+	var req dtos.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	key, err := c.services.ServicePrincipal.CreateAPIKey(ctx.Request.Context(), id, &req)
+	if err != nil {
+		c.sendServicePrincipalError(ctx, err, constants.ErrFailedToCreateAPIKeyMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgCreateAPIKey, key)
+}
+
+// RotateAPIKey godoc
+// @Summary Rotate API key
+// @Description Replace an API key with a new one. The old key is revoked immediately unless grace_period_minutes keeps it valid for the switch-over.
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Param servicePrincipalId path string true "Service principal ID"
+// @Param keyId path string true "API key ID"
+// @Param request body dtos.RotateAPIKeyRequest false "Rotate API key request"
+// @Success 201 {object} dtos.APIKeySecretResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals/{servicePrincipalId}/api-keys/{keyId}/rotate [post]
+// @Security BearerAuth
+func (c *ServicePrincipalController) RotateAPIKey(ctx *gin.Context) {
+	id, keyID, ok := c.apiKeyParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dtos.RotateAPIKeyRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+			utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+			return
+		}
+	}
+
+	key, err := c.services.ServicePrincipal.RotateAPIKey(ctx.Request.Context(), id, keyID, &req)
+	if err != nil {
+		c.sendServicePrincipalError(ctx, err, constants.ErrFailedToRotateAPIKeyMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgRotateAPIKey, key)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke API key
+// @Description Revoke an API key immediately
+// @Tags service-principals
+// @Accept json
+// @Produce json
+// @Param servicePrincipalId path string true "Service principal ID"
+// @Param keyId path string true "API key ID"
+// @Success 200 {object} dtos.APIKeyResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/service-principals/{servicePrincipalId}/api-keys/{keyId} [delete]
+// @Security BearerAuth
+func (c *ServicePrincipalController) RevokeAPIKey(ctx *gin.Context) {
+	id, keyID, ok := c.apiKeyParams(ctx)
+	if !ok {
+		return
+	}
+
+	key, err := c.services.ServicePrincipal.RevokeAPIKey(ctx.Request.Context(), id, keyID)
+	if err != nil {
+		c.sendServicePrincipalError(ctx, err, constants.ErrFailedToRevokeAPIKeyMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgRevokeAPIKey, key)
+}
+
+// apiKeyParams reads the service principal and key IDs from the path; it writes
+// the error response itself and reports false when one is missing.
+func (c *ServicePrincipalController) apiKeyParams(ctx *gin.Context) (string, string, bool) {
+	id := ctx.Param("servicePrincipalId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrServicePrincipalIDRequiredMsg)
+		return "", "", false
+	}
+
+	keyID := ctx.Param("keyId")
+	if keyID == "" {
+		utils.SendBadRequest(ctx, constants.ErrAPIKeyIDRequiredMsg)
+		return "", "", false
+	}
+
+	return id, keyID, true
+}
+
+// sendServicePrincipalError maps service validation errors to client responses and everything else to a 500.
+func (c *ServicePrincipalController) sendServicePrincipalError(ctx *gin.Context, err error, fallback string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrServicePrincipalNotFound), errors.Is(err, constants.ErrAPIKeyNotFound):
+		utils.SendNotFound(ctx, err.Error())
+	case errors.Is(err, constants.ErrServicePrincipalAlreadyExists), errors.Is(err, constants.ErrAPIKeyRevoked):
+		utils.SendConflict(ctx, err.Error())
+	case errors.Is(err, constants.ErrServicePrincipalEntraIDs), errors.Is(err, constants.ErrInvalidAPIKeyExpiresAt):
+		utils.SendValidationError(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallback)
+	}
+}
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+)
+
+const (
+	testCallerTenantID = "0b9a7c1e-5f2d-4e8a-9c3b-1d2e3f4a5b6c"
+	testOtherTenantID  = "7e6d5c4b-3a29-4817-b6c5-d4e3f2a1b0c9"
+	testCallerUserID   = "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d"
+	testOwnPrincipalID = "11111111-2222-4333-8444-555555555555"
+	testPrincipalID    = "99999999-8888-4777-8666-555555555555"
+	testAPIKeyID       = "aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee"
+)
+
+// fakePrincipalDB answers the service principal queries from memory and
+// applies their home tenant filters, so the results depend on the tenant the
+// service passes.
+type fakePrincipalDB struct {
+	principals []repository.ListServicePrincipalsRow
+	tenants    map[pgtype.UUID]pgtype.UUID
+	keys       []repository.ApiKey
+}
+
+func (db *fakePrincipalDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, fmt.Errorf("unexpected exec: %s", queryName(sql))
+}
+
+func (db *fakePrincipalDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	switch queryName(sql) {
+	case "ListServicePrincipals":
+		tenantID, id := args[0].(pgtype.UUID), args[1].(pgtype.UUID)
+		var rows [][]any
+		for _, p := range db.principals {
+			if db.tenants[p.ID] == tenantID && (!id.Valid || p.ID == id) {
+				rows = append(rows, []any{p.ID, p.UserID, p.DisplayName, p.Description, p.AppID, p.CreatedBy, p.CreatedAt, p.UpdatedAt, p.DeletedAt, p.ObjectID, p.ActiveApiKeys})
+			}
+		}
+		return &fakeRows{rows: rows}, nil
+	case "ListAPIKeys":
+		var rows [][]any
+		for _, key := range db.matchingKeys(pgtype.UUID{}, args[0].(pgtype.UUID), args[1].(pgtype.UUID)) {
+			rows = append(rows, apiKeyValues(key))
+		}
+		return &fakeRows{rows: rows}, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", queryName(sql))
+}
+
+func (db *fakePrincipalDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	var keys []repository.ApiKey
+	switch queryName(sql) {
+	case "GetAPIKeyByID":
+		keys = db.matchingKeys(args[0].(pgtype.UUID), args[1].(pgtype.UUID), args[2].(pgtype.UUID))
+	case "RevokeAPIKey":
+		keys = db.matchingKeys(args[0].(pgtype.UUID), args[1].(pgtype.UUID), args[3].(pgtype.UUID))
+	default:
+		return &fakeRows{err: fmt.Errorf("unexpected query: %s", queryName(sql))}
+	}
+	if len(keys) == 0 {
+		return &fakeRows{err: pgx.ErrNoRows}
+	}
+	return &fakeRows{rows: [][]any{apiKeyValues(keys[0])}}
+}
+
+// matchingKeys returns the keys of principalID in tenantID, only the one with
+// id when it is set.
+func (db *fakePrincipalDB) matchingKeys(id, principalID, tenantID pgtype.UUID) []repository.ApiKey {
+	var keys []repository.ApiKey
+	for _, key := range db.keys {
+		if key.ServicePrincipalID == principalID && db.tenants[principalID] == tenantID && (!id.Valid || key.ID == id) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func apiKeyValues(key repository.ApiKey) []any {
+	return []any{key.ID, key.ServicePrincipalID, key.Name, key.KeyPrefix, key.KeyHash, key.ExpiresAt, key.LastUsedAt, key.LastUsedIp, key.RotatedFrom, key.RevokedAt, key.RevokedBy, key.CreatedBy, key.CreatedAt}
+}
+
+func queryName(sql string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(sql, "-- name: "), " ")
+	return name
+}
+
+// fakeRows serves rows of values, which Scan copies into same-typed targets.
+type fakeRows struct {
+	rows [][]any
+	next int
+	err  error
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return r.err }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) Values() ([]any, error)                       { return r.rows[r.next-1], nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	if r.err != nil || r.next >= len(r.rows) {
+		return false
+	}
+	r.next++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.next == 0 {
+		r.next++
+	}
+	for i, value := range r.rows[r.next-1] {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func mustUUID(t *testing.T, s string) pgtype.UUID {
+	t.Helper()
+	var id pgtype.UUID
+	if err := id.Scan(s); err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func newServicePrincipalTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	ownID, otherID := mustUUID(t, testOwnPrincipalID), mustUUID(t, testPrincipalID)
+	db := &fakePrincipalDB{
+		principals: []repository.ListServicePrincipalsRow{
+			{ID: ownID, UserID: mustUUID(t, "22222222-2222-4222-8222-222222222222"), DisplayName: "Own importer", ObjectID: "sp_own"},
+			{ID: otherID, UserID: mustUUID(t, "33333333-3333-4333-8333-333333333333"), DisplayName: "Other importer", ObjectID: "sp_other"},
+		},
+		tenants: map[pgtype.UUID]pgtype.UUID{
+			ownID:   mustUUID(t, testCallerTenantID),
+			otherID: mustUUID(t, testOtherTenantID),
+		},
+		keys: []repository.ApiKey{
+			{ID: mustUUID(t, testAPIKeyID), ServicePrincipalID: otherID, Name: "ci", KeyPrefix: "0123456789abcdef"},
+		},
+	}
+
+	controller := NewServicePrincipalController(&service.Services{
+		ServicePrincipal: service.NewServicePrincipalService(nil, repository.New(db)),
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		ctx := utils.SetTenantContext(c.Request.Context(), testCallerTenantID, "caller-object-id", "Caller", "token")
+		c.Request = c.Request.WithContext(utils.SetInternalUserID(ctx, testCallerUserID))
+	})
+	principals := router.Group("/v1/service-principals")
+	principals.GET("", controller.GetServicePrincipals)
+	principals.GET("/:servicePrincipalId", controller.GetServicePrincipalByID)
+	principals.DELETE("/:servicePrincipalId", controller.DeleteServicePrincipal)
+	principals.GET("/:servicePrincipalId/api-keys", controller.GetAPIKeys)
+	principals.POST("/:servicePrincipalId/api-keys", controller.CreateAPIKey)
+	principals.POST("/:servicePrincipalId/api-keys/:keyId/rotate", controller.RotateAPIKey)
+	principals.DELETE("/:servicePrincipalId/api-keys/:keyId", controller.RevokeAPIKey)
+	return router
+}
+
+func TestServicePrincipalsOfOtherTenantsAreNotFound(t *testing.T) {
+	router := newServicePrincipalTestRouter(t)
+	base := "/v1/service-principals/" + testPrincipalID
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"get", http.MethodGet, base, ""},
+		{"delete", http.MethodDelete, base, ""},
+		{"list api keys", http.MethodGet, base + "/api-keys", ""},
+		{"create api key", http.MethodPost, base + "/api-keys", `{"name": "takeover"}`},
+		{"rotate api key", http.MethodPost, base + "/api-keys/" + testAPIKeyID + "/rotate", `{}`},
+		{"revoke api key", http.MethodDelete, base + "/api-keys/" + testAPIKeyID, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, http.StatusNotFound, rec.Body)
+			}
+		})
+	}
+}
+
+func TestServicePrincipalsListOnlyTheCallersTenant(t *testing.T) {
+	router := newServicePrincipalTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/service-principals", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/service-principals = %d: %s", rec.Code, rec.Body)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, testOwnPrincipalID) || strings.Contains(body, testPrincipalID) {
+		t.Errorf("GET /v1/service-principals = %s, want only %s", body, testOwnPrincipalID)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/service-principals/"+testOwnPrincipalID, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET own service principal = %d: %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SodConstraintController struct {
+	services *service.Services
+}
+
+func NewSodConstraintController(services *service.Services) *SodConstraintController {
+	return &SodConstraintController{
+		services: services,
+	}
+}
+
+// GetSodConstraints godoc
+// @Summary List separation-of-duties constraints
+// @Description List the role pairs that must not be held by the same user
+// @Tags sod-constraints
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.SodConstraintsListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/sod-constraints [get]
+// @Security BearerAuth
+func (c *SodConstraintController) GetSodConstraints(ctx *gin.Context) {
+	constraints, err := c.services.SodConstraint.GetSodConstraints(ctx.Request.Context())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToRetrieveSodConstraintsMsg)
+		utils.SendInternalServerError(ctx, constants.ErrFailedToRetrieveSodConstraintsMsg)
+		return
+	}
+
+	responses := make([]dtos.SodConstraintResponse, len(constraints))
+	for i, constraint := range constraints {
+		responses[i] = *constraint.ToResponse()
+	}
+
+	response := dtos.NewSodConstraintsListResponse(responses, 1, len(responses), int64(len(responses)))
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetSodConstraints, response)
+}
+
+// GetSodConstraintByID godoc
+// @Summary Get separation-of-duties constraint
+// @Description Retrieve a single separation-of-duties constraint
+// @Tags sod-constraints
+// @Accept json
+// @Produce json
+// @Param constraintId path string true "Constraint ID"
+// @Success 200 {object} dtos.SodConstraintResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/sod-constraints/{constraintId} [get]
+// @Security BearerAuth
+func (c *SodConstraintController) GetSodConstraintByID(ctx *gin.Context) {
+	id := ctx.Param("constraintId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrSodConstraintIDRequiredMsg)
+		return
+	}
+
+	constraint, err := c.services.SodConstraint.GetSodConstraintByID(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendSodConstraintError(ctx, err, constants.ErrFailedToRetrieveSodConstraintsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetSodConstraintByID, constraint.ToResponse())
+}
+
+// CreateSodConstraint godoc
+// @Summary Create separation-of-duties constraint
+// @Description Declare two roles as mutually exclusive. New role assignments that would give a user both roles are rejected.
+// @Tags sod-constraints
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateSodConstraintRequest true "Create constraint request"
+// @Success 201 {object} dtos.SodConstraintResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/sod-constraints [post]
+// @Security BearerAuth
+func (c *SodConstraintController) CreateSodConstraint(ctx *gin.Context) {
+	var req dtos.CreateSodConstraintRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(ctx, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	constraint, err := c.services.SodConstraint.CreateSodConstraint(ctx.Request.Context(), &req)
+	if err != nil {
+		c.sendSodConstraintError(ctx, err, constants.ErrFailedToCreateSodConstraintMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusCreated, constants.SuccessMsgCreateSodConstraint, constraint.ToResponse())
+}
+
+// DeleteSodConstraint godoc
+// @Summary Delete separation-of-duties constraint
+// @Description Remove a separation-of-duties constraint
+// @Tags sod-constraints
+// @Accept json
+// @Produce json
+// @Param constraintId path string true "Constraint ID"
+// @Success 200 {object} dtos.SodConstraintResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/sod-constraints/{constraintId} [delete]
+// @Security BearerAuth
+func (c *SodConstraintController) DeleteSodConstraint(ctx *gin.Context) {
+	id := ctx.Param("constraintId")
+	if id == "" {
+		utils.SendBadRequest(ctx, constants.ErrSodConstraintIDRequiredMsg)
+		return
+	}
+
+	constraint, err := c.services.SodConstraint.DeleteSodConstraint(ctx.Request.Context(), id)
+	if err != nil {
+		c.sendSodConstraintError(ctx, err, constants.ErrFailedToDeleteSodConstraintMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgDeleteSodConstraint, constraint.ToResponse())
+}
+
+// GetSodViolations godoc
+// @Summary Separation-of-duties violations report
+// @Description List users whose current role assignments already hold both roles of an active constraint
+// @Tags sod-constraints
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.SodViolationsResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/sod-constraints/violations [get]
+// @Security BearerAuth
+func (c *SodConstraintController) GetSodViolations(ctx *gin.Context) {
+	violations, err := c.services.SodConstraint.GetSodViolations(ctx.Request.Context())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(constants.ErrFailedToGetSodViolationsMsg)
+		utils.SendInternalServerError(ctx, constants.ErrFailedToGetSodViolationsMsg)
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, constants.SuccessMsgGetSodViolations, dtos.SodViolationsResponse{Violations: violations})
+}
+
+// sendSodConstraintError maps service validation errors to client responses and everything else to a 500.
+func (c *SodConstraintController) sendSodConstraintError(ctx *gin.Context, err error, fallback string) {
+	log.Ctx(ctx).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrSodConstraintNotFound), errors.Is(err, constants.ErrRoleNotFound):
+		utils.SendNotFound(ctx, err.Error())
+	case errors.Is(err, constants.ErrSodConstraintAlreadyExists):
+		utils.SendConflict(ctx, err.Error())
+	default:
+		utils.SendInternalServerError(ctx, fallback)
+	}
+}
@@ -2,10 +2,11 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/url"
 
 	"yet-another-itsm/internal/constants"
-	"yet-another-itsm/internal/model"
 	"yet-another-itsm/internal/service"
 	"yet-another-itsm/internal/utils"
 
@@ -35,6 +36,8 @@ func NewUserController(services *service.Services) *UserController {
 // @Accept json
 // @Produce json
 // @Param departmentId path string true "Department ID"
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
 // @Success 200 {object} dtos.UsersListResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -49,25 +52,103 @@ func (uc *UserController) GetAllUsersInDepartment(c *gin.Context) {
 		Str("department_id", departmentID).
 		Msg("Getting all users in department")
 
+	page, ok := bindPage(c)
+	if !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
 
-	users, err := uc.userService.GetAllUsersInDepartment(ctx, departmentID)
+	users, total, err := uc.userService.GetAllUsersInDepartment(ctx, departmentID, page)
 	if err != nil {
 		log.Error().Err(err).Msg(constants.ErrFailedToGetUsersInDepartmentMsg)
 		utils.SendNotFound(c, constants.ErrDepartmentNotFoundMsg)
 		return
 	}
 
-	var userResponses []responseModel.UserResponse
+	userResponses := make([]responseModel.UserResponse, 0, len(users))
 	for _, user := range users {
 		userResponses = append(userResponses, *user.ToResponse())
 	}
 
-	response := responseModel.NewUsersListResponse(userResponses, 1, len(userResponses), int64(len(userResponses))) // Thay vì dtos.NewUsersListResponse
+	response := responseModel.NewUsersListResponse(userResponses, page.Page, page.PageSize, total)
 
 	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetAllUsersInDepartment, response)
 }
 
+// SearchUsers godoc
+// @Summary Search users
+// @Description Cursor-paginated user directory of the caller's tenant. q matches word prefixes in display name, mail and job title; follow the next link until it is absent.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param q query string false "Search text"
+// @Param department_id query string false "Filter by department ID"
+// @Param business_unit_id query string false "Filter by business unit ID"
+// @Param status query string false "Filter by status (active, inactive, deleted)"
+// @Param manager_id query string false "Filter by manager ID"
+// @Param sort query string false "display_name, mail, created_at or last_login, prefixed with - for descending (default display_name)"
+// @Param cursor query string false "Cursor from a previous page's next link"
+// @Param limit query int false "Page size, 1-100 (default 20)"
+// @Success 200 {object} dtos.UsersPageResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users [get]
+// @Security BearerAuth
+func (uc *UserController) SearchUsers(c *gin.Context) {
+	query := responseModel.UserSearchQuery{
+		Q:              c.Query("q"),
+		DepartmentID:   c.Query("department_id"),
+		BusinessUnitID: c.Query("business_unit_id"),
+		Status:         c.Query("status"),
+		ManagerID:      c.Query("manager_id"),
+		Sort:           c.Query("sort"),
+		Cursor:         c.Query("cursor"),
+		Limit:          c.Query("limit"),
+	}
+
+	result, err := uc.userService.SearchUsers(c.Request.Context(), query)
+	if err != nil {
+		log.Ctx(c).Error().Err(err).Msg(constants.ErrFailedToRetrieveUsersMsg)
+		switch {
+		case errors.Is(err, constants.ErrInvalidUserSort), errors.Is(err, constants.ErrInvalidUserStatus),
+			errors.Is(err, constants.ErrInvalidPageLimit), errors.Is(err, constants.ErrInvalidCursor),
+			errors.Is(err, constants.ErrInvalidUserFilter):
+			utils.SendBadRequest(c, err.Error())
+		default:
+			utils.SendInternalServerError(c, constants.ErrFailedToRetrieveUsersMsg)
+		}
+		return
+	}
+
+	response := responseModel.UsersPageResponse{
+		Self:  c.Request.URL.RequestURI(),
+		First: pageLink(c.Request.URL, ""),
+		Items: make([]responseModel.UserResponse, len(result.Users)),
+	}
+	if result.NextCursor != "" {
+		response.Next = pageLink(c.Request.URL, result.NextCursor)
+	}
+	for i, user := range result.Users {
+		response.Items[i] = *user.ToResponse()
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgSearchUsers, response)
+}
+
+// pageLink is the request URL with its cursor replaced; an empty cursor links
+// to the first page.
+func pageLink(requestURL *url.URL, cursor string) string {
+	values := requestURL.Query()
+	values.Del("cursor")
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+
+	link := url.URL{Path: requestURL.Path, RawQuery: values.Encode()}
+	return link.String()
+}
+
 // GetUserByID godoc
 // @Summary Get user by ID
 // @Description Get a specific user by ID
@@ -140,9 +221,169 @@ func (uc *UserController) GetUserByEmail(c *gin.Context) {
 	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetUserByEmail, user.ToResponse())
 }
 
+// SuspendUser godoc
+// @Summary Suspend user
+// @Description Suspend a user until they are unlocked, or lock them until locked_until. Suspended and locked users are rejected at authentication. Callers cannot suspend themselves.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dtos.SuspendUserRequest true "Reason and optional lock end"
+// @Success 200 {object} dtos.UserResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/suspend [post]
+// @Security BearerAuth
+func (uc *UserController) SuspendUser(c *gin.Context) {
+	id := c.Param("userId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrUserIDRequiredMsg)
+		return
+	}
+
+	var req responseModel.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Ctx(c).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	user, err := uc.userService.SuspendUser(c.Request.Context(), id, &req)
+	if err != nil {
+		sendUserStatusError(c, err, constants.ErrFailedToSuspendUserMsg)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgSuspendUser, user.ToResponse())
+}
+
+// UnlockUser godoc
+// @Summary Unlock user
+// @Description Reactivate a suspended user and clear any lock
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dtos.UnlockUserRequest true "Reason"
+// @Success 200 {object} dtos.UserResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/unlock [post]
+// @Security BearerAuth
+func (uc *UserController) UnlockUser(c *gin.Context) {
+	id := c.Param("userId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrUserIDRequiredMsg)
+		return
+	}
+
+	var req responseModel.UnlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Ctx(c).Error().Err(err).Msg(constants.ErrInvalidRequestBody)
+		utils.SendBadRequest(c, constants.ErrInvalidRequestBody)
+		return
+	}
+
+	user, err := uc.userService.UnlockUser(c.Request.Context(), id, &req)
+	if err != nil {
+		sendUserStatusError(c, err, constants.ErrFailedToUnlockUserMsg)
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgUnlockUser, user.ToResponse())
+}
+
+// GetUserStatusChanges godoc
+// @Summary List user status changes
+// @Description List the suspensions, locks and unlocks of a user with their reasons, newest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
+// @Success 200 {object} dtos.UserStatusChangesListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/status-changes [get]
+// @Security BearerAuth
+func (uc *UserController) GetUserStatusChanges(c *gin.Context) {
+	id := c.Param("userId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrUserIDRequiredMsg)
+		return
+	}
+
+	page, ok := bindPage(c)
+	if !ok {
+		return
+	}
+
+	changes, total, err := uc.userService.GetUserStatusChanges(c.Request.Context(), id, page)
+	if err != nil {
+		sendUserStatusError(c, err, constants.ErrFailedToGetUserStatusMsg)
+		return
+	}
+
+	responses := make([]responseModel.UserStatusChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = *change
+	}
+
+	response := responseModel.NewUserStatusChangesListResponse(responses, page.Page, page.PageSize, total)
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetUserStatusChanges, response)
+}
+
+// GetUserProfileChanges godoc
+// @Summary List user profile changes
+// @Description List the profile fields refreshed from the identity provider at login, with their old and new values, newest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param page query int false "Page number, from 1"
+// @Param size query int false "Page size, at most 100"
+// @Success 200 {object} dtos.UserProfileChangesListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /v1/users/{userId}/profile-changes [get]
+// @Security BearerAuth
+func (uc *UserController) GetUserProfileChanges(c *gin.Context) {
+	id := c.Param("userId")
+	if id == "" {
+		utils.SendBadRequest(c, constants.ErrUserIDRequiredMsg)
+		return
+	}
+
+	page, ok := bindPage(c)
+	if !ok {
+		return
+	}
+
+	changes, total, err := uc.userService.GetUserProfileChanges(c.Request.Context(), id, page)
+	if err != nil {
+		sendUserStatusError(c, err, constants.ErrFailedToGetProfileMsg)
+		return
+	}
+
+	responses := make([]responseModel.UserProfileChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = *change
+	}
+
+	response := responseModel.NewUserProfileChangesListResponse(responses, page.Page, page.PageSize, total)
+	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetUserProfileChanges, response)
+}
+
 // GetCurrentUser godoc
 // @Summary Get user information
-// @Description Get user information and create user if not exists
+// @Description Get user information, creating the user on first login and refreshing their profile (department, job title, manager, ...) on every later login. Role assignments from directory role mappings are synced to the token's app roles and groups.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -171,21 +412,24 @@ func (uc *UserController) GetCurrentUser(c *gin.Context) {
 	ctx := c.Request.Context()
 	userEmail := utils.GetStringValue(user.GetMail())
 
-	// Check if user exists and get business unit info using GetOrCreate
-	existingUser, businessUnitName := uc.handleExistingUserWithGetOrCreate(ctx, userEmail)
+	// Create the user or bring the stored profile up to date
+	profile := responseModel.NewUserProfile(user, uc.services.Graph.GraphEnabled())
+	storedUser, err := uc.userService.SyncUserProfile(ctx, profile)
+	if err != nil {
+		log.Error().Err(err).Str("email", userEmail).Msg(constants.ErrFailedToSyncProfileMsg)
+		utils.SendInternalServerError(c, constants.ErrFailedToSyncProfileMsg)
+		return
+	}
 
 	// Update last login asynchronously
-	uc.updateLastLoginAsync(userEmail)
+	uc.updateLastLoginAsync(ctx, storedUser.Mail)
 
-	// Create user if not exists using GetOrCreate methods
-	if existingUser == nil {
-		if err := uc.createNewUserWithGetOrCreate(ctx, c, user, userEmail); err != nil {
-			return // Error already handled in createNewUser
-		}
-	}
+	// Mirror Entra app roles and groups into directory managed role assignments
+	uc.syncDirectoryRoles(ctx, storedUser.ID)
 
 	// Build and send response
-	userInfoResponse := uc.buildUserInfoResponse(user, businessUnitName)
+	businessUnitName := uc.getBusinessUnitName(ctx, storedUser.BusinessUnitID)
+	userInfoResponse := uc.buildUserInfoResponse(user, storedUser, businessUnitName)
 	log.Info().
 		Str("user_id", userInfoResponse.ID).
 		Msg(constants.SuccessMsgGetCurrentUser)
@@ -193,47 +437,28 @@ func (uc *UserController) GetCurrentUser(c *gin.Context) {
 	utils.SendSuccess(c, http.StatusOK, constants.SuccessMsgGetCurrentUser, userInfoResponse)
 }
 
-// handleExistingUserWithGetOrCreate checks if user exists and gets business unit name using GetOrCreate
-func (uc *UserController) handleExistingUserWithGetOrCreate(ctx context.Context, userEmail string) (*responseModel.User, string) {
-	existingUser, err := uc.services.User.GetUserByEmail(ctx, userEmail)
-	businessUnitName := uc.getBusinessUnitNameWithGetOrCreate(ctx, userEmail)
-
-	if err != nil {
-		return nil, businessUnitName
-	}
-	return existingUser, businessUnitName
-}
-
-// getBusinessUnitNameWithGetOrCreate extracts domain from email and gets business unit name using GetOrCreate
-func (uc *UserController) getBusinessUnitNameWithGetOrCreate(ctx context.Context, userEmail string) string {
-	domainName, isValid := utils.ExtractDomainFromEmail(userEmail)
-	if !isValid {
+// getBusinessUnitName gets the name of the user's business unit
+func (uc *UserController) getBusinessUnitName(ctx context.Context, businessUnitID string) string {
+	if businessUnitID == "" {
 		return ""
 	}
 
-	// Sử dụng GetOrCreateBusinessUnitByDomainName thay vì GetBusinessUnitByDomainName
-	businessUnit, err := uc.services.BusinessUnit.GetOrCreateBusinessUnitByDomainName(ctx, domainName)
+	businessUnit, err := uc.services.BusinessUnit.GetBusinessUnitByID(ctx, businessUnitID)
 	if err != nil {
 		log.Error().Err(err).
-			Str("domain_name", domainName).
-			Str("user_email", userEmail).
-			Msg("Failed to get or create business unit by domain")
+			Str("business_unit_id", businessUnitID).
+			Msg("Failed to get business unit by ID")
 		return ""
 	}
 
-	log.Info().
-		Str("domain_name", domainName).
-		Str("business_unit_name", businessUnit.Name).
-		Str("user_email", userEmail).
-		Msg("Successfully got or created business unit by domain")
-
 	return businessUnit.Name
 }
 
-// updateLastLoginAsync updates user's last login time in background
-func (uc *UserController) updateLastLoginAsync(userEmail string) {
+// updateLastLoginAsync updates user's last login time in background. The
+// request context is detached from cancellation but keeps the caller's tenant.
+func (uc *UserController) updateLastLoginAsync(ctx context.Context, userEmail string) {
 	go func() {
-		if updateErr := uc.services.User.UpdateUserLastLogin(context.Background(), userEmail); updateErr != nil {
+		if updateErr := uc.services.User.UpdateUserLastLogin(context.WithoutCancel(ctx), userEmail); updateErr != nil {
 			log.Error().Err(updateErr).
 				Str("email", userEmail).
 				Msg("Failed to update last login in background")
@@ -241,108 +466,24 @@ func (uc *UserController) updateLastLoginAsync(userEmail string) {
 	}()
 }
 
-// createNewUserWithGetOrCreate creates a new user using GetOrCreate methods for department and business unit
-func (uc *UserController) createNewUserWithGetOrCreate(ctx context.Context, c *gin.Context, user models.Userable, userEmail string) error {
-	log.Info().
-		Str("email", userEmail).
-		Msg("User not found in database, creating new user")
-
-	// Sử dụng GetOrCreate methods thay vì logic phức tạp
-	departmentID := uc.getDepartmentIDWithGetOrCreate(ctx, user)
-	businessUnitID := uc.getBusinessUnitIDWithGetOrCreate(ctx, userEmail)
-
-	tenantID, err := utils.GetTenantID(ctx)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to get tenant ID from context")
-		utils.SendInternalServerError(c, constants.ErrFailedToGetTenantID)
-		return err
-	}
-
-	createUserReq := &responseModel.CreateUserRequest{
-		AzureAdObjectID: utils.GetStringValue(user.GetId()),
-		HomeTenantID:    tenantID,
-		Mail:            userEmail,
-		DisplayName:     utils.GetStringValue(user.GetDisplayName()),
-		GivenName:       utils.GetStringValue(user.GetGivenName()),
-		SurName:         utils.GetStringValue(user.GetSurname()),
-		JobTitle:        utils.GetStringValue(user.GetJobTitle()),
-		OfficeLocation:  utils.GetStringValue(user.GetOfficeLocation()),
-		DepartmentID:    departmentID,
-		BusinessUnitID:  businessUnitID,
-		ManagerID:       responseModel.GetManagerId(user),
-		Status:          model.UserStatusActive,
-	}
-
-	_, createErr := uc.services.User.CreateUser(ctx, createUserReq)
-	if createErr != nil {
-		log.Error().Err(createErr).Msg("Failed to create user in database")
-	} else {
-		log.Info().
-			Str("email", userEmail).
-			Msg("Successfully created user in database")
-	}
-
-	return nil
-}
-
-// getDepartmentIDWithGetOrCreate gets department ID using GetOrCreateDepartmentByName
-func (uc *UserController) getDepartmentIDWithGetOrCreate(ctx context.Context, user models.Userable) string {
-	departmentName := utils.GetStringValue(user.GetDepartment())
-	if departmentName == "" {
-		return ""
-	}
-
-	// Sử dụng GetOrCreateDepartmentByName thay vì logic phức tạp
-	department, err := uc.services.Department.GetOrCreateDepartmentByName(ctx, departmentName)
-	if err != nil {
-		log.Error().Err(err).
-			Str("department_name", departmentName).
-			Msg("Failed to get or create department")
-		return ""
-	}
-
-	log.Info().
-		Str("department_name", departmentName).
-		Str("department_id", department.ID).
-		Msg("Successfully got or created department")
-
-	return department.ID
-}
-
-// getBusinessUnitIDWithGetOrCreate gets business unit ID using GetOrCreateBusinessUnitByDomainName
-func (uc *UserController) getBusinessUnitIDWithGetOrCreate(ctx context.Context, userEmail string) string {
-	domainName, isValid := utils.ExtractDomainFromEmail(userEmail)
-	if !isValid {
-		log.Warn().
-			Str("user_email", userEmail).
-			Msg("Invalid email format, cannot extract domain")
-		return ""
-	}
-
-	// Sử dụng GetOrCreateBusinessUnitByDomainName thay vì logic phức tạp
-	businessUnit, err := uc.services.BusinessUnit.GetOrCreateBusinessUnitByDomainName(ctx, domainName)
-	if err != nil {
+// syncDirectoryRoles applies the directory role mappings matched by the token's
+// app roles and groups. Failures are logged and do not block the login.
+func (uc *UserController) syncDirectoryRoles(ctx context.Context, userID string) {
+	claims := utils.GetDirectoryClaims(ctx)
+	if _, err := uc.services.DirectoryRoleMapping.SyncUserRoleAssignments(ctx, userID, claims); err != nil {
 		log.Error().Err(err).
-			Str("domain_name", domainName).
-			Str("user_email", userEmail).
-			Msg("Failed to get or create business unit")
-		return ""
+			Str("user_id", userID).
+			Strs("app_roles", claims.AppRoles).
+			Int("groups", len(claims.Groups)).
+			Msg("Failed to sync directory role assignments")
 	}
-
-	log.Info().
-		Str("domain_name", domainName).
-		Str("business_unit_id", businessUnit.ID).
-		Str("business_unit_name", businessUnit.Name).
-		Str("user_email", userEmail).
-		Msg("Successfully got or created business unit")
-
-	return businessUnit.ID
 }
 
-// buildUserInfoResponse creates the user info response object
-func (uc *UserController) buildUserInfoResponse(user models.Userable, businessUnitName string) responseModel.UserInfoResponse {
+// buildUserInfoResponse creates the user info response object. IDs, manager
+// and status come from the stored user; the rest is the fresh profile.
+func (uc *UserController) buildUserInfoResponse(user models.Userable, storedUser *responseModel.User, businessUnitName string) responseModel.UserInfoResponse {
 	return responseModel.UserInfoResponse{
-		ID:             utils.GetStringValue(user.GetId()),
+		ID:             storedUser.ID,
 		DisplayName:    utils.GetStringValue(user.GetDisplayName()),
 		Surname:        utils.GetStringValue(user.GetSurname()),
 		GivenName:      utils.GetStringValue(user.GetGivenName()),
@@ -352,7 +493,23 @@ func (uc *UserController) buildUserInfoResponse(user models.Userable, businessUn
 		OfficeLocation: utils.GetStringValue(user.GetOfficeLocation()),
 		Department:     utils.GetStringValue(user.GetDepartment()),
 		BusinessUnit:   businessUnitName,
-		Manager:        responseModel.GetManagerId(user),
-		Status:         model.UserStatusActive,
+		Manager:        storedUser.ManagerID,
+		Status:         storedUser.Status.String,
+	}
+}
+
+// sendUserStatusError maps account status validation errors to client responses and everything else to a 500.
+func sendUserStatusError(c *gin.Context, err error, fallback string) {
+	log.Ctx(c).Error().Err(err).Msg(fallback)
+
+	switch {
+	case errors.Is(err, constants.ErrUserNotFound):
+		utils.SendNotFound(c, constants.ErrUserNotFoundMsg)
+	case errors.Is(err, constants.ErrCannotSuspendSelf):
+		utils.SendForbidden(c, err.Error())
+	case errors.Is(err, constants.ErrInvalidLockedUntil):
+		utils.SendValidationError(c, err.Error())
+	default:
+		utils.SendInternalServerError(c, fallback)
 	}
 }
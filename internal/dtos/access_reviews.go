@@ -0,0 +1,227 @@
+package dtos
+
+import (
+	"strings"
+
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+type CreateAccessReviewCampaignRequest struct {
+	Name            string   `json:"name" binding:"required"`
+	Description     string   `json:"description"`
+	RoleIDs         []string `json:"role_ids"`
+	BusinessUnitIDs []string `json:"business_unit_ids" binding:"omitempty,dive,uuid"`
+	EndsAt          string   `json:"ends_at" binding:"required"`
+}
+
+type DecideAccessReviewItemRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approved revoked"`
+	Comment  string `json:"comment"`
+}
+
+type AccessReviewProgress struct {
+	Total    int64 `json:"total"`
+	Pending  int64 `json:"pending"`
+	Approved int64 `json:"approved"`
+	Revoked  int64 `json:"revoked"`
+}
+
+type AccessReviewCampaignResponse struct {
+	ID              string               `json:"id"`
+	Name            string               `json:"name"`
+	Description     string               `json:"description"`
+	RoleIDs         []string             `json:"role_ids"`
+	BusinessUnitIDs []string             `json:"business_unit_ids"`
+	EndsAt          string               `json:"ends_at"`
+	Status          string               `json:"status"`
+	CreatedBy       string               `json:"created_by"`
+	CompletedAt     string               `json:"completed_at,omitempty"`
+	CreatedAt       string               `json:"created_at"`
+	UpdatedAt       string               `json:"updated_at"`
+	Progress        AccessReviewProgress `json:"progress"`
+}
+
+type AccessReviewCampaignsListResponse struct {
+	Campaigns []AccessReviewCampaignResponse `json:"campaigns"`
+	Meta      PaginationMeta                 `json:"meta"`
+}
+
+type AccessReviewItemResponse struct {
+	ID               string `json:"id"`
+	CampaignID       string `json:"campaign_id"`
+	CampaignName     string `json:"campaign_name"`
+	CampaignEndsAt   string `json:"campaign_ends_at"`
+	RoleAssignmentID string `json:"role_assignment_id"`
+	AssigneeID       string `json:"assignee_id"`
+	AssigneeName     string `json:"assignee_name"`
+	AssigneeMail     string `json:"assignee_mail"`
+	ReviewerID       string `json:"reviewer_id"`
+	ReviewerName     string `json:"reviewer_name"`
+	ReviewerMail     string `json:"reviewer_mail"`
+	RoleID           string `json:"role_id"`
+	RoleName         string `json:"role_name"`
+	PermissionID     string `json:"permission_id"`
+	BusinessUnitID   string `json:"business_unit_id,omitempty"`
+	Decision         string `json:"decision"`
+	AutoRevoked      bool   `json:"auto_revoked"`
+	DecidedBy        string `json:"decided_by,omitempty"`
+	DecidedAt        string `json:"decided_at,omitempty"`
+	Comment          string `json:"comment,omitempty"`
+}
+
+type AccessReviewItemsListResponse struct {
+	Items []AccessReviewItemResponse `json:"items"`
+	Meta  PaginationMeta             `json:"meta"`
+}
+
+// AccessReviewExportHeader is the column order of the CSV export; see AccessReviewItemResponse.CSVRecord.
+var AccessReviewExportHeader = []string{
+	"campaign_id",
+	"campaign_name",
+	"item_id",
+	"role_assignment_id",
+	"assignee_id",
+	"assignee_name",
+	"assignee_mail",
+	"role_id",
+	"role_name",
+	"permission_id",
+	"business_unit_id",
+	"reviewer_id",
+	"reviewer_name",
+	"reviewer_mail",
+	"decision",
+	"auto_revoked",
+	"decided_by",
+	"decided_at",
+	"comment",
+}
+
+func NewAccessReviewCampaignResponse(campaign repository.AccessReviewCampaign, progress repository.GetAccessReviewCampaignProgressRow) *AccessReviewCampaignResponse {
+	response := &AccessReviewCampaignResponse{
+		ID:              campaign.ID.String(),
+		Name:            campaign.Name,
+		RoleIDs:         campaign.RoleIds,
+		BusinessUnitIDs: make([]string, len(campaign.BusinessUnitIds)),
+		EndsAt:          utils.FormatTime(campaign.EndsAt.Time),
+		Status:          string(campaign.Status),
+		CreatedBy:       campaign.CreatedBy.String(),
+		CreatedAt:       utils.FormatTime(campaign.CreatedAt.Time),
+		UpdatedAt:       utils.FormatTime(campaign.UpdatedAt.Time),
+		Progress: AccessReviewProgress{
+			Total:    progress.Total,
+			Pending:  progress.Pending,
+			Approved: progress.Approved,
+			Revoked:  progress.Revoked,
+		},
+	}
+
+	if response.RoleIDs == nil {
+		response.RoleIDs = []string{}
+	}
+	for i, id := range campaign.BusinessUnitIds {
+		response.BusinessUnitIDs[i] = id.String()
+	}
+	if campaign.Description.Valid {
+		response.Description = campaign.Description.String
+	}
+	if campaign.CompletedAt.Valid {
+		response.CompletedAt = utils.FormatTime(campaign.CompletedAt.Time)
+	}
+
+	return response
+}
+
+func NewAccessReviewItemResponse(item repository.ListAccessReviewItemsRow) *AccessReviewItemResponse {
+	response := &AccessReviewItemResponse{
+		ID:               item.ID.String(),
+		CampaignID:       item.CampaignID.String(),
+		CampaignName:     item.CampaignName,
+		CampaignEndsAt:   utils.FormatTime(item.EndsAt.Time),
+		RoleAssignmentID: item.RoleAssignmentID.String(),
+		AssigneeID:       item.AssigneeID.String(),
+		AssigneeName:     item.AssigneeName,
+		AssigneeMail:     item.AssigneeMail,
+		ReviewerID:       item.ReviewerID.String(),
+		ReviewerName:     item.ReviewerName,
+		ReviewerMail:     item.ReviewerMail,
+		RoleID:           item.RoleID,
+		RoleName:         item.RoleName,
+		PermissionID:     item.PermissionID,
+		Decision:         string(item.Decision),
+		AutoRevoked:      item.AutoRevoked,
+	}
+
+	if item.BusinessUnitID.Valid {
+		response.BusinessUnitID = item.BusinessUnitID.String()
+	}
+	if item.DecidedBy.Valid {
+		response.DecidedBy = item.DecidedBy.String()
+	}
+	if item.DecidedAt.Valid {
+		response.DecidedAt = utils.FormatTime(item.DecidedAt.Time)
+	}
+	if item.Comment.Valid {
+		response.Comment = item.Comment.String
+	}
+
+	return response
+}
+
+// CSVRecord returns the item as a row matching AccessReviewExportHeader.
+func (i *AccessReviewItemResponse) CSVRecord() []string {
+	autoRevoked := "false"
+	if i.AutoRevoked {
+		autoRevoked = "true"
+	}
+
+	record := []string{
+		i.CampaignID,
+		i.CampaignName,
+		i.ID,
+		i.RoleAssignmentID,
+		i.AssigneeID,
+		i.AssigneeName,
+		i.AssigneeMail,
+		i.RoleID,
+		i.RoleName,
+		i.PermissionID,
+		i.BusinessUnitID,
+		i.ReviewerID,
+		i.ReviewerName,
+		i.ReviewerMail,
+		i.Decision,
+		autoRevoked,
+		i.DecidedBy,
+		i.DecidedAt,
+		i.Comment,
+	}
+	for j, cell := range record {
+		record[j] = csvCell(cell)
+	}
+	return record
+}
+
+// csvCell defuses cells that spreadsheet applications would read as a formula
+// by prefixing them with a quote. Names and comments come from users.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func NewAccessReviewCampaignsListResponse(data []AccessReviewCampaignResponse, page, pageSize int, total int64) *AccessReviewCampaignsListResponse {
+	return &AccessReviewCampaignsListResponse{
+		Campaigns: data,
+		Meta:      CreatePaginationMeta(page, pageSize, total),
+	}
+}
+
+func NewAccessReviewItemsListResponse(data []AccessReviewItemResponse, page, pageSize int, total int64) *AccessReviewItemsListResponse {
+	return &AccessReviewItemsListResponse{
+		Items: data,
+		Meta:  CreatePaginationMeta(page, pageSize, total),
+	}
+}
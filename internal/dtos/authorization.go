@@ -0,0 +1,78 @@
+package dtos
+
+// PermissionGrant is a single active role assignment granting a resource/action,
+// with the organisational unit it applies to.
+type PermissionGrant struct {
+	RoleAssignmentID    string `json:"role_assignment_id"`
+	RoleID              string `json:"role_id"`
+	RoleName            string `json:"role_name"`
+	InheritedFromRoleID string `json:"inherited_from_role_id,omitempty"`
+	ScopeID             string `json:"scope_id"`
+	BusinessUnitID      string `json:"business_unit_id"`
+	DepartmentID        string `json:"department_id"`
+	TenantID            string `json:"tenant_id"`
+	ExpiresAt           string `json:"expires_at,omitempty"`
+}
+
+// AuthorizationTarget describes who owns the resource being accessed and the
+// tenant it belongs to.
+type AuthorizationTarget struct {
+	OwnerID        string `json:"owner_id"`
+	DepartmentID   string `json:"department_id"`
+	BusinessUnitID string `json:"business_unit_id"`
+	TenantID       string `json:"tenant_id"`
+}
+
+// ScopeFilter is the union of everything a caller may see for a resource/action,
+// used to restrict list queries. Every row must belong to TenantID; AllAccess
+// covers all rows of that tenant.
+type ScopeFilter struct {
+	TenantID        string
+	AllAccess       bool
+	OwnerID         string
+	DepartmentIDs   []string
+	BusinessUnitIDs []string
+}
+
+// IsEmpty reports whether the filter grants access to nothing.
+func (f *ScopeFilter) IsEmpty() bool {
+	return !f.AllAccess && f.OwnerID == "" && len(f.DepartmentIDs) == 0 && len(f.BusinessUnitIDs) == 0
+}
+
+// EffectivePermission is a flattened resource/action/scope tuple the user holds,
+// with every assignment that grants it.
+type EffectivePermission struct {
+	Resource       string            `json:"resource"`
+	Action         string            `json:"action"`
+	ScopeID        string            `json:"scope_id"`
+	BusinessUnitID string            `json:"business_unit_id,omitempty"`
+	DepartmentID   string            `json:"department_id,omitempty"`
+	Grants         []PermissionGrant `json:"grants"`
+}
+
+type EffectivePermissionsResponse struct {
+	UserID      string                `json:"user_id"`
+	Permissions []EffectivePermission `json:"permissions"`
+}
+
+type AuthorizationCheck struct {
+	Resource string               `json:"resource" binding:"required"`
+	Action   string               `json:"action" binding:"required"`
+	Target   *AuthorizationTarget `json:"target"`
+}
+
+type AuthorizationCheckRequest struct {
+	Checks []AuthorizationCheck `json:"checks" binding:"required,min=1,max=100,dive"`
+}
+
+type AuthorizationCheckResult struct {
+	Resource string           `json:"resource"`
+	Action   string           `json:"action"`
+	Allowed  bool             `json:"allowed"`
+	Reason   string           `json:"reason"`
+	Grant    *PermissionGrant `json:"grant,omitempty"`
+}
+
+type AuthorizationCheckResponse struct {
+	Results []AuthorizationCheckResult `json:"results"`
+}
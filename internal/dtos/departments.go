@@ -11,32 +11,69 @@ import (
 type Department struct {
 	model.BaseModel
 	Name           string `json:"name"`
+	BusinessUnitID string `json:"business_unit_id"`
+	ParentID       string `json:"parent_id"`
 	DeletedAt      string `json:"deleted_at"`
+	// Depth is the distance from the root of a subtree listing.
+	Depth int `json:"depth"`
 }
 
+// CreateDepartmentRequest creates a department in a business unit of the
+// caller's tenant, optionally below a parent department of the same unit.
 type CreateDepartmentRequest struct {
-	Name   string `json:"name" validate:"required,min=1,max=255"`
+	BusinessUnitID string `json:"business_unit_id" binding:"required"`
+	ParentID       string `json:"parent_id"`
+	Name           string `json:"name" binding:"required,min=1,max=255"`
+	Status         string `json:"status,omitempty"`
+}
+
+type UpdateDepartmentRequest struct {
+	Name   string `json:"name" binding:"required,min=1,max=255"`
 	Status string `json:"status,omitempty"`
 }
 
+// MoveDepartmentRequest moves a department, with its subtree, below another
+// department. An empty parent_id makes it a root department, of
+// business_unit_id when given and of its current business unit otherwise.
+type MoveDepartmentRequest struct {
+	ParentID       string `json:"parent_id"`
+	BusinessUnitID string `json:"business_unit_id"`
+}
+
 type DepartmentResponse struct {
 	ID             string `json:"id"`
 	Name           string `json:"name"`
+	BusinessUnitID string `json:"business_unit_id,omitempty"`
+	ParentID       string `json:"parent_id,omitempty"`
 	Status         string `json:"status"`
 	CreatedAt      string `json:"created_at"`
 	UpdatedAt      string `json:"updated_at"`
 	DeletedAt      string `json:"deleted_at"`
 }
 
+// DepartmentSubtreeResponse lists a department followed by its descendants,
+// depth first; depth 0 is the department itself.
+type DepartmentSubtreeResponse struct {
+	DepartmentResponse
+	Depth int `json:"depth"`
+}
+
 type DepartmentsListResponse struct {
 	Departments []DepartmentResponse `json:"departments"`
 	Meta        PaginationMeta       `json:"meta"`
 }
 
+type DepartmentSubtreeListResponse struct {
+	Departments []DepartmentSubtreeResponse `json:"departments"`
+	Meta        PaginationMeta              `json:"meta"`
+}
+
 func (d *Department) ToResponse() *DepartmentResponse {
 	return &DepartmentResponse{
 		ID:             d.ID,
 		Name:           d.Name,
+		BusinessUnitID: d.BusinessUnitID,
+		ParentID:       d.ParentID,
 		Status:         d.Status.String,
 		CreatedAt:      utils.FormatTime(d.CreatedAt.Time),
 		UpdatedAt:      utils.FormatTime(d.UpdatedAt.Time),
@@ -44,16 +81,48 @@ func (d *Department) ToResponse() *DepartmentResponse {
 	}
 }
 
+func (d *Department) ToSubtreeResponse() *DepartmentSubtreeResponse {
+	return &DepartmentSubtreeResponse{
+		DepartmentResponse: *d.ToResponse(),
+		Depth:              d.Depth,
+	}
+}
+
 func (d *Department) FromRepositoryModel(repo repository.Department) *Department {
-	return &Department{
+	department := &Department{
 		BaseModel: model.BaseModel{
 			ID:        repo.ID.String(),
 			Status:    pgtype.Text{String: string(repo.Status.StatusEnum), Valid: repo.Status.Valid},
 			CreatedAt: pgtype.Timestamptz{Time: repo.CreatedAt.Time, Valid: repo.CreatedAt.Valid},
 			UpdatedAt: pgtype.Timestamptz{Time: repo.UpdatedAt.Time, Valid: repo.UpdatedAt.Valid},
 		},
-		Name:           repo.Name,
+		Name: repo.Name,
 	}
+	if repo.BusinessUnitID.Valid {
+		department.BusinessUnitID = repo.BusinessUnitID.String()
+	}
+	if repo.ParentID.Valid {
+		department.ParentID = repo.ParentID.String()
+	}
+	if repo.DeletedAt.Valid {
+		department.DeletedAt = utils.FormatTime(repo.DeletedAt.Time)
+	}
+	return department
+}
+
+func (d *Department) FromSubtreeRow(row repository.ListDepartmentSubtreeRow) *Department {
+	department := d.FromRepositoryModel(repository.Department{
+		ID:             row.ID,
+		Name:           row.Name,
+		Status:         row.Status,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+		DeletedAt:      row.DeletedAt,
+		BusinessUnitID: row.BusinessUnitID,
+		ParentID:       row.ParentID,
+	})
+	department.Depth = int(row.Depth)
+	return department
 }
 
 func NewDepartmentsListResponse(data []DepartmentResponse, page, pageSize int, total int64) *DepartmentsListResponse {
@@ -62,3 +131,10 @@ func NewDepartmentsListResponse(data []DepartmentResponse, page, pageSize int, t
 		Meta:        CreatePaginationMeta(page, pageSize, total),
 	}
 }
+
+func NewDepartmentSubtreeListResponse(data []DepartmentSubtreeResponse, page, pageSize int, total int64) *DepartmentSubtreeListResponse {
+	return &DepartmentSubtreeListResponse{
+		Departments: data,
+		Meta:        CreatePaginationMeta(page, pageSize, total),
+	}
+}
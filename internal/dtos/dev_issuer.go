@@ -0,0 +1,36 @@
+package dtos
+
+// MintDevTokenRequest describes the caller to mint a dev token for. oid
+// defaults to a UUID derived from email, tid to the dev tenant.
+type MintDevTokenRequest struct {
+	ObjectID       string   `json:"oid" binding:"omitempty,uuid"`
+	TenantID       string   `json:"tid" binding:"omitempty,uuid"`
+	Name           string   `json:"name"`
+	Email          string   `json:"email" binding:"omitempty,email"`
+	GivenName      string   `json:"given_name"`
+	FamilyName     string   `json:"family_name"`
+	JobTitle       string   `json:"job_title"`
+	Department     string   `json:"department"`
+	OfficeLocation string   `json:"office_location"`
+	Roles          []string `json:"roles"`
+	Groups         []string `json:"groups"`
+	Scopes         string   `json:"scp"`
+	AppOnly        bool     `json:"app_only"`
+	TTLMinutes     int      `json:"ttl_minutes" binding:"omitempty,min=1,max=1440"`
+}
+
+type DevTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// DevDiscoveryResponse is the subset of OpenID provider metadata served by the
+// dev issuer.
+type DevDiscoveryResponse struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
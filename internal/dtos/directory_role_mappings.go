@@ -0,0 +1,71 @@
+package dtos
+
+import (
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+type CreateDirectoryRoleMappingRequest struct {
+	ClaimType      string `json:"claim_type" binding:"required,oneof=app_role group scim_group"`
+	ClaimValue     string `json:"claim_value" binding:"required"`
+	RoleID         string `json:"role_id" binding:"required"`
+	BusinessUnitID string `json:"business_unit_id" binding:"omitempty,uuid"`
+	Description    string `json:"description"`
+}
+
+type DirectoryRoleMappingResponse struct {
+	ID             string `json:"id"`
+	ClaimType      string `json:"claim_type"`
+	ClaimValue     string `json:"claim_value"`
+	RoleID         string `json:"role_id"`
+	TenantID       string `json:"tenant_id"`
+	BusinessUnitID string `json:"business_unit_id,omitempty"`
+	Description    string `json:"description"`
+	CreatedBy      string `json:"created_by"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+type DirectoryRoleMappingsListResponse struct {
+	DirectoryRoleMappings []DirectoryRoleMappingResponse `json:"directory_role_mappings"`
+	Meta                  PaginationMeta                 `json:"meta"`
+}
+
+// DirectoryRoleSyncResult summarises how a user's directory managed
+// assignments changed to match their token claims.
+type DirectoryRoleSyncResult struct {
+	Granted int `json:"granted"`
+	Revoked int `json:"revoked"`
+	Skipped int `json:"skipped"`
+}
+
+func NewDirectoryRoleMappingResponse(mapping repository.DirectoryRoleMapping) *DirectoryRoleMappingResponse {
+	response := &DirectoryRoleMappingResponse{
+		ID:         mapping.ID.String(),
+		ClaimType:  string(mapping.ClaimType),
+		ClaimValue: mapping.ClaimValue,
+		RoleID:     mapping.RoleID,
+		TenantID:   mapping.TenantID.String(),
+		CreatedAt:  utils.FormatTime(mapping.CreatedAt.Time),
+		UpdatedAt:  utils.FormatTime(mapping.UpdatedAt.Time),
+	}
+
+	if mapping.BusinessUnitID.Valid {
+		response.BusinessUnitID = mapping.BusinessUnitID.String()
+	}
+	if mapping.Description.Valid {
+		response.Description = mapping.Description.String
+	}
+	if mapping.CreatedBy.Valid {
+		response.CreatedBy = mapping.CreatedBy.String()
+	}
+
+	return response
+}
+
+func NewDirectoryRoleMappingsListResponse(data []DirectoryRoleMappingResponse, page, pageSize int, total int64) *DirectoryRoleMappingsListResponse {
+	return &DirectoryRoleMappingsListResponse{
+		DirectoryRoleMappings: data,
+		Meta:                  CreatePaginationMeta(page, pageSize, total),
+	}
+}
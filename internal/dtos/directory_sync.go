@@ -0,0 +1,12 @@
+package dtos
+
+// DirectorySyncResult counts what one directory sync run changed.
+type DirectorySyncResult struct {
+	FullSync        bool `json:"full_sync"`
+	Created         int  `json:"created"`
+	Updated         int  `json:"updated"`
+	Deactivated     int  `json:"deactivated"`
+	Reactivated     int  `json:"reactivated"`
+	ManagersUpdated int  `json:"managers_updated"`
+	Skipped         int  `json:"skipped"`
+}
@@ -0,0 +1,95 @@
+package dtos
+
+import (
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+// CreateElevationRequest asks for a role for a bounded time. Elevations last at
+// most eight hours.
+type CreateElevationRequest struct {
+	RoleID          string `json:"role_id" binding:"required"`
+	BusinessUnitID  string `json:"business_unit_id" binding:"omitempty,uuid"`
+	Justification   string `json:"justification" binding:"required,min=10"`
+	DurationMinutes int32  `json:"duration_minutes" binding:"required,min=1,max=480"`
+}
+
+type DecideElevationRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ElevationRequestFilter narrows the audit listing; empty fields match everything.
+type ElevationRequestFilter struct {
+	Status      string
+	RequesterID string
+	RoleID      string
+}
+
+type ElevationRequestResponse struct {
+	ID              string `json:"id"`
+	RequesterID     string `json:"requester_id"`
+	RequesterName   string `json:"requester_name"`
+	RequesterMail   string `json:"requester_mail"`
+	RoleID          string `json:"role_id"`
+	RoleName        string `json:"role_name"`
+	BusinessUnitID  string `json:"business_unit_id,omitempty"`
+	Justification   string `json:"justification"`
+	DurationMinutes int32  `json:"duration_minutes"`
+	Status          string `json:"status"`
+	DecidedBy       string `json:"decided_by,omitempty"`
+	DecidedByName   string `json:"decided_by_name,omitempty"`
+	DecidedAt       string `json:"decided_at,omitempty"`
+	DecisionComment string `json:"decision_comment,omitempty"`
+	ExpiresAt       string `json:"expires_at,omitempty"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+type ElevationRequestsListResponse struct {
+	ElevationRequests []ElevationRequestResponse `json:"elevation_requests"`
+	Meta              PaginationMeta             `json:"meta"`
+}
+
+func NewElevationRequestResponse(request repository.ListElevationRequestsRow) *ElevationRequestResponse {
+	response := &ElevationRequestResponse{
+		ID:              request.ID.String(),
+		RequesterID:     request.RequesterID.String(),
+		RequesterName:   request.RequesterName,
+		RequesterMail:   request.RequesterMail,
+		RoleID:          request.RoleID,
+		RoleName:        request.RoleName,
+		Justification:   request.Justification,
+		DurationMinutes: request.DurationMinutes,
+		Status:          string(request.Status),
+		CreatedAt:       utils.FormatTime(request.CreatedAt.Time),
+		UpdatedAt:       utils.FormatTime(request.UpdatedAt.Time),
+	}
+
+	if request.BusinessUnitID.Valid {
+		response.BusinessUnitID = request.BusinessUnitID.String()
+	}
+	if request.DecidedBy.Valid {
+		response.DecidedBy = request.DecidedBy.String()
+	}
+	if request.DecidedByName.Valid {
+		response.DecidedByName = request.DecidedByName.String
+	}
+	if request.DecidedAt.Valid {
+		response.DecidedAt = utils.FormatTime(request.DecidedAt.Time)
+	}
+	if request.DecisionComment.Valid {
+		response.DecisionComment = request.DecisionComment.String
+	}
+	if request.ExpiresAt.Valid {
+		response.ExpiresAt = utils.FormatTime(request.ExpiresAt.Time)
+	}
+
+	return response
+}
+
+func NewElevationRequestsListResponse(data []ElevationRequestResponse, page, pageSize int, total int64) *ElevationRequestsListResponse {
+	return &ElevationRequestsListResponse{
+		ElevationRequests: data,
+		Meta:              CreatePaginationMeta(page, pageSize, total),
+	}
+}
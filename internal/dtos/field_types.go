@@ -0,0 +1,99 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"yet-another-itsm/internal/model"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type FieldType struct {
+	model.BaseModel
+	TypeName         string          `json:"type_name"`
+	Description      string          `json:"description"`
+	ValidationSchema json.RawMessage `json:"validation_schema"`
+	IsBuiltin        bool            `json:"is_builtin"`
+	DeletedAt        string          `json:"deleted_at"`
+}
+
+type FieldTypeResponse struct {
+	ID               string          `json:"id"`
+	TypeName         string          `json:"type_name"`
+	Description      string          `json:"description,omitempty"`
+	ValidationSchema json.RawMessage `json:"validation_schema,omitempty"`
+	IsBuiltin        bool            `json:"is_builtin"`
+	Status           string          `json:"status"`
+	CreatedAt        string          `json:"created_at"`
+	UpdatedAt        string          `json:"updated_at"`
+	DeletedAt        string          `json:"deleted_at"`
+}
+
+// CreateFieldTypeRequest registers a field type. validation_schema is the JSON
+// Schema the config of every field of this type must satisfy; without one any
+// config object is accepted.
+type CreateFieldTypeRequest struct {
+	TypeName         string          `json:"type_name" binding:"required,max=50"`
+	Description      string          `json:"description"`
+	ValidationSchema json.RawMessage `json:"validation_schema"`
+}
+
+// UpdateFieldTypeRequest replaces the description and validation schema of a
+// field type. The type name cannot change since form fields reference it.
+type UpdateFieldTypeRequest struct {
+	Description      string          `json:"description"`
+	ValidationSchema json.RawMessage `json:"validation_schema"`
+}
+
+type FieldTypesListResponse struct {
+	Items      []FieldTypeResponse `json:"items"`
+	Page       int                 `json:"page"`
+	Size       int                 `json:"size"`
+	TotalItems int64               `json:"total_items"`
+}
+
+func NewFieldTypesListResponse(items []FieldTypeResponse, page, size int, totalItems int64) *FieldTypesListResponse {
+	return &FieldTypesListResponse{
+		Items:      items,
+		Page:       page,
+		Size:       size,
+		TotalItems: totalItems,
+	}
+}
+
+func (ft *FieldType) ToResponse() *FieldTypeResponse {
+	return &FieldTypeResponse{
+		ID:               ft.ID,
+		TypeName:         ft.TypeName,
+		Description:      ft.Description,
+		ValidationSchema: ft.ValidationSchema,
+		IsBuiltin:        ft.IsBuiltin,
+		Status:           ft.Status.String,
+		CreatedAt:        utils.FormatTime(ft.CreatedAt.Time),
+		UpdatedAt:        utils.FormatTime(ft.UpdatedAt.Time),
+		DeletedAt:        ft.DeletedAt,
+	}
+}
+
+func (ft *FieldType) FromRepositoryModel(repo repository.FieldType) FieldType {
+	fieldType := FieldType{
+		BaseModel: model.BaseModel{
+			ID:        repo.ID.String(),
+			Status:    pgtype.Text{String: string(repo.Status.StatusEnum), Valid: repo.Status.Valid},
+			CreatedAt: pgtype.Timestamptz{Time: repo.CreatedAt.Time, Valid: repo.CreatedAt.Valid},
+			UpdatedAt: pgtype.Timestamptz{Time: repo.UpdatedAt.Time, Valid: repo.UpdatedAt.Valid},
+		},
+		TypeName:         repo.TypeName,
+		Description:      repo.Description.String,
+		ValidationSchema: json.RawMessage(repo.ValidationSchema),
+		IsBuiltin:        repo.IsBuiltin,
+	}
+
+	if repo.DeletedAt.Valid {
+		fieldType.DeletedAt = utils.FormatTime(repo.DeletedAt.Time)
+	}
+
+	return fieldType
+}
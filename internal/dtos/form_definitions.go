@@ -0,0 +1,129 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+// FormDefinitionResponse is one version of a form template as a single
+// document for rendering: its sections ordered by section_order, each with its
+// fields ordered by field_order, and the fields that sit outside any section.
+// Every key is always present and lists are never null, so equal definitions
+// serialize to the same bytes.
+type FormDefinitionResponse struct {
+	ID             string                  `json:"id"`
+	LineageID      string                  `json:"lineage_id"`
+	Version        int32                   `json:"version"`
+	State          string                  `json:"state"`
+	Name           string                  `json:"name"`
+	Description    string                  `json:"description"`
+	FormCategoryID string                  `json:"form_category_id"`
+	BusinessUnitID string                  `json:"business_unit_id"`
+	DepartmentID   string                  `json:"department_id"`
+	PublishedAt    string                  `json:"published_at"`
+	Sections       []FormDefinitionSection `json:"sections"`
+	Fields         []FormDefinitionField   `json:"fields"`
+}
+
+type FormDefinitionSection struct {
+	ID           string                `json:"id"`
+	SectionName  string                `json:"section_name"`
+	Description  string                `json:"description"`
+	SectionOrder int32                 `json:"section_order"`
+	Fields       []FormDefinitionField `json:"fields"`
+}
+
+type FormDefinitionField struct {
+	ID         string                  `json:"id"`
+	FieldName  string                  `json:"field_name"`
+	FieldOrder int32                   `json:"field_order"`
+	FieldType  FormDefinitionFieldType `json:"field_type"`
+	Config     json.RawMessage         `json:"config"`
+}
+
+// FormDefinitionFieldType is the registry entry of a field's type. A type that
+// has been deleted since the field was saved resolves to its name only.
+type FormDefinitionFieldType struct {
+	TypeName         string          `json:"type_name"`
+	Description      string          `json:"description"`
+	IsBuiltin        bool            `json:"is_builtin"`
+	Status           string          `json:"status"`
+	ValidationSchema json.RawMessage `json:"validation_schema"`
+}
+
+// NewFormDefinitionResponse nests fields, in the order given, under their
+// sections, keeping the order of sections as given.
+func NewFormDefinitionResponse(template repository.FormTemplate, sections []repository.FormSection, fields []repository.FormField, fieldTypes []repository.FieldType) *FormDefinitionResponse {
+	definition := &FormDefinitionResponse{
+		ID:             template.ID.String(),
+		LineageID:      template.LineageID.String(),
+		Version:        template.Version,
+		State:          string(template.State),
+		Name:           template.Name,
+		Description:    template.Description.String,
+		FormCategoryID: template.FormCategoryID.String(),
+		BusinessUnitID: template.BusinessUnitID.String(),
+		Sections:       make([]FormDefinitionSection, len(sections)),
+		Fields:         []FormDefinitionField{},
+	}
+	if template.DepartmentID.Valid {
+		definition.DepartmentID = template.DepartmentID.String()
+	}
+	if template.PublishedAt.Valid {
+		definition.PublishedAt = utils.FormatTime(template.PublishedAt.Time)
+	}
+
+	types := make(map[string]FormDefinitionFieldType, len(fieldTypes))
+	for _, fieldType := range fieldTypes {
+		types[fieldType.TypeName] = FormDefinitionFieldType{
+			TypeName:         fieldType.TypeName,
+			Description:      fieldType.Description.String,
+			IsBuiltin:        fieldType.IsBuiltin,
+			Status:           string(fieldType.Status.StatusEnum),
+			ValidationSchema: jsonOrEmptyObject(fieldType.ValidationSchema),
+		}
+	}
+
+	sectionIndex := make(map[string]int, len(sections))
+	for i, section := range sections {
+		sectionIndex[section.ID.String()] = i
+		definition.Sections[i] = FormDefinitionSection{
+			ID:           section.ID.String(),
+			SectionName:  section.SectionName,
+			Description:  section.Description.String,
+			SectionOrder: section.SectionOrder,
+			Fields:       []FormDefinitionField{},
+		}
+	}
+
+	for _, field := range fields {
+		fieldType, ok := types[field.FieldType]
+		if !ok {
+			fieldType = FormDefinitionFieldType{TypeName: field.FieldType, ValidationSchema: jsonOrEmptyObject(nil)}
+		}
+		item := FormDefinitionField{
+			ID:         field.ID.String(),
+			FieldName:  field.FieldName,
+			FieldOrder: field.FieldOrder,
+			FieldType:  fieldType,
+			Config:     jsonOrEmptyObject(field.Config),
+		}
+
+		if i, ok := sectionIndex[field.FormSectionID.String()]; field.FormSectionID.Valid && ok {
+			definition.Sections[i].Fields = append(definition.Sections[i].Fields, item)
+			continue
+		}
+		definition.Fields = append(definition.Fields, item)
+	}
+
+	return definition
+}
+
+func jsonOrEmptyObject(raw []byte) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage(`{}`)
+	}
+	return json.RawMessage(raw)
+}
@@ -0,0 +1,113 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"yet-another-itsm/internal/model"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type FormField struct {
+	model.BaseModel
+	FormTemplateID string          `json:"form_template_id"`
+	FormSectionID  string          `json:"form_section_id"`
+	FieldName      string          `json:"field_name"`
+	FieldType      string          `json:"field_type"`
+	FieldOrder     int32           `json:"field_order"`
+	Config         json.RawMessage `json:"config"`
+	DeletedAt      string          `json:"deleted_at"`
+}
+
+type FormFieldResponse struct {
+	ID             string          `json:"id"`
+	FormTemplateID string          `json:"form_template_id"`
+	FormSectionID  string          `json:"form_section_id,omitempty"`
+	FieldName      string          `json:"field_name"`
+	FieldType      string          `json:"field_type"`
+	FieldOrder     int32           `json:"field_order"`
+	Config         json.RawMessage `json:"config,omitempty"`
+	Status         string          `json:"status"`
+	CreatedAt      string          `json:"created_at"`
+	UpdatedAt      string          `json:"updated_at"`
+	DeletedAt      string          `json:"deleted_at"`
+}
+
+// CreateFormFieldRequest adds a field to the template. form_section_id must be
+// a section of the same template; field_type must be a registered field type
+// and config must satisfy its validation schema.
+type CreateFormFieldRequest struct {
+	FormSectionID string          `json:"form_section_id"`
+	FieldName     string          `json:"field_name" binding:"required,max=100"`
+	FieldType     string          `json:"field_type" binding:"required,max=50"`
+	FieldOrder    int32           `json:"field_order" binding:"required,min=1"`
+	Config        json.RawMessage `json:"config"`
+}
+
+// UpdateFormFieldRequest replaces a field; it is validated like a new field.
+type UpdateFormFieldRequest struct {
+	FormSectionID string          `json:"form_section_id"`
+	FieldName     string          `json:"field_name" binding:"required,max=100"`
+	FieldType     string          `json:"field_type" binding:"required,max=50"`
+	FieldOrder    int32           `json:"field_order" binding:"required,min=1"`
+	Config        json.RawMessage `json:"config"`
+}
+
+type FormFieldsListResponse struct {
+	Items      []FormFieldResponse `json:"items"`
+	Page       int                 `json:"page"`
+	Size       int                 `json:"size"`
+	TotalItems int64               `json:"total_items"`
+}
+
+func NewFormFieldsListResponse(items []FormFieldResponse, page, size int, totalItems int64) *FormFieldsListResponse {
+	return &FormFieldsListResponse{
+		Items:      items,
+		Page:       page,
+		Size:       size,
+		TotalItems: totalItems,
+	}
+}
+
+func (ff *FormField) ToResponse() *FormFieldResponse {
+	return &FormFieldResponse{
+		ID:             ff.ID,
+		FormTemplateID: ff.FormTemplateID,
+		FormSectionID:  ff.FormSectionID,
+		FieldName:      ff.FieldName,
+		FieldType:      ff.FieldType,
+		FieldOrder:     ff.FieldOrder,
+		Config:         ff.Config,
+		Status:         ff.Status.String,
+		CreatedAt:      utils.FormatTime(ff.CreatedAt.Time),
+		UpdatedAt:      utils.FormatTime(ff.UpdatedAt.Time),
+		DeletedAt:      ff.DeletedAt,
+	}
+}
+
+func (ff *FormField) FromRepositoryModel(repo repository.FormField) FormField {
+	field := FormField{
+		BaseModel: model.BaseModel{
+			ID:        repo.ID.String(),
+			Status:    pgtype.Text{String: string(repo.Status.StatusEnum), Valid: repo.Status.Valid},
+			CreatedAt: pgtype.Timestamptz{Time: repo.CreatedAt.Time, Valid: repo.CreatedAt.Valid},
+			UpdatedAt: pgtype.Timestamptz{Time: repo.UpdatedAt.Time, Valid: repo.UpdatedAt.Valid},
+		},
+		FormTemplateID: repo.FormTemplateID.String(),
+		FieldName:      repo.FieldName,
+		FieldType:      repo.FieldType,
+		FieldOrder:     repo.FieldOrder,
+		Config:         json.RawMessage(repo.Config),
+	}
+
+	if repo.FormSectionID.Valid {
+		field.FormSectionID = repo.FormSectionID.String()
+	}
+	if repo.DeletedAt.Valid {
+		field.DeletedAt = utils.FormatTime(repo.DeletedAt.Time)
+	}
+
+	return field
+}
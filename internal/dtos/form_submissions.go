@@ -0,0 +1,142 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"yet-another-itsm/internal/model"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type FormSubmission struct {
+	model.BaseModel
+	FormTemplateID string          `json:"form_template_id"`
+	LineageID      string          `json:"lineage_id"`
+	BusinessUnitID string          `json:"business_unit_id"`
+	DepartmentID   string          `json:"department_id"`
+	SubmittedBy    string          `json:"submitted_by"`
+	State          string          `json:"state"`
+	Answers        json.RawMessage `json:"answers"`
+	SubmittedAt    string          `json:"submitted_at"`
+	DeletedAt      string          `json:"deleted_at"`
+}
+
+type FormSubmissionResponse struct {
+	ID             string          `json:"id"`
+	FormTemplateID string          `json:"form_template_id"`
+	LineageID      string          `json:"lineage_id"`
+	BusinessUnitID string          `json:"business_unit_id,omitempty"`
+	DepartmentID   string          `json:"department_id,omitempty"`
+	SubmittedBy    string          `json:"submitted_by"`
+	State          string          `json:"state"`
+	Answers        json.RawMessage `json:"answers"`
+	SubmittedAt    string          `json:"submitted_at,omitempty"`
+	Status         string          `json:"status"`
+	CreatedAt      string          `json:"created_at"`
+	UpdatedAt      string          `json:"updated_at"`
+	DeletedAt      string          `json:"deleted_at"`
+}
+
+// CreateFormSubmissionRequest answers the fields of a published template
+// version, keyed by field name. With draft set the answers are saved for the
+// submitter to finish later: required fields may still be missing, but the
+// answers given must be valid.
+type CreateFormSubmissionRequest struct {
+	Answers json.RawMessage `json:"answers"`
+	Draft   bool            `json:"draft"`
+}
+
+// UpdateFormSubmissionRequest replaces the answers of a draft. Without draft
+// set the draft is validated in full and submitted.
+type UpdateFormSubmissionRequest struct {
+	Answers json.RawMessage `json:"answers"`
+	Draft   bool            `json:"draft"`
+}
+
+// FormSubmissionQuery holds the raw filters and paging of a submission list.
+// TemplateID selects every version of the template's form; SubmittedBy may be
+// "me" for the caller.
+type FormSubmissionQuery struct {
+	TemplateID  string
+	SubmittedBy string
+	State       string
+	From        string
+	To          string
+	Page        string
+	Size        string
+}
+
+// FormSubmissionSearchResult is one page of submissions, newest first.
+type FormSubmissionSearchResult struct {
+	Submissions []*FormSubmission
+	Page        int
+	Size        int
+	TotalItems  int64
+}
+
+type FormSubmissionsListResponse struct {
+	Items      []FormSubmissionResponse `json:"items"`
+	Page       int                      `json:"page"`
+	Size       int                      `json:"size"`
+	TotalItems int64                    `json:"total_items"`
+}
+
+func NewFormSubmissionsListResponse(items []FormSubmissionResponse, page, size int, totalItems int64) *FormSubmissionsListResponse {
+	return &FormSubmissionsListResponse{
+		Items:      items,
+		Page:       page,
+		Size:       size,
+		TotalItems: totalItems,
+	}
+}
+
+func (fs *FormSubmission) ToResponse() *FormSubmissionResponse {
+	return &FormSubmissionResponse{
+		ID:             fs.ID,
+		FormTemplateID: fs.FormTemplateID,
+		LineageID:      fs.LineageID,
+		BusinessUnitID: fs.BusinessUnitID,
+		DepartmentID:   fs.DepartmentID,
+		SubmittedBy:    fs.SubmittedBy,
+		State:          fs.State,
+		Answers:        fs.Answers,
+		SubmittedAt:    fs.SubmittedAt,
+		Status:         fs.Status.String,
+		CreatedAt:      utils.FormatTime(fs.CreatedAt.Time),
+		UpdatedAt:      utils.FormatTime(fs.UpdatedAt.Time),
+		DeletedAt:      fs.DeletedAt,
+	}
+}
+
+func (fs *FormSubmission) FromRepositoryModel(repo repository.FormSubmission) FormSubmission {
+	submission := FormSubmission{
+		BaseModel: model.BaseModel{
+			ID:        repo.ID.String(),
+			Status:    pgtype.Text{String: string(repo.Status.StatusEnum), Valid: repo.Status.Valid},
+			CreatedAt: pgtype.Timestamptz{Time: repo.CreatedAt.Time, Valid: repo.CreatedAt.Valid},
+			UpdatedAt: pgtype.Timestamptz{Time: repo.UpdatedAt.Time, Valid: repo.UpdatedAt.Valid},
+		},
+		FormTemplateID: repo.FormTemplateID.String(),
+		LineageID:      repo.LineageID.String(),
+		SubmittedBy:    repo.SubmittedBy.String(),
+		State:          string(repo.State),
+		Answers:        json.RawMessage(repo.Answers),
+	}
+
+	if repo.BusinessUnitID.Valid {
+		submission.BusinessUnitID = repo.BusinessUnitID.String()
+	}
+	if repo.DepartmentID.Valid {
+		submission.DepartmentID = repo.DepartmentID.String()
+	}
+	if repo.SubmittedAt.Valid {
+		submission.SubmittedAt = utils.FormatTime(repo.SubmittedAt.Time)
+	}
+	if repo.DeletedAt.Valid {
+		submission.DeletedAt = utils.FormatTime(repo.DeletedAt.Time)
+	}
+
+	return submission
+}
@@ -15,12 +15,17 @@ type FormTemplate struct {
 	Description    string `json:"description"`
 	FormCategoryID string `json:"form_category_id"`
 	BusinessUnitID string `json:"business_unit_id"`
+	DepartmentID   string `json:"department_id"`
+	LineageID      string `json:"lineage_id"`
 	Version        int32  `json:"version"`
+	State          string `json:"state"`
 	PublishedAt    string `json:"published_at"`
 	CreatedBy      string `json:"created_by"`
 	ApprovedBy     string `json:"approved_by"`
 	ApprovedAt     string `json:"approved_at"`
+	RetiredAt      string `json:"retired_at"`
 	DeletedAt      string `json:"deleted_at"`
+	TenantID       string `json:"tenant_id"`
 }
 
 type FormTemplateResponse struct {
@@ -29,11 +34,15 @@ type FormTemplateResponse struct {
 	Description    string `json:"description"`
 	FormCategoryID string `json:"form_category_id"`
 	BusinessUnitID string `json:"business_unit_id"`
+	DepartmentID   string `json:"department_id"`
+	LineageID      string `json:"lineage_id"`
 	Version        int32  `json:"version"`
+	State          string `json:"state"`
 	PublishedAt    string `json:"published_at"`
 	CreatedBy      string `json:"created_by"`
 	ApprovedBy     string `json:"approved_by"`
 	ApprovedAt     string `json:"approved_at"`
+	RetiredAt      string `json:"retired_at"`
 	Status         string `json:"status"`
 	CreatedAt      string `json:"created_at"`
 	UpdatedAt      string `json:"updated_at"`
@@ -45,20 +54,18 @@ type CreateFormTemplateRequest struct {
 	Description    string `json:"description"`
 	FormCategoryID string `json:"form_category_id" binding:"required"`
 	BusinessUnitID string `json:"business_unit_id" binding:"required"`
-	Version        int32  `json:"version"`
+	DepartmentID   string `json:"department_id"`
 	CreatedBy      string `json:"created_by"`
 }
 
+// UpdateFormTemplateRequest changes a draft in place. Applied to a published or
+// retired version it starts the next draft version with the changes instead.
 type UpdateFormTemplateRequest struct {
 	Name           string `json:"name" binding:"required"`
 	Description    string `json:"description"`
 	FormCategoryID string `json:"form_category_id"`
 	BusinessUnitID string `json:"business_unit_id"`
-	Version        int32  `json:"version"`
-}
-
-type PublishFormTemplateRequest struct {
-	ApprovedBy string `json:"approved_by" binding:"required"`
+	DepartmentID   string `json:"department_id"`
 }
 
 type FormTemplatesListResponse struct {
@@ -84,11 +91,15 @@ func (ft *FormTemplate) ToResponse() *FormTemplateResponse {
 		Description:    ft.Description,
 		FormCategoryID: ft.FormCategoryID,
 		BusinessUnitID: ft.BusinessUnitID,
+		DepartmentID:   ft.DepartmentID,
+		LineageID:      ft.LineageID,
 		Version:        ft.Version,
+		State:          ft.State,
 		PublishedAt:    ft.PublishedAt,
 		CreatedBy:      ft.CreatedBy,
 		ApprovedBy:     ft.ApprovedBy,
 		ApprovedAt:     ft.ApprovedAt,
+		RetiredAt:      ft.RetiredAt,
 		Status:         ft.Status.String,
 		CreatedAt:      utils.FormatTime(ft.CreatedAt.Time),
 		UpdatedAt:      utils.FormatTime(ft.UpdatedAt.Time),
@@ -104,20 +115,46 @@ func (ft *FormTemplate) FromRepositoryModel(repo repository.FormTemplate) FormTe
 			CreatedAt: pgtype.Timestamptz{Time: repo.CreatedAt.Time, Valid: repo.CreatedAt.Valid},
 			UpdatedAt: pgtype.Timestamptz{Time: repo.UpdatedAt.Time, Valid: repo.UpdatedAt.Valid},
 		},
+		ID:             repo.ID.String(),
 		Name:           repo.Name,
 		Description:    repo.Description.String,
 		FormCategoryID: repo.FormCategoryID.String(),
 		BusinessUnitID: repo.BusinessUnitID.String(),
-		Version:        repo.Version.Int32,
+		LineageID:      repo.LineageID.String(),
+		Version:        repo.Version,
+		State:          string(repo.State),
 		CreatedBy:      repo.CreatedBy.String(),
+		TenantID:       repo.TenantID.String(),
 	}
 
+	if repo.DepartmentID.Valid {
+		template.DepartmentID = repo.DepartmentID.String()
+	}
 	if repo.PublishedAt.Valid {
 		template.PublishedAt = utils.FormatTime(repo.PublishedAt.Time)
 	}
+	if repo.ApprovedBy.Valid {
+		template.ApprovedBy = repo.ApprovedBy.String()
+	}
+	if repo.ApprovedAt.Valid {
+		template.ApprovedAt = utils.FormatTime(repo.ApprovedAt.Time)
+	}
+	if repo.RetiredAt.Valid {
+		template.RetiredAt = utils.FormatTime(repo.RetiredAt.Time)
+	}
 	if repo.DeletedAt.Valid {
 		template.DeletedAt = utils.FormatTime(repo.DeletedAt.Time)
 	}
 
 	return template
 }
+
+// AuthorizationTarget describes the ownership of the template for scoped permission checks.
+func (ft *FormTemplate) AuthorizationTarget() *AuthorizationTarget {
+	return &AuthorizationTarget{
+		OwnerID:        ft.CreatedBy,
+		DepartmentID:   ft.DepartmentID,
+		BusinessUnitID: ft.BusinessUnitID,
+		TenantID:       ft.TenantID,
+	}
+}
@@ -21,6 +21,26 @@ type UserInfoResponse struct {
 	Status         string `json:"status"`
 }
 
+// NewUserProfile reads the profile fields of a Graph user. managerKnown tells
+// whether the manager was requested, so a missing manager means there is none.
+func NewUserProfile(user models.Userable, managerKnown bool) *UserProfile {
+	profile := &UserProfile{
+		ObjectID:       utils.GetStringValue(user.GetId()),
+		Mail:           utils.GetStringValue(user.GetMail()),
+		DisplayName:    utils.GetStringValue(user.GetDisplayName()),
+		GivenName:      utils.GetStringValue(user.GetGivenName()),
+		Surname:        utils.GetStringValue(user.GetSurname()),
+		JobTitle:       utils.GetStringValue(user.GetJobTitle()),
+		OfficeLocation: utils.GetStringValue(user.GetOfficeLocation()),
+		Department:     utils.GetStringValue(user.GetDepartment()),
+	}
+	if managerKnown {
+		managerObjectID := GetManagerId(user)
+		profile.ManagerObjectID = &managerObjectID
+	}
+	return profile
+}
+
 func GetManagerId(user models.Userable) string {
 	if user.GetManager() != nil {
 		return utils.GetStringValue(user.GetManager().GetId())
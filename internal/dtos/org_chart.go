@@ -0,0 +1,73 @@
+package dtos
+
+import (
+	"yet-another-itsm/internal/repository"
+)
+
+// OrgChartUser is a user placed in the management hierarchy. Depth counts the
+// levels from the user the query started at, or from the chart's roots.
+type OrgChartUser struct {
+	ID             string          `json:"id"`
+	ManagerID      string          `json:"manager_id,omitempty"`
+	DepartmentID   string          `json:"department_id,omitempty"`
+	BusinessUnitID string          `json:"business_unit_id,omitempty"`
+	DisplayName    string          `json:"display_name"`
+	Mail           string          `json:"mail"`
+	JobTitle       string          `json:"job_title,omitempty"`
+	Status         string          `json:"status"`
+	Depth          int             `json:"depth"`
+	Reports        []*OrgChartUser `json:"reports,omitempty"`
+}
+
+type OrgChartUsersListResponse struct {
+	Users []OrgChartUser `json:"users"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// OrgChartFilter selects the members of an org chart. At least one of
+// DepartmentID and BusinessUnitID is required; Depth limits the levels.
+type OrgChartFilter struct {
+	DepartmentID   string
+	BusinessUnitID string
+	Depth          string
+}
+
+type OrgChartResponse struct {
+	DepartmentID   string          `json:"department_id,omitempty"`
+	BusinessUnitID string          `json:"business_unit_id,omitempty"`
+	Depth          int             `json:"depth"`
+	Roots          []*OrgChartUser `json:"roots"`
+}
+
+// NewOrgChartUser converts a hierarchy row. The hierarchy queries select the
+// same columns, so their rows convert to repository.ListReportsRow.
+func NewOrgChartUser(row repository.ListReportsRow) *OrgChartUser {
+	user := &OrgChartUser{
+		ID:          row.ID.String(),
+		DisplayName: row.DisplayName,
+		Mail:        row.Mail,
+		Status:      string(row.Status.StatusEnum),
+		Depth:       int(row.Depth),
+	}
+	if row.ManagerID.Valid {
+		user.ManagerID = row.ManagerID.String()
+	}
+	if row.DepartmentID.Valid {
+		user.DepartmentID = row.DepartmentID.String()
+	}
+	if row.BusinessUnitID.Valid {
+		user.BusinessUnitID = row.BusinessUnitID.String()
+	}
+	if row.JobTitle.Valid {
+		user.JobTitle = row.JobTitle.String
+	}
+
+	return user
+}
+
+func NewOrgChartUsersListResponse(data []OrgChartUser, page, pageSize int, total int64) *OrgChartUsersListResponse {
+	return &OrgChartUsersListResponse{
+		Users: data,
+		Meta:  CreatePaginationMeta(page, pageSize, total),
+	}
+}
@@ -10,6 +10,22 @@ type PaginationMeta struct {
 	IsLastPage bool  `json:"is_last_page"`
 }
 
+// PageRequest selects one page of a paged list. Pages start at 1.
+type PageRequest struct {
+	Page     int
+	PageSize int
+}
+
+// Limit is the LIMIT of the page's query.
+func (p PageRequest) Limit() int32 {
+	return int32(p.PageSize)
+}
+
+// Offset is the OFFSET of the page's query.
+func (p PageRequest) Offset() int32 {
+	return int32((p.Page - 1) * p.PageSize)
+}
+
 func CreatePaginationMeta(page, pageSize int, total int64) PaginationMeta {
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 	isLastPage := page >= totalPages
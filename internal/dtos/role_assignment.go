@@ -31,43 +31,55 @@ type RoleAssignment struct {
 	DepartmentName    string      `json:"department_name"`
 	AssigneeName      string      `json:"assignee_name"`
 	AssigneeEmail     string      `json:"assignee_email"`
+	// DirectoryMappingID is set when the assignment mirrors an Entra claim and
+	// is maintained at login rather than through the API.
+	DirectoryMappingID string `json:"directory_mapping_id"`
+	// ElevationRequestID is set when the assignment was granted by an approved
+	// elevation request and is revoked when the elevation expires.
+	ElevationRequestID string `json:"elevation_request_id"`
 }
 
 type RoleAssignmentResponse struct {
-	ID                string `json:"id"`
-	RolePermissionsID string `json:"role_permissions_id"`
-	AssigneeID        string `json:"assignee_id"`
-	BusinessUnitID    string `json:"business_unit_id"`
-	DepartmentID      string `json:"department_id"`
-	AssignedBy        string `json:"assigned_by"`
-	AssignedAt        string `json:"assigned_at"`
-	ExpiresAt         string `json:"expires_at"`
-	Status            string `json:"status"`
-	UpdatedAt         string `json:"updated_at"`
-	DeletedAt         string `json:"deleted_at"`
+	ID                 string `json:"id"`
+	RolePermissionsID  string `json:"role_permissions_id"`
+	AssigneeID         string `json:"assignee_id"`
+	BusinessUnitID     string `json:"business_unit_id"`
+	DepartmentID       string `json:"department_id"`
+	AssignedBy         string `json:"assigned_by"`
+	AssignedAt         string `json:"assigned_at"`
+	ExpiresAt          string `json:"expires_at"`
+	Status             string `json:"status"`
+	UpdatedAt          string `json:"updated_at"`
+	DeletedAt          string `json:"deleted_at"`
+	ExternallyManaged  bool   `json:"externally_managed"`
+	DirectoryMappingID string `json:"directory_mapping_id,omitempty"`
+	ElevationRequestID string `json:"elevation_request_id,omitempty"`
 }
 
 type RoleAssignmentDetailResponse struct {
-	ID                string `json:"id"`
-	RolePermissionsID string `json:"role_permissions_id"`
-	AssigneeID        string `json:"assignee_id"`
-	AssigneeName      string `json:"assignee_name"`
-	AssigneeEmail     string `json:"assignee_email"`
-	BusinessUnitID    string `json:"business_unit_id"`
-	BusinessUnitName  string `json:"business_unit_name"`
-	DepartmentID      string `json:"department_id"`
-	DepartmentName    string `json:"department_name"`
-	AssignedBy        string `json:"assigned_by"`
-	AssignedAt        string `json:"assigned_at"`
-	ExpiresAt         string `json:"expires_at"`
-	RoleName          string `json:"role_name"`
-	PermissionName    string `json:"permission_name"`
-	Resource          string `json:"resource"`
-	Action            string `json:"action"`
-	ScopeName         string `json:"scope_name"`
-	Status            string `json:"status"`
-	UpdatedAt         string `json:"updated_at"`
-	DeletedAt         string `json:"deleted_at"`
+	ID                 string `json:"id"`
+	RolePermissionsID  string `json:"role_permissions_id"`
+	AssigneeID         string `json:"assignee_id"`
+	AssigneeName       string `json:"assignee_name"`
+	AssigneeEmail      string `json:"assignee_email"`
+	BusinessUnitID     string `json:"business_unit_id"`
+	BusinessUnitName   string `json:"business_unit_name"`
+	DepartmentID       string `json:"department_id"`
+	DepartmentName     string `json:"department_name"`
+	AssignedBy         string `json:"assigned_by"`
+	AssignedAt         string `json:"assigned_at"`
+	ExpiresAt          string `json:"expires_at"`
+	RoleName           string `json:"role_name"`
+	PermissionName     string `json:"permission_name"`
+	Resource           string `json:"resource"`
+	Action             string `json:"action"`
+	ScopeName          string `json:"scope_name"`
+	Status             string `json:"status"`
+	UpdatedAt          string `json:"updated_at"`
+	DeletedAt          string `json:"deleted_at"`
+	ExternallyManaged  bool   `json:"externally_managed"`
+	DirectoryMappingID string `json:"directory_mapping_id,omitempty"`
+	ElevationRequestID string `json:"elevation_request_id,omitempty"`
 }
 
 type RoleAssignmentsListResponse struct {
@@ -80,14 +92,21 @@ type CreateRoleAssignmentRequest struct {
 	AssigneeID        string `json:"assignee_id" binding:"required"`
 	BusinessUnitID    string `json:"business_unit_id"`
 	DepartmentID      string `json:"department_id"`
-	AssignedBy        string `json:"assigned_by"`
 	ExpiresAt         string `json:"expires_at"`
-	Status            string `json:"status"`
+	Status            string `json:"status" binding:"omitempty,oneof=active inactive"`
+}
+
+type BulkCreateRoleAssignmentRequest struct {
+	Assignments []CreateRoleAssignmentRequest `json:"assignments" binding:"required,min=1,max=100,dive"`
 }
 
 type UpdateRoleAssignmentRequest struct {
 	ExpiresAt string `json:"expires_at"`
-	Status    string `json:"status"`
+	Status    string `json:"status" binding:"omitempty,oneof=active inactive"`
+}
+
+type BulkRoleAssignmentsResponse struct {
+	RoleAssignments []RoleAssignmentResponse `json:"role_assignments"`
 }
 
 func (ra *RoleAssignment) ToResponse() *RoleAssignmentResponse {
@@ -117,17 +136,20 @@ func (ra *RoleAssignment) ToResponse() *RoleAssignmentResponse {
 	}
 
 	return &RoleAssignmentResponse{
-		ID:                ra.ID.String(),
-		RolePermissionsID: ra.RolePermissionsID,
-		AssigneeID:        ra.AssigneeID,
-		BusinessUnitID:    businessUnitID,
-		DepartmentID:      departmentID,
-		AssignedBy:        assignedBy,
-		AssignedAt:        ra.AssignedAt,
-		ExpiresAt:         expiresAt,
-		Status:            ra.Status,
-		UpdatedAt:         ra.UpdatedAt,
-		DeletedAt:         deletedAt,
+		ID:                 ra.ID.String(),
+		RolePermissionsID:  ra.RolePermissionsID,
+		AssigneeID:         ra.AssigneeID,
+		BusinessUnitID:     businessUnitID,
+		DepartmentID:       departmentID,
+		AssignedBy:         assignedBy,
+		AssignedAt:         ra.AssignedAt,
+		ExpiresAt:          expiresAt,
+		Status:             ra.Status,
+		UpdatedAt:          ra.UpdatedAt,
+		DeletedAt:          deletedAt,
+		ExternallyManaged:  ra.DirectoryMappingID != "",
+		DirectoryMappingID: ra.DirectoryMappingID,
+		ElevationRequestID: ra.ElevationRequestID,
 	}
 }
 
@@ -162,20 +184,40 @@ func (ra *RoleAssignment) FromRepositoryModel(repo repository.RoleAssignment) *R
 		deletedAt = utils.FormatTime(repo.DeletedAt.Time)
 	}
 
+	updatedAt := ""
+	if repo.UpdatedAt.Valid {
+		updatedAt = utils.FormatTime(repo.UpdatedAt.Time)
+	}
+
+	directoryMappingID := ""
+	if repo.DirectoryMappingID.Valid {
+		directoryMappingID = repo.DirectoryMappingID.String()
+	}
+
+	elevationRequestID := ""
+	if repo.ElevationRequestID.Valid {
+		elevationRequestID = repo.ElevationRequestID.String()
+	}
+
 	return &RoleAssignment{
 		BaseModel: model.BaseModel{
 			ID:        repo.ID.String(),
 			Status:    pgtype.Text{String: string(repo.Status.StatusEnum), Valid: repo.Status.Valid},
 			UpdatedAt: pgtype.Timestamptz{Time: repo.UpdatedAt.Time, Valid: repo.UpdatedAt.Valid},
 		},
-		RolePermissionsID: repo.RolePermissionsID.String(),
-		AssigneeID:        repo.AssigneeID.String(),
-		BusinessUnitID:    businessUnitID,
-		DepartmentID:      departmentID,
-		AssignedBy:        assignedBy,
-		AssignedAt:        assignedAt,
-		ExpiresAt:         expiresAt,
-		DeletedAt:         deletedAt,
+		ID:                 repo.ID,
+		RolePermissionsID:  repo.RolePermissionsID.String(),
+		AssigneeID:         repo.AssigneeID.String(),
+		BusinessUnitID:     businessUnitID,
+		DepartmentID:       departmentID,
+		AssignedBy:         assignedBy,
+		AssignedAt:         assignedAt,
+		ExpiresAt:          expiresAt,
+		DeletedAt:          deletedAt,
+		Status:             string(repo.Status.StatusEnum),
+		UpdatedAt:          updatedAt,
+		DirectoryMappingID: directoryMappingID,
+		ElevationRequestID: elevationRequestID,
 	}
 }
 
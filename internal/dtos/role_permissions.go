@@ -37,6 +37,7 @@ type RolePermissionDetailResponse struct {
 	Action         string `json:"action"`
 	ScopeID        string `json:"scope_id"`
 	ScopeName      string `json:"scope_name"`
+	Inherited      bool   `json:"inherited"`
 	Status         string `json:"status"`
 	CreatedAt      string `json:"created_at"`
 	UpdatedAt      string `json:"updated_at"`
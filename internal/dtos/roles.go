@@ -14,6 +14,7 @@ type Role struct {
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 	IsSystemRole bool   `json:"is_system_role"`
+	ParentRoleID string `json:"parent_role_id"`
 	DeletedAt    string `json:"deleted_at"`
 }
 
@@ -22,6 +23,7 @@ type RoleResponse struct {
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 	IsSystemRole bool   `json:"is_system_role"`
+	ParentRoleID string `json:"parent_role_id,omitempty"`
 	Status       string `json:"status"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
@@ -38,9 +40,16 @@ type CreateRoleRequest struct {
 	Name         string `json:"name" binding:"required"`
 	Description  string `json:"description"`
 	IsSystemRole bool   `json:"is_system_role"`
+	ParentRoleID string `json:"parent_role_id"`
 	Status       string `json:"status"`
 }
 
+// SetRoleParentRequest sets the role this role inherits permissions from.
+// An empty parent_role_id detaches the role from its parent.
+type SetRoleParentRequest struct {
+	ParentRoleID string `json:"parent_role_id"`
+}
+
 type UpdateRoleRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
@@ -63,6 +72,7 @@ func (r *Role) ToResponse() *RoleResponse {
 		Name:         r.Name,
 		Description:  description,
 		IsSystemRole: r.IsSystemRole,
+		ParentRoleID: r.ParentRoleID,
 		Status:       r.Status.String,
 		CreatedAt:    utils.FormatTime(r.CreatedAt.Time),
 		UpdatedAt:    utils.FormatTime(r.UpdatedAt.Time),
@@ -91,6 +101,7 @@ func (r *Role) FromRepositoryModel(repo repository.Role) *Role {
 		Name:         repo.Name,
 		Description:  description,
 		IsSystemRole: repo.IsSystemRole.Bool,
+		ParentRoleID: repo.ParentRoleID.String,
 		DeletedAt:    deletedAt,
 	}
 }
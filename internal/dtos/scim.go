@@ -0,0 +1,461 @@
+package dtos
+
+import (
+	"encoding/json"
+	"strings"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+// SCIMMeta is the meta attribute of a SCIM resource. Location is built by the
+// service relative to the SCIM root; the controller makes it absolute.
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMMultiValue is an entry of a multi-valued attribute such as emails.
+type SCIMMultiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type SCIMAddress struct {
+	Formatted string `json:"formatted,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Primary   bool   `json:"primary,omitempty"`
+}
+
+// SCIMReference points at another resource, such as a group member or a
+// user's group.
+type SCIMReference struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type SCIMManager struct {
+	Value string `json:"value,omitempty"`
+	Ref   string `json:"$ref,omitempty"`
+}
+
+// SCIMEnterpriseUser is the enterprise user extension (RFC 7643 section 4.3).
+type SCIMEnterpriseUser struct {
+	Department string       `json:"department,omitempty"`
+	Manager    *SCIMManager `json:"manager,omitempty"`
+}
+
+// SCIMUser is the SCIM User resource. userName is stored as the user's mail
+// and externalId as their directory object ID.
+type SCIMUser struct {
+	Schemas     []string            `json:"schemas"`
+	ID          string              `json:"id,omitempty"`
+	ExternalID  string              `json:"externalId,omitempty"`
+	UserName    string              `json:"userName"`
+	Name        *SCIMName           `json:"name,omitempty"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Title       string              `json:"title,omitempty"`
+	Active      *bool               `json:"active,omitempty"`
+	Emails      []SCIMMultiValue    `json:"emails,omitempty"`
+	Addresses   []SCIMAddress       `json:"addresses,omitempty"`
+	Groups      []SCIMReference     `json:"groups,omitempty"`
+	Enterprise  *SCIMEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *SCIMMeta           `json:"meta,omitempty"`
+}
+
+// SCIMGroup is the SCIM Group resource. Members are users only.
+type SCIMGroup struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	ExternalID  string          `json:"externalId,omitempty"`
+	DisplayName string          `json:"displayName"`
+	Members     []SCIMReference `json:"members,omitempty"`
+	Meta        *SCIMMeta       `json:"meta,omitempty"`
+}
+
+type SCIMUserListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    []*SCIMUser `json:"Resources"`
+}
+
+type SCIMGroupListResponse struct {
+	Schemas      []string     `json:"schemas"`
+	TotalResults int64        `json:"totalResults"`
+	StartIndex   int          `json:"startIndex"`
+	ItemsPerPage int          `json:"itemsPerPage"`
+	Resources    []*SCIMGroup `json:"Resources"`
+}
+
+// SCIMListQuery holds the query parameters of a SCIM list request as sent.
+type SCIMListQuery struct {
+	Filter             string
+	StartIndex         string
+	Count              string
+	ExcludedAttributes string
+}
+
+// ExcludesMembers reports whether excludedAttributes names the members of a
+// group, which clients exclude to avoid fetching large groups.
+func (q SCIMListQuery) ExcludesMembers() bool {
+	for _, attribute := range strings.Split(q.ExcludedAttributes, ",") {
+		attribute = strings.ToLower(strings.TrimSpace(attribute))
+		attribute = strings.TrimPrefix(attribute, strings.ToLower(constants.SCIMSchemaGroup)+":")
+		if attribute == "members" {
+			return true
+		}
+	}
+	return false
+}
+
+// SCIMPatchRequest is a PATCH request body (RFC 7644 section 3.5.2).
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation is one PATCH operation. Value is kept raw because its
+// shape depends on the path.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// NewSCIMUser converts a user. departmentName and groups are resolved by the
+// caller.
+func NewSCIMUser(user repository.User, departmentName string, groups []repository.ScimGroup) *SCIMUser {
+	active := user.Status.Valid && user.Status.StatusEnum == repository.StatusEnumActive
+	result := &SCIMUser{
+		Schemas:     []string{constants.SCIMSchemaUser, constants.SCIMSchemaEnterpriseUser},
+		ID:          user.ID.String(),
+		UserName:    user.Mail,
+		DisplayName: user.DisplayName,
+		Title:       user.JobTitle.String,
+		Active:      &active,
+		Emails:      []SCIMMultiValue{{Value: user.Mail, Type: "work", Primary: true}},
+		Meta: &SCIMMeta{
+			ResourceType: "User",
+			Created:      utils.FormatTime(utils.ConvertPgTimestamp(user.CreatedAt)),
+			LastModified: utils.FormatTime(utils.ConvertPgTimestamp(user.UpdatedAt)),
+			Location:     "/Users/" + user.ID.String(),
+		},
+	}
+	if !strings.HasPrefix(user.AzureAdObjectID, constants.SCIMLocalObjectIDPrefix) {
+		result.ExternalID = user.AzureAdObjectID
+	}
+	if user.GivenName.Valid || user.SurName.Valid {
+		result.Name = &SCIMName{GivenName: user.GivenName.String, FamilyName: user.SurName.String}
+	}
+	if user.OfficeLocation.Valid {
+		result.Addresses = []SCIMAddress{{Formatted: user.OfficeLocation.String, Type: "work", Primary: true}}
+	}
+	if departmentName != "" || user.ManagerID.Valid {
+		result.Enterprise = &SCIMEnterpriseUser{Department: departmentName}
+		if user.ManagerID.Valid {
+			result.Enterprise.Manager = &SCIMManager{Value: user.ManagerID.String(), Ref: "/Users/" + user.ManagerID.String()}
+		}
+	}
+	for _, group := range groups {
+		result.Groups = append(result.Groups, SCIMReference{
+			Value:   group.ID.String(),
+			Ref:     "/Groups/" + group.ID.String(),
+			Display: group.DisplayName,
+		})
+	}
+	return result
+}
+
+// NewSCIMGroup converts a group. members is nil when the members attribute is
+// excluded.
+func NewSCIMGroup(group repository.ScimGroup, members []repository.ListScimGroupMembersRow) *SCIMGroup {
+	result := &SCIMGroup{
+		Schemas:     []string{constants.SCIMSchemaGroup},
+		ID:          group.ID.String(),
+		ExternalID:  group.ExternalID.String,
+		DisplayName: group.DisplayName,
+		Meta: &SCIMMeta{
+			ResourceType: "Group",
+			Created:      utils.FormatTime(utils.ConvertPgTimestamp(group.CreatedAt)),
+			LastModified: utils.FormatTime(utils.ConvertPgTimestamp(group.UpdatedAt)),
+			Location:     "/Groups/" + group.ID.String(),
+		},
+	}
+	for _, member := range members {
+		result.Members = append(result.Members, SCIMReference{
+			Value:   member.ID.String(),
+			Ref:     "/Users/" + member.ID.String(),
+			Display: member.DisplayName,
+		})
+	}
+	return result
+}
+
+// ResolveReferences prefixes the location and the references of the user,
+// which are relative to the SCIM root, with baseURL.
+func (u *SCIMUser) ResolveReferences(baseURL string) {
+	if u.Meta != nil {
+		u.Meta.Location = baseURL + u.Meta.Location
+	}
+	if u.Enterprise != nil && u.Enterprise.Manager != nil && u.Enterprise.Manager.Ref != "" {
+		u.Enterprise.Manager.Ref = baseURL + u.Enterprise.Manager.Ref
+	}
+	for i := range u.Groups {
+		u.Groups[i].Ref = baseURL + u.Groups[i].Ref
+	}
+}
+
+// ResolveReferences prefixes the location and the member references of the
+// group, which are relative to the SCIM root, with baseURL.
+func (g *SCIMGroup) ResolveReferences(baseURL string) {
+	if g.Meta != nil {
+		g.Meta.Location = baseURL + g.Meta.Location
+	}
+	for i := range g.Members {
+		g.Members[i].Ref = baseURL + g.Members[i].Ref
+	}
+}
+
+// SCIMSupported is a feature flag of the service provider configuration.
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type SCIMBulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type SCIMFilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type SCIMAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary,omitempty"`
+}
+
+// SCIMServiceProviderConfig describes the supported SCIM features (RFC 7643
+// section 5).
+type SCIMServiceProviderConfig struct {
+	Schemas               []string                   `json:"schemas"`
+	Patch                 SCIMSupported              `json:"patch"`
+	Bulk                  SCIMBulkSupport            `json:"bulk"`
+	Filter                SCIMFilterSupport          `json:"filter"`
+	ChangePassword        SCIMSupported              `json:"changePassword"`
+	Sort                  SCIMSupported              `json:"sort"`
+	ETag                  SCIMSupported              `json:"etag"`
+	AuthenticationSchemes []SCIMAuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *SCIMMeta                  `json:"meta,omitempty"`
+}
+
+type SCIMSchemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+// SCIMResourceType describes a resource endpoint (RFC 7643 section 6).
+type SCIMResourceType struct {
+	Schemas          []string              `json:"schemas"`
+	ID               string                `json:"id"`
+	Name             string                `json:"name"`
+	Endpoint         string                `json:"endpoint"`
+	Description      string                `json:"description,omitempty"`
+	Schema           string                `json:"schema"`
+	SchemaExtensions []SCIMSchemaExtension `json:"schemaExtensions,omitempty"`
+	Meta             *SCIMMeta             `json:"meta,omitempty"`
+}
+
+// SCIMAttribute describes an attribute of a schema (RFC 7643 section 7).
+type SCIMAttribute struct {
+	Name           string          `json:"name"`
+	Type           string          `json:"type"`
+	MultiValued    bool            `json:"multiValued"`
+	Required       bool            `json:"required"`
+	CaseExact      bool            `json:"caseExact"`
+	Mutability     string          `json:"mutability"`
+	Returned       string          `json:"returned"`
+	Uniqueness     string          `json:"uniqueness"`
+	SubAttributes  []SCIMAttribute `json:"subAttributes,omitempty"`
+	ReferenceTypes []string        `json:"referenceTypes,omitempty"`
+}
+
+type SCIMSchemaDefinition struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Attributes  []SCIMAttribute `json:"attributes"`
+	Meta        *SCIMMeta       `json:"meta,omitempty"`
+}
+
+// SCIMDiscoveryListResponse lists resource types or schemas.
+type SCIMDiscoveryListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// scimAttribute describes a read-write, singular, optional string attribute;
+// callers adjust the rest.
+func scimAttribute(name string) SCIMAttribute {
+	return SCIMAttribute{
+		Name:       name,
+		Type:       "string",
+		Mutability: "readWrite",
+		Returned:   "default",
+		Uniqueness: "none",
+	}
+}
+
+func scimReferenceAttribute(name string, referenceTypes ...string) SCIMAttribute {
+	value := scimAttribute("value")
+	value.Required = true
+	reference := scimAttribute("$ref")
+	reference.Type = "reference"
+	reference.ReferenceTypes = referenceTypes
+	display := scimAttribute("display")
+	display.Mutability = "readOnly"
+
+	attribute := scimAttribute(name)
+	attribute.Type = "complex"
+	attribute.MultiValued = true
+	attribute.SubAttributes = []SCIMAttribute{value, reference, display}
+	return attribute
+}
+
+// NewSCIMServiceProviderConfig describes what the SCIM endpoints support.
+func NewSCIMServiceProviderConfig(maxResults int) *SCIMServiceProviderConfig {
+	return &SCIMServiceProviderConfig{
+		Schemas: []string{constants.SCIMSchemaServiceProviderConfig},
+		Patch:   SCIMSupported{Supported: true},
+		Filter:  SCIMFilterSupport{Supported: true, MaxResults: maxResults},
+		AuthenticationSchemes: []SCIMAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "API key",
+			Description: "An API key of a service principal, sent as a bearer token",
+			Primary:     true,
+		}},
+		Meta: &SCIMMeta{ResourceType: "ServiceProviderConfig", Location: "/ServiceProviderConfig"},
+	}
+}
+
+// NewSCIMResourceTypes describes the User and Group endpoints.
+func NewSCIMResourceTypes() []SCIMResourceType {
+	return []SCIMResourceType{
+		{
+			Schemas:          []string{constants.SCIMSchemaResourceType},
+			ID:               "User",
+			Name:             "User",
+			Endpoint:         "/Users",
+			Description:      "User account",
+			Schema:           constants.SCIMSchemaUser,
+			SchemaExtensions: []SCIMSchemaExtension{{Schema: constants.SCIMSchemaEnterpriseUser}},
+			Meta:             &SCIMMeta{ResourceType: "ResourceType", Location: "/ResourceTypes/User"},
+		},
+		{
+			Schemas:     []string{constants.SCIMSchemaResourceType},
+			ID:          "Group",
+			Name:        "Group",
+			Endpoint:    "/Groups",
+			Description: "Group of users",
+			Schema:      constants.SCIMSchemaGroup,
+			Meta:        &SCIMMeta{ResourceType: "ResourceType", Location: "/ResourceTypes/Group"},
+		},
+	}
+}
+
+// NewSCIMSchemaDefinitions describes the stored attributes of the User,
+// enterprise User and Group schemas.
+func NewSCIMSchemaDefinitions() []SCIMSchemaDefinition {
+	id := scimAttribute("id")
+	id.CaseExact = true
+	id.Mutability = "readOnly"
+	id.Returned = "always"
+	id.Uniqueness = "server"
+
+	externalID := scimAttribute("externalId")
+	externalID.CaseExact = true
+
+	userName := scimAttribute("userName")
+	userName.Required = true
+	userName.Uniqueness = "server"
+
+	name := scimAttribute("name")
+	name.Type = "complex"
+	name.SubAttributes = []SCIMAttribute{scimAttribute("givenName"), scimAttribute("familyName")}
+
+	active := scimAttribute("active")
+	active.Type = "boolean"
+
+	emails := scimAttribute("emails")
+	emails.Type = "complex"
+	emails.MultiValued = true
+	emails.Mutability = "readOnly"
+	emails.SubAttributes = []SCIMAttribute{scimAttribute("value"), scimAttribute("type")}
+
+	addresses := scimAttribute("addresses")
+	addresses.Type = "complex"
+	addresses.MultiValued = true
+	addresses.SubAttributes = []SCIMAttribute{scimAttribute("formatted"), scimAttribute("type")}
+
+	groups := scimReferenceAttribute("groups", "Group")
+	groups.Mutability = "readOnly"
+
+	manager := scimAttribute("manager")
+	manager.Type = "complex"
+	manager.SubAttributes = []SCIMAttribute{scimAttribute("value")}
+
+	groupName := scimAttribute("displayName")
+	groupName.Required = true
+	groupName.Uniqueness = "server"
+
+	return []SCIMSchemaDefinition{
+		{
+			Schemas:     []string{constants.SCIMSchemaSchema},
+			ID:          constants.SCIMSchemaUser,
+			Name:        "User",
+			Description: "User account",
+			Attributes: []SCIMAttribute{
+				id, externalID, userName, name, scimAttribute("displayName"), scimAttribute("title"),
+				active, emails, addresses, groups,
+			},
+			Meta: &SCIMMeta{ResourceType: "Schema", Location: "/Schemas/" + constants.SCIMSchemaUser},
+		},
+		{
+			Schemas:     []string{constants.SCIMSchemaSchema},
+			ID:          constants.SCIMSchemaEnterpriseUser,
+			Name:        "EnterpriseUser",
+			Description: "Enterprise user",
+			Attributes:  []SCIMAttribute{scimAttribute("department"), manager},
+			Meta:        &SCIMMeta{ResourceType: "Schema", Location: "/Schemas/" + constants.SCIMSchemaEnterpriseUser},
+		},
+		{
+			Schemas:     []string{constants.SCIMSchemaSchema},
+			ID:          constants.SCIMSchemaGroup,
+			Name:        "Group",
+			Description: "Group of users",
+			Attributes:  []SCIMAttribute{id, externalID, groupName, scimReferenceAttribute("members", "User")},
+			Meta:        &SCIMMeta{ResourceType: "Schema", Location: "/Schemas/" + constants.SCIMSchemaGroup},
+		},
+	}
+}
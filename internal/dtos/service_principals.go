@@ -0,0 +1,162 @@
+package dtos
+
+import (
+	"time"
+
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+// API key statuses reported in APIKeyResponse.Status.
+const (
+	APIKeyStatusActive  = "active"
+	APIKeyStatusExpired = "expired"
+	APIKeyStatusRevoked = "revoked"
+)
+
+// CreateServicePrincipalRequest registers a machine caller. Set app_id and
+// object_id for an Entra application using client-credential tokens; leave both
+// empty for a principal that only authenticates with API keys.
+type CreateServicePrincipalRequest struct {
+	DisplayName string `json:"display_name" binding:"required"`
+	Description string `json:"description"`
+	AppID       string `json:"app_id" binding:"omitempty,uuid"`
+	ObjectID    string `json:"object_id" binding:"omitempty,uuid"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string `json:"name" binding:"required"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// RotateAPIKeyRequest replaces a key with a new one. The old key is revoked
+// immediately unless grace_period_minutes keeps it valid a little longer.
+type RotateAPIKeyRequest struct {
+	ExpiresAt          string `json:"expires_at"`
+	GracePeriodMinutes int32  `json:"grace_period_minutes" binding:"min=0,max=10080"`
+}
+
+type ServicePrincipalResponse struct {
+	ID            string `json:"id"`
+	UserID        string `json:"user_id"`
+	DisplayName   string `json:"display_name"`
+	Description   string `json:"description"`
+	AppID         string `json:"app_id,omitempty"`
+	ObjectID      string `json:"object_id"`
+	ActiveAPIKeys int64  `json:"active_api_keys"`
+	CreatedBy     string `json:"created_by,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+type ServicePrincipalsListResponse struct {
+	ServicePrincipals []ServicePrincipalResponse `json:"service_principals"`
+	Meta              PaginationMeta             `json:"meta"`
+}
+
+type APIKeyResponse struct {
+	ID                 string `json:"id"`
+	ServicePrincipalID string `json:"service_principal_id"`
+	Name               string `json:"name"`
+	Prefix             string `json:"prefix"`
+	Status             string `json:"status"`
+	ExpiresAt          string `json:"expires_at,omitempty"`
+	LastUsedAt         string `json:"last_used_at,omitempty"`
+	LastUsedIP         string `json:"last_used_ip,omitempty"`
+	RotatedFrom        string `json:"rotated_from,omitempty"`
+	RevokedAt          string `json:"revoked_at,omitempty"`
+	RevokedBy          string `json:"revoked_by,omitempty"`
+	CreatedBy          string `json:"created_by,omitempty"`
+	CreatedAt          string `json:"created_at"`
+}
+
+// APIKeySecretResponse is returned when a key is created or rotated. Key is
+// the only copy of the secret; it cannot be retrieved again.
+type APIKeySecretResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type APIKeysListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+	Meta    PaginationMeta   `json:"meta"`
+}
+
+func NewServicePrincipalResponse(sp repository.ListServicePrincipalsRow) *ServicePrincipalResponse {
+	response := &ServicePrincipalResponse{
+		ID:            sp.ID.String(),
+		UserID:        sp.UserID.String(),
+		DisplayName:   sp.DisplayName,
+		ObjectID:      sp.ObjectID,
+		ActiveAPIKeys: sp.ActiveApiKeys,
+		CreatedAt:     utils.FormatTime(sp.CreatedAt.Time),
+		UpdatedAt:     utils.FormatTime(sp.UpdatedAt.Time),
+	}
+
+	if sp.Description.Valid {
+		response.Description = sp.Description.String
+	}
+	if sp.AppID.Valid {
+		response.AppID = sp.AppID.String
+	}
+	if sp.CreatedBy.Valid {
+		response.CreatedBy = sp.CreatedBy.String()
+	}
+
+	return response
+}
+
+// NewAPIKeyResponse describes a key without its secret. prefix is the public
+// part of the key shown to identify it.
+func NewAPIKeyResponse(key repository.ApiKey, prefix string) *APIKeyResponse {
+	response := &APIKeyResponse{
+		ID:                 key.ID.String(),
+		ServicePrincipalID: key.ServicePrincipalID.String(),
+		Name:               key.Name,
+		Prefix:             prefix,
+		Status:             APIKeyStatusActive,
+		CreatedAt:          utils.FormatTime(key.CreatedAt.Time),
+	}
+
+	if key.ExpiresAt.Valid {
+		response.ExpiresAt = utils.FormatTime(key.ExpiresAt.Time)
+		if !key.ExpiresAt.Time.After(time.Now()) {
+			response.Status = APIKeyStatusExpired
+		}
+	}
+	if key.RevokedAt.Valid {
+		response.Status = APIKeyStatusRevoked
+		response.RevokedAt = utils.FormatTime(key.RevokedAt.Time)
+	}
+	if key.RevokedBy.Valid {
+		response.RevokedBy = key.RevokedBy.String()
+	}
+	if key.LastUsedAt.Valid {
+		response.LastUsedAt = utils.FormatTime(key.LastUsedAt.Time)
+	}
+	if key.LastUsedIp.Valid {
+		response.LastUsedIP = key.LastUsedIp.String
+	}
+	if key.RotatedFrom.Valid {
+		response.RotatedFrom = key.RotatedFrom.String()
+	}
+	if key.CreatedBy.Valid {
+		response.CreatedBy = key.CreatedBy.String()
+	}
+
+	return response
+}
+
+func NewServicePrincipalsListResponse(data []ServicePrincipalResponse, page, pageSize int, total int64) *ServicePrincipalsListResponse {
+	return &ServicePrincipalsListResponse{
+		ServicePrincipals: data,
+		Meta:              CreatePaginationMeta(page, pageSize, total),
+	}
+}
+
+func NewAPIKeysListResponse(data []APIKeyResponse, page, pageSize int, total int64) *APIKeysListResponse {
+	return &APIKeysListResponse{
+		APIKeys: data,
+		Meta:    CreatePaginationMeta(page, pageSize, total),
+	}
+}
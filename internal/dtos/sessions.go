@@ -0,0 +1,31 @@
+package dtos
+
+// LoginRedirect sends the browser to the identity provider. LoginCookie holds
+// the encrypted state and PKCE verifier until the callback.
+type LoginRedirect struct {
+	AuthURL     string
+	LoginCookie string
+	MaxAge      int
+}
+
+// CompleteLoginRequest carries the callback parameters and the login cookie.
+type CompleteLoginRequest struct {
+	Code        string
+	State       string
+	LoginCookie string
+	IPAddress   string
+	UserAgent   string
+}
+
+// LoginSession is a started browser session. SessionCookie is the encrypted
+// session ID; CSRFToken must be echoed on state-changing requests.
+type LoginSession struct {
+	SessionCookie string
+	CSRFToken     string
+	MaxAge        int
+	ReturnTo      string
+}
+
+type LogoutResponse struct {
+	LogoutURL string `json:"logout_url,omitempty"`
+}
@@ -0,0 +1,116 @@
+package dtos
+
+import (
+	"yet-another-itsm/internal/model"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SodConstraint declares two roles that must not be held by the same user.
+type SodConstraint struct {
+	model.BaseModel
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	RoleAID     string `json:"role_a_id"`
+	RoleBID     string `json:"role_b_id"`
+	CreatedBy   string `json:"created_by"`
+	DeletedAt   string `json:"deleted_at"`
+}
+
+type SodConstraintResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	RoleAID     string `json:"role_a_id"`
+	RoleBID     string `json:"role_b_id"`
+	CreatedBy   string `json:"created_by"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	DeletedAt   string `json:"deleted_at"`
+}
+
+type SodConstraintsListResponse struct {
+	SodConstraints []SodConstraintResponse `json:"sod_constraints"`
+	Meta           PaginationMeta          `json:"meta"`
+}
+
+type CreateSodConstraintRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	RoleAID     string `json:"role_a_id" binding:"required"`
+	RoleBID     string `json:"role_b_id" binding:"required,nefield=RoleAID"`
+}
+
+// SodViolation is a user whose current assignments hold both roles of a constraint.
+type SodViolation struct {
+	ConstraintID      string `json:"constraint_id"`
+	ConstraintName    string `json:"constraint_name"`
+	RoleAID           string `json:"role_a_id"`
+	RoleBID           string `json:"role_b_id"`
+	UserID            string `json:"user_id"`
+	DisplayName       string `json:"display_name"`
+	Mail              string `json:"mail"`
+	RoleAAssignmentID string `json:"role_a_assignment_id"`
+	RoleBAssignmentID string `json:"role_b_assignment_id"`
+}
+
+type SodViolationsResponse struct {
+	Violations []SodViolation `json:"violations"`
+}
+
+func (sc *SodConstraint) ToResponse() *SodConstraintResponse {
+	return &SodConstraintResponse{
+		ID:          sc.ID,
+		Name:        sc.Name,
+		Description: sc.Description,
+		RoleAID:     sc.RoleAID,
+		RoleBID:     sc.RoleBID,
+		CreatedBy:   sc.CreatedBy,
+		Status:      sc.Status.String,
+		CreatedAt:   utils.FormatTime(sc.CreatedAt.Time),
+		UpdatedAt:   utils.FormatTime(sc.UpdatedAt.Time),
+		DeletedAt:   sc.DeletedAt,
+	}
+}
+
+func (sc *SodConstraint) FromRepositoryModel(repo repository.SodConstraint) *SodConstraint {
+	description := ""
+	if repo.Description.Valid {
+		description = repo.Description.String
+	}
+
+	createdBy := ""
+	if repo.CreatedBy.Valid {
+		createdBy = repo.CreatedBy.String()
+	}
+
+	deletedAt := ""
+	if repo.DeletedAt.Valid {
+		deletedAt = utils.FormatTime(repo.DeletedAt.Time)
+	}
+
+	return &SodConstraint{
+		BaseModel: model.BaseModel{
+			ID:        repo.ID.String(),
+			Status:    pgtype.Text{String: string(repo.Status.StatusEnum), Valid: repo.Status.Valid},
+			CreatedAt: pgtype.Timestamptz{Time: repo.CreatedAt.Time, Valid: repo.CreatedAt.Valid},
+			UpdatedAt: pgtype.Timestamptz{Time: repo.UpdatedAt.Time, Valid: repo.UpdatedAt.Valid},
+		},
+		Name:        repo.Name,
+		Description: description,
+		RoleAID:     repo.RoleAID,
+		RoleBID:     repo.RoleBID,
+		CreatedBy:   createdBy,
+		DeletedAt:   deletedAt,
+	}
+}
+
+func NewSodConstraintsListResponse(data []SodConstraintResponse, page, pageSize int, total int64) *SodConstraintsListResponse {
+	return &SodConstraintsListResponse{
+		SodConstraints: data,
+		Meta:           CreatePaginationMeta(page, pageSize, total),
+	}
+}
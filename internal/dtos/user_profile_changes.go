@@ -0,0 +1,59 @@
+package dtos
+
+import (
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+// UserProfile is the caller's profile as reported by the identity provider at
+// login. ManagerObjectID is nil when the provider cannot tell the manager, and
+// empty when the user has none.
+type UserProfile struct {
+	ObjectID        string
+	Mail            string
+	DisplayName     string
+	GivenName       string
+	Surname         string
+	JobTitle        string
+	OfficeLocation  string
+	Department      string
+	ManagerObjectID *string
+}
+
+type UserProfileChangeResponse struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Field     string `json:"field"`
+	OldValue  string `json:"old_value,omitempty"`
+	NewValue  string `json:"new_value,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type UserProfileChangesListResponse struct {
+	ProfileChanges []UserProfileChangeResponse `json:"profile_changes"`
+	Meta           PaginationMeta              `json:"meta"`
+}
+
+func NewUserProfileChangeResponse(change repository.UserProfileChange) *UserProfileChangeResponse {
+	response := &UserProfileChangeResponse{
+		ID:        change.ID.String(),
+		UserID:    change.UserID.String(),
+		Field:     change.Field,
+		CreatedAt: utils.FormatTime(change.CreatedAt.Time),
+	}
+	if change.OldValue.Valid {
+		response.OldValue = change.OldValue.String
+	}
+	if change.NewValue.Valid {
+		response.NewValue = change.NewValue.String
+	}
+
+	return response
+}
+
+func NewUserProfileChangesListResponse(data []UserProfileChangeResponse, page, pageSize int, total int64) *UserProfileChangesListResponse {
+	return &UserProfileChangesListResponse{
+		ProfileChanges: data,
+		Meta:           CreatePaginationMeta(page, pageSize, total),
+	}
+}
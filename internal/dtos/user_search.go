@@ -0,0 +1,32 @@
+package dtos
+
+// UserSearchQuery holds the query parameters of GET /v1/users. Q matches
+// prefixes of the words in display name, mail and job title; Sort is a field
+// name with an optional + or - prefix; Cursor is the opaque value of a
+// previous page's next link.
+type UserSearchQuery struct {
+	Q              string
+	DepartmentID   string
+	BusinessUnitID string
+	Status         string
+	ManagerID      string
+	Sort           string
+	Cursor         string
+	Limit          string
+}
+
+// UserSearchResult is one page of users and the cursor of the next page,
+// empty on the last page.
+type UserSearchResult struct {
+	Users      []*User
+	NextCursor string
+}
+
+// UsersPageResponse is a cursor-paginated page of users with the Zalando
+// pagination links; next is omitted on the last page.
+type UsersPageResponse struct {
+	Self  string         `json:"self"`
+	First string         `json:"first"`
+	Next  string         `json:"next,omitempty"`
+	Items []UserResponse `json:"items"`
+}
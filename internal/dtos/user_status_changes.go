@@ -0,0 +1,61 @@
+package dtos
+
+import (
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+// SuspendUserRequest suspends a user until they are unlocked, or locks them
+// until locked_until (RFC 3339) when it is set.
+type SuspendUserRequest struct {
+	Reason      string `json:"reason" binding:"required,min=3"`
+	LockedUntil string `json:"locked_until"`
+}
+
+type UnlockUserRequest struct {
+	Reason string `json:"reason" binding:"required,min=3"`
+}
+
+type UserStatusChangeResponse struct {
+	ID            string `json:"id"`
+	UserID        string `json:"user_id"`
+	Action        string `json:"action"`
+	Reason        string `json:"reason"`
+	LockedUntil   string `json:"locked_until,omitempty"`
+	ChangedBy     string `json:"changed_by,omitempty"`
+	ChangedByName string `json:"changed_by_name,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type UserStatusChangesListResponse struct {
+	StatusChanges []UserStatusChangeResponse `json:"status_changes"`
+	Meta          PaginationMeta             `json:"meta"`
+}
+
+func NewUserStatusChangeResponse(change repository.ListUserStatusChangesRow) *UserStatusChangeResponse {
+	response := &UserStatusChangeResponse{
+		ID:        change.ID.String(),
+		UserID:    change.UserID.String(),
+		Action:    change.Action,
+		Reason:    change.Reason,
+		CreatedAt: utils.FormatTime(change.CreatedAt.Time),
+	}
+	if change.LockedUntil.Valid {
+		response.LockedUntil = utils.FormatTime(change.LockedUntil.Time)
+	}
+	if change.ChangedBy.Valid {
+		response.ChangedBy = change.ChangedBy.String()
+	}
+	if change.ChangedByName.Valid {
+		response.ChangedByName = change.ChangedByName.String
+	}
+
+	return response
+}
+
+func NewUserStatusChangesListResponse(data []UserStatusChangeResponse, page, pageSize int, total int64) *UserStatusChangesListResponse {
+	return &UserStatusChangesListResponse{
+		StatusChanges: data,
+		Meta:          CreatePaginationMeta(page, pageSize, total),
+	}
+}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+
+	"yet-another-itsm/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AccessReviewCloseJob completes access review campaigns past their ends_at,
+// revoking every item left undecided.
+type AccessReviewCloseJob struct {
+	accessReviewService service.AccessReviewService
+}
+
+func NewAccessReviewCloseJob(services *service.Services) *AccessReviewCloseJob {
+	return &AccessReviewCloseJob{
+		accessReviewService: services.AccessReview,
+	}
+}
+
+func (j *AccessReviewCloseJob) Name() string {
+	return "access_review_close"
+}
+
+func (j *AccessReviewCloseJob) Run(ctx context.Context) error {
+	count, err := j.accessReviewService.CloseDueCampaigns(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Info().
+			Str("job", j.Name()).
+			Int("count", count).
+			Msg("Closed access review campaigns")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+
+	"yet-another-itsm/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DirectorySyncJob pulls user changes from the directory with delta queries
+// and applies them to users, departments and manager links.
+type DirectorySyncJob struct {
+	directorySyncService service.DirectorySyncService
+}
+
+func NewDirectorySyncJob(services *service.Services) *DirectorySyncJob {
+	return &DirectorySyncJob{
+		directorySyncService: services.DirectorySync,
+	}
+}
+
+func (j *DirectorySyncJob) Name() string {
+	return "directory_sync"
+}
+
+func (j *DirectorySyncJob) Run(ctx context.Context) error {
+	result, err := j.directorySyncService.SyncUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if result.Created+result.Updated+result.Deactivated+result.Reactivated+result.ManagersUpdated > 0 {
+		log.Info().
+			Str("job", j.Name()).
+			Bool("full_sync", result.FullSync).
+			Int("created", result.Created).
+			Int("updated", result.Updated).
+			Int("deactivated", result.Deactivated).
+			Int("reactivated", result.Reactivated).
+			Int("managers_updated", result.ManagersUpdated).
+			Int("skipped", result.Skipped).
+			Msg("Synced directory users")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+
+	"yet-another-itsm/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ElevationExpiryJob expires approved elevation requests past their expires_at
+// and revokes the role assignments they granted.
+type ElevationExpiryJob struct {
+	elevationRequestService service.ElevationRequestService
+}
+
+func NewElevationExpiryJob(services *service.Services) *ElevationExpiryJob {
+	return &ElevationExpiryJob{
+		elevationRequestService: services.ElevationRequest,
+	}
+}
+
+func (j *ElevationExpiryJob) Name() string {
+	return "elevation_expiry"
+}
+
+func (j *ElevationExpiryJob) Run(ctx context.Context) error {
+	count, err := j.elevationRequestService.ExpireElevations(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Info().
+			Str("job", j.Name()).
+			Int("count", count).
+			Msg("Expired elevation requests")
+	}
+	return nil
+}
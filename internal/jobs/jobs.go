@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Job is a unit of background work that runs on a fixed interval.
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// Schedule runs job immediately and then every interval until ctx is cancelled.
+// A non-positive interval disables the job.
+func Schedule(ctx context.Context, job Job, interval time.Duration) {
+	if interval <= 0 {
+		log.Info().Str("job", job.Name()).Msg("Background job disabled")
+		return
+	}
+
+	log.Info().
+		Str("job", job.Name()).
+		Dur("interval", interval).
+		Msg("Scheduling background job")
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run(ctx, job)
+
+			select {
+			case <-ctx.Done():
+				log.Info().Str("job", job.Name()).Msg("Background job stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func run(ctx context.Context, job Job) {
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		log.Error().Err(err).Str("job", job.Name()).Msg("Background job failed")
+		return
+	}
+
+	log.Debug().
+		Str("job", job.Name()).
+		Dur("duration", time.Since(start)).
+		Msg("Background job completed")
+}
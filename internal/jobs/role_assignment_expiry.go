@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+
+	"yet-another-itsm/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RoleAssignmentExpiryJob marks role assignments past their expires_at as inactive.
+type RoleAssignmentExpiryJob struct {
+	roleAssignmentService service.RoleAssignmentService
+}
+
+func NewRoleAssignmentExpiryJob(services *service.Services) *RoleAssignmentExpiryJob {
+	return &RoleAssignmentExpiryJob{
+		roleAssignmentService: services.RoleAssignment,
+	}
+}
+
+func (j *RoleAssignmentExpiryJob) Name() string {
+	return "role_assignment_expiry"
+}
+
+func (j *RoleAssignmentExpiryJob) Run(ctx context.Context) error {
+	count, err := j.roleAssignmentService.ExpireRoleAssignments(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Info().
+			Str("job", j.Name()).
+			Int("count", count).
+			Msg("Expired role assignments")
+	}
+	return nil
+}
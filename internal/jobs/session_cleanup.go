@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+
+	"yet-another-itsm/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SessionCleanupJob deletes browser sessions that expired or were revoked,
+// together with their stored tokens.
+type SessionCleanupJob struct {
+	sessionService service.SessionService
+}
+
+func NewSessionCleanupJob(services *service.Services) *SessionCleanupJob {
+	return &SessionCleanupJob{
+		sessionService: services.Session,
+	}
+}
+
+func (j *SessionCleanupJob) Name() string {
+	return "session_cleanup"
+}
+
+func (j *SessionCleanupJob) Run(ctx context.Context) error {
+	count, err := j.sessionService.DeleteStaleSessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Info().
+			Str("job", j.Name()).
+			Int("count", count).
+			Msg("Deleted stale sessions")
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,61 +28,254 @@ type AzureADClaims struct {
 	TID      string      `json:"tid"`
 	UPN      string      `json:"upn"`
 	Roles    []string    `json:"roles"`
+	Groups   []string    `json:"groups"`
 	Scp      string      `json:"scp"`
 	AppID    string      `json:"appid"`
+	AZP      string      `json:"azp"`
+	IDType   string      `json:"idtyp"`
+	AppName  string      `json:"app_displayname"`
 	IPAddr   string      `json:"ipaddr"`
 	Expiry   int64       `json:"exp"`
 	IssuedAt int64       `json:"iat"`
+	// ClaimNames lists claims that did not fit in the token; a "groups" entry
+	// means the groups claim was replaced by a Graph overage reference.
+	ClaimNames map[string]string `json:"_claim_names"`
 	jwt.RegisteredClaims
 }
 
+// apiKeyHeader carries locally issued API keys for machine callers.
+const apiKeyHeader = "X-API-Key"
+
+// AuthMiddleWare authenticates the caller from an access token of the
+// configured identity provider in the Authorization header, delegated or
+// app-only, from an API key in the X-API-Key header, or from a browser session
+// cookie. Session requests that change state must carry the session's CSRF
+// token in the X-CSRF-Token header. Suspended and locked accounts are rejected.
 func AuthMiddleWare(oauthConfig *config.OAuthConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if oauthConfig == nil || oauthConfig.JWKSEntra == nil {
+		if rawKey := c.GetHeader(apiKeyHeader); rawKey != "" && oauthConfig != nil {
+			authenticateAPIKey(c, oauthConfig, rawKey)
+			return
+		}
+
+		if oauthConfig == nil || oauthConfig.Provider == nil {
 			log.Error().Msg("OAuth not initialized")
 			utils.SendInternalServerError(c, constants.ErrAuthServiceNotAvailableMsg)
 			c.Abort()
 			return
 		}
 
-		rawToken, err := validateAuthHeader(c)
-		if err != nil {
-			utils.SendUnauthorized(c, err.Error())
-			c.Abort()
-			return
+		var rawToken string
+		if c.GetHeader("Authorization") == "" && oauthConfig.Sessions != nil {
+			if cookie, err := c.Cookie(config.SessionCookieName); err == nil && cookie != "" {
+				token, ok := authenticateSession(c, oauthConfig.Sessions, cookie)
+				if !ok {
+					return
+				}
+				rawToken = token
+			}
 		}
 
-		claims, err := validateAndExtractClaims(rawToken, oauthConfig.JWKSEntra.Keyfunc, c)
+		if rawToken == "" {
+			var err error
+			rawToken, err = validateAuthHeader(c)
+			if err != nil {
+				utils.SendUnauthorized(c, err.Error())
+				c.Abort()
+				return
+			}
+		}
+
+		provider := oauthConfig.Provider
+		claims, err := validateAndExtractClaims(rawToken, provider.Keyfunc, c)
 		if err != nil {
 			utils.SendUnauthorized(c, err.Error())
 			c.Abort()
 			return
 		}
 
-		if err := validateTokenMetadata(claims, oauthConfig, c); err != nil {
+		// Generic OIDC issuers identify the caller by sub and issue no tid.
+		if claims.OID == "" {
+			claims.OID = claims.Subject
+		}
+		if claims.TID == "" {
+			claims.TID = provider.DefaultTenantID()
+		}
+
+		if err := validateTokenMetadata(claims, provider, c); err != nil {
 			utils.SendUnauthorized(c, err.Error())
 			c.Abort()
 			return
 		}
 
+		// App-only (client-credential) tokens have no user name; the calling
+		// application is resolved by its service principal oid like a user.
+		appOnly := isAppOnlyToken(claims)
+		name := claims.Name
+		if appOnly {
+			name = appDisplayName(claims)
+		}
+
 		ctx := utils.SetTenantContext(
 			c.Request.Context(),
 			claims.TID,
 			claims.OID,
-			claims.Name,
+			name,
 			rawToken,
 		)
+		_, groupsOverage := claims.ClaimNames["groups"]
+		ctx = utils.SetDirectoryClaims(ctx, utils.DirectoryClaims{
+			AppRoles:      claims.Roles,
+			Groups:        claims.Groups,
+			GroupsOverage: groupsOverage,
+		})
 		c.Request = c.Request.WithContext(ctx)
 
+		if !enforceAccountStatus(c, oauthConfig.Accounts, claims.TID, claims.OID) {
+			return
+		}
+
 		log.Info().
 			Str("user_id", claims.OID).
-			Str("user_name", claims.Name).
+			Str("user_name", name).
+			Bool("app_only", appOnly).
 			Msg(constants.ErrUserAuthenticatedSuccessfullyMsg)
 
 		c.Next()
 	}
 }
 
+// authenticateAPIKey resolves an API key to its service principal and sets the
+// same tenant context as a token would, so permission checks apply unchanged.
+func authenticateAPIKey(c *gin.Context, oauthConfig *config.OAuthConfig, rawKey string) {
+	verifier := oauthConfig.APIKeys
+	if verifier == nil {
+		log.Error().Msg("API key verifier not initialized")
+		utils.SendInternalServerError(c, constants.ErrAuthServiceNotAvailableMsg)
+		c.Abort()
+		return
+	}
+
+	identity, err := verifier.VerifyAPIKey(c.Request.Context(), rawKey, c.ClientIP())
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidAPIKey) {
+			log.Warn().Str("ip", c.ClientIP()).Msg(constants.ErrInvalidAPIKeyMsg)
+			utils.SendUnauthorized(c, constants.ErrInvalidAPIKeyMsg)
+		} else {
+			log.Error().Err(err).Str("ip", c.ClientIP()).Msg(constants.ErrFailedToVerifyAPIKey)
+			utils.SendInternalServerError(c, constants.ErrAuthServiceNotAvailableMsg)
+		}
+		c.Abort()
+		return
+	}
+
+	ctx := utils.SetTenantContext(
+		c.Request.Context(),
+		identity.TenantID,
+		identity.ObjectID,
+		identity.DisplayName,
+		"",
+	)
+	c.Request = c.Request.WithContext(ctx)
+
+	if !enforceAccountStatus(c, oauthConfig.Accounts, identity.TenantID, identity.ObjectID) {
+		return
+	}
+
+	log.Info().
+		Str("user_id", identity.ObjectID).
+		Str("user_name", identity.DisplayName).
+		Msg(constants.ErrUserAuthenticatedSuccessfullyMsg)
+
+	c.Next()
+}
+
+// authenticateSession resolves a session cookie to the session's access token,
+// which is then verified like a bearer token. It aborts the request and
+// returns false when the session is unknown or the CSRF check fails.
+func authenticateSession(c *gin.Context, resolver config.SessionResolver, cookie string) (string, bool) {
+	identity, err := resolver.ResolveSession(c.Request.Context(), cookie)
+	if err != nil {
+		if errors.Is(err, constants.ErrSessionNotFound) {
+			log.Warn().Str("ip", c.ClientIP()).Msg(constants.ErrSessionExpiredMsg)
+			utils.SendUnauthorized(c, constants.ErrSessionExpiredMsg)
+		} else {
+			log.Error().Err(err).Str("ip", c.ClientIP()).Msg(constants.ErrFailedToGetSession)
+			utils.SendInternalServerError(c, constants.ErrAuthServiceNotAvailableMsg)
+		}
+		c.Abort()
+		return "", false
+	}
+
+	if !isSafeMethod(c.Request.Method) {
+		csrfToken := c.GetHeader(config.CSRFHeader)
+		if csrfToken == "" || subtle.ConstantTimeCompare([]byte(csrfToken), []byte(identity.CSRFToken)) != 1 {
+			log.Warn().Str("ip", c.ClientIP()).Str("method", c.Request.Method).Msg(constants.ErrInvalidCSRFTokenMsg)
+			utils.SendForbidden(c, constants.ErrInvalidCSRFTokenMsg)
+			c.Abort()
+			return "", false
+		}
+	}
+
+	return identity.AccessToken, true
+}
+
+// enforceAccountStatus rejects suspended and locked accounts with 403. It
+// aborts the request and returns false when the caller must not continue.
+func enforceAccountStatus(c *gin.Context, checker config.AccountStatusChecker, tenantID, objectID string) bool {
+	if checker == nil {
+		return true
+	}
+
+	err := checker.CheckAccountStatus(c.Request.Context(), tenantID, objectID)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, constants.ErrAccountSuspended):
+		log.Warn().Str("user_id", objectID).Str("ip", c.ClientIP()).Msg(constants.ErrAccountSuspendedMsg)
+		utils.SendForbidden(c, constants.ErrAccountSuspendedMsg)
+	case errors.Is(err, constants.ErrAccountLocked):
+		log.Warn().Err(err).Str("user_id", objectID).Str("ip", c.ClientIP()).Msg(constants.ErrAccountLockedMsg)
+		utils.SendForbidden(c, constants.ErrAccountLockedMsg)
+	default:
+		log.Error().Err(err).Str("user_id", objectID).Msg(constants.ErrFailedToGetAccountStatus)
+		utils.SendInternalServerError(c, constants.ErrAuthServiceNotAvailableMsg)
+	}
+	c.Abort()
+	return false
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isAppOnlyToken reports whether the token was issued to an application through
+// the client-credentials flow. Such tokens carry no delegated scopes and their
+// sub is the service principal's oid; idtyp=app is set when the optional claim
+// is configured.
+func isAppOnlyToken(claims *AzureADClaims) bool {
+	if claims.IDType != "" {
+		return claims.IDType == "app"
+	}
+	return claims.Scp == "" && claims.OID != "" && claims.OID == claims.Subject
+}
+
+func appDisplayName(claims *AzureADClaims) string {
+	switch {
+	case claims.AppName != "":
+		return claims.AppName
+	case claims.AppID != "":
+		return claims.AppID
+	default:
+		return claims.AZP
+	}
+}
+
 func validateAuthHeader(c *gin.Context) (string, error) {
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -109,26 +306,31 @@ func validateAndExtractClaims(rawToken string, keyfunc jwt.Keyfunc, c *gin.Conte
 	return claims, nil
 }
 
-func validateTokenMetadata(claims *AzureADClaims, oauthConfig *config.OAuthConfig, c *gin.Context) error {
+func validateTokenMetadata(claims *AzureADClaims, provider config.IdentityProvider, c *gin.Context) error {
 	if err := validateTokenExpiry(claims); err != nil {
 		log.Warn().Err(err).Str("ip", c.ClientIP()).Msg(constants.ErrTokenExpiredMsg)
 		return fmt.Errorf(constants.ErrTokenExpiredMsg)
 	}
 
-	expectedIssuer := fmt.Sprintf("https://sts.windows.net/%s/", oauthConfig.TenantID)
-	if claims.Issuer != expectedIssuer {
-		log.Warn().Str("issuer", claims.Issuer).Str("expected_v1", expectedIssuer).Msg(constants.ErrInvalidTokenIssuerMsg)
-		return fmt.Errorf(constants.ErrInvalidTokenIssuerMsg)
+	// The tenant is checked first so the issuer can be matched against it:
+	// guest and partner tenants sign tokens with their own tenant ID.
+	if !provider.IsTenantAllowed(claims.TID) {
+		log.Warn().Str("tid", claims.TID).Str("provider", provider.Name()).Msg(constants.ErrInvalidTenantIDMsg)
+		return fmt.Errorf(constants.ErrInvalidTenantIDMsg)
 	}
 
-	if !validateAudience(claims.Audience, oauthConfig.ClientID) {
-		log.Warn().Interface("audience", claims.Audience).Str("expected", oauthConfig.ClientID).Msg(constants.ErrInvalidTokenAudienceMsg)
-		return fmt.Errorf(constants.ErrInvalidTokenAudienceMsg)
+	// Entra issues v1 or v2 tokens depending on the app registration's
+	// accessTokenAcceptedVersion; client-credential tokens are often v2.
+	expectedIssuers := provider.AcceptedIssuers(claims.TID)
+	if !slices.Contains(expectedIssuers, claims.Issuer) {
+		log.Warn().Str("issuer", claims.Issuer).Strs("expected", expectedIssuers).Msg(constants.ErrInvalidTokenIssuerMsg)
+		return fmt.Errorf(constants.ErrInvalidTokenIssuerMsg)
 	}
 
-	if claims.TID != oauthConfig.TenantID {
-		log.Warn().Str("tid", claims.TID).Str("expected", oauthConfig.TenantID).Msg(constants.ErrInvalidTenantIDMsg)
-		return fmt.Errorf(constants.ErrInvalidTenantIDMsg)
+	audiences := provider.Audiences()
+	if !slices.ContainsFunc(audiences, func(expected string) bool { return validateAudience(claims.Audience, expected) }) {
+		log.Warn().Interface("audience", claims.Audience).Strs("expected", audiences).Msg(constants.ErrInvalidTokenAudienceMsg)
+		return fmt.Errorf(constants.ErrInvalidTokenAudienceMsg)
 	}
 
 	return nil
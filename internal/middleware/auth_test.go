@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/utils"
+)
+
+const testDevTenantID = "3f2e1d0c-9b8a-4765-8432-10fedcba9876"
+
+// loadAuthConfig loads the configuration from the environment with the given
+// identity provider settings and no key file, so every load signs with a new key.
+func loadAuthConfig(t *testing.T, env, provider, issuerURL string) (*config.Config, error) {
+	t.Helper()
+	t.Setenv("APP_ENV", env)
+	t.Setenv("AUTH_PROVIDER", provider)
+	t.Setenv("OIDC_ISSUER_URL", issuerURL)
+	t.Setenv("OIDC_AUDIENCE", "api://yet-another-itsm")
+	t.Setenv("OIDC_TENANT_ID", testDevTenantID)
+	t.Setenv("DEV_ISSUER_KEY_FILE", "")
+	t.Setenv("SESSION_ENCRYPTION_KEY", "")
+	return config.Load()
+}
+
+func mintDevToken(t *testing.T, issuer *config.DevIssuer) string {
+	t.Helper()
+	token, _, err := issuer.Mint(config.DevTokenClaims{Name: "Dev User", Email: "dev.user@example.com"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// authenticate sends token through AuthMiddleWare and returns the response
+// and the tenant context the handler saw.
+func authenticate(oauthConfig *config.OAuthConfig, token string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	var tenantID string
+	router.GET("/", AuthMiddleWare(oauthConfig), func(c *gin.Context) {
+		tenantID, _ = utils.GetTenantID(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec, tenantID
+}
+
+func TestAuthMiddleWareAcceptsDevIssuerTokens(t *testing.T) {
+	cfg, err := loadAuthConfig(t, config.EnvironmentDevelopment, config.ProviderDev, "http://localhost:8080/dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, tenantID := authenticate(&cfg.OAuth, mintDevToken(t, cfg.OAuth.DevIssuer))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("dev token = %d, want %d: %s", rec.Code, http.StatusNoContent, rec.Body)
+	}
+	if tenantID != testDevTenantID {
+		t.Errorf("tenant ID = %q, want %q", tenantID, testDevTenantID)
+	}
+}
+
+func TestAuthMiddleWareRejectsDevIssuerTokensWhenDisabled(t *testing.T) {
+	dev, err := loadAuthConfig(t, config.EnvironmentDevelopment, config.ProviderDev, "http://localhost:8080/dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := mintDevToken(t, dev.OAuth.DevIssuer)
+
+	if _, err := loadAuthConfig(t, "production", config.ProviderDev, "http://localhost:8080/dev"); !errors.Is(err, constants.ErrDevIssuerRequiresDevelopmentMsg) {
+		t.Errorf("Load() with the dev issuer in production = %v, want %v", err, constants.ErrDevIssuerRequiresDevelopmentMsg)
+	}
+
+	// Another OpenID issuer with its own key stands in for the provider that
+	// is configured when the dev issuer is disabled.
+	other, err := loadAuthConfig(t, config.EnvironmentDevelopment, config.ProviderDev, "http://localhost:8080/dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(other.OAuth.DevIssuer.JWKS())
+	})
+
+	cfg, err := loadAuthConfig(t, "production", config.ProviderOIDC, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OAuth.DevIssuer != nil {
+		t.Fatal("dev issuer is enabled with the oidc provider")
+	}
+
+	if rec, _ := authenticate(&cfg.OAuth, token); rec.Code != http.StatusUnauthorized {
+		t.Errorf("dev token = %d, want %d: %s", rec.Code, http.StatusUnauthorized, rec.Body)
+	}
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/service"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// PermissionMiddleware authorizes authenticated callers against the
+// roles/permissions/role_permissions tables.
+type PermissionMiddleware struct {
+	userService           service.UserService
+	roleAssignmentService service.RoleAssignmentService
+}
+
+func NewPermissionMiddleware(services *service.Services) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		userService:           services.User,
+		roleAssignmentService: services.RoleAssignment,
+	}
+}
+
+// RequirePermission resolves the caller's internal user from the oid claim and
+// aborts with 403 unless one of their active role assignments grants resource/action.
+// It must run after AuthMiddleWare.
+func (pm *PermissionMiddleware) RequirePermission(resource, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if pm.authorize(c, resource, action, sendAPIError) {
+			c.Next()
+		}
+	}
+}
+
+// RequireUser resolves the caller's internal user from the oid claim without
+// checking any permission. Use it for self-service routes such as /users/me/*.
+// It must run after AuthMiddleWare.
+func (pm *PermissionMiddleware) RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := pm.resolveUser(c, sendAPIError)
+		if !ok {
+			return
+		}
+
+		c.Request = c.Request.WithContext(utils.SetInternalUserID(c.Request.Context(), user.ID))
+		c.Next()
+	}
+}
+
+// errorWriter answers a request the permission middleware rejects, so the
+// same checks can reply in the API or the SCIM error schema.
+type errorWriter func(c *gin.Context, status int, message string)
+
+func sendAPIError(c *gin.Context, status int, message string) {
+	switch status {
+	case http.StatusUnauthorized:
+		utils.SendUnauthorized(c, message)
+	case http.StatusForbidden:
+		utils.SendForbidden(c, message)
+	default:
+		utils.SendInternalServerError(c, message)
+	}
+}
+
+func sendSCIMError(c *gin.Context, status int, message string) {
+	utils.SendSCIMError(c, status, "", message)
+}
+
+// authorize resolves the caller's user and checks that one of their active role
+// assignments grants resource/action, storing the user's ID in the request
+// context. It aborts the request through send and returns false otherwise.
+func (pm *PermissionMiddleware) authorize(c *gin.Context, resource, action string, send errorWriter) bool {
+	ctx := c.Request.Context()
+
+	user, ok := pm.resolveUser(c, send)
+	if !ok {
+		return false
+	}
+
+	allowed, err := pm.roleAssignmentService.CheckUserPermission(ctx, user.ID, resource, action)
+	if err != nil {
+		log.Error().Err(err).
+			Str("user_id", user.ID).
+			Str("resource", resource).
+			Str("action", action).
+			Msg(constants.ErrFailedToCheckPermissionsMsg)
+		send(c, http.StatusInternalServerError, constants.ErrFailedToCheckPermissionsMsg)
+		c.Abort()
+		return false
+	}
+
+	if !allowed {
+		log.Warn().
+			Str("user_id", user.ID).
+			Str("resource", resource).
+			Str("action", action).
+			Str("ip", c.ClientIP()).
+			Msg(constants.ErrPermissionDeniedMsg)
+		send(c, http.StatusForbidden, constants.ErrPermissionDeniedMsg)
+		c.Abort()
+		return false
+	}
+
+	c.Request = c.Request.WithContext(utils.SetInternalUserID(ctx, user.ID))
+	return true
+}
+
+// resolveUser looks up the provisioned user for the token's oid claim, aborting
+// the request through send and returning false if there is none.
+func (pm *PermissionMiddleware) resolveUser(c *gin.Context, send errorWriter) (*dtos.User, bool) {
+	ctx := c.Request.Context()
+
+	objectID, err := utils.GetUserID(ctx)
+	if err != nil {
+		log.Warn().Err(err).Str("ip", c.ClientIP()).Msg(constants.ErrUserIDNotFound)
+		send(c, http.StatusUnauthorized, constants.ErrUserIDNotFound)
+		c.Abort()
+		return nil, false
+	}
+
+	user, err := pm.userService.GetUserByAzureADObjectID(ctx, objectID)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("azure_ad_object_id", objectID).
+			Str("path", c.Request.URL.Path).
+			Msg(constants.ErrUserNotProvisionedMsg)
+		send(c, http.StatusForbidden, constants.ErrUserNotProvisionedMsg)
+		c.Abort()
+		return nil, false
+	}
+
+	// A user belongs to exactly one tenant; a token from another allowed
+	// tenant must not act as them.
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil || !strings.EqualFold(user.HomeTenantID, tenantID) {
+		log.Warn().
+			Str("azure_ad_object_id", objectID).
+			Str("home_tenant_id", user.HomeTenantID).
+			Str("tenant_id", tenantID).
+			Str("path", c.Request.URL.Path).
+			Msg(constants.ErrUserNotProvisionedMsg)
+		send(c, http.StatusForbidden, constants.ErrUserNotProvisionedMsg)
+		c.Abort()
+		return nil, false
+	}
+
+	return user, true
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// SCIMAuth authenticates SCIM clients. Provisioning clients such as Entra ID
+// send a static secret token as a bearer token, so the token must be an API
+// key of a service principal. Failures are answered in the SCIM error schema.
+func SCIMAuth(oauthConfig *config.OAuthConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if oauthConfig == nil || oauthConfig.APIKeys == nil {
+			log.Error().Msg("API key verifier not initialized")
+			utils.SendSCIMError(c, http.StatusInternalServerError, "", constants.ErrAuthServiceNotAvailableMsg)
+			c.Abort()
+			return
+		}
+
+		authHeader := c.GetHeader("Authorization")
+		scheme, rawKey, found := strings.Cut(authHeader, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(rawKey) == "" {
+			log.Warn().Str("ip", c.ClientIP()).Msg(constants.ErrMissingAuthHeaderMsg)
+			utils.SendSCIMError(c, http.StatusUnauthorized, "", constants.ErrMissingAuthHeaderMsg)
+			c.Abort()
+			return
+		}
+
+		identity, err := oauthConfig.APIKeys.VerifyAPIKey(c.Request.Context(), strings.TrimSpace(rawKey), c.ClientIP())
+		if err != nil {
+			if errors.Is(err, constants.ErrInvalidAPIKey) {
+				log.Warn().Str("ip", c.ClientIP()).Msg(constants.ErrInvalidAPIKeyMsg)
+				utils.SendSCIMError(c, http.StatusUnauthorized, "", constants.ErrInvalidAPIKeyMsg)
+			} else {
+				log.Error().Err(err).Str("ip", c.ClientIP()).Msg(constants.ErrFailedToVerifyAPIKey)
+				utils.SendSCIMError(c, http.StatusInternalServerError, "", constants.ErrAuthServiceNotAvailableMsg)
+			}
+			c.Abort()
+			return
+		}
+
+		if oauthConfig.Accounts != nil {
+			if err := oauthConfig.Accounts.CheckAccountStatus(c.Request.Context(), identity.TenantID, identity.ObjectID); err != nil {
+				switch {
+				case errors.Is(err, constants.ErrAccountSuspended), errors.Is(err, constants.ErrAccountLocked):
+					log.Warn().Err(err).Str("user_id", identity.ObjectID).Str("ip", c.ClientIP()).Msg(constants.ErrAccountSuspendedMsg)
+					utils.SendSCIMError(c, http.StatusForbidden, "", err.Error())
+				default:
+					log.Error().Err(err).Str("user_id", identity.ObjectID).Msg(constants.ErrFailedToGetAccountStatus)
+					utils.SendSCIMError(c, http.StatusInternalServerError, "", constants.ErrAuthServiceNotAvailableMsg)
+				}
+				c.Abort()
+				return
+			}
+		}
+
+		ctx := utils.SetTenantContext(
+			c.Request.Context(),
+			identity.TenantID,
+			identity.ObjectID,
+			identity.DisplayName,
+			"",
+		)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// RequireSCIMPermission is RequirePermission on the scim resource with errors
+// in the SCIM error schema. It must run after SCIMAuth.
+func (pm *PermissionMiddleware) RequireSCIMPermission(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if pm.authorize(c, constants.ResourceSCIM, action, sendSCIMError) {
+			c.Next()
+		}
+	}
+}
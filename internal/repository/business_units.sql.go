@@ -114,11 +114,16 @@ SELECT
     updated_at,
     deleted_at
 FROM business_units 
-WHERE domain_name = $1
+WHERE domain_name = $1 AND tenant_id = $2
 `
 
-func (q *Queries) GetBusinessUnitByDomainName(ctx context.Context, domainName string) (BusinessUnit, error) {
-	row := q.db.QueryRow(ctx, getBusinessUnitByDomainName, domainName)
+type GetBusinessUnitByDomainNameParams struct {
+	DomainName string `json:"domain_name"`
+	TenantID   string `json:"tenant_id"`
+}
+
+func (q *Queries) GetBusinessUnitByDomainName(ctx context.Context, arg GetBusinessUnitByDomainNameParams) (BusinessUnit, error) {
+	row := q.db.QueryRow(ctx, getBusinessUnitByDomainName, arg.DomainName, arg.TenantID)
 	var i BusinessUnit
 	err := row.Scan(
 		&i.ID,
@@ -144,11 +149,16 @@ SELECT
     updated_at,
     deleted_at
 FROM business_units 
-WHERE id = $1
+WHERE id = $1 AND tenant_id = $2
 `
 
-func (q *Queries) GetBusinessUnitByID(ctx context.Context, id pgtype.UUID) (BusinessUnit, error) {
-	row := q.db.QueryRow(ctx, getBusinessUnitByID, id)
+type GetBusinessUnitByIDParams struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID string      `json:"tenant_id"`
+}
+
+func (q *Queries) GetBusinessUnitByID(ctx context.Context, arg GetBusinessUnitByIDParams) (BusinessUnit, error) {
+	row := q.db.QueryRow(ctx, getBusinessUnitByID, arg.ID, arg.TenantID)
 	var i BusinessUnit
 	err := row.Scan(
 		&i.ID,
@@ -11,29 +11,78 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countDepartmentChildren = `-- name: CountDepartmentChildren :one
+SELECT COUNT(*)
+FROM departments
+WHERE parent_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountDepartmentChildren(ctx context.Context, id pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countDepartmentChildren, id)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countDepartmentSubtreeRoleAssignments = `-- name: CountDepartmentSubtreeRoleAssignments :one
+WITH RECURSIVE subtree AS (
+    SELECT d.id, ARRAY[d.id] AS path
+    FROM departments d
+    WHERE d.id = $1
+    UNION ALL
+    SELECT d.id, s.path || d.id
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE NOT d.id = ANY(s.path)
+)
+SELECT COUNT(*) FROM role_assignment ra
+WHERE ra.department_id IN (SELECT id FROM subtree) AND ra.deleted_at IS NULL
+`
+
+// Counts the live role assignments scoped to a department or its descendants.
+func (q *Queries) CountDepartmentSubtreeRoleAssignments(ctx context.Context, id pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countDepartmentSubtreeRoleAssignments, id)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createDepartment = `-- name: CreateDepartment :one
 INSERT INTO departments (
+    business_unit_id,
+    parent_id,
     name,
     status
 ) VALUES (
     $1,
-    $2
-) RETURNING 
+    $2,
+    $3,
+    $4
+) RETURNING
     id,
     name,
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    business_unit_id,
+    parent_id
 `
 
 type CreateDepartmentParams struct {
-	Name   string         `json:"name"`
-	Status NullStatusEnum `json:"status"`
+	BusinessUnitID pgtype.UUID    `json:"business_unit_id"`
+	ParentID       pgtype.UUID    `json:"parent_id"`
+	Name           string         `json:"name"`
+	Status         NullStatusEnum `json:"status"`
 }
 
 func (q *Queries) CreateDepartment(ctx context.Context, arg CreateDepartmentParams) (Department, error) {
-	row := q.db.QueryRow(ctx, createDepartment, arg.Name, arg.Status)
+	row := q.db.QueryRow(ctx, createDepartment,
+		arg.BusinessUnitID,
+		arg.ParentID,
+		arg.Name,
+		arg.Status,
+	)
 	var i Department
 	err := row.Scan(
 		&i.ID,
@@ -42,24 +91,32 @@ func (q *Queries) CreateDepartment(ctx context.Context, arg CreateDepartmentPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.BusinessUnitID,
+		&i.ParentID,
 	)
 	return i, err
 }
 
-const getDepartmentByID = `-- name: GetDepartmentByID :one
-SELECT 
+const deleteDepartment = `-- name: DeleteDepartment :one
+UPDATE departments
+SET
+    status = 'deleted',
+    deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING
     id,
     name,
     status,
     created_at,
     updated_at,
-    deleted_at
-FROM departments 
-WHERE id = $1
+    deleted_at,
+    business_unit_id,
+    parent_id
 `
 
-func (q *Queries) GetDepartmentByID(ctx context.Context, id pgtype.UUID) (Department, error) {
-	row := q.db.QueryRow(ctx, getDepartmentByID, id)
+func (q *Queries) DeleteDepartment(ctx context.Context, id pgtype.UUID) (Department, error) {
+	row := q.db.QueryRow(ctx, deleteDepartment, id)
 	var i Department
 	err := row.Scan(
 		&i.ID,
@@ -68,24 +125,358 @@ func (q *Queries) GetDepartmentByID(ctx context.Context, id pgtype.UUID) (Depart
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.BusinessUnitID,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getDepartmentByID = `-- name: GetDepartmentByID :one
+SELECT
+    d.id,
+    d.name,
+    d.status,
+    d.created_at,
+    d.updated_at,
+    d.deleted_at,
+    d.business_unit_id,
+    d.parent_id
+FROM departments d
+LEFT JOIN business_units bu ON bu.id = d.business_unit_id
+WHERE d.id = $1
+  AND d.deleted_at IS NULL
+  AND (d.business_unit_id IS NULL OR bu.tenant_id = $2)
+`
+
+type GetDepartmentByIDParams struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID string      `json:"tenant_id"`
+}
+
+// Departments without a business unit predate business unit scoping and are
+// visible to every tenant until moved into one.
+func (q *Queries) GetDepartmentByID(ctx context.Context, arg GetDepartmentByIDParams) (Department, error) {
+	row := q.db.QueryRow(ctx, getDepartmentByID, arg.ID, arg.TenantID)
+	var i Department
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.BusinessUnitID,
+		&i.ParentID,
 	)
 	return i, err
 }
 
 const getDepartmentByName = `-- name: GetDepartmentByName :one
-SELECT 
+SELECT
     id,
     name,
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    business_unit_id,
+    parent_id
 FROM departments
-WHERE name = $1
+WHERE business_unit_id = $1
+  AND name = $2
+  AND deleted_at IS NULL
+`
+
+type GetDepartmentByNameParams struct {
+	BusinessUnitID pgtype.UUID `json:"business_unit_id"`
+	Name           string      `json:"name"`
+}
+
+func (q *Queries) GetDepartmentByName(ctx context.Context, arg GetDepartmentByNameParams) (Department, error) {
+	row := q.db.QueryRow(ctx, getDepartmentByName, arg.BusinessUnitID, arg.Name)
+	var i Department
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.BusinessUnitID,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const listDepartmentSubtree = `-- name: ListDepartmentSubtree :many
+WITH RECURSIVE subtree AS (
+    SELECT
+        d.id, d.name, d.status, d.created_at, d.updated_at, d.deleted_at,
+        d.business_unit_id, d.parent_id, 0 AS depth,
+        ARRAY[d.id] AS path, ARRAY[d.name::text] AS name_path
+    FROM departments d
+    WHERE d.id = $1 AND d.deleted_at IS NULL
+    UNION ALL
+    SELECT
+        d.id, d.name, d.status, d.created_at, d.updated_at, d.deleted_at,
+        d.business_unit_id, d.parent_id, s.depth + 1,
+        s.path || d.id, s.name_path || d.name::text
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE d.deleted_at IS NULL
+      AND NOT d.id = ANY(s.path)
+)
+SELECT
+    id,
+    name,
+    status,
+    created_at,
+    updated_at,
+    deleted_at,
+    business_unit_id,
+    parent_id,
+    depth::int AS depth
+FROM subtree
+ORDER BY name_path
 `
 
-func (q *Queries) GetDepartmentByName(ctx context.Context, name string) (Department, error) {
-	row := q.db.QueryRow(ctx, getDepartmentByName, name)
+type ListDepartmentSubtreeRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Name           string             `json:"name"`
+	Status         NullStatusEnum     `json:"status"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	BusinessUnitID pgtype.UUID        `json:"business_unit_id"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
+	Depth          int32              `json:"depth"`
+}
+
+// Walks parent_id downwards from a department: depth 0 is the department
+// itself. The path guards against cycles and orders the result depth first.
+func (q *Queries) ListDepartmentSubtree(ctx context.Context, id pgtype.UUID) ([]ListDepartmentSubtreeRow, error) {
+	rows, err := q.db.Query(ctx, listDepartmentSubtree, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDepartmentSubtreeRow
+	for rows.Next() {
+		var i ListDepartmentSubtreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.BusinessUnitID,
+			&i.ParentID,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDepartments = `-- name: ListDepartments :many
+SELECT
+    d.id,
+    d.name,
+    d.status,
+    d.created_at,
+    d.updated_at,
+    d.deleted_at,
+    d.business_unit_id,
+    d.parent_id
+FROM departments d
+JOIN business_units bu ON bu.id = d.business_unit_id
+WHERE bu.tenant_id = $1
+  AND d.deleted_at IS NULL
+  AND ($2::uuid IS NULL OR d.business_unit_id = $2::uuid)
+  AND ($3::uuid IS NULL OR d.parent_id = $3::uuid)
+ORDER BY d.name, d.id
+`
+
+type ListDepartmentsParams struct {
+	TenantID       string      `json:"tenant_id"`
+	BusinessUnitID pgtype.UUID `json:"business_unit_id"`
+	ParentID       pgtype.UUID `json:"parent_id"`
+}
+
+func (q *Queries) ListDepartments(ctx context.Context, arg ListDepartmentsParams) ([]Department, error) {
+	rows, err := q.db.Query(ctx, listDepartments, arg.TenantID, arg.BusinessUnitID, arg.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Department
+	for rows.Next() {
+		var i Department
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.BusinessUnitID,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTenantDepartmentHierarchy = `-- name: LockTenantDepartmentHierarchy :exec
+SELECT id FROM business_units
+WHERE tenant_id = $1
+ORDER BY id
+FOR UPDATE
+`
+
+// Locks the tenant's business units so that department moves of the tenant
+// run one at a time and each sees the hierarchy the previous one left.
+func (q *Queries) LockTenantDepartmentHierarchy(ctx context.Context, tenantID string) error {
+	_, err := q.db.Exec(ctx, lockTenantDepartmentHierarchy, tenantID)
+	return err
+}
+
+const moveDepartment = `-- name: MoveDepartment :one
+UPDATE departments
+SET
+    parent_id = $1,
+    business_unit_id = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    status,
+    created_at,
+    updated_at,
+    deleted_at,
+    business_unit_id,
+    parent_id
+`
+
+type MoveDepartmentParams struct {
+	ParentID       pgtype.UUID `json:"parent_id"`
+	BusinessUnitID pgtype.UUID `json:"business_unit_id"`
+	ID             pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) MoveDepartment(ctx context.Context, arg MoveDepartmentParams) (Department, error) {
+	row := q.db.QueryRow(ctx, moveDepartment, arg.ParentID, arg.BusinessUnitID, arg.ID)
+	var i Department
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.BusinessUnitID,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const setDepartmentSubtreeBusinessUnit = `-- name: SetDepartmentSubtreeBusinessUnit :exec
+WITH RECURSIVE subtree AS (
+    SELECT d.id, ARRAY[d.id] AS path
+    FROM departments d
+    WHERE d.parent_id = $1
+    UNION ALL
+    SELECT d.id, s.path || d.id
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE NOT d.id = ANY(s.path)
+)
+UPDATE departments
+SET business_unit_id = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT id FROM subtree)
+`
+
+type SetDepartmentSubtreeBusinessUnitParams struct {
+	ID             pgtype.UUID `json:"id"`
+	BusinessUnitID pgtype.UUID `json:"business_unit_id"`
+}
+
+// Moves the descendants of a department into its business unit after the
+// department itself moved.
+func (q *Queries) SetDepartmentSubtreeBusinessUnit(ctx context.Context, arg SetDepartmentSubtreeBusinessUnitParams) error {
+	_, err := q.db.Exec(ctx, setDepartmentSubtreeBusinessUnit, arg.ID, arg.BusinessUnitID)
+	return err
+}
+
+const setDepartmentSubtreeUsersBusinessUnit = `-- name: SetDepartmentSubtreeUsersBusinessUnit :execrows
+WITH RECURSIVE subtree AS (
+    SELECT d.id, ARRAY[d.id] AS path
+    FROM departments d
+    WHERE d.id = $1
+    UNION ALL
+    SELECT d.id, s.path || d.id
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE NOT d.id = ANY(s.path)
+)
+UPDATE users
+SET business_unit_id = $2, updated_at = CURRENT_TIMESTAMP
+WHERE department_id IN (SELECT id FROM subtree) AND deleted_at IS NULL
+`
+
+type SetDepartmentSubtreeUsersBusinessUnitParams struct {
+	ID             pgtype.UUID `json:"id"`
+	BusinessUnitID pgtype.UUID `json:"business_unit_id"`
+}
+
+// Moves the users of a department and its descendants into the business unit
+// the department moved to.
+func (q *Queries) SetDepartmentSubtreeUsersBusinessUnit(ctx context.Context, arg SetDepartmentSubtreeUsersBusinessUnitParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setDepartmentSubtreeUsersBusinessUnit, arg.ID, arg.BusinessUnitID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateDepartment = `-- name: UpdateDepartment :one
+UPDATE departments
+SET
+    name = $1,
+    status = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    status,
+    created_at,
+    updated_at,
+    deleted_at,
+    business_unit_id,
+    parent_id
+`
+
+type UpdateDepartmentParams struct {
+	Name   string         `json:"name"`
+	Status NullStatusEnum `json:"status"`
+	ID     pgtype.UUID    `json:"id"`
+}
+
+func (q *Queries) UpdateDepartment(ctx context.Context, arg UpdateDepartmentParams) (Department, error) {
+	row := q.db.QueryRow(ctx, updateDepartment, arg.Name, arg.Status, arg.ID)
 	var i Department
 	err := row.Scan(
 		&i.ID,
@@ -94,6 +485,8 @@ func (q *Queries) GetDepartmentByName(ctx context.Context, name string) (Departm
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.BusinessUnitID,
+		&i.ParentID,
 	)
 	return i, err
 }
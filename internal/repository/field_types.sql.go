@@ -11,11 +11,23 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countFormFieldsByType = `-- name: CountFormFieldsByType :one
+SELECT COUNT(*) FROM form_fields
+WHERE field_type = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountFormFieldsByType(ctx context.Context, fieldType string) (int64, error) {
+	row := q.db.QueryRow(ctx, countFormFieldsByType, fieldType)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createFieldType = `-- name: CreateFieldType :one
 INSERT INTO field_types (
     type_name, description, validation_schema
 ) VALUES ($1, $2, $3)
-RETURNING id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at
+RETURNING id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at, is_builtin
 `
 
 type CreateFieldTypeParams struct {
@@ -36,25 +48,29 @@ func (q *Queries) CreateFieldType(ctx context.Context, arg CreateFieldTypeParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.IsBuiltin,
 	)
 	return i, err
 }
 
-const deleteFieldType = `-- name: DeleteFieldType :exec
+const deleteFieldType = `-- name: DeleteFieldType :execrows
 UPDATE field_types
 SET 
     status = 'inactive',
     deleted_at = CURRENT_TIMESTAMP
-WHERE id = $1
+WHERE id = $1 AND NOT is_builtin AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteFieldType(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteFieldType, id)
-	return err
+func (q *Queries) DeleteFieldType(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFieldType, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getFieldTypeByID = `-- name: GetFieldTypeByID :one
-SELECT id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at FROM field_types
+SELECT id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at, is_builtin FROM field_types
 WHERE id = $1 AND status = 'active' AND deleted_at IS NULL
 `
 
@@ -70,12 +86,35 @@ func (q *Queries) GetFieldTypeByID(ctx context.Context, id pgtype.UUID) (FieldTy
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.IsBuiltin,
+	)
+	return i, err
+}
+
+const getFieldTypeByName = `-- name: GetFieldTypeByName :one
+SELECT id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at, is_builtin FROM field_types
+WHERE type_name = $1 AND status = 'active' AND deleted_at IS NULL
+`
+
+func (q *Queries) GetFieldTypeByName(ctx context.Context, typeName string) (FieldType, error) {
+	row := q.db.QueryRow(ctx, getFieldTypeByName, typeName)
+	var i FieldType
+	err := row.Scan(
+		&i.ID,
+		&i.TypeName,
+		&i.Description,
+		&i.ValidationSchema,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.IsBuiltin,
 	)
 	return i, err
 }
 
 const getFieldTypes = `-- name: GetFieldTypes :many
-SELECT id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at FROM field_types
+SELECT id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at, is_builtin FROM field_types
 WHERE status = 'active' AND deleted_at IS NULL
 ORDER BY type_name
 `
@@ -98,6 +137,44 @@ func (q *Queries) GetFieldTypes(ctx context.Context) ([]FieldType, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.IsBuiltin,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFieldTypesByNames = `-- name: GetFieldTypesByNames :many
+SELECT id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at, is_builtin FROM field_types
+WHERE type_name = ANY($1::text[]) AND deleted_at IS NULL
+ORDER BY type_name
+`
+
+// Resolves the types of existing fields, including types deactivated since.
+func (q *Queries) GetFieldTypesByNames(ctx context.Context, typeNames []string) ([]FieldType, error) {
+	rows, err := q.db.Query(ctx, getFieldTypesByNames, typeNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FieldType
+	for rows.Next() {
+		var i FieldType
+		if err := rows.Scan(
+			&i.ID,
+			&i.TypeName,
+			&i.Description,
+			&i.ValidationSchema,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.IsBuiltin,
 		); err != nil {
 			return nil, err
 		}
@@ -112,28 +189,23 @@ func (q *Queries) GetFieldTypes(ctx context.Context) ([]FieldType, error) {
 const updateFieldType = `-- name: UpdateFieldType :one
 UPDATE field_types
 SET 
-    type_name = COALESCE($2, type_name),
-    description = COALESCE($3, description),
-    validation_schema = COALESCE($4, validation_schema),
+    description = $2,
+    validation_schema = $3,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at
+WHERE id = $1 AND NOT is_builtin AND status = 'active' AND deleted_at IS NULL
+RETURNING id, type_name, description, validation_schema, status, created_at, updated_at, deleted_at, is_builtin
 `
 
 type UpdateFieldTypeParams struct {
 	ID               pgtype.UUID `json:"id"`
-	TypeName         string      `json:"type_name"`
 	Description      pgtype.Text `json:"description"`
 	ValidationSchema []byte      `json:"validation_schema"`
 }
 
+// The type name is what form fields reference, so it never changes. Built-in
+// types are shared by every tenant and cannot be changed.
 func (q *Queries) UpdateFieldType(ctx context.Context, arg UpdateFieldTypeParams) (FieldType, error) {
-	row := q.db.QueryRow(ctx, updateFieldType,
-		arg.ID,
-		arg.TypeName,
-		arg.Description,
-		arg.ValidationSchema,
-	)
+	row := q.db.QueryRow(ctx, updateFieldType, arg.ID, arg.Description, arg.ValidationSchema)
 	var i FieldType
 	err := row.Scan(
 		&i.ID,
@@ -144,6 +216,7 @@ func (q *Queries) UpdateFieldType(ctx context.Context, arg UpdateFieldTypeParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.IsBuiltin,
 	)
 	return i, err
 }
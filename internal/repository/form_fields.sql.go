@@ -54,26 +54,39 @@ func (q *Queries) CreateFormField(ctx context.Context, arg CreateFormFieldParams
 	return i, err
 }
 
-const deleteFormField = `-- name: DeleteFormField :exec
+const deleteFormField = `-- name: DeleteFormField :execrows
 UPDATE form_fields
 SET 
     status = 'inactive',
     deleted_at = CURRENT_TIMESTAMP
-WHERE id = $1
+WHERE id = $1 AND form_template_id = $2 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteFormField(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteFormField, id)
-	return err
+type DeleteFormFieldParams struct {
+	ID             pgtype.UUID `json:"id"`
+	FormTemplateID pgtype.UUID `json:"form_template_id"`
+}
+
+func (q *Queries) DeleteFormField(ctx context.Context, arg DeleteFormFieldParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFormField, arg.ID, arg.FormTemplateID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getFormFieldByID = `-- name: GetFormFieldByID :one
 SELECT id, form_template_id, form_section_id, field_name, field_type, field_order, config, status, created_at, updated_at, deleted_at FROM form_fields
-WHERE id = $1 AND status = 'active' AND deleted_at IS NULL
+WHERE id = $1 AND form_template_id = $2 AND status = 'active' AND deleted_at IS NULL
 `
 
-func (q *Queries) GetFormFieldByID(ctx context.Context, id pgtype.UUID) (FormField, error) {
-	row := q.db.QueryRow(ctx, getFormFieldByID, id)
+type GetFormFieldByIDParams struct {
+	ID             pgtype.UUID `json:"id"`
+	FormTemplateID pgtype.UUID `json:"form_template_id"`
+}
+
+func (q *Queries) GetFormFieldByID(ctx context.Context, arg GetFormFieldByIDParams) (FormField, error) {
+	row := q.db.QueryRow(ctx, getFormFieldByID, arg.ID, arg.FormTemplateID)
 	var i FormField
 	err := row.Scan(
 		&i.ID,
@@ -176,26 +189,31 @@ func (q *Queries) GetFormFieldsBySection(ctx context.Context, arg GetFormFieldsB
 const updateFormField = `-- name: UpdateFormField :one
 UPDATE form_fields
 SET 
-    field_name = COALESCE($2, field_name),
-    field_type = COALESCE($3, field_type),
-    field_order = COALESCE($4, field_order),
-    config = COALESCE($5, config),
+    form_section_id = $3,
+    field_name = $4,
+    field_type = $5,
+    field_order = $6,
+    config = $7,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
+WHERE id = $1 AND form_template_id = $2 AND deleted_at IS NULL
 RETURNING id, form_template_id, form_section_id, field_name, field_type, field_order, config, status, created_at, updated_at, deleted_at
 `
 
 type UpdateFormFieldParams struct {
-	ID         pgtype.UUID `json:"id"`
-	FieldName  string      `json:"field_name"`
-	FieldType  string      `json:"field_type"`
-	FieldOrder int32       `json:"field_order"`
-	Config     []byte      `json:"config"`
+	ID             pgtype.UUID `json:"id"`
+	FormTemplateID pgtype.UUID `json:"form_template_id"`
+	FormSectionID  pgtype.UUID `json:"form_section_id"`
+	FieldName      string      `json:"field_name"`
+	FieldType      string      `json:"field_type"`
+	FieldOrder     int32       `json:"field_order"`
+	Config         []byte      `json:"config"`
 }
 
 func (q *Queries) UpdateFormField(ctx context.Context, arg UpdateFormFieldParams) (FormField, error) {
 	row := q.db.QueryRow(ctx, updateFormField,
 		arg.ID,
+		arg.FormTemplateID,
+		arg.FormSectionID,
 		arg.FieldName,
 		arg.FieldType,
 		arg.FieldOrder,
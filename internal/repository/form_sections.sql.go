@@ -86,7 +86,7 @@ func (q *Queries) GetFormSectionByID(ctx context.Context, id pgtype.UUID) (FormS
 const getFormSections = `-- name: GetFormSections :many
 SELECT id, form_template_id, section_name, section_order, description, status, created_at, updated_at, deleted_at FROM form_sections
 WHERE form_template_id = $1 AND status = 'active' AND deleted_at IS NULL
-ORDER BY section_order
+ORDER BY section_order, id
 `
 
 func (q *Queries) GetFormSections(ctx context.Context, formTemplateID pgtype.UUID) ([]FormSection, error) {
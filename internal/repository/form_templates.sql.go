@@ -11,12 +11,57 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const copyFormFields = `-- name: CopyFormFields :exec
+INSERT INTO form_fields (form_template_id, form_section_id, field_name, field_type, field_order, config)
+SELECT $1, target_section.id, f.field_name, f.field_type, f.field_order, f.config
+FROM form_fields f
+LEFT JOIN form_sections source_section ON source_section.id = f.form_section_id
+LEFT JOIN form_sections target_section
+    ON target_section.form_template_id = $1
+    AND target_section.section_name = source_section.section_name
+    AND target_section.deleted_at IS NULL
+WHERE f.form_template_id = $2 AND f.status = 'active' AND f.deleted_at IS NULL
+`
+
+type CopyFormFieldsParams struct {
+	TargetTemplateID pgtype.UUID `json:"target_template_id"`
+	SourceTemplateID pgtype.UUID `json:"source_template_id"`
+}
+
+// Run after CopyFormSections; fields follow their section by name.
+func (q *Queries) CopyFormFields(ctx context.Context, arg CopyFormFieldsParams) error {
+	_, err := q.db.Exec(ctx, copyFormFields, arg.TargetTemplateID, arg.SourceTemplateID)
+	return err
+}
+
+const copyFormSections = `-- name: CopyFormSections :exec
+INSERT INTO form_sections (form_template_id, section_name, section_order, description)
+SELECT $1, section_name, section_order, description
+FROM form_sections
+WHERE form_template_id = $2 AND status = 'active' AND deleted_at IS NULL
+`
+
+type CopyFormSectionsParams struct {
+	TargetTemplateID pgtype.UUID `json:"target_template_id"`
+	SourceTemplateID pgtype.UUID `json:"source_template_id"`
+}
+
+func (q *Queries) CopyFormSections(ctx context.Context, arg CopyFormSectionsParams) error {
+	_, err := q.db.Exec(ctx, copyFormSections, arg.TargetTemplateID, arg.SourceTemplateID)
+	return err
+}
+
 const createFormTemplate = `-- name: CreateFormTemplate :one
+WITH new_template AS (
+    SELECT gen_random_uuid() AS id
+)
 INSERT INTO form_templates (
-    name, description, form_category_id, business_unit_id,
-    version, created_by
-) VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at
+    id, lineage_id, name, description, form_category_id, business_unit_id,
+    version, created_by, department_id, tenant_id
+)
+SELECT new_template.id, new_template.id, $1, $2, $3, $4, 1, $5, $6, $7
+FROM new_template
+RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id
 `
 
 type CreateFormTemplateParams struct {
@@ -24,19 +69,69 @@ type CreateFormTemplateParams struct {
 	Description    pgtype.Text `json:"description"`
 	FormCategoryID pgtype.UUID `json:"form_category_id"`
 	BusinessUnitID pgtype.UUID `json:"business_unit_id"`
-	Version        pgtype.Int4 `json:"version"`
 	CreatedBy      pgtype.UUID `json:"created_by"`
+	DepartmentID   pgtype.UUID `json:"department_id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
 }
 
+// The first version of a form starts its own lineage in the creator's tenant.
 func (q *Queries) CreateFormTemplate(ctx context.Context, arg CreateFormTemplateParams) (FormTemplate, error) {
 	row := q.db.QueryRow(ctx, createFormTemplate,
 		arg.Name,
 		arg.Description,
 		arg.FormCategoryID,
 		arg.BusinessUnitID,
-		arg.Version,
 		arg.CreatedBy,
+		arg.DepartmentID,
+		arg.TenantID,
+	)
+	var i FormTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.FormCategoryID,
+		&i.BusinessUnitID,
+		&i.Version,
+		&i.PublishedAt,
+		&i.CreatedBy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
 	)
+	return i, err
+}
+
+const createFormTemplateVersion = `-- name: CreateFormTemplateVersion :one
+INSERT INTO form_templates (
+    lineage_id, name, description, form_category_id, business_unit_id,
+    version, created_by, department_id, tenant_id
+)
+SELECT
+    source.lineage_id, source.name, source.description, source.form_category_id, source.business_unit_id,
+    (SELECT MAX(v.version) + 1 FROM form_templates v WHERE v.lineage_id = source.lineage_id),
+    $1, source.department_id, source.tenant_id
+FROM form_templates source
+WHERE source.id = $2
+RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id
+`
+
+type CreateFormTemplateVersionParams struct {
+	CreatedBy pgtype.UUID `json:"created_by"`
+	SourceID  pgtype.UUID `json:"source_id"`
+}
+
+// Starts the next draft version of a form from one of its versions.
+func (q *Queries) CreateFormTemplateVersion(ctx context.Context, arg CreateFormTemplateVersionParams) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, createFormTemplateVersion, arg.CreatedBy, arg.SourceID)
 	var i FormTemplate
 	err := row.Scan(
 		&i.ID,
@@ -51,30 +146,88 @@ func (q *Queries) CreateFormTemplate(ctx context.Context, arg CreateFormTemplate
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
 	)
 	return i, err
 }
 
-const deleteFormTemplate = `-- name: DeleteFormTemplate :exec
+const deleteFormTemplate = `-- name: DeleteFormTemplate :execrows
 UPDATE form_templates
 SET 
     status = 'inactive',
     deleted_at = CURRENT_TIMESTAMP
-WHERE id = $1
+WHERE id = $1 AND tenant_id = $2 AND state IN ('draft', 'in_review') AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteFormTemplate(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteFormTemplate, id)
-	return err
+type DeleteFormTemplateParams struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+}
+
+// Published and retired versions stay for the submissions made against them.
+func (q *Queries) DeleteFormTemplate(ctx context.Context, arg DeleteFormTemplateParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFormTemplate, arg.ID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getFormTemplateByID = `-- name: GetFormTemplateByID :one
-SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at FROM form_templates
-WHERE id = $1 AND status = 'active' AND deleted_at IS NULL
+SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id FROM form_templates
+WHERE id = $1 AND tenant_id = $2 AND status = 'active' AND deleted_at IS NULL
+`
+
+type GetFormTemplateByIDParams struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetFormTemplateByID(ctx context.Context, arg GetFormTemplateByIDParams) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, getFormTemplateByID, arg.ID, arg.TenantID)
+	var i FormTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.FormCategoryID,
+		&i.BusinessUnitID,
+		&i.Version,
+		&i.PublishedAt,
+		&i.CreatedBy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const getFormTemplateVersion = `-- name: GetFormTemplateVersion :one
+SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id FROM form_templates
+WHERE lineage_id = $1 AND version = $2 AND status = 'active' AND deleted_at IS NULL
 `
 
-func (q *Queries) GetFormTemplateByID(ctx context.Context, id pgtype.UUID) (FormTemplate, error) {
-	row := q.db.QueryRow(ctx, getFormTemplateByID, id)
+type GetFormTemplateVersionParams struct {
+	LineageID pgtype.UUID `json:"lineage_id"`
+	Version   int32       `json:"version"`
+}
+
+func (q *Queries) GetFormTemplateVersion(ctx context.Context, arg GetFormTemplateVersionParams) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, getFormTemplateVersion, arg.LineageID, arg.Version)
 	var i FormTemplate
 	err := row.Scan(
 		&i.ID,
@@ -89,18 +242,91 @@ func (q *Queries) GetFormTemplateByID(ctx context.Context, id pgtype.UUID) (Form
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
 	)
 	return i, err
 }
 
+const getFormTemplateVersions = `-- name: GetFormTemplateVersions :many
+SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id FROM form_templates
+WHERE lineage_id = $1 AND status = 'active' AND deleted_at IS NULL
+ORDER BY version DESC
+`
+
+func (q *Queries) GetFormTemplateVersions(ctx context.Context, lineageID pgtype.UUID) ([]FormTemplate, error) {
+	rows, err := q.db.Query(ctx, getFormTemplateVersions, lineageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FormTemplate
+	for rows.Next() {
+		var i FormTemplate
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.FormCategoryID,
+			&i.BusinessUnitID,
+			&i.Version,
+			&i.PublishedAt,
+			&i.CreatedBy,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.DepartmentID,
+			&i.LineageID,
+			&i.State,
+			&i.ApprovedBy,
+			&i.ApprovedAt,
+			&i.RetiredAt,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFormTemplates = `-- name: GetFormTemplates :many
-SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at FROM form_templates
-WHERE status = 'active' AND deleted_at IS NULL
+SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id FROM form_templates
+WHERE tenant_id = $1 AND status = 'active' AND deleted_at IS NULL
+    AND (
+        $2::boolean
+        OR business_unit_id = ANY($3::uuid[])
+        OR department_id = ANY($4::uuid[])
+        OR created_by = $5
+    )
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetFormTemplates(ctx context.Context) ([]FormTemplate, error) {
-	rows, err := q.db.Query(ctx, getFormTemplates)
+type GetFormTemplatesParams struct {
+	TenantID        pgtype.UUID   `json:"tenant_id"`
+	AllAccess       bool          `json:"all_access"`
+	BusinessUnitIds []pgtype.UUID `json:"business_unit_ids"`
+	DepartmentIds   []pgtype.UUID `json:"department_ids"`
+	OwnerID         pgtype.UUID   `json:"owner_id"`
+}
+
+func (q *Queries) GetFormTemplates(ctx context.Context, arg GetFormTemplatesParams) ([]FormTemplate, error) {
+	rows, err := q.db.Query(ctx, getFormTemplates,
+		arg.TenantID,
+		arg.AllAccess,
+		arg.BusinessUnitIds,
+		arg.DepartmentIds,
+		arg.OwnerID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +347,13 @@ func (q *Queries) GetFormTemplates(ctx context.Context) ([]FormTemplate, error)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.DepartmentID,
+			&i.LineageID,
+			&i.State,
+			&i.ApprovedBy,
+			&i.ApprovedAt,
+			&i.RetiredAt,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -133,13 +366,35 @@ func (q *Queries) GetFormTemplates(ctx context.Context) ([]FormTemplate, error)
 }
 
 const getFormTemplatesByCategory = `-- name: GetFormTemplatesByCategory :many
-SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at FROM form_templates
-WHERE form_category_id = $1 AND status = 'active' AND deleted_at IS NULL
+SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id FROM form_templates
+WHERE form_category_id = $1 AND tenant_id = $2 AND status = 'active' AND deleted_at IS NULL
+    AND (
+        $3::boolean
+        OR business_unit_id = ANY($4::uuid[])
+        OR department_id = ANY($5::uuid[])
+        OR created_by = $6
+    )
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetFormTemplatesByCategory(ctx context.Context, formCategoryID pgtype.UUID) ([]FormTemplate, error) {
-	rows, err := q.db.Query(ctx, getFormTemplatesByCategory, formCategoryID)
+type GetFormTemplatesByCategoryParams struct {
+	FormCategoryID  pgtype.UUID   `json:"form_category_id"`
+	TenantID        pgtype.UUID   `json:"tenant_id"`
+	AllAccess       bool          `json:"all_access"`
+	BusinessUnitIds []pgtype.UUID `json:"business_unit_ids"`
+	DepartmentIds   []pgtype.UUID `json:"department_ids"`
+	OwnerID         pgtype.UUID   `json:"owner_id"`
+}
+
+func (q *Queries) GetFormTemplatesByCategory(ctx context.Context, arg GetFormTemplatesByCategoryParams) ([]FormTemplate, error) {
+	rows, err := q.db.Query(ctx, getFormTemplatesByCategory,
+		arg.FormCategoryID,
+		arg.TenantID,
+		arg.AllAccess,
+		arg.BusinessUnitIds,
+		arg.DepartmentIds,
+		arg.OwnerID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +415,13 @@ func (q *Queries) GetFormTemplatesByCategory(ctx context.Context, formCategoryID
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.DepartmentID,
+			&i.LineageID,
+			&i.State,
+			&i.ApprovedBy,
+			&i.ApprovedAt,
+			&i.RetiredAt,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -171,18 +433,181 @@ func (q *Queries) GetFormTemplatesByCategory(ctx context.Context, formCategoryID
 	return items, nil
 }
 
+const getPublishedFormTemplate = `-- name: GetPublishedFormTemplate :one
+SELECT id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id FROM form_templates
+WHERE lineage_id = $1 AND state = 'published' AND status = 'active' AND deleted_at IS NULL
+`
+
+func (q *Queries) GetPublishedFormTemplate(ctx context.Context, lineageID pgtype.UUID) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, getPublishedFormTemplate, lineageID)
+	var i FormTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.FormCategoryID,
+		&i.BusinessUnitID,
+		&i.Version,
+		&i.PublishedAt,
+		&i.CreatedBy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
 const publishFormTemplate = `-- name: PublishFormTemplate :one
 UPDATE form_templates
 SET 
+    state = 'published',
     published_at = CURRENT_TIMESTAMP,
+    approved_by = $2,
     approved_at = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at
+WHERE id = $1 AND state = 'in_review' AND deleted_at IS NULL
+RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id
+`
+
+type PublishFormTemplateParams struct {
+	ID         pgtype.UUID `json:"id"`
+	ApprovedBy pgtype.UUID `json:"approved_by"`
+}
+
+func (q *Queries) PublishFormTemplate(ctx context.Context, arg PublishFormTemplateParams) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, publishFormTemplate, arg.ID, arg.ApprovedBy)
+	var i FormTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.FormCategoryID,
+		&i.BusinessUnitID,
+		&i.Version,
+		&i.PublishedAt,
+		&i.CreatedBy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const rejectFormTemplate = `-- name: RejectFormTemplate :one
+UPDATE form_templates
+SET 
+    state = 'draft',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'in_review' AND deleted_at IS NULL
+RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id
+`
+
+func (q *Queries) RejectFormTemplate(ctx context.Context, id pgtype.UUID) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, rejectFormTemplate, id)
+	var i FormTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.FormCategoryID,
+		&i.BusinessUnitID,
+		&i.Version,
+		&i.PublishedAt,
+		&i.CreatedBy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const retireFormTemplate = `-- name: RetireFormTemplate :one
+UPDATE form_templates
+SET 
+    state = 'retired',
+    retired_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'published' AND deleted_at IS NULL
+RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id
+`
+
+func (q *Queries) RetireFormTemplate(ctx context.Context, id pgtype.UUID) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, retireFormTemplate, id)
+	var i FormTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.FormCategoryID,
+		&i.BusinessUnitID,
+		&i.Version,
+		&i.PublishedAt,
+		&i.CreatedBy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const retirePublishedFormTemplates = `-- name: RetirePublishedFormTemplates :exec
+UPDATE form_templates
+SET 
+    state = 'retired',
+    retired_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE lineage_id = $1 AND state = 'published' AND deleted_at IS NULL
+`
+
+// Retires the published version of a lineage ahead of publishing a newer one.
+func (q *Queries) RetirePublishedFormTemplates(ctx context.Context, lineageID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, retirePublishedFormTemplates, lineageID)
+	return err
+}
+
+const submitFormTemplateForReview = `-- name: SubmitFormTemplateForReview :one
+UPDATE form_templates
+SET 
+    state = 'in_review',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'draft' AND deleted_at IS NULL
+RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id
 `
 
-func (q *Queries) PublishFormTemplate(ctx context.Context, id pgtype.UUID) (FormTemplate, error) {
-	row := q.db.QueryRow(ctx, publishFormTemplate, id)
+func (q *Queries) SubmitFormTemplateForReview(ctx context.Context, id pgtype.UUID) (FormTemplate, error) {
+	row := q.db.QueryRow(ctx, submitFormTemplateForReview, id)
 	var i FormTemplate
 	err := row.Scan(
 		&i.ID,
@@ -197,6 +622,13 @@ func (q *Queries) PublishFormTemplate(ctx context.Context, id pgtype.UUID) (Form
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -208,10 +640,10 @@ SET
     description = COALESCE($3, description),
     form_category_id = COALESCE($4, form_category_id),
     business_unit_id = COALESCE($5, business_unit_id),
-    version = COALESCE($6, version),
+    department_id = COALESCE($6, department_id),
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at
+WHERE id = $1 AND state = 'draft' AND deleted_at IS NULL
+RETURNING id, name, description, form_category_id, business_unit_id, version, published_at, created_by, status, created_at, updated_at, deleted_at, department_id, lineage_id, state, approved_by, approved_at, retired_at, tenant_id
 `
 
 type UpdateFormTemplateParams struct {
@@ -220,7 +652,7 @@ type UpdateFormTemplateParams struct {
 	Description    pgtype.Text `json:"description"`
 	FormCategoryID pgtype.UUID `json:"form_category_id"`
 	BusinessUnitID pgtype.UUID `json:"business_unit_id"`
-	Version        pgtype.Int4 `json:"version"`
+	DepartmentID   pgtype.UUID `json:"department_id"`
 }
 
 func (q *Queries) UpdateFormTemplate(ctx context.Context, arg UpdateFormTemplateParams) (FormTemplate, error) {
@@ -230,7 +662,7 @@ func (q *Queries) UpdateFormTemplate(ctx context.Context, arg UpdateFormTemplate
 		arg.Description,
 		arg.FormCategoryID,
 		arg.BusinessUnitID,
-		arg.Version,
+		arg.DepartmentID,
 	)
 	var i FormTemplate
 	err := row.Scan(
@@ -246,6 +678,13 @@ func (q *Queries) UpdateFormTemplate(ctx context.Context, arg UpdateFormTemplate
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DepartmentID,
+		&i.LineageID,
+		&i.State,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+		&i.RetiredAt,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -11,6 +11,265 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccessReviewDecision string
+
+const (
+	AccessReviewDecisionPending  AccessReviewDecision = "pending"
+	AccessReviewDecisionApproved AccessReviewDecision = "approved"
+	AccessReviewDecisionRevoked  AccessReviewDecision = "revoked"
+)
+
+func (e *AccessReviewDecision) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccessReviewDecision(s)
+	case string:
+		*e = AccessReviewDecision(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccessReviewDecision: %T", src)
+	}
+	return nil
+}
+
+type NullAccessReviewDecision struct {
+	AccessReviewDecision AccessReviewDecision `json:"access_review_decision"`
+	Valid                bool                 `json:"valid"` // Valid is true if AccessReviewDecision is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccessReviewDecision) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccessReviewDecision, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccessReviewDecision.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccessReviewDecision) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccessReviewDecision), nil
+}
+
+type AccessReviewStatus string
+
+const (
+	AccessReviewStatusActive    AccessReviewStatus = "active"
+	AccessReviewStatusCompleted AccessReviewStatus = "completed"
+)
+
+func (e *AccessReviewStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccessReviewStatus(s)
+	case string:
+		*e = AccessReviewStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccessReviewStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAccessReviewStatus struct {
+	AccessReviewStatus AccessReviewStatus `json:"access_review_status"`
+	Valid              bool               `json:"valid"` // Valid is true if AccessReviewStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccessReviewStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccessReviewStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccessReviewStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccessReviewStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccessReviewStatus), nil
+}
+
+type DirectoryClaimType string
+
+const (
+	DirectoryClaimTypeAppRole   DirectoryClaimType = "app_role"
+	DirectoryClaimTypeGroup     DirectoryClaimType = "group"
+	DirectoryClaimTypeScimGroup DirectoryClaimType = "scim_group"
+)
+
+func (e *DirectoryClaimType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DirectoryClaimType(s)
+	case string:
+		*e = DirectoryClaimType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DirectoryClaimType: %T", src)
+	}
+	return nil
+}
+
+type NullDirectoryClaimType struct {
+	DirectoryClaimType DirectoryClaimType `json:"directory_claim_type"`
+	Valid              bool               `json:"valid"` // Valid is true if DirectoryClaimType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDirectoryClaimType) Scan(value interface{}) error {
+	if value == nil {
+		ns.DirectoryClaimType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DirectoryClaimType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDirectoryClaimType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DirectoryClaimType), nil
+}
+
+type ElevationRequestStatus string
+
+const (
+	ElevationRequestStatusPending   ElevationRequestStatus = "pending"
+	ElevationRequestStatusApproved  ElevationRequestStatus = "approved"
+	ElevationRequestStatusRejected  ElevationRequestStatus = "rejected"
+	ElevationRequestStatusCancelled ElevationRequestStatus = "cancelled"
+	ElevationRequestStatusExpired   ElevationRequestStatus = "expired"
+)
+
+func (e *ElevationRequestStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ElevationRequestStatus(s)
+	case string:
+		*e = ElevationRequestStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ElevationRequestStatus: %T", src)
+	}
+	return nil
+}
+
+type NullElevationRequestStatus struct {
+	ElevationRequestStatus ElevationRequestStatus `json:"elevation_request_status"`
+	Valid                  bool                   `json:"valid"` // Valid is true if ElevationRequestStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullElevationRequestStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ElevationRequestStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ElevationRequestStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullElevationRequestStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ElevationRequestStatus), nil
+}
+
+type FormSubmissionState string
+
+const (
+	FormSubmissionStateDraft     FormSubmissionState = "draft"
+	FormSubmissionStateSubmitted FormSubmissionState = "submitted"
+)
+
+func (e *FormSubmissionState) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FormSubmissionState(s)
+	case string:
+		*e = FormSubmissionState(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FormSubmissionState: %T", src)
+	}
+	return nil
+}
+
+type NullFormSubmissionState struct {
+	FormSubmissionState FormSubmissionState `json:"form_submission_state"`
+	Valid               bool                `json:"valid"` // Valid is true if FormSubmissionState is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFormSubmissionState) Scan(value interface{}) error {
+	if value == nil {
+		ns.FormSubmissionState, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FormSubmissionState.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFormSubmissionState) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FormSubmissionState), nil
+}
+
+type FormTemplateState string
+
+const (
+	FormTemplateStateDraft     FormTemplateState = "draft"
+	FormTemplateStateInReview  FormTemplateState = "in_review"
+	FormTemplateStatePublished FormTemplateState = "published"
+	FormTemplateStateRetired   FormTemplateState = "retired"
+)
+
+func (e *FormTemplateState) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FormTemplateState(s)
+	case string:
+		*e = FormTemplateState(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FormTemplateState: %T", src)
+	}
+	return nil
+}
+
+type NullFormTemplateState struct {
+	FormTemplateState FormTemplateState `json:"form_template_state"`
+	Valid             bool              `json:"valid"` // Valid is true if FormTemplateState is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFormTemplateState) Scan(value interface{}) error {
+	if value == nil {
+		ns.FormTemplateState, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FormTemplateState.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFormTemplateState) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FormTemplateState), nil
+}
+
 type StatusEnum string
 
 const (
@@ -54,6 +313,55 @@ func (ns NullStatusEnum) Value() (driver.Value, error) {
 	return string(ns.StatusEnum), nil
 }
 
+type AccessReviewCampaign struct {
+	ID              pgtype.UUID        `json:"id"`
+	Name            string             `json:"name"`
+	Description     pgtype.Text        `json:"description"`
+	RoleIds         []string           `json:"role_ids"`
+	BusinessUnitIds []pgtype.UUID      `json:"business_unit_ids"`
+	EndsAt          pgtype.Timestamptz `json:"ends_at"`
+	Status          AccessReviewStatus `json:"status"`
+	CreatedBy       pgtype.UUID        `json:"created_by"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	TenantID        pgtype.UUID        `json:"tenant_id"`
+}
+
+type AccessReviewItem struct {
+	ID               pgtype.UUID          `json:"id"`
+	CampaignID       pgtype.UUID          `json:"campaign_id"`
+	RoleAssignmentID pgtype.UUID          `json:"role_assignment_id"`
+	AssigneeID       pgtype.UUID          `json:"assignee_id"`
+	ReviewerID       pgtype.UUID          `json:"reviewer_id"`
+	RoleID           string               `json:"role_id"`
+	PermissionID     string               `json:"permission_id"`
+	BusinessUnitID   pgtype.UUID          `json:"business_unit_id"`
+	Decision         AccessReviewDecision `json:"decision"`
+	AutoRevoked      bool                 `json:"auto_revoked"`
+	DecidedBy        pgtype.UUID          `json:"decided_by"`
+	DecidedAt        pgtype.Timestamptz   `json:"decided_at"`
+	Comment          pgtype.Text          `json:"comment"`
+	CreatedAt        pgtype.Timestamptz   `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz   `json:"updated_at"`
+}
+
+type ApiKey struct {
+	ID                 pgtype.UUID        `json:"id"`
+	ServicePrincipalID pgtype.UUID        `json:"service_principal_id"`
+	Name               string             `json:"name"`
+	KeyPrefix          string             `json:"key_prefix"`
+	KeyHash            string             `json:"key_hash"`
+	ExpiresAt          pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt         pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp         pgtype.Text        `json:"last_used_ip"`
+	RotatedFrom        pgtype.UUID        `json:"rotated_from"`
+	RevokedAt          pgtype.Timestamptz `json:"revoked_at"`
+	RevokedBy          pgtype.UUID        `json:"revoked_by"`
+	CreatedBy          pgtype.UUID        `json:"created_by"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
 type BusinessUnit struct {
 	ID         pgtype.UUID        `json:"id"`
 	DomainName string             `json:"domain_name"`
@@ -66,12 +374,54 @@ type BusinessUnit struct {
 }
 
 type Department struct {
-	ID        pgtype.UUID        `json:"id"`
-	Name      string             `json:"name"`
-	Status    NullStatusEnum     `json:"status"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	ID             pgtype.UUID        `json:"id"`
+	Name           string             `json:"name"`
+	Status         NullStatusEnum     `json:"status"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	BusinessUnitID pgtype.UUID        `json:"business_unit_id"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
+}
+
+type DirectoryRoleMapping struct {
+	ID             pgtype.UUID        `json:"id"`
+	ClaimType      DirectoryClaimType `json:"claim_type"`
+	ClaimValue     string             `json:"claim_value"`
+	RoleID         string             `json:"role_id"`
+	BusinessUnitID pgtype.UUID        `json:"business_unit_id"`
+	Description    pgtype.Text        `json:"description"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+}
+
+type DirectorySyncState struct {
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	Resource       string             `json:"resource"`
+	DeltaLink      pgtype.Text        `json:"delta_link"`
+	LastSyncedAt   pgtype.Timestamptz `json:"last_synced_at"`
+	LastFullSyncAt pgtype.Timestamptz `json:"last_full_sync_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ElevationRequest struct {
+	ID              pgtype.UUID            `json:"id"`
+	RequesterID     pgtype.UUID            `json:"requester_id"`
+	RoleID          string                 `json:"role_id"`
+	BusinessUnitID  pgtype.UUID            `json:"business_unit_id"`
+	Justification   string                 `json:"justification"`
+	DurationMinutes int32                  `json:"duration_minutes"`
+	Status          ElevationRequestStatus `json:"status"`
+	DecidedBy       pgtype.UUID            `json:"decided_by"`
+	DecidedAt       pgtype.Timestamptz     `json:"decided_at"`
+	DecisionComment pgtype.Text            `json:"decision_comment"`
+	ExpiresAt       pgtype.Timestamptz     `json:"expires_at"`
+	CreatedAt       pgtype.Timestamptz     `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz     `json:"updated_at"`
 }
 
 type FieldType struct {
@@ -83,6 +433,7 @@ type FieldType struct {
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	IsBuiltin        bool               `json:"is_builtin"`
 }
 
 type FormCategory struct {
@@ -121,19 +472,43 @@ type FormSection struct {
 	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type FormSubmission struct {
+	ID             pgtype.UUID         `json:"id"`
+	FormTemplateID pgtype.UUID         `json:"form_template_id"`
+	LineageID      pgtype.UUID         `json:"lineage_id"`
+	BusinessUnitID pgtype.UUID         `json:"business_unit_id"`
+	DepartmentID   pgtype.UUID         `json:"department_id"`
+	SubmittedBy    pgtype.UUID         `json:"submitted_by"`
+	State          FormSubmissionState `json:"state"`
+	Answers        []byte              `json:"answers"`
+	SubmittedAt    pgtype.Timestamptz  `json:"submitted_at"`
+	Status         NullStatusEnum      `json:"status"`
+	CreatedAt      pgtype.Timestamptz  `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz  `json:"updated_at"`
+	DeletedAt      pgtype.Timestamptz  `json:"deleted_at"`
+	TenantID       pgtype.UUID         `json:"tenant_id"`
+}
+
 type FormTemplate struct {
 	ID             pgtype.UUID        `json:"id"`
 	Name           string             `json:"name"`
 	Description    pgtype.Text        `json:"description"`
 	FormCategoryID pgtype.UUID        `json:"form_category_id"`
 	BusinessUnitID pgtype.UUID        `json:"business_unit_id"`
-	Version        pgtype.Int4        `json:"version"`
+	Version        int32              `json:"version"`
 	PublishedAt    pgtype.Timestamptz `json:"published_at"`
 	CreatedBy      pgtype.UUID        `json:"created_by"`
 	Status         NullStatusEnum     `json:"status"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	DepartmentID   pgtype.UUID        `json:"department_id"`
+	LineageID      pgtype.UUID        `json:"lineage_id"`
+	State          FormTemplateState  `json:"state"`
+	ApprovedBy     pgtype.UUID        `json:"approved_by"`
+	ApprovedAt     pgtype.Timestamptz `json:"approved_at"`
+	RetiredAt      pgtype.Timestamptz `json:"retired_at"`
+	TenantID       pgtype.UUID        `json:"tenant_id"`
 }
 
 type Permission struct {
@@ -157,20 +532,24 @@ type Role struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	ParentRoleID pgtype.Text        `json:"parent_role_id"`
+	TenantID     pgtype.UUID        `json:"tenant_id"`
 }
 
 type RoleAssignment struct {
-	ID                pgtype.UUID        `json:"id"`
-	RolePermissionsID pgtype.UUID        `json:"role_permissions_id"`
-	AssigneeID        pgtype.UUID        `json:"assignee_id"`
-	BusinessUnitID    pgtype.UUID        `json:"business_unit_id"`
-	DepartmentID      pgtype.UUID        `json:"department_id"`
-	AssignedBy        pgtype.UUID        `json:"assigned_by"`
-	AssignedAt        pgtype.Timestamptz `json:"assigned_at"`
-	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
-	Status            NullStatusEnum     `json:"status"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	ID                 pgtype.UUID        `json:"id"`
+	RolePermissionsID  pgtype.UUID        `json:"role_permissions_id"`
+	AssigneeID         pgtype.UUID        `json:"assignee_id"`
+	BusinessUnitID     pgtype.UUID        `json:"business_unit_id"`
+	DepartmentID       pgtype.UUID        `json:"department_id"`
+	AssignedBy         pgtype.UUID        `json:"assigned_by"`
+	AssignedAt         pgtype.Timestamptz `json:"assigned_at"`
+	ExpiresAt          pgtype.Timestamptz `json:"expires_at"`
+	Status             NullStatusEnum     `json:"status"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt          pgtype.Timestamptz `json:"deleted_at"`
+	DirectoryMappingID pgtype.UUID        `json:"directory_mapping_id"`
+	ElevationRequestID pgtype.UUID        `json:"elevation_request_id"`
 }
 
 type RolePermission struct {
@@ -184,6 +563,21 @@ type RolePermission struct {
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type ScimGroup struct {
+	ID          pgtype.UUID        `json:"id"`
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	DisplayName string             `json:"display_name"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ScimGroupMember struct {
+	GroupID   pgtype.UUID        `json:"group_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Scope struct {
 	ID          string             `json:"id"`
 	Name        string             `json:"name"`
@@ -194,6 +588,31 @@ type Scope struct {
 	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type ServicePrincipal struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	DisplayName string             `json:"display_name"`
+	Description pgtype.Text        `json:"description"`
+	AppID       pgtype.Text        `json:"app_id"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type SodConstraint struct {
+	ID          pgtype.UUID        `json:"id"`
+	Name        string             `json:"name"`
+	Description pgtype.Text        `json:"description"`
+	RoleAID     string             `json:"role_a_id"`
+	RoleBID     string             `json:"role_b_id"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	Status      NullStatusEnum     `json:"status"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+}
+
 type User struct {
 	ID              pgtype.UUID        `json:"id"`
 	AzureAdObjectID string             `json:"azure_ad_object_id"`
@@ -214,3 +633,38 @@ type User struct {
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
 }
+
+type UserProfileChange struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Field     string             `json:"field"`
+	OldValue  pgtype.Text        `json:"old_value"`
+	NewValue  pgtype.Text        `json:"new_value"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserSession struct {
+	ID                   pgtype.UUID        `json:"id"`
+	ObjectID             string             `json:"object_id"`
+	TenantID             string             `json:"tenant_id"`
+	AccessToken          []byte             `json:"access_token"`
+	AccessTokenExpiresAt pgtype.Timestamptz `json:"access_token_expires_at"`
+	RefreshToken         []byte             `json:"refresh_token"`
+	CsrfToken            string             `json:"csrf_token"`
+	IpAddress            pgtype.Text        `json:"ip_address"`
+	UserAgent            pgtype.Text        `json:"user_agent"`
+	ExpiresAt            pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt            pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserStatusChange struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Action      string             `json:"action"`
+	Reason      string             `json:"reason"`
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+	ChangedBy   pgtype.UUID        `json:"changed_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
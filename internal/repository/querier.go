@@ -11,67 +11,291 @@ import (
 )
 
 type Querier interface {
+	AddScimGroupMember(ctx context.Context, arg AddScimGroupMemberParams) error
+	ApproveElevationRequest(ctx context.Context, arg ApproveElevationRequestParams) (ElevationRequest, error)
+	AutoRevokeAccessReviewItems(ctx context.Context, campaignID pgtype.UUID) ([]AccessReviewItem, error)
+	CancelElevationRequest(ctx context.Context, arg CancelElevationRequestParams) (ElevationRequest, error)
 	CheckUserPermission(ctx context.Context, arg CheckUserPermissionParams) (bool, error)
+	CompleteAccessReviewCampaign(ctx context.Context, id pgtype.UUID) (AccessReviewCampaign, error)
+	// Run after CopyFormSections; fields follow their section by name.
+	CopyFormFields(ctx context.Context, arg CopyFormFieldsParams) error
+	CopyFormSections(ctx context.Context, arg CopyFormSectionsParams) error
+	CountAccessReviewItems(ctx context.Context, arg CountAccessReviewItemsParams) (int64, error)
+	CountDepartmentChildren(ctx context.Context, id pgtype.UUID) (int64, error)
+	// Counts the live role assignments scoped to a department or its descendants.
+	CountDepartmentSubtreeRoleAssignments(ctx context.Context, id pgtype.UUID) (int64, error)
+	CountElevationRequests(ctx context.Context, arg CountElevationRequestsParams) (int64, error)
+	CountFormFieldsByType(ctx context.Context, fieldType string) (int64, error)
+	CountFormSubmissions(ctx context.Context, arg CountFormSubmissionsParams) (int64, error)
+	CountScimGroups(ctx context.Context, arg CountScimGroupsParams) (int64, error)
+	CountScimUsers(ctx context.Context, arg CountScimUsersParams) (int64, error)
+	CountUserProfileChanges(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUserRoleAssignments(ctx context.Context, arg CountUserRoleAssignmentsParams) (int64, error)
+	CountUserStatusChanges(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUsersInDepartment(ctx context.Context, arg CountUsersInDepartmentParams) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	CreateAccessReviewCampaign(ctx context.Context, arg CreateAccessReviewCampaignParams) (AccessReviewCampaign, error)
+	// One item per active assignment in the campaign's scope, reviewed by the
+	// assignee's manager or, for users without a manager, the campaign creator.
+	// Nobody may decide the review of their own assignment, so an item of the
+	// creator's without a manager stays pending and is revoked at ends_at.
+	// Directory managed assignments are recertified in Entra and are skipped, and
+	// only users of the campaign's tenant are reviewed.
+	CreateAccessReviewItems(ctx context.Context, id pgtype.UUID) (int64, error)
 	CreateBusinessUnit(ctx context.Context, arg CreateBusinessUnitParams) (BusinessUnit, error)
 	CreateDepartment(ctx context.Context, arg CreateDepartmentParams) (Department, error)
+	CreateDirectoryRoleAssignment(ctx context.Context, arg CreateDirectoryRoleAssignmentParams) (RoleAssignment, error)
+	CreateDirectoryRoleMapping(ctx context.Context, arg CreateDirectoryRoleMappingParams) (DirectoryRoleMapping, error)
+	CreateElevationRequest(ctx context.Context, arg CreateElevationRequestParams) (ElevationRequest, error)
+	// Assigns every active role permission of the approved request's role until the
+	// request expires. Permissions the requester already holds actively are left
+	// untouched, so a standing assignment is never shortened by an elevation.
+	CreateElevationRoleAssignments(ctx context.Context, id pgtype.UUID) ([]RoleAssignment, error)
 	CreateFieldType(ctx context.Context, arg CreateFieldTypeParams) (FieldType, error)
 	CreateFormCategory(ctx context.Context, arg CreateFormCategoryParams) (FormCategory, error)
 	CreateFormField(ctx context.Context, arg CreateFormFieldParams) (FormField, error)
 	CreateFormSection(ctx context.Context, arg CreateFormSectionParams) (FormSection, error)
+	CreateFormSubmission(ctx context.Context, arg CreateFormSubmissionParams) (FormSubmission, error)
+	// The first version of a form starts its own lineage in the creator's tenant.
 	CreateFormTemplate(ctx context.Context, arg CreateFormTemplateParams) (FormTemplate, error)
+	// Starts the next draft version of a form from one of its versions.
+	CreateFormTemplateVersion(ctx context.Context, arg CreateFormTemplateVersionParams) (FormTemplate, error)
 	CreatePermission(ctx context.Context, arg CreatePermissionParams) (Permission, error)
+	// Roles created through the API belong to the creator's tenant.
 	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
 	CreateRoleAssignment(ctx context.Context, arg CreateRoleAssignmentParams) (RoleAssignment, error)
 	CreateRolePermission(ctx context.Context, arg CreateRolePermissionParams) (RolePermission, error)
+	CreateScimGroup(ctx context.Context, arg CreateScimGroupParams) (ScimGroup, error)
 	CreateScope(ctx context.Context, arg CreateScopeParams) (Scope, error)
+	CreateServicePrincipal(ctx context.Context, arg CreateServicePrincipalParams) (ServicePrincipal, error)
+	CreateSodConstraint(ctx context.Context, arg CreateSodConstraintParams) (SodConstraint, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteFieldType(ctx context.Context, id pgtype.UUID) error
+	CreateUserProfileChange(ctx context.Context, arg CreateUserProfileChangeParams) error
+	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
+	CreateUserStatusChange(ctx context.Context, arg CreateUserStatusChangeParams) (UserStatusChange, error)
+	DeactivateDirectoryUser(ctx context.Context, id pgtype.UUID) (int64, error)
+	// Removes the backing users row from authentication and drops its role
+	// assignments once the service principal is deleted.
+	DeactivateServicePrincipalUser(ctx context.Context, userID pgtype.UUID) error
+	DecideAccessReviewItem(ctx context.Context, arg DecideAccessReviewItemParams) (AccessReviewItem, error)
+	DeleteDepartment(ctx context.Context, id pgtype.UUID) (Department, error)
+	DeleteDirectoryRoleMapping(ctx context.Context, arg DeleteDirectoryRoleMappingParams) (DirectoryRoleMapping, error)
+	DeleteFieldType(ctx context.Context, id pgtype.UUID) (int64, error)
 	DeleteFormCategory(ctx context.Context, id pgtype.UUID) error
-	DeleteFormField(ctx context.Context, id pgtype.UUID) error
+	DeleteFormField(ctx context.Context, arg DeleteFormFieldParams) (int64, error)
 	DeleteFormSection(ctx context.Context, id pgtype.UUID) error
-	DeleteFormTemplate(ctx context.Context, id pgtype.UUID) error
+	DeleteFormSubmissionDraft(ctx context.Context, id pgtype.UUID) (int64, error)
+	// Published and retired versions stay for the submissions made against them.
+	DeleteFormTemplate(ctx context.Context, arg DeleteFormTemplateParams) (int64, error)
 	DeletePermission(ctx context.Context, id string) error
+	DeleteScimGroup(ctx context.Context, arg DeleteScimGroupParams) (ScimGroup, error)
+	DeleteScimUser(ctx context.Context, arg DeleteScimUserParams) (User, error)
+	DeleteServicePrincipal(ctx context.Context, arg DeleteServicePrincipalParams) (ServicePrincipal, error)
+	DeleteSodConstraint(ctx context.Context, id pgtype.UUID) (SodConstraint, error)
+	// Removes sessions that ended more than a day ago.
+	DeleteStaleUserSessions(ctx context.Context) (int64, error)
+	ExpireElevationRequests(ctx context.Context) ([]ElevationRequest, error)
+	ExpireRoleAssignments(ctx context.Context) ([]RoleAssignment, error)
+	GetAPIKeyByID(ctx context.Context, arg GetAPIKeyByIDParams) (ApiKey, error)
+	GetAccessReviewCampaignByID(ctx context.Context, arg GetAccessReviewCampaignByIDParams) (AccessReviewCampaign, error)
+	GetAccessReviewCampaignProgress(ctx context.Context, campaignID pgtype.UUID) (GetAccessReviewCampaignProgressRow, error)
+	GetAccessReviewItemByID(ctx context.Context, arg GetAccessReviewItemByIDParams) (AccessReviewItem, error)
+	// Resolves an API key to the identity of its service principal. Revoked and
+	// expired keys, and keys of deleted principals, are not returned.
+	GetActiveAPIKeyByPrefix(ctx context.Context, keyPrefix string) (GetActiveAPIKeyByPrefixRow, error)
 	GetActivePermissions(ctx context.Context) ([]Permission, error)
+	GetActiveUserSession(ctx context.Context, id pgtype.UUID) (UserSession, error)
 	GetAllBusinessUnitsInTenant(ctx context.Context, tenantID string) ([]BusinessUnit, error)
 	GetAllPermissions(ctx context.Context) ([]Permission, error)
-	GetAllRoles(ctx context.Context) ([]Role, error)
+	GetAllRoles(ctx context.Context, tenantID pgtype.UUID) ([]Role, error)
 	GetAllScopes(ctx context.Context) ([]Scope, error)
-	GetAllUsersInDepartment(ctx context.Context, departmentID pgtype.UUID) ([]User, error)
-	GetBusinessUnitByDomainName(ctx context.Context, domainName string) (BusinessUnit, error)
-	GetBusinessUnitByID(ctx context.Context, id pgtype.UUID) (BusinessUnit, error)
-	GetDepartmentByID(ctx context.Context, id pgtype.UUID) (Department, error)
-	GetDepartmentByName(ctx context.Context, name string) (Department, error)
+	GetAllUsersInDepartment(ctx context.Context, arg GetAllUsersInDepartmentParams) ([]User, error)
+	GetBusinessUnitByDomainName(ctx context.Context, arg GetBusinessUnitByDomainNameParams) (BusinessUnit, error)
+	GetBusinessUnitByID(ctx context.Context, arg GetBusinessUnitByIDParams) (BusinessUnit, error)
+	// Departments without a business unit predate business unit scoping and are
+	// visible to every tenant until moved into one.
+	GetDepartmentByID(ctx context.Context, arg GetDepartmentByIDParams) (Department, error)
+	GetDepartmentByName(ctx context.Context, arg GetDepartmentByNameParams) (Department, error)
+	// GetDirectoryRoleGrants expands the mappings of the token's tenant matched by
+	// its app roles and groups, or by the user's SCIM groups, into the active role
+	// permissions they grant.
+	GetDirectoryRoleGrants(ctx context.Context, arg GetDirectoryRoleGrantsParams) ([]GetDirectoryRoleGrantsRow, error)
+	GetDirectoryRoleMappingByID(ctx context.Context, arg GetDirectoryRoleMappingByIDParams) (DirectoryRoleMapping, error)
+	GetDirectorySyncState(ctx context.Context, arg GetDirectorySyncStateParams) (DirectorySyncState, error)
+	GetDueAccessReviewCampaigns(ctx context.Context) ([]AccessReviewCampaign, error)
 	GetFieldTypeByID(ctx context.Context, id pgtype.UUID) (FieldType, error)
+	GetFieldTypeByName(ctx context.Context, typeName string) (FieldType, error)
 	GetFieldTypes(ctx context.Context) ([]FieldType, error)
+	// Resolves the types of existing fields, including types deactivated since.
+	GetFieldTypesByNames(ctx context.Context, typeNames []string) ([]FieldType, error)
 	GetFormCategories(ctx context.Context) ([]FormCategory, error)
 	GetFormCategoryByID(ctx context.Context, id pgtype.UUID) (FormCategory, error)
-	GetFormFieldByID(ctx context.Context, id pgtype.UUID) (FormField, error)
+	GetFormFieldByID(ctx context.Context, arg GetFormFieldByIDParams) (FormField, error)
 	GetFormFields(ctx context.Context, formTemplateID pgtype.UUID) ([]FormField, error)
 	GetFormFieldsBySection(ctx context.Context, arg GetFormFieldsBySectionParams) ([]FormField, error)
 	GetFormSectionByID(ctx context.Context, id pgtype.UUID) (FormSection, error)
 	GetFormSections(ctx context.Context, formTemplateID pgtype.UUID) ([]FormSection, error)
-	GetFormTemplateByID(ctx context.Context, id pgtype.UUID) (FormTemplate, error)
-	GetFormTemplates(ctx context.Context) ([]FormTemplate, error)
-	GetFormTemplatesByCategory(ctx context.Context, formCategoryID pgtype.UUID) ([]FormTemplate, error)
+	GetFormSubmissionByID(ctx context.Context, arg GetFormSubmissionByIDParams) (FormSubmission, error)
+	GetFormTemplateByID(ctx context.Context, arg GetFormTemplateByIDParams) (FormTemplate, error)
+	GetFormTemplateVersion(ctx context.Context, arg GetFormTemplateVersionParams) (FormTemplate, error)
+	GetFormTemplateVersions(ctx context.Context, lineageID pgtype.UUID) ([]FormTemplate, error)
+	GetFormTemplates(ctx context.Context, arg GetFormTemplatesParams) ([]FormTemplate, error)
+	GetFormTemplatesByCategory(ctx context.Context, arg GetFormTemplatesByCategoryParams) ([]FormTemplate, error)
+	// Walks manager_id upwards from a user: depth 1 is the direct manager. The
+	// path guards against cycles in manager links.
+	GetManagementChain(ctx context.Context, arg GetManagementChainParams) ([]GetManagementChainRow, error)
 	GetPermissionByID(ctx context.Context, id string) (Permission, error)
 	GetPermissionsByResource(ctx context.Context, resource string) ([]Permission, error)
 	GetPermissionsByResourceAndAction(ctx context.Context, arg GetPermissionsByResourceAndActionParams) (Permission, error)
 	GetPermissionsByRole(ctx context.Context, roleID string) ([]GetPermissionsByRoleRow, error)
-	GetRoleByID(ctx context.Context, id string) (Role, error)
+	GetPublishedFormTemplate(ctx context.Context, lineageID pgtype.UUID) (FormTemplate, error)
+	// Permissions granted to a role directly (depth 0) or inherited from its ancestors.
+	GetResolvedRolePermissions(ctx context.Context, roleID string) ([]GetResolvedRolePermissionsRow, error)
+	GetRoleAncestors(ctx context.Context, id string) ([]GetRoleAncestorsRow, error)
+	GetRoleAssignmentByIDForUpdate(ctx context.Context, arg GetRoleAssignmentByIDForUpdateParams) (RoleAssignment, error)
+	GetRoleByID(ctx context.Context, arg GetRoleByIDParams) (Role, error)
+	// Only assignments of users in the given tenant are found.
+	GetRoleAssignmentByID(ctx context.Context, arg GetRoleAssignmentByIDParams) (RoleAssignment, error)
 	GetRolePermissionByID(ctx context.Context, id pgtype.UUID) (GetRolePermissionByIDRow, error)
+	GetScimGroup(ctx context.Context, arg GetScimGroupParams) (ScimGroup, error)
+	// Service principals are backed by users rows but are not provisioned over
+	// SCIM, so they are hidden from it.
+	GetScimUser(ctx context.Context, arg GetScimUserParams) (User, error)
 	GetScopeByID(ctx context.Context, id string) (Scope, error)
-	GetSystemRoles(ctx context.Context) ([]Role, error)
-	GetUserByEmail(ctx context.Context, mail string) (User, error)
-	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
-	GetUserRoleAssignments(ctx context.Context, assigneeID pgtype.UUID) ([]GetUserRoleAssignmentsRow, error)
-	PublishFormTemplate(ctx context.Context, id pgtype.UUID) (FormTemplate, error)
+	// Active constraints that assigning role_id to the assignee would violate, given
+	// the roles the assignee already holds. Both sides include inherited roles.
+	GetSodConflicts(ctx context.Context, arg GetSodConflictsParams) ([]SodConstraint, error)
+	GetSodConstraintByID(ctx context.Context, id pgtype.UUID) (SodConstraint, error)
+	// Users whose current assignments hold both roles of an active constraint.
+	GetSodViolations(ctx context.Context) ([]GetSodViolationsRow, error)
+	GetSystemRoles(ctx context.Context, tenantID pgtype.UUID) ([]Role, error)
+	// Resolves the account state checked by authentication. Users that are not
+	// provisioned yet have no row; deleted users are returned so they can be
+	// blocked, unless a live row exists for the same object ID.
+	GetUserAccountStatus(ctx context.Context, arg GetUserAccountStatusParams) (GetUserAccountStatusRow, error)
+	GetUserByAzureADObjectID(ctx context.Context, azureAdObjectID string) (User, error)
+	GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error)
+	GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error)
+	GetUserByObjectID(ctx context.Context, arg GetUserByObjectIDParams) (User, error)
+	// GetUserDirectorySyncAssignments returns the user's live assignments, with the
+	// claim type of the mapping that owns them when they are directory managed.
+	GetUserDirectorySyncAssignments(ctx context.Context, assigneeID pgtype.UUID) ([]GetUserDirectorySyncAssignmentsRow, error)
+	GetUserEffectivePermissions(ctx context.Context, assigneeID pgtype.UUID) ([]GetUserEffectivePermissionsRow, error)
+	// A grant carries the assignee's tenant, which bounds a tenant-wide scope.
+	GetUserPermissionGrants(ctx context.Context, arg GetUserPermissionGrantsParams) ([]GetUserPermissionGrantsRow, error)
+	GetUserRoleAssignments(ctx context.Context, arg GetUserRoleAssignmentsParams) ([]GetUserRoleAssignmentsRow, error)
+	ListAPIKeys(ctx context.Context, arg ListAPIKeysParams) ([]ApiKey, error)
+	ListAccessReviewCampaigns(ctx context.Context, tenantID pgtype.UUID) ([]AccessReviewCampaign, error)
+	// Items of one campaign, or the pending items of a reviewer across active
+	// campaigns, of the caller's tenant.
+	ListAccessReviewItems(ctx context.Context, arg ListAccessReviewItemsParams) ([]ListAccessReviewItemsRow, error)
+	// Walks parent_id downwards from a department: depth 0 is the department
+	// itself. The path guards against cycles and orders the result depth first.
+	ListDepartmentSubtree(ctx context.Context, id pgtype.UUID) ([]ListDepartmentSubtreeRow, error)
+	ListDepartments(ctx context.Context, arg ListDepartmentsParams) ([]Department, error)
+	ListDirectoryRoleMappings(ctx context.Context, tenantID pgtype.UUID) ([]DirectoryRoleMapping, error)
+	ListElevationRequests(ctx context.Context, arg ListElevationRequestsParams) ([]ListElevationRequestsRow, error)
+	// Drafts are only listed for their submitter.
+	ListFormSubmissions(ctx context.Context, arg ListFormSubmissionsParams) ([]FormSubmission, error)
+	// Builds the org chart of a department and/or business unit. Roots are the
+	// members whose manager is not a member; depth 1 are the roots.
+	ListOrgChartUsers(ctx context.Context, arg ListOrgChartUsersParams) ([]ListOrgChartUsersRow, error)
+	// Walks manager_id downwards from a manager: depth 1 are the direct reports.
+	ListReports(ctx context.Context, arg ListReportsParams) ([]ListReportsRow, error)
+	ListScimGroupMembers(ctx context.Context, groupID pgtype.UUID) ([]ListScimGroupMembersRow, error)
+	ListScimGroups(ctx context.Context, arg ListScimGroupsParams) ([]ScimGroup, error)
+	ListScimUsers(ctx context.Context, arg ListScimUsersParams) ([]User, error)
+	ListServicePrincipals(ctx context.Context, arg ListServicePrincipalsParams) ([]ListServicePrincipalsRow, error)
+	ListSodConstraints(ctx context.Context) ([]SodConstraint, error)
+	ListUserProfileChanges(ctx context.Context, arg ListUserProfileChangesParams) ([]UserProfileChange, error)
+	ListUserScimGroups(ctx context.Context, userID pgtype.UUID) ([]ScimGroup, error)
+	ListUserStatusChanges(ctx context.Context, arg ListUserStatusChangesParams) ([]ListUserStatusChangesRow, error)
+	// Serializes separation-of-duties checks for one assignee until the end of the
+	// transaction, so concurrent grants cannot each miss the other.
+	LockSodAssignee(ctx context.Context, id pgtype.UUID) error
+	LockUser(ctx context.Context, arg LockUserParams) (User, error)
+	MoveDepartment(ctx context.Context, arg MoveDepartmentParams) (Department, error)
+	PublishFormTemplate(ctx context.Context, arg PublishFormTemplateParams) (FormTemplate, error)
+	// Only reactivates users deactivated by directory sync; users suspended by an
+	// administrator stay suspended.
+	ReactivateDirectoryUser(ctx context.Context, id pgtype.UUID) (int64, error)
+	RejectElevationRequest(ctx context.Context, arg RejectElevationRequestParams) (ElevationRequest, error)
+	RejectFormTemplate(ctx context.Context, id pgtype.UUID) (FormTemplate, error)
+	RemoveScimGroupMember(ctx context.Context, arg RemoveScimGroupMemberParams) error
+	RemoveScimGroupMembers(ctx context.Context, groupID pgtype.UUID) error
+	RemoveUserScimGroupMemberships(ctx context.Context, userID pgtype.UUID) error
+	ReplaceScimUser(ctx context.Context, arg ReplaceScimUserParams) (User, error)
+	RetireFormTemplate(ctx context.Context, id pgtype.UUID) (FormTemplate, error)
+	// Retires the published version of a lineage ahead of publishing a newer one.
+	RetirePublishedFormTemplates(ctx context.Context, lineageID pgtype.UUID) error
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (ApiKey, error)
+	RevokeDirectoryMappingAssignments(ctx context.Context, directoryMappingID pgtype.UUID) (int64, error)
+	RevokeElevationRoleAssignments(ctx context.Context, elevationRequestID pgtype.UUID) (int64, error)
+	RevokeRoleAssignment(ctx context.Context, id pgtype.UUID) (RoleAssignment, error)
+	RevokeServicePrincipalAPIKeys(ctx context.Context, arg RevokeServicePrincipalAPIKeysParams) (int64, error)
+	RevokeUserSession(ctx context.Context, id pgtype.UUID) error
+	SaveDirectorySyncState(ctx context.Context, arg SaveDirectorySyncStateParams) error
+	ScimGroupNameExists(ctx context.Context, arg ScimGroupNameExistsParams) (bool, error)
+	// Reports whether another live user of the tenant already has the user name,
+	// which SCIM treats as unique and case-insensitive.
+	ScimUserNameExists(ctx context.Context, arg ScimUserNameExistsParams) (bool, error)
+	// Orders by creation time.
+	SearchUsersByCreatedAt(ctx context.Context, arg SearchUsersByCreatedAtParams) ([]User, error)
+	// Orders by creation time, descending.
+	SearchUsersByCreatedAtDesc(ctx context.Context, arg SearchUsersByCreatedAtDescParams) ([]User, error)
+	// Orders by display name.
+	SearchUsersByDisplayName(ctx context.Context, arg SearchUsersByDisplayNameParams) ([]User, error)
+	// Orders by display name, descending.
+	SearchUsersByDisplayNameDesc(ctx context.Context, arg SearchUsersByDisplayNameDescParams) ([]User, error)
+	// Orders by last login; users who never logged in come first.
+	SearchUsersByLastLogin(ctx context.Context, arg SearchUsersByLastLoginParams) ([]User, error)
+	// Orders by last login, descending; users who never logged in come last.
+	SearchUsersByLastLoginDesc(ctx context.Context, arg SearchUsersByLastLoginDescParams) ([]User, error)
+	// Orders by mail.
+	SearchUsersByMail(ctx context.Context, arg SearchUsersByMailParams) ([]User, error)
+	// Orders by mail, descending.
+	SearchUsersByMailDesc(ctx context.Context, arg SearchUsersByMailDescParams) ([]User, error)
+	// Moves the descendants of a department into its business unit after the
+	// department itself moved.
+	SetDepartmentSubtreeBusinessUnit(ctx context.Context, arg SetDepartmentSubtreeBusinessUnitParams) error
+	// Moves the users of a department and its descendants into the business unit
+	// the department moved to.
+	SetDepartmentSubtreeUsersBusinessUnit(ctx context.Context, arg SetDepartmentSubtreeUsersBusinessUnitParams) (int64, error)
+	// Shared roles have no tenant and are left alone.
+	SetRoleParent(ctx context.Context, arg SetRoleParentParams) (Role, error)
+	// Links a user to their manager by object IDs; a NULL or unknown manager
+	// object ID clears the link.
+	SetUserManagerByObjectID(ctx context.Context, arg SetUserManagerByObjectIDParams) (int64, error)
+	// Lets a rotated key keep working for a grace period; never extends its expiry.
+	ShortenAPIKeyExpiry(ctx context.Context, arg ShortenAPIKeyExpiryParams) error
+	SubmitFormTemplateForReview(ctx context.Context, id pgtype.UUID) (FormTemplate, error)
+	SuspendUser(ctx context.Context, arg SuspendUserParams) (User, error)
+	// Records key usage at most once a minute to keep authentication read-mostly.
+	TouchAPIKeyLastUsed(ctx context.Context, arg TouchAPIKeyLastUsedParams) error
+	UnlockUser(ctx context.Context, arg UnlockUserParams) (User, error)
+	UpdateDepartment(ctx context.Context, arg UpdateDepartmentParams) (Department, error)
+	// Overwrites the profile attributes mastered by the directory. Status and
+	// manager are changed separately.
+	UpdateDirectoryUser(ctx context.Context, arg UpdateDirectoryUserParams) (User, error)
+	// The type name is what form fields reference, so it never changes. Built-in
+	// types are shared by every tenant and cannot be changed.
 	UpdateFieldType(ctx context.Context, arg UpdateFieldTypeParams) (FieldType, error)
 	UpdateFormCategory(ctx context.Context, arg UpdateFormCategoryParams) (FormCategory, error)
 	UpdateFormField(ctx context.Context, arg UpdateFormFieldParams) (FormField, error)
 	UpdateFormSection(ctx context.Context, arg UpdateFormSectionParams) (FormSection, error)
+	// Replaces the answers of a draft; state 'submitted' submits it.
+	UpdateFormSubmissionDraft(ctx context.Context, arg UpdateFormSubmissionDraftParams) (FormSubmission, error)
 	UpdateFormTemplate(ctx context.Context, arg UpdateFormTemplateParams) (FormTemplate, error)
 	UpdatePermission(ctx context.Context, arg UpdatePermissionParams) (Permission, error)
-	UpdateUserLastLogin(ctx context.Context, mail string) error
+	UpdateRoleAssignment(ctx context.Context, arg UpdateRoleAssignmentParams) (RoleAssignment, error)
+	UpdateScimGroup(ctx context.Context, arg UpdateScimGroupParams) (ScimGroup, error)
+	UpdateUserLastLogin(ctx context.Context, arg UpdateUserLastLoginParams) error
+	// Applies a profile refreshed from the identity provider. Status is left
+	// alone so suspensions survive the next login.
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
+	// Stores the tokens obtained with the refresh token. Entra may or may not
+	// rotate the refresh token, so a NULL keeps the current one.
+	UpdateUserSessionTokens(ctx context.Context, arg UpdateUserSessionTokensParams) error
 }
 
 var _ Querier = (*Queries)(nil)
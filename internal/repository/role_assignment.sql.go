@@ -13,17 +13,9 @@ import (
 
 const checkUserPermission = `-- name: CheckUserPermission :one
 SELECT COUNT(*) > 0 as hasPermission
-FROM role_assignment ra
-JOIN role_permissions rp ON ra.role_permissions_id = rp.id
-JOIN permissions p ON rp.permission_id = p.id
-WHERE ra.assignee_id = $1 
-    AND p.resource = $2 
-    AND p.action = $3
-    AND ra.status = 'active'
-    AND ra.deleted_at IS NULL
-    AND rp.status = 'active'
-    AND rp.deleted_at IS NULL
-    AND (ra.expires_at IS NULL OR ra.expires_at > CURRENT_TIMESTAMP)
+FROM user_permission_grants($1) g
+WHERE g.resource = $2
+    AND g.action = $3
 `
 
 type CheckUserPermissionParams struct {
@@ -39,6 +31,24 @@ func (q *Queries) CheckUserPermission(ctx context.Context, arg CheckUserPermissi
 	return haspermission, err
 }
 
+const countUserRoleAssignments = `-- name: CountUserRoleAssignments :one
+SELECT COUNT(*) FROM role_assignment ra
+JOIN users u ON ra.assignee_id = u.id
+WHERE ra.assignee_id = $1 AND u.home_tenant_id = $2 AND ra.deleted_at IS NULL
+`
+
+type CountUserRoleAssignmentsParams struct {
+	AssigneeID   pgtype.UUID `json:"assignee_id"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+}
+
+func (q *Queries) CountUserRoleAssignments(ctx context.Context, arg CountUserRoleAssignmentsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserRoleAssignments, arg.AssigneeID, arg.HomeTenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createRoleAssignment = `-- name: CreateRoleAssignment :one
 INSERT INTO role_assignment (
     role_permissions_id,
@@ -51,7 +61,19 @@ INSERT INTO role_assignment (
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at
+ON CONFLICT ON CONSTRAINT role_assignment_grant_key DO UPDATE
+SET
+    department_id = EXCLUDED.department_id,
+    assigned_by = EXCLUDED.assigned_by,
+    assigned_at = CURRENT_TIMESTAMP,
+    expires_at = EXCLUDED.expires_at,
+    status = EXCLUDED.status,
+    directory_mapping_id = NULL,
+    elevation_request_id = NULL,
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = NULL
+WHERE role_assignment.deleted_at IS NOT NULL OR role_assignment.status <> 'active'
+RETURNING id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at, directory_mapping_id, elevation_request_id
 `
 
 type CreateRoleAssignmentParams struct {
@@ -87,10 +109,255 @@ func (q *Queries) CreateRoleAssignment(ctx context.Context, arg CreateRoleAssign
 		&i.Status,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DirectoryMappingID,
+		&i.ElevationRequestID,
+	)
+	return i, err
+}
+
+const expireRoleAssignments = `-- name: ExpireRoleAssignments :many
+UPDATE role_assignment
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP
+WHERE status = 'active'
+    AND deleted_at IS NULL
+    AND expires_at IS NOT NULL
+    AND expires_at <= CURRENT_TIMESTAMP
+RETURNING id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at, directory_mapping_id, elevation_request_id
+`
+
+func (q *Queries) ExpireRoleAssignments(ctx context.Context) ([]RoleAssignment, error) {
+	rows, err := q.db.Query(ctx, expireRoleAssignments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RoleAssignment
+	for rows.Next() {
+		var i RoleAssignment
+		if err := rows.Scan(
+			&i.ID,
+			&i.RolePermissionsID,
+			&i.AssigneeID,
+			&i.BusinessUnitID,
+			&i.DepartmentID,
+			&i.AssignedBy,
+			&i.AssignedAt,
+			&i.ExpiresAt,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.DirectoryMappingID,
+			&i.ElevationRequestID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoleAssignmentByID = `-- name: GetRoleAssignmentByID :one
+SELECT id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at, directory_mapping_id, elevation_request_id FROM role_assignment
+WHERE id = $1 AND deleted_at IS NULL
+    AND assignee_id IN (SELECT u.id FROM users u WHERE u.home_tenant_id = $2)
+`
+
+type GetRoleAssignmentByIDParams struct {
+	ID           pgtype.UUID `json:"id"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+}
+
+// Only assignments of users in the given tenant are found.
+func (q *Queries) GetRoleAssignmentByID(ctx context.Context, arg GetRoleAssignmentByIDParams) (RoleAssignment, error) {
+	row := q.db.QueryRow(ctx, getRoleAssignmentByID, arg.ID, arg.HomeTenantID)
+	var i RoleAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.RolePermissionsID,
+		&i.AssigneeID,
+		&i.BusinessUnitID,
+		&i.DepartmentID,
+		&i.AssignedBy,
+		&i.AssignedAt,
+		&i.ExpiresAt,
+		&i.Status,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DirectoryMappingID,
+		&i.ElevationRequestID,
+	)
+	return i, err
+}
+
+const getRoleAssignmentByIDForUpdate = `-- name: GetRoleAssignmentByIDForUpdate :one
+SELECT id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at, directory_mapping_id, elevation_request_id FROM role_assignment
+WHERE id = $1 AND deleted_at IS NULL
+    AND assignee_id IN (SELECT u.id FROM users u WHERE u.home_tenant_id = $2)
+FOR UPDATE OF role_assignment
+`
+
+type GetRoleAssignmentByIDForUpdateParams struct {
+	ID           pgtype.UUID `json:"id"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+}
+
+func (q *Queries) GetRoleAssignmentByIDForUpdate(ctx context.Context, arg GetRoleAssignmentByIDForUpdateParams) (RoleAssignment, error) {
+	row := q.db.QueryRow(ctx, getRoleAssignmentByIDForUpdate, arg.ID, arg.HomeTenantID)
+	var i RoleAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.RolePermissionsID,
+		&i.AssigneeID,
+		&i.BusinessUnitID,
+		&i.DepartmentID,
+		&i.AssignedBy,
+		&i.AssignedAt,
+		&i.ExpiresAt,
+		&i.Status,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DirectoryMappingID,
+		&i.ElevationRequestID,
 	)
 	return i, err
 }
 
+const getUserEffectivePermissions = `-- name: GetUserEffectivePermissions :many
+SELECT
+    g.role_assignment_id,
+    g.role_id,
+    g.role_name,
+    g.resource,
+    g.action,
+    g.scope_id,
+    g.business_unit_id,
+    g.department_id,
+    g.expires_at,
+    g.source_role_id,
+    u.home_tenant_id AS tenant_id
+FROM user_permission_grants($1) g
+JOIN users u ON u.id = $1
+ORDER BY g.resource, g.action, g.depth, g.assigned_at
+`
+
+type GetUserEffectivePermissionsRow struct {
+	RoleAssignmentID pgtype.UUID        `json:"role_assignment_id"`
+	RoleID           string             `json:"role_id"`
+	RoleName         string             `json:"role_name"`
+	Resource         string             `json:"resource"`
+	Action           string             `json:"action"`
+	ScopeID          pgtype.Text        `json:"scope_id"`
+	BusinessUnitID   pgtype.UUID        `json:"business_unit_id"`
+	DepartmentID     pgtype.UUID        `json:"department_id"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	SourceRoleID     string             `json:"source_role_id"`
+	TenantID         pgtype.UUID        `json:"tenant_id"`
+}
+
+func (q *Queries) GetUserEffectivePermissions(ctx context.Context, assigneeID pgtype.UUID) ([]GetUserEffectivePermissionsRow, error) {
+	rows, err := q.db.Query(ctx, getUserEffectivePermissions, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserEffectivePermissionsRow
+	for rows.Next() {
+		var i GetUserEffectivePermissionsRow
+		if err := rows.Scan(
+			&i.RoleAssignmentID,
+			&i.RoleID,
+			&i.RoleName,
+			&i.Resource,
+			&i.Action,
+			&i.ScopeID,
+			&i.BusinessUnitID,
+			&i.DepartmentID,
+			&i.ExpiresAt,
+			&i.SourceRoleID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserPermissionGrants = `-- name: GetUserPermissionGrants :many
+SELECT
+    g.role_assignment_id,
+    g.role_id,
+    g.role_name,
+    g.scope_id,
+    g.business_unit_id,
+    g.department_id,
+    g.expires_at,
+    g.source_role_id,
+    u.home_tenant_id AS tenant_id
+FROM user_permission_grants($1) g
+JOIN users u ON u.id = $1
+WHERE g.resource = $2
+    AND g.action = $3
+ORDER BY g.depth, g.assigned_at
+`
+
+type GetUserPermissionGrantsParams struct {
+	AssigneeID pgtype.UUID `json:"assignee_id"`
+	Resource   string      `json:"resource"`
+	Action     string      `json:"action"`
+}
+
+type GetUserPermissionGrantsRow struct {
+	RoleAssignmentID pgtype.UUID        `json:"role_assignment_id"`
+	RoleID           string             `json:"role_id"`
+	RoleName         string             `json:"role_name"`
+	ScopeID          pgtype.Text        `json:"scope_id"`
+	BusinessUnitID   pgtype.UUID        `json:"business_unit_id"`
+	DepartmentID     pgtype.UUID        `json:"department_id"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	SourceRoleID     string             `json:"source_role_id"`
+	TenantID         pgtype.UUID        `json:"tenant_id"`
+}
+
+// A grant carries the assignee's tenant, which bounds a tenant-wide scope.
+func (q *Queries) GetUserPermissionGrants(ctx context.Context, arg GetUserPermissionGrantsParams) ([]GetUserPermissionGrantsRow, error) {
+	rows, err := q.db.Query(ctx, getUserPermissionGrants, arg.AssigneeID, arg.Resource, arg.Action)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserPermissionGrantsRow
+	for rows.Next() {
+		var i GetUserPermissionGrantsRow
+		if err := rows.Scan(
+			&i.RoleAssignmentID,
+			&i.RoleID,
+			&i.RoleName,
+			&i.ScopeID,
+			&i.BusinessUnitID,
+			&i.DepartmentID,
+			&i.ExpiresAt,
+			&i.SourceRoleID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserRoleAssignments = `-- name: GetUserRoleAssignments :many
 SELECT 
     ra.id,
@@ -104,6 +371,8 @@ SELECT
     ra.status,
     ra.updated_at,
     ra.deleted_at,
+    ra.directory_mapping_id,
+    ra.elevation_request_id,
     r.name as role_name,
     p.name as permission_name,
     p.resource,
@@ -118,33 +387,49 @@ JOIN permissions p ON rp.permission_id = p.id
 LEFT JOIN scopes s ON rp.scope_id = s.id
 LEFT JOIN business_units bu ON ra.business_unit_id = bu.id
 LEFT JOIN departments d ON ra.department_id = d.id
-WHERE ra.assignee_id = $1 AND ra.deleted_at IS NULL
-ORDER BY ra.assigned_at DESC
+JOIN users u ON ra.assignee_id = u.id
+WHERE ra.assignee_id = $1 AND u.home_tenant_id = $2 AND ra.deleted_at IS NULL
+ORDER BY ra.assigned_at DESC, ra.id
+LIMIT $3 OFFSET $4
 `
 
+type GetUserRoleAssignmentsParams struct {
+	AssigneeID   pgtype.UUID `json:"assignee_id"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+	Limit        int32       `json:"limit"`
+	Offset       int32       `json:"offset"`
+}
+
 type GetUserRoleAssignmentsRow struct {
-	ID                pgtype.UUID        `json:"id"`
-	RolePermissionsID pgtype.UUID        `json:"role_permissions_id"`
-	AssigneeID        pgtype.UUID        `json:"assignee_id"`
-	BusinessUnitID    pgtype.UUID        `json:"business_unit_id"`
-	DepartmentID      pgtype.UUID        `json:"department_id"`
-	AssignedBy        pgtype.UUID        `json:"assigned_by"`
-	AssignedAt        pgtype.Timestamptz `json:"assigned_at"`
-	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
-	Status            NullStatusEnum     `json:"status"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
-	RoleName          string             `json:"role_name"`
-	PermissionName    string             `json:"permission_name"`
-	Resource          string             `json:"resource"`
-	Action            string             `json:"action"`
-	ScopeName         pgtype.Text        `json:"scope_name"`
-	BusinessUnitName  pgtype.Text        `json:"business_unit_name"`
-	DepartmentName    pgtype.Text        `json:"department_name"`
-}
-
-func (q *Queries) GetUserRoleAssignments(ctx context.Context, assigneeID pgtype.UUID) ([]GetUserRoleAssignmentsRow, error) {
-	rows, err := q.db.Query(ctx, getUserRoleAssignments, assigneeID)
+	ID                 pgtype.UUID        `json:"id"`
+	RolePermissionsID  pgtype.UUID        `json:"role_permissions_id"`
+	AssigneeID         pgtype.UUID        `json:"assignee_id"`
+	BusinessUnitID     pgtype.UUID        `json:"business_unit_id"`
+	DepartmentID       pgtype.UUID        `json:"department_id"`
+	AssignedBy         pgtype.UUID        `json:"assigned_by"`
+	AssignedAt         pgtype.Timestamptz `json:"assigned_at"`
+	ExpiresAt          pgtype.Timestamptz `json:"expires_at"`
+	Status             NullStatusEnum     `json:"status"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt          pgtype.Timestamptz `json:"deleted_at"`
+	DirectoryMappingID pgtype.UUID        `json:"directory_mapping_id"`
+	ElevationRequestID pgtype.UUID        `json:"elevation_request_id"`
+	RoleName           string             `json:"role_name"`
+	PermissionName     string             `json:"permission_name"`
+	Resource           string             `json:"resource"`
+	Action             string             `json:"action"`
+	ScopeName          pgtype.Text        `json:"scope_name"`
+	BusinessUnitName   pgtype.Text        `json:"business_unit_name"`
+	DepartmentName     pgtype.Text        `json:"department_name"`
+}
+
+func (q *Queries) GetUserRoleAssignments(ctx context.Context, arg GetUserRoleAssignmentsParams) ([]GetUserRoleAssignmentsRow, error) {
+	rows, err := q.db.Query(ctx, getUserRoleAssignments,
+		arg.AssigneeID,
+		arg.HomeTenantID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +449,8 @@ func (q *Queries) GetUserRoleAssignments(ctx context.Context, assigneeID pgtype.
 			&i.Status,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.DirectoryMappingID,
+			&i.ElevationRequestID,
 			&i.RoleName,
 			&i.PermissionName,
 			&i.Resource,
@@ -181,3 +468,71 @@ func (q *Queries) GetUserRoleAssignments(ctx context.Context, assigneeID pgtype.
 	}
 	return items, nil
 }
+
+const revokeRoleAssignment = `-- name: RevokeRoleAssignment :one
+UPDATE role_assignment
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at, directory_mapping_id, elevation_request_id
+`
+
+func (q *Queries) RevokeRoleAssignment(ctx context.Context, id pgtype.UUID) (RoleAssignment, error) {
+	row := q.db.QueryRow(ctx, revokeRoleAssignment, id)
+	var i RoleAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.RolePermissionsID,
+		&i.AssigneeID,
+		&i.BusinessUnitID,
+		&i.DepartmentID,
+		&i.AssignedBy,
+		&i.AssignedAt,
+		&i.ExpiresAt,
+		&i.Status,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DirectoryMappingID,
+		&i.ElevationRequestID,
+	)
+	return i, err
+}
+
+const updateRoleAssignment = `-- name: UpdateRoleAssignment :one
+UPDATE role_assignment
+SET
+    expires_at = COALESCE($2, expires_at),
+    status = COALESCE($3, status),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at, directory_mapping_id, elevation_request_id
+`
+
+type UpdateRoleAssignmentParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	Status    NullStatusEnum     `json:"status"`
+}
+
+func (q *Queries) UpdateRoleAssignment(ctx context.Context, arg UpdateRoleAssignmentParams) (RoleAssignment, error) {
+	row := q.db.QueryRow(ctx, updateRoleAssignment, arg.ID, arg.ExpiresAt, arg.Status)
+	var i RoleAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.RolePermissionsID,
+		&i.AssigneeID,
+		&i.BusinessUnitID,
+		&i.DepartmentID,
+		&i.AssignedBy,
+		&i.AssignedAt,
+		&i.ExpiresAt,
+		&i.Status,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DirectoryMappingID,
+		&i.ElevationRequestID,
+	)
+	return i, err
+}
@@ -124,6 +124,95 @@ func (q *Queries) GetPermissionsByRole(ctx context.Context, roleID string) ([]Ge
 	return items, nil
 }
 
+const getResolvedRolePermissions = `-- name: GetResolvedRolePermissions :many
+WITH RECURSIVE role_tree AS (
+    SELECT r.id, 0 AS depth, ARRAY[r.id]::VARCHAR[] AS path
+    FROM roles r
+    WHERE r.id = $1
+    UNION ALL
+    SELECT r.parent_role_id, rt.depth + 1, rt.path || r.parent_role_id
+    FROM role_tree rt
+    JOIN roles r ON r.id = rt.id
+    WHERE r.parent_role_id IS NOT NULL
+        AND NOT r.parent_role_id = ANY(rt.path)
+)
+SELECT 
+    rp.id,
+    rp.role_id,
+    rp.permission_id,
+    rp.scope_id,
+    rp.status,
+    rp.created_at,
+    rp.updated_at,
+    rp.deleted_at,
+    r.name as role_name,
+    p.name as permission_name,
+    p.resource,
+    p.action,
+    s.name as scope_name,
+    rt.depth::INTEGER AS depth
+FROM role_tree rt
+JOIN roles r ON rt.id = r.id AND (rt.depth = 0 OR r.deleted_at IS NULL)
+JOIN role_permissions rp ON rp.role_id = rt.id
+JOIN permissions p ON rp.permission_id = p.id
+LEFT JOIN scopes s ON rp.scope_id = s.id
+WHERE rp.deleted_at IS NULL
+ORDER BY p.resource, p.action, rt.depth
+`
+
+type GetResolvedRolePermissionsRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	RoleID         string             `json:"role_id"`
+	PermissionID   string             `json:"permission_id"`
+	ScopeID        pgtype.Text        `json:"scope_id"`
+	Status         NullStatusEnum     `json:"status"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	RoleName       string             `json:"role_name"`
+	PermissionName string             `json:"permission_name"`
+	Resource       string             `json:"resource"`
+	Action         string             `json:"action"`
+	ScopeName      pgtype.Text        `json:"scope_name"`
+	Depth          int32              `json:"depth"`
+}
+
+// Permissions granted to a role directly (depth 0) or inherited from its ancestors.
+func (q *Queries) GetResolvedRolePermissions(ctx context.Context, roleID string) ([]GetResolvedRolePermissionsRow, error) {
+	rows, err := q.db.Query(ctx, getResolvedRolePermissions, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetResolvedRolePermissionsRow
+	for rows.Next() {
+		var i GetResolvedRolePermissionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RoleID,
+			&i.PermissionID,
+			&i.ScopeID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.RoleName,
+			&i.PermissionName,
+			&i.Resource,
+			&i.Action,
+			&i.ScopeName,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRolePermissionByID = `-- name: GetRolePermissionByID :one
 SELECT 
     rp.id,
@@ -17,11 +17,13 @@ INSERT INTO roles (
     name,
     description,
     is_system_role,
-    status
+    status,
+    parent_role_id,
+    tenant_id
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, name, description, is_system_role, status, created_at, updated_at, deleted_at
+RETURNING id, name, description, is_system_role, status, created_at, updated_at, deleted_at, parent_role_id, tenant_id
 `
 
 type CreateRoleParams struct {
@@ -30,8 +32,11 @@ type CreateRoleParams struct {
 	Description  pgtype.Text    `json:"description"`
 	IsSystemRole pgtype.Bool    `json:"is_system_role"`
 	Status       NullStatusEnum `json:"status"`
+	ParentRoleID pgtype.Text    `json:"parent_role_id"`
+	TenantID     pgtype.UUID    `json:"tenant_id"`
 }
 
+// Roles created through the API belong to the creator's tenant.
 func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error) {
 	row := q.db.QueryRow(ctx, createRole,
 		arg.ID,
@@ -39,6 +44,8 @@ func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, e
 		arg.Description,
 		arg.IsSystemRole,
 		arg.Status,
+		arg.ParentRoleID,
+		arg.TenantID,
 	)
 	var i Role
 	err := row.Scan(
@@ -50,6 +57,8 @@ func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ParentRoleID,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -63,14 +72,16 @@ SELECT
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    parent_role_id,
+    tenant_id
 FROM roles 
-WHERE deleted_at IS NULL
+WHERE deleted_at IS NULL AND (tenant_id IS NULL OR tenant_id = $1)
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetAllRoles(ctx context.Context) ([]Role, error) {
-	rows, err := q.db.Query(ctx, getAllRoles)
+func (q *Queries) GetAllRoles(ctx context.Context, tenantID pgtype.UUID) ([]Role, error) {
+	rows, err := q.db.Query(ctx, getAllRoles, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +98,8 @@ func (q *Queries) GetAllRoles(ctx context.Context) ([]Role, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ParentRoleID,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -98,6 +111,48 @@ func (q *Queries) GetAllRoles(ctx context.Context) ([]Role, error) {
 	return items, nil
 }
 
+const getRoleAncestors = `-- name: GetRoleAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT r.parent_role_id AS id, 1 AS depth, ARRAY[r.id]::VARCHAR[] AS path
+    FROM roles r
+    WHERE r.id = $1 AND r.parent_role_id IS NOT NULL
+    UNION ALL
+    SELECT r.parent_role_id, a.depth + 1, a.path || a.id
+    FROM ancestors a
+    JOIN roles r ON r.id = a.id
+    WHERE r.parent_role_id IS NOT NULL
+        AND NOT r.parent_role_id = ANY(a.path || a.id)
+)
+SELECT id::VARCHAR AS id, depth::INTEGER AS depth
+FROM ancestors
+ORDER BY depth
+`
+
+type GetRoleAncestorsRow struct {
+	ID    string `json:"id"`
+	Depth int32  `json:"depth"`
+}
+
+func (q *Queries) GetRoleAncestors(ctx context.Context, id string) ([]GetRoleAncestorsRow, error) {
+	rows, err := q.db.Query(ctx, getRoleAncestors, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRoleAncestorsRow
+	for rows.Next() {
+		var i GetRoleAncestorsRow
+		if err := rows.Scan(&i.ID, &i.Depth); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRoleByID = `-- name: GetRoleByID :one
 SELECT 
     id,
@@ -107,13 +162,20 @@ SELECT
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    parent_role_id,
+    tenant_id
 FROM roles 
-WHERE id = $1
+WHERE id = $1 AND (tenant_id IS NULL OR tenant_id = $2)
 `
 
-func (q *Queries) GetRoleByID(ctx context.Context, id string) (Role, error) {
-	row := q.db.QueryRow(ctx, getRoleByID, id)
+type GetRoleByIDParams struct {
+	ID       string      `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetRoleByID(ctx context.Context, arg GetRoleByIDParams) (Role, error) {
+	row := q.db.QueryRow(ctx, getRoleByID, arg.ID, arg.TenantID)
 	var i Role
 	err := row.Scan(
 		&i.ID,
@@ -124,6 +186,8 @@ func (q *Queries) GetRoleByID(ctx context.Context, id string) (Role, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ParentRoleID,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -137,14 +201,16 @@ SELECT
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    parent_role_id,
+    tenant_id
 FROM roles 
-WHERE is_system_role AND deleted_at IS NULL
+WHERE is_system_role AND deleted_at IS NULL AND (tenant_id IS NULL OR tenant_id = $1)
 ORDER BY name ASC
 `
 
-func (q *Queries) GetSystemRoles(ctx context.Context) ([]Role, error) {
-	rows, err := q.db.Query(ctx, getSystemRoles)
+func (q *Queries) GetSystemRoles(ctx context.Context, tenantID pgtype.UUID) ([]Role, error) {
+	rows, err := q.db.Query(ctx, getSystemRoles, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +227,8 @@ func (q *Queries) GetSystemRoles(ctx context.Context) ([]Role, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ParentRoleID,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -171,3 +239,37 @@ func (q *Queries) GetSystemRoles(ctx context.Context) ([]Role, error) {
 	}
 	return items, nil
 }
+
+const setRoleParent = `-- name: SetRoleParent :one
+UPDATE roles
+SET
+    parent_role_id = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+RETURNING id, name, description, is_system_role, status, created_at, updated_at, deleted_at, parent_role_id, tenant_id
+`
+
+type SetRoleParentParams struct {
+	ParentRoleID pgtype.Text `json:"parent_role_id"`
+	ID           string      `json:"id"`
+	TenantID     pgtype.UUID `json:"tenant_id"`
+}
+
+// Shared roles have no tenant and are left alone.
+func (q *Queries) SetRoleParent(ctx context.Context, arg SetRoleParentParams) (Role, error) {
+	row := q.db.QueryRow(ctx, setRoleParent, arg.ParentRoleID, arg.ID, arg.TenantID)
+	var i Role
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.IsSystemRole,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ParentRoleID,
+		&i.TenantID,
+	)
+	return i, err
+}
@@ -11,6 +11,23 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countUsersInDepartment = `-- name: CountUsersInDepartment :one
+SELECT COUNT(*) FROM users
+WHERE department_id = $1 AND home_tenant_id = $2 AND deleted_at IS NULL
+`
+
+type CountUsersInDepartmentParams struct {
+	DepartmentID pgtype.UUID `json:"department_id"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+}
+
+func (q *Queries) CountUsersInDepartment(ctx context.Context, arg CountUsersInDepartmentParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsersInDepartment, arg.DepartmentID, arg.HomeTenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     azure_ad_object_id,
@@ -106,12 +123,25 @@ SELECT
     updated_at,
     deleted_at
 FROM users 
-WHERE department_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC
+WHERE department_id = $1 AND home_tenant_id = $2 AND deleted_at IS NULL
+ORDER BY created_at DESC, id
+LIMIT $3 OFFSET $4
 `
 
-func (q *Queries) GetAllUsersInDepartment(ctx context.Context, departmentID pgtype.UUID) ([]User, error) {
-	rows, err := q.db.Query(ctx, getAllUsersInDepartment, departmentID)
+type GetAllUsersInDepartmentParams struct {
+	DepartmentID pgtype.UUID `json:"department_id"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+	Limit        int32       `json:"limit"`
+	Offset       int32       `json:"offset"`
+}
+
+func (q *Queries) GetAllUsersInDepartment(ctx context.Context, arg GetAllUsersInDepartmentParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, getAllUsersInDepartment,
+		arg.DepartmentID,
+		arg.HomeTenantID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +179,57 @@ func (q *Queries) GetAllUsersInDepartment(ctx context.Context, departmentID pgty
 	return items, nil
 }
 
+const getUserByAzureADObjectID = `-- name: GetUserByAzureADObjectID :one
+SELECT 
+    id,
+    azure_ad_object_id,
+    home_tenant_id,
+    department_id,
+    business_unit_id,
+    manager_id,
+    mail,
+    display_name,
+    given_name,
+    sur_name,
+    job_title,
+    office_location,
+    status,
+    last_login,
+    locked_until,
+    created_at,
+    updated_at,
+    deleted_at
+FROM users 
+WHERE azure_ad_object_id = $1 AND deleted_at IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetUserByAzureADObjectID(ctx context.Context, azureAdObjectID string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByAzureADObjectID, azureAdObjectID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.AzureAdObjectID,
+		&i.HomeTenantID,
+		&i.DepartmentID,
+		&i.BusinessUnitID,
+		&i.ManagerID,
+		&i.Mail,
+		&i.DisplayName,
+		&i.GivenName,
+		&i.SurName,
+		&i.JobTitle,
+		&i.OfficeLocation,
+		&i.Status,
+		&i.LastLogin,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT 
     id,
@@ -170,11 +251,16 @@ SELECT
     updated_at,
     deleted_at
 FROM users 
-WHERE mail = $1
+WHERE mail = $1 AND home_tenant_id = $2
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, mail string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, mail)
+type GetUserByEmailParams struct {
+	Mail         string      `json:"mail"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, arg.Mail, arg.HomeTenantID)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -220,11 +306,16 @@ SELECT
     updated_at,
     deleted_at
 FROM users 
-WHERE id = $1
+WHERE id = $1 AND home_tenant_id = $2
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByID, id)
+type GetUserByIDParams struct {
+	ID           pgtype.UUID `json:"id"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+}
+
+func (q *Queries) GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByID, arg.ID, arg.HomeTenantID)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -254,10 +345,15 @@ UPDATE users
 SET 
     last_login = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP
-WHERE mail = $1
+WHERE mail = $1 AND home_tenant_id = $2
 `
 
-func (q *Queries) UpdateUserLastLogin(ctx context.Context, mail string) error {
-	_, err := q.db.Exec(ctx, updateUserLastLogin, mail)
+type UpdateUserLastLoginParams struct {
+	Mail         string      `json:"mail"`
+	HomeTenantID pgtype.UUID `json:"home_tenant_id"`
+}
+
+func (q *Queries) UpdateUserLastLogin(ctx context.Context, arg UpdateUserLastLoginParams) error {
+	_, err := q.db.Exec(ctx, updateUserLastLogin, arg.Mail, arg.HomeTenantID)
 	return err
 }
@@ -0,0 +1,43 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccessReviewRouter struct {
+	controller *controller.AccessReviewController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewAccessReviewRouter(controller *controller.AccessReviewController, config *config.Config, permission *middleware.PermissionMiddleware) *AccessReviewRouter {
+	return &AccessReviewRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (arr *AccessReviewRouter) SetupAccessReviewRoutes(v1 *gin.RouterGroup) {
+	campaignGroup := v1.Group("/access-reviews").Use(middleware.AuthMiddleWare(&arr.config.OAuth))
+	{
+		campaignGroup.GET("", arr.permission.RequirePermission(constants.ResourceAccessReviews, constants.ActionRead), arr.controller.GetCampaigns)
+		campaignGroup.POST("", arr.permission.RequirePermission(constants.ResourceAccessReviews, constants.ActionCreate), arr.controller.CreateCampaign)
+		campaignGroup.GET("/:campaignId", arr.permission.RequirePermission(constants.ResourceAccessReviews, constants.ActionRead), arr.controller.GetCampaignByID)
+		campaignGroup.GET("/:campaignId/items", arr.permission.RequirePermission(constants.ResourceAccessReviews, constants.ActionRead), arr.controller.GetCampaignItems)
+		campaignGroup.GET("/:campaignId/export", arr.permission.RequirePermission(constants.ResourceAccessReviews, constants.ActionRead), arr.controller.ExportCampaign)
+		campaignGroup.POST("/:campaignId/complete", arr.permission.RequirePermission(constants.ResourceAccessReviews, constants.ActionUpdate), arr.controller.CompleteCampaign)
+	}
+
+	// Reviewers act on their own items; being assigned as reviewer is the authorization.
+	itemGroup := v1.Group("/access-review-items").Use(middleware.AuthMiddleWare(&arr.config.OAuth))
+	{
+		itemGroup.GET("", arr.permission.RequireUser(), arr.controller.GetMyPendingItems)
+		itemGroup.POST("/:itemId/decision", arr.permission.RequireUser(), arr.controller.DecideItem)
+	}
+}
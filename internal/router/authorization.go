@@ -0,0 +1,35 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorizationRouter struct {
+	controller *controller.AuthorizationController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewAuthorizationRouter(controller *controller.AuthorizationController, config *config.Config, permission *middleware.PermissionMiddleware) *AuthorizationRouter {
+	return &AuthorizationRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (ar *AuthorizationRouter) SetupAuthorizationRoutes(v1 *gin.RouterGroup) {
+	userGroup := v1.Group("/users").Use(middleware.AuthMiddleWare(&ar.config.OAuth))
+	{
+		userGroup.GET("/me/effective-permissions", ar.permission.RequireUser(), ar.controller.GetMyEffectivePermissions)
+	}
+
+	authzGroup := v1.Group("/authz").Use(middleware.AuthMiddleWare(&ar.config.OAuth))
+	{
+		authzGroup.POST("/check", ar.permission.RequireUser(), ar.controller.CheckAuthorization)
+	}
+}
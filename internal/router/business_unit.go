@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,20 +12,22 @@ import (
 type BusinessUnitRouter struct {
 	controller *controller.BusinessUnitController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewBusinessUnitRouter(controller *controller.BusinessUnitController, config *config.Config) *BusinessUnitRouter {
+func NewBusinessUnitRouter(controller *controller.BusinessUnitController, config *config.Config, permission *middleware.PermissionMiddleware) *BusinessUnitRouter {
 	return &BusinessUnitRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (bur *BusinessUnitRouter) SetupBusinessUnitRoutes(v1 *gin.RouterGroup) {
 	businessUnitGroup := v1.Group("/business-units").Use(middleware.AuthMiddleWare(&bur.config.OAuth))
 	{
-		businessUnitGroup.GET("/", bur.controller.GetAllBusinessUnitsInTenant)
-		businessUnitGroup.GET("/domain", bur.controller.GetBusinessUnitByDomainName)
-		businessUnitGroup.GET("/:businessUnitId", bur.controller.GetBusinessUnitByID)
+		businessUnitGroup.GET("/", bur.permission.RequirePermission(constants.ResourceBusinessUnits, constants.ActionRead), bur.controller.GetAllBusinessUnitsInTenant)
+		businessUnitGroup.GET("/domain", bur.permission.RequirePermission(constants.ResourceBusinessUnits, constants.ActionRead), bur.controller.GetBusinessUnitByDomainName)
+		businessUnitGroup.GET("/:businessUnitId", bur.permission.RequirePermission(constants.ResourceBusinessUnits, constants.ActionRead), bur.controller.GetBusinessUnitByID)
 	}
 }
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,19 +12,27 @@ import (
 type DepartmentRouter struct {
 	controller *controller.DepartmentController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewDepartmentRouter(controller *controller.DepartmentController, config *config.Config) *DepartmentRouter {
+func NewDepartmentRouter(controller *controller.DepartmentController, config *config.Config, permission *middleware.PermissionMiddleware) *DepartmentRouter {
 	return &DepartmentRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (dr *DepartmentRouter) SetupDepartmentRoutes(v1 *gin.RouterGroup) {
 	departmentGroup := v1.Group("/departments").Use(middleware.AuthMiddleWare(&dr.config.OAuth))
 	{
-		departmentGroup.GET("/", dr.controller.GetDepartmentByName)
-		departmentGroup.GET("/:departmentId", dr.controller.GetDepartmentByID)
+		departmentGroup.GET("", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionRead), dr.controller.GetDepartments)
+		departmentGroup.GET("/", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionRead), dr.controller.GetDepartmentByName)
+		departmentGroup.POST("", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionCreate), dr.controller.CreateDepartment)
+		departmentGroup.GET("/:departmentId", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionRead), dr.controller.GetDepartmentByID)
+		departmentGroup.PUT("/:departmentId", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionUpdate), dr.controller.UpdateDepartment)
+		departmentGroup.DELETE("/:departmentId", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionDelete), dr.controller.DeleteDepartment)
+		departmentGroup.PUT("/:departmentId/parent", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionUpdate), dr.controller.MoveDepartment)
+		departmentGroup.GET("/:departmentId/subtree", dr.permission.RequirePermission(constants.ResourceDepartments, constants.ActionRead), dr.controller.GetDepartmentSubtree)
 	}
 }
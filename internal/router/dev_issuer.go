@@ -0,0 +1,37 @@
+package router
+
+import (
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type DevIssuerRouter struct {
+	controller *controller.DevIssuerController
+}
+
+func NewDevIssuerRouter(controller *controller.DevIssuerController) *DevIssuerRouter {
+	return &DevIssuerRouter{
+		controller: controller,
+	}
+}
+
+// SetupDevIssuerRoutes serves the dev issuer at /dev, the path of its default
+// issuer URL, so its discovery document is found like any OpenID issuer's.
+// Nothing is served in release mode, so a misconfigured production server
+// fails closed.
+func (dr *DevIssuerRouter) SetupDevIssuerRoutes(router *gin.Engine) {
+	if gin.Mode() == gin.ReleaseMode {
+		log.Error().Msg(constants.ErrDevIssuerReleaseModeMsg)
+		return
+	}
+
+	devGroup := router.Group("/dev")
+	{
+		devGroup.GET("/.well-known/openid-configuration", dr.controller.Discovery)
+		devGroup.GET("/jwks", dr.controller.JWKS)
+		devGroup.POST("/token", dr.controller.MintToken)
+	}
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DirectoryRoleMappingRouter struct {
+	controller *controller.DirectoryRoleMappingController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewDirectoryRoleMappingRouter(controller *controller.DirectoryRoleMappingController, config *config.Config, permission *middleware.PermissionMiddleware) *DirectoryRoleMappingRouter {
+	return &DirectoryRoleMappingRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (drmr *DirectoryRoleMappingRouter) SetupDirectoryRoleMappingRoutes(v1 *gin.RouterGroup) {
+	mappingGroup := v1.Group("/directory-role-mappings").Use(middleware.AuthMiddleWare(&drmr.config.OAuth))
+	{
+		mappingGroup.GET("", drmr.permission.RequirePermission(constants.ResourceDirectoryRoleMappings, constants.ActionRead), drmr.controller.GetDirectoryRoleMappings)
+		mappingGroup.POST("", drmr.permission.RequirePermission(constants.ResourceDirectoryRoleMappings, constants.ActionCreate), drmr.controller.CreateDirectoryRoleMapping)
+		mappingGroup.GET("/:mappingId", drmr.permission.RequirePermission(constants.ResourceDirectoryRoleMappings, constants.ActionRead), drmr.controller.GetDirectoryRoleMappingByID)
+		mappingGroup.DELETE("/:mappingId", drmr.permission.RequirePermission(constants.ResourceDirectoryRoleMappings, constants.ActionDelete), drmr.controller.DeleteDirectoryRoleMapping)
+	}
+}
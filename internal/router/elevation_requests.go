@@ -0,0 +1,39 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ElevationRequestRouter struct {
+	controller *controller.ElevationRequestController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewElevationRequestRouter(controller *controller.ElevationRequestController, config *config.Config, permission *middleware.PermissionMiddleware) *ElevationRequestRouter {
+	return &ElevationRequestRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (ers *ElevationRequestRouter) SetupElevationRequestRoutes(v1 *gin.RouterGroup) {
+	requestGroup := v1.Group("/elevation-requests").Use(middleware.AuthMiddleWare(&ers.config.OAuth))
+	{
+		// Any user may request elevation and manage their own requests.
+		requestGroup.POST("", ers.permission.RequireUser(), ers.controller.CreateElevationRequest)
+		requestGroup.GET("/mine", ers.permission.RequireUser(), ers.controller.GetMyElevationRequests)
+		requestGroup.POST("/:requestId/cancel", ers.permission.RequireUser(), ers.controller.CancelElevationRequest)
+
+		requestGroup.GET("", ers.permission.RequirePermission(constants.ResourceElevationRequests, constants.ActionRead), ers.controller.GetElevationRequests)
+		requestGroup.GET("/:requestId", ers.permission.RequirePermission(constants.ResourceElevationRequests, constants.ActionRead), ers.controller.GetElevationRequestByID)
+		requestGroup.POST("/:requestId/approve", ers.permission.RequirePermission(constants.ResourceElevationRequests, constants.ActionApprove), ers.controller.ApproveElevationRequest)
+		requestGroup.POST("/:requestId/reject", ers.permission.RequirePermission(constants.ResourceElevationRequests, constants.ActionApprove), ers.controller.RejectElevationRequest)
+	}
+}
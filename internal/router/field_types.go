@@ -0,0 +1,35 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FieldTypeRouter struct {
+	controller *controller.FieldTypeController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewFieldTypeRouter(controller *controller.FieldTypeController, config *config.Config, permission *middleware.PermissionMiddleware) *FieldTypeRouter {
+	return &FieldTypeRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (ftr *FieldTypeRouter) SetupFieldTypeRoutes(v1 *gin.RouterGroup) {
+	fieldTypeGroup := v1.Group("/field-types").Use(middleware.AuthMiddleWare(&ftr.config.OAuth))
+	{
+		fieldTypeGroup.GET("", ftr.permission.RequirePermission(constants.ResourceFieldTypes, constants.ActionRead), ftr.controller.GetFieldTypes)
+		fieldTypeGroup.GET("/:fieldTypeId", ftr.permission.RequirePermission(constants.ResourceFieldTypes, constants.ActionRead), ftr.controller.GetFieldTypeByID)
+		fieldTypeGroup.POST("", ftr.permission.RequirePermission(constants.ResourceFieldTypes, constants.ActionCreate), ftr.controller.CreateFieldType)
+		fieldTypeGroup.PUT("/:fieldTypeId", ftr.permission.RequirePermission(constants.ResourceFieldTypes, constants.ActionUpdate), ftr.controller.UpdateFieldType)
+		fieldTypeGroup.DELETE("/:fieldTypeId", ftr.permission.RequirePermission(constants.ResourceFieldTypes, constants.ActionDelete), ftr.controller.DeleteFieldType)
+	}
+}
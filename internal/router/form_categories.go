@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,22 +12,24 @@ import (
 type FormCategoryRouter struct {
 	controller *controller.FormCategoryController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewFormCategoryRouter(controller *controller.FormCategoryController, config *config.Config) *FormCategoryRouter {
+func NewFormCategoryRouter(controller *controller.FormCategoryController, config *config.Config, permission *middleware.PermissionMiddleware) *FormCategoryRouter {
 	return &FormCategoryRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (fcr *FormCategoryRouter) SetupFormCategoryRoutes(v1 *gin.RouterGroup) {
 	formCategoryGroup := v1.Group("/form-categories").Use(middleware.AuthMiddleWare(&fcr.config.OAuth))
 	{
-		formCategoryGroup.GET("/", fcr.controller.GetFormCategories)
-		formCategoryGroup.GET("/:categoryId", fcr.controller.GetFormCategoryByID)
-		formCategoryGroup.POST("/", fcr.controller.CreateFormCategory)
-		formCategoryGroup.PUT("/:categoryId", fcr.controller.UpdateFormCategory)
-		formCategoryGroup.DELETE("/:categoryId", fcr.controller.DeleteFormCategory)
+		formCategoryGroup.GET("/", fcr.permission.RequirePermission(constants.ResourceFormCategories, constants.ActionRead), fcr.controller.GetFormCategories)
+		formCategoryGroup.GET("/:categoryId", fcr.permission.RequirePermission(constants.ResourceFormCategories, constants.ActionRead), fcr.controller.GetFormCategoryByID)
+		formCategoryGroup.POST("/", fcr.permission.RequirePermission(constants.ResourceFormCategories, constants.ActionCreate), fcr.controller.CreateFormCategory)
+		formCategoryGroup.PUT("/:categoryId", fcr.permission.RequirePermission(constants.ResourceFormCategories, constants.ActionUpdate), fcr.controller.UpdateFormCategory)
+		formCategoryGroup.DELETE("/:categoryId", fcr.permission.RequirePermission(constants.ResourceFormCategories, constants.ActionDelete), fcr.controller.DeleteFormCategory)
 	}
 }
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FormFieldRouter struct {
+	controller *controller.FormFieldController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewFormFieldRouter(controller *controller.FormFieldController, config *config.Config, permission *middleware.PermissionMiddleware) *FormFieldRouter {
+	return &FormFieldRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (ffr *FormFieldRouter) SetupFormFieldRoutes(v1 *gin.RouterGroup) {
+	formFieldGroup := v1.Group("/form-templates/:templateId/fields").Use(middleware.AuthMiddleWare(&ffr.config.OAuth))
+	{
+		formFieldGroup.GET("", ffr.permission.RequirePermission(constants.ResourceFormFields, constants.ActionRead), ffr.controller.GetFormFields)
+		formFieldGroup.GET("/:fieldId", ffr.permission.RequirePermission(constants.ResourceFormFields, constants.ActionRead), ffr.controller.GetFormFieldByID)
+		formFieldGroup.POST("", ffr.permission.RequirePermission(constants.ResourceFormFields, constants.ActionCreate), ffr.controller.CreateFormField)
+		formFieldGroup.PUT("/:fieldId", ffr.permission.RequirePermission(constants.ResourceFormFields, constants.ActionUpdate), ffr.controller.UpdateFormField)
+		formFieldGroup.DELETE("/:fieldId", ffr.permission.RequirePermission(constants.ResourceFormFields, constants.ActionDelete), ffr.controller.DeleteFormField)
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,22 +12,24 @@ import (
 type FormSectionRouter struct {
 	controller *controller.FormSectionController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewFormSectionRouter(controller *controller.FormSectionController, config *config.Config) *FormSectionRouter {
+func NewFormSectionRouter(controller *controller.FormSectionController, config *config.Config, permission *middleware.PermissionMiddleware) *FormSectionRouter {
 	return &FormSectionRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (fsr *FormSectionRouter) SetupFormSectionRoutes(v1 *gin.RouterGroup) {
 	formSectionGroup := v1.Group("/form-sections").Use(middleware.AuthMiddleWare(&fsr.config.OAuth))
 	{
-		formSectionGroup.GET("/", fsr.controller.GetFormSections)
-		formSectionGroup.GET("/:sectionId", fsr.controller.GetFormSectionByID)
-		formSectionGroup.POST("/", fsr.controller.CreateFormSection)
-		formSectionGroup.PUT("/:sectionId", fsr.controller.UpdateFormSection)
-		formSectionGroup.DELETE("/:sectionId", fsr.controller.DeleteFormSection)
+		formSectionGroup.GET("/", fsr.permission.RequirePermission(constants.ResourceFormSections, constants.ActionRead), fsr.controller.GetFormSections)
+		formSectionGroup.GET("/:sectionId", fsr.permission.RequirePermission(constants.ResourceFormSections, constants.ActionRead), fsr.controller.GetFormSectionByID)
+		formSectionGroup.POST("/", fsr.permission.RequirePermission(constants.ResourceFormSections, constants.ActionCreate), fsr.controller.CreateFormSection)
+		formSectionGroup.PUT("/:sectionId", fsr.permission.RequirePermission(constants.ResourceFormSections, constants.ActionUpdate), fsr.controller.UpdateFormSection)
+		formSectionGroup.DELETE("/:sectionId", fsr.permission.RequirePermission(constants.ResourceFormSections, constants.ActionDelete), fsr.controller.DeleteFormSection)
 	}
 }
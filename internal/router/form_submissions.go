@@ -0,0 +1,40 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FormSubmissionRouter struct {
+	controller *controller.FormSubmissionController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewFormSubmissionRouter(controller *controller.FormSubmissionController, config *config.Config, permission *middleware.PermissionMiddleware) *FormSubmissionRouter {
+	return &FormSubmissionRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (fsr *FormSubmissionRouter) SetupFormSubmissionRoutes(v1 *gin.RouterGroup) {
+	templateSubmissionGroup := v1.Group("/form-templates/:templateId/submissions").Use(middleware.AuthMiddleWare(&fsr.config.OAuth))
+	{
+		templateSubmissionGroup.GET("", fsr.permission.RequirePermission(constants.ResourceFormSubmissions, constants.ActionRead), fsr.controller.GetFormSubmissions)
+		templateSubmissionGroup.POST("", fsr.permission.RequirePermission(constants.ResourceFormSubmissions, constants.ActionCreate), fsr.controller.CreateFormSubmission)
+	}
+
+	formSubmissionGroup := v1.Group("/form-submissions").Use(middleware.AuthMiddleWare(&fsr.config.OAuth))
+	{
+		formSubmissionGroup.GET("", fsr.permission.RequirePermission(constants.ResourceFormSubmissions, constants.ActionRead), fsr.controller.GetFormSubmissions)
+		formSubmissionGroup.GET("/:submissionId", fsr.permission.RequirePermission(constants.ResourceFormSubmissions, constants.ActionRead), fsr.controller.GetFormSubmissionByID)
+		formSubmissionGroup.PUT("/:submissionId", fsr.permission.RequirePermission(constants.ResourceFormSubmissions, constants.ActionUpdate), fsr.controller.UpdateFormSubmission)
+		formSubmissionGroup.DELETE("/:submissionId", fsr.permission.RequirePermission(constants.ResourceFormSubmissions, constants.ActionDelete), fsr.controller.DeleteFormSubmission)
+	}
+}
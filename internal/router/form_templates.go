@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,24 +12,33 @@ import (
 type FormTemplateRouter struct {
 	controller *controller.FormTemplateController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewFormTemplateRouter(controller *controller.FormTemplateController, config *config.Config) *FormTemplateRouter {
+func NewFormTemplateRouter(controller *controller.FormTemplateController, config *config.Config, permission *middleware.PermissionMiddleware) *FormTemplateRouter {
 	return &FormTemplateRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (ftr *FormTemplateRouter) SetupFormTemplateRoutes(v1 *gin.RouterGroup) {
 	formTemplateGroup := v1.Group("/form-templates").Use(middleware.AuthMiddleWare(&ftr.config.OAuth))
 	{
-		formTemplateGroup.GET("/", ftr.controller.GetFormTemplates)
-		formTemplateGroup.GET("/:templateId", ftr.controller.GetFormTemplateByID)
-		formTemplateGroup.GET("/category/:categoryId", ftr.controller.GetFormTemplatesByCategory)
-		formTemplateGroup.POST("/", ftr.controller.CreateFormTemplate)
-		formTemplateGroup.PUT("/:templateId", ftr.controller.UpdateFormTemplate)
-		// formTemplateGroup.POST("/:templateId/publish", ftr.controller.PublishFormTemplate)
-		formTemplateGroup.DELETE("/:templateId", ftr.controller.DeleteFormTemplate)
+		formTemplateGroup.GET("/", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionRead), ftr.controller.GetFormTemplates)
+		formTemplateGroup.GET("/:templateId", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionRead), ftr.controller.GetFormTemplateByID)
+		formTemplateGroup.GET("/category/:categoryId", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionRead), ftr.controller.GetFormTemplatesByCategory)
+		formTemplateGroup.POST("/", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionCreate), ftr.controller.CreateFormTemplate)
+		formTemplateGroup.PUT("/:templateId", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionUpdate), ftr.controller.UpdateFormTemplate)
+		formTemplateGroup.POST("/:templateId/submit", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionUpdate), ftr.controller.SubmitFormTemplateForReview)
+		formTemplateGroup.POST("/:templateId/reject", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionApprove), ftr.controller.RejectFormTemplate)
+		formTemplateGroup.POST("/:templateId/publish", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionApprove), ftr.controller.PublishFormTemplate)
+		formTemplateGroup.POST("/:templateId/retire", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionApprove), ftr.controller.RetireFormTemplate)
+		formTemplateGroup.GET("/:templateId/versions", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionRead), ftr.controller.GetFormTemplateVersions)
+		formTemplateGroup.GET("/:templateId/versions/:version", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionRead), ftr.controller.GetFormTemplateVersion)
+		formTemplateGroup.POST("/:templateId/versions", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionUpdate), ftr.controller.CreateFormTemplateVersion)
+		formTemplateGroup.GET("/:templateId/definition", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionRead), ftr.controller.GetFormDefinition)
+		formTemplateGroup.DELETE("/:templateId", ftr.permission.RequirePermission(constants.ResourceFormTemplates, constants.ActionDelete), ftr.controller.DeleteFormTemplate)
 	}
 }
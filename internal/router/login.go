@@ -0,0 +1,25 @@
+package router
+
+import (
+	"yet-another-itsm/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoginRouter struct {
+	controller *controller.LoginController
+}
+
+func NewLoginRouter(controller *controller.LoginController) *LoginRouter {
+	return &LoginRouter{
+		controller: controller,
+	}
+}
+
+// SetupLoginRoutes serves the browser login flow at the root, where the
+// redirect URI registered with the identity provider points.
+func (lr *LoginRouter) SetupLoginRoutes(router *gin.Engine) {
+	router.GET("/login", lr.controller.Login)
+	router.GET("/callback", lr.controller.Callback)
+	router.POST("/logout", lr.controller.Logout)
+}
@@ -0,0 +1,39 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrgChartRouter struct {
+	controller *controller.OrgChartController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewOrgChartRouter(controller *controller.OrgChartController, config *config.Config, permission *middleware.PermissionMiddleware) *OrgChartRouter {
+	return &OrgChartRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (ocr *OrgChartRouter) SetupOrgChartRoutes(v1 *gin.RouterGroup) {
+	userGroup := v1.Group("/users").Use(middleware.AuthMiddleWare(&ocr.config.OAuth))
+	{
+		userGroup.GET("/:userId/manager", ocr.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ocr.controller.GetManager)
+		userGroup.GET("/:userId/management-chain", ocr.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ocr.controller.GetManagementChain)
+		userGroup.GET("/:userId/direct-reports", ocr.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ocr.controller.GetDirectReports)
+		userGroup.GET("/:userId/reports", ocr.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ocr.controller.GetReports)
+	}
+
+	orgChartGroup := v1.Group("/org-chart").Use(middleware.AuthMiddleWare(&ocr.config.OAuth))
+	{
+		orgChartGroup.GET("", ocr.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ocr.controller.GetOrgChart)
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,12 +12,14 @@ import (
 type PermissionRouter struct {
 	controller *controller.PermissionController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewPermissionRouter(controller *controller.PermissionController, config *config.Config) *PermissionRouter {
+func NewPermissionRouter(controller *controller.PermissionController, config *config.Config, permission *middleware.PermissionMiddleware) *PermissionRouter {
 	return &PermissionRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
@@ -25,13 +28,13 @@ func (pr *PermissionRouter) SetupPermissionRoutes(v1 *gin.RouterGroup) {
 
 	permissionGroup := v1.Group("/permissions").Use(middleware.AuthMiddleWare(&pr.config.OAuth))
 	{
-		permissionGroup.GET("/", pr.controller.GetAllPermissions)
-		permissionGroup.GET(permissionIDPath, pr.controller.GetPermissionByID)
-		permissionGroup.GET("/active", pr.controller.GetActivePermissions)
-		permissionGroup.GET("/resource/", pr.controller.GetPermissionsByResource)
-		permissionGroup.GET("/permission", pr.controller.GetPermissionsByResourceAndAction)
-		permissionGroup.POST("/", pr.controller.CreatePermission)
-		permissionGroup.PUT(permissionIDPath, pr.controller.UpdatePermission)
-		permissionGroup.DELETE(permissionIDPath, pr.controller.DeletePermission)
+		permissionGroup.GET("/", pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionRead), pr.controller.GetAllPermissions)
+		permissionGroup.GET(permissionIDPath, pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionRead), pr.controller.GetPermissionByID)
+		permissionGroup.GET("/active", pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionRead), pr.controller.GetActivePermissions)
+		permissionGroup.GET("/resource/", pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionRead), pr.controller.GetPermissionsByResource)
+		permissionGroup.GET("/permission", pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionRead), pr.controller.GetPermissionsByResourceAndAction)
+		permissionGroup.POST("/", pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionCreate), pr.controller.CreatePermission)
+		permissionGroup.PUT(permissionIDPath, pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionUpdate), pr.controller.UpdatePermission)
+		permissionGroup.DELETE(permissionIDPath, pr.permission.RequirePermission(constants.ResourcePermissions, constants.ActionDelete), pr.controller.DeletePermission)
 	}
 }
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,18 +12,29 @@ import (
 type RoleAssignmentRouter struct {
 	controller *controller.RoleAssignmentController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewRoleAssignmentRouter(controller *controller.RoleAssignmentController, config *config.Config) *RoleAssignmentRouter {
+func NewRoleAssignmentRouter(controller *controller.RoleAssignmentController, config *config.Config, permission *middleware.PermissionMiddleware) *RoleAssignmentRouter {
 	return &RoleAssignmentRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (rar *RoleAssignmentRouter) SetupRoleAssignmentRoutes(v1 *gin.RouterGroup) {
 	userGroup := v1.Group("/users").Use(middleware.AuthMiddleWare(&rar.config.OAuth))
 	{
-		userGroup.GET("/:userId/role-assignments", rar.controller.GetUserRoleAssignments)
+		userGroup.GET("/:userId/role-assignments", rar.permission.RequirePermission(constants.ResourceRoleAssignments, constants.ActionRead), rar.controller.GetUserRoleAssignments)
+	}
+
+	roleAssignmentGroup := v1.Group("/role-assignments").Use(middleware.AuthMiddleWare(&rar.config.OAuth))
+	{
+		roleAssignmentGroup.POST("", rar.permission.RequirePermission(constants.ResourceRoleAssignments, constants.ActionCreate), rar.controller.CreateRoleAssignment)
+		roleAssignmentGroup.POST("/bulk", rar.permission.RequirePermission(constants.ResourceRoleAssignments, constants.ActionCreate), rar.controller.BulkCreateRoleAssignments)
+		roleAssignmentGroup.GET("/:assignmentId", rar.permission.RequirePermission(constants.ResourceRoleAssignments, constants.ActionRead), rar.controller.GetRoleAssignmentByID)
+		roleAssignmentGroup.PUT("/:assignmentId", rar.permission.RequirePermission(constants.ResourceRoleAssignments, constants.ActionUpdate), rar.controller.UpdateRoleAssignment)
+		roleAssignmentGroup.DELETE("/:assignmentId", rar.permission.RequirePermission(constants.ResourceRoleAssignments, constants.ActionDelete), rar.controller.RevokeRoleAssignment)
 	}
 }
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,25 +12,27 @@ import (
 type RolePermissionRouter struct {
 	controller *controller.RolePermissionController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewRolePermissionRouter(controller *controller.RolePermissionController, config *config.Config) *RolePermissionRouter {
+func NewRolePermissionRouter(controller *controller.RolePermissionController, config *config.Config, permission *middleware.PermissionMiddleware) *RolePermissionRouter {
 	return &RolePermissionRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (rpr *RolePermissionRouter) SetupRolePermissionRoutes(v1 *gin.RouterGroup) {
 	rolePermissionGroup := v1.Group("/role-permissions").Use(middleware.AuthMiddleWare(&rpr.config.OAuth))
 	{
-		rolePermissionGroup.GET("/:rolePermissionId", rpr.controller.GetRolePermissionByID)
-		rolePermissionGroup.POST("/", rpr.controller.CreateRolePermission)
+		rolePermissionGroup.GET("/:rolePermissionId", rpr.permission.RequirePermission(constants.ResourceRolePermissions, constants.ActionRead), rpr.controller.GetRolePermissionByID)
+		rolePermissionGroup.POST("/", rpr.permission.RequirePermission(constants.ResourceRolePermissions, constants.ActionCreate), rpr.controller.CreateRolePermission)
 	}
 
 	roleGroup := v1.Group("/roles").Use(middleware.AuthMiddleWare(&rpr.config.OAuth))
 	{
-		roleGroup.GET("/:roleId/permissions", rpr.controller.GetPermissionsByRole)
-		roleGroup.GET("/:roleId/available-permissions", rpr.controller.GetPermissionsByRole)
+		roleGroup.GET("/:roleId/permissions", rpr.permission.RequirePermission(constants.ResourceRolePermissions, constants.ActionRead), rpr.controller.GetPermissionsByRole)
+		roleGroup.GET("/:roleId/available-permissions", rpr.permission.RequirePermission(constants.ResourceRolePermissions, constants.ActionRead), rpr.controller.GetPermissionsByRole)
 	}
 }
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,21 +12,24 @@ import (
 type RoleRouter struct {
 	controller *controller.RoleController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewRoleRouter(controller *controller.RoleController, config *config.Config) *RoleRouter {
+func NewRoleRouter(controller *controller.RoleController, config *config.Config, permission *middleware.PermissionMiddleware) *RoleRouter {
 	return &RoleRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (rr *RoleRouter) SetupRoleRoutes(v1 *gin.RouterGroup) {
 	roleGroup := v1.Group("/roles").Use(middleware.AuthMiddleWare(&rr.config.OAuth))
 	{
-		roleGroup.GET("/", rr.controller.GetAllRoles)
-		roleGroup.GET("/:roleId", rr.controller.GetRoleByID)
-		roleGroup.GET("/system", rr.controller.GetSystemRoles)
-		roleGroup.POST("/", rr.controller.CreateRole)
+		roleGroup.GET("/", rr.permission.RequirePermission(constants.ResourceRoles, constants.ActionRead), rr.controller.GetAllRoles)
+		roleGroup.GET("/:roleId", rr.permission.RequirePermission(constants.ResourceRoles, constants.ActionRead), rr.controller.GetRoleByID)
+		roleGroup.GET("/system", rr.permission.RequirePermission(constants.ResourceRoles, constants.ActionRead), rr.controller.GetSystemRoles)
+		roleGroup.POST("/", rr.permission.RequirePermission(constants.ResourceRoles, constants.ActionCreate), rr.controller.CreateRole)
+		roleGroup.PUT("/:roleId/parent", rr.permission.RequirePermission(constants.ResourceRoles, constants.ActionUpdate), rr.controller.SetRoleParent)
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"yet-another-itsm/internal/config"
 	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+	"yet-another-itsm/internal/service"
 	"yet-another-itsm/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -11,35 +13,68 @@ import (
 )
 
 type Routers struct {
-	Health         *HealthRouter
-	BusinessUnit   *BusinessUnitRouter
-	Department     *DepartmentRouter
-	User           *UserRouter
-	Role           *RoleRouter
-	Permission     *PermissionRouter
-	Scope          *ScopeRouter
-	RolePermission *RolePermissionRouter
-	RoleAssignment *RoleAssignmentRouter
-	FormCategory   *FormCategoryRouter
-	FormTemplate   *FormTemplateRouter
-	FormSection    *FormSectionRouter
+	Health               *HealthRouter
+	BusinessUnit         *BusinessUnitRouter
+	Department           *DepartmentRouter
+	User                 *UserRouter
+	Role                 *RoleRouter
+	Permission           *PermissionRouter
+	Scope                *ScopeRouter
+	RolePermission       *RolePermissionRouter
+	RoleAssignment       *RoleAssignmentRouter
+	FormCategory         *FormCategoryRouter
+	FormTemplate         *FormTemplateRouter
+	FormSection          *FormSectionRouter
+	FormField            *FormFieldRouter
+	FormSubmission       *FormSubmissionRouter
+	FieldType            *FieldTypeRouter
+	Authorization        *AuthorizationRouter
+	SodConstraint        *SodConstraintRouter
+	AccessReview         *AccessReviewRouter
+	DirectoryRoleMapping *DirectoryRoleMappingRouter
+	ElevationRequest     *ElevationRequestRouter
+	ServicePrincipal     *ServicePrincipalRouter
+	OrgChart             *OrgChartRouter
+	SCIM                 *SCIMRouter
+	Login                *LoginRouter
+	DevIssuer            *DevIssuerRouter
 }
 
-func NewRouters(controllers *controller.Controllers, config *config.Config) *Routers {
-	return &Routers{
-		Health:         NewHealthRouter(controllers.Health),
-		BusinessUnit:   NewBusinessUnitRouter(controllers.BusinessUnit, config),
-		Department:     NewDepartmentRouter(controllers.Department, config),
-		User:           NewUserRouter(controllers.User, config),
-		Role:           NewRoleRouter(controllers.Role, config),
-		Permission:     NewPermissionRouter(controllers.Permission, config),
-		Scope:          NewScopeRouter(controllers.Scope, config),
-		RolePermission: NewRolePermissionRouter(controllers.RolePermission, config),
-		RoleAssignment: NewRoleAssignmentRouter(controllers.RoleAssignment, config),
-		FormCategory:   NewFormCategoryRouter(controllers.FormCategory, config),
-		FormTemplate:   NewFormTemplateRouter(controllers.FormTemplate, config),
-		FormSection:    NewFormSectionRouter(controllers.FormSection, config),
+func NewRouters(controllers *controller.Controllers, services *service.Services, config *config.Config) *Routers {
+	permission := middleware.NewPermissionMiddleware(services)
+
+	routers := &Routers{
+		Health:               NewHealthRouter(controllers.Health),
+		BusinessUnit:         NewBusinessUnitRouter(controllers.BusinessUnit, config, permission),
+		Department:           NewDepartmentRouter(controllers.Department, config, permission),
+		User:                 NewUserRouter(controllers.User, config, permission),
+		Role:                 NewRoleRouter(controllers.Role, config, permission),
+		Permission:           NewPermissionRouter(controllers.Permission, config, permission),
+		Scope:                NewScopeRouter(controllers.Scope, config, permission),
+		RolePermission:       NewRolePermissionRouter(controllers.RolePermission, config, permission),
+		RoleAssignment:       NewRoleAssignmentRouter(controllers.RoleAssignment, config, permission),
+		FormCategory:         NewFormCategoryRouter(controllers.FormCategory, config, permission),
+		FormTemplate:         NewFormTemplateRouter(controllers.FormTemplate, config, permission),
+		FormSection:          NewFormSectionRouter(controllers.FormSection, config, permission),
+		FormField:            NewFormFieldRouter(controllers.FormField, config, permission),
+		FormSubmission:       NewFormSubmissionRouter(controllers.FormSubmission, config, permission),
+		FieldType:            NewFieldTypeRouter(controllers.FieldType, config, permission),
+		Authorization:        NewAuthorizationRouter(controllers.Authorization, config, permission),
+		SodConstraint:        NewSodConstraintRouter(controllers.SodConstraint, config, permission),
+		AccessReview:         NewAccessReviewRouter(controllers.AccessReview, config, permission),
+		DirectoryRoleMapping: NewDirectoryRoleMappingRouter(controllers.DirectoryRoleMapping, config, permission),
+		ElevationRequest:     NewElevationRequestRouter(controllers.ElevationRequest, config, permission),
+		ServicePrincipal:     NewServicePrincipalRouter(controllers.ServicePrincipal, config, permission),
+		OrgChart:             NewOrgChartRouter(controllers.OrgChart, config, permission),
+		SCIM:                 NewSCIMRouter(controllers.SCIM, config, permission),
+		Login:                NewLoginRouter(controllers.Login),
 	}
+
+	if controllers.DevIssuer != nil {
+		routers.DevIssuer = NewDevIssuerRouter(controllers.DevIssuer)
+	}
+
+	return routers
 }
 
 func (r *Routers) SetupRoutes(router *gin.Engine) {
@@ -81,6 +116,47 @@ func (r *Routers) SetupRoutes(router *gin.Engine) {
 	// Form section routes
 	r.FormSection.SetupFormSectionRoutes(v1)
 
+	// Form field routes
+	r.FormField.SetupFormFieldRoutes(v1)
+
+	// Form submission routes
+	r.FormSubmission.SetupFormSubmissionRoutes(v1)
+
+	// Field type routes
+	r.FieldType.SetupFieldTypeRoutes(v1)
+
+	// Authorization routes
+	r.Authorization.SetupAuthorizationRoutes(v1)
+
+	// Separation-of-duties constraint routes
+	r.SodConstraint.SetupSodConstraintRoutes(v1)
+
+	// Access review routes
+	r.AccessReview.SetupAccessReviewRoutes(v1)
+
+	// Directory role mapping routes
+	r.DirectoryRoleMapping.SetupDirectoryRoleMappingRoutes(v1)
+
+	// Elevation request routes
+	r.ElevationRequest.SetupElevationRequestRoutes(v1)
+
+	// Service principal routes
+	r.ServicePrincipal.SetupServicePrincipalRoutes(v1)
+
+	// Manager hierarchy and org chart routes
+	r.OrgChart.SetupOrgChartRoutes(v1)
+
+	// SCIM provisioning routes, outside /v1 at the SCIM tenant URL
+	r.SCIM.SetupSCIMRoutes(router)
+
+	// Browser login routes, outside /v1 where the redirect URI points
+	r.Login.SetupLoginRoutes(router)
+
+	// Dev issuer routes, outside /v1 and only in dev mode
+	if r.DevIssuer != nil {
+		r.DevIssuer.SetupDevIssuerRoutes(router)
+	}
+
 	router.NoRoute(func(c *gin.Context) {
 		log.Warn().
 			Str("path", c.Request.URL.Path).
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SCIMRouter struct {
+	controller *controller.SCIMController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewSCIMRouter(controller *controller.SCIMController, config *config.Config, permission *middleware.PermissionMiddleware) *SCIMRouter {
+	return &SCIMRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+// SetupSCIMRoutes serves SCIM 2.0 at /scim/v2, outside /v1, where
+// provisioning clients expect the tenant URL. The discovery endpoints are
+// public as RFC 7644 allows.
+func (sr *SCIMRouter) SetupSCIMRoutes(router *gin.Engine) {
+	scimGroup := router.Group("/scim/v2")
+	{
+		scimGroup.GET("/ServiceProviderConfig", sr.controller.GetServiceProviderConfig)
+		scimGroup.GET("/ResourceTypes", sr.controller.ListResourceTypes)
+		scimGroup.GET("/Schemas", sr.controller.ListSchemas)
+	}
+
+	userGroup := scimGroup.Group("/Users").Use(middleware.SCIMAuth(&sr.config.OAuth))
+	{
+		userGroup.GET("", sr.permission.RequireSCIMPermission(constants.ActionRead), sr.controller.ListUsers)
+		userGroup.GET("/:id", sr.permission.RequireSCIMPermission(constants.ActionRead), sr.controller.GetUser)
+		userGroup.POST("", sr.permission.RequireSCIMPermission(constants.ActionCreate), sr.controller.CreateUser)
+		userGroup.PUT("/:id", sr.permission.RequireSCIMPermission(constants.ActionUpdate), sr.controller.ReplaceUser)
+		userGroup.PATCH("/:id", sr.permission.RequireSCIMPermission(constants.ActionUpdate), sr.controller.PatchUser)
+		userGroup.DELETE("/:id", sr.permission.RequireSCIMPermission(constants.ActionDelete), sr.controller.DeleteUser)
+	}
+
+	groupGroup := scimGroup.Group("/Groups").Use(middleware.SCIMAuth(&sr.config.OAuth))
+	{
+		groupGroup.GET("", sr.permission.RequireSCIMPermission(constants.ActionRead), sr.controller.ListGroups)
+		groupGroup.GET("/:id", sr.permission.RequireSCIMPermission(constants.ActionRead), sr.controller.GetGroup)
+		groupGroup.POST("", sr.permission.RequireSCIMPermission(constants.ActionCreate), sr.controller.CreateGroup)
+		groupGroup.PUT("/:id", sr.permission.RequireSCIMPermission(constants.ActionUpdate), sr.controller.ReplaceGroup)
+		groupGroup.PATCH("/:id", sr.permission.RequireSCIMPermission(constants.ActionUpdate), sr.controller.PatchGroup)
+		groupGroup.DELETE("/:id", sr.permission.RequireSCIMPermission(constants.ActionDelete), sr.controller.DeleteGroup)
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,20 +12,22 @@ import (
 type ScopeRouter struct {
 	controller *controller.ScopeController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewScopeRouter(controller *controller.ScopeController, config *config.Config) *ScopeRouter {
+func NewScopeRouter(controller *controller.ScopeController, config *config.Config, permission *middleware.PermissionMiddleware) *ScopeRouter {
 	return &ScopeRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (sr *ScopeRouter) SetupScopeRoutes(v1 *gin.RouterGroup) {
 	scopeGroup := v1.Group("/scopes").Use(middleware.AuthMiddleWare(&sr.config.OAuth))
 	{
-		scopeGroup.GET("/", sr.controller.GetAllScopes)
-		scopeGroup.GET("/:scopeId", sr.controller.GetScopeByID)
-		scopeGroup.POST("/", sr.controller.CreateScope)
+		scopeGroup.GET("/", sr.permission.RequirePermission(constants.ResourceScopes, constants.ActionRead), sr.controller.GetAllScopes)
+		scopeGroup.GET("/:scopeId", sr.permission.RequirePermission(constants.ResourceScopes, constants.ActionRead), sr.controller.GetScopeByID)
+		scopeGroup.POST("/", sr.permission.RequirePermission(constants.ResourceScopes, constants.ActionCreate), sr.controller.CreateScope)
 	}
 }
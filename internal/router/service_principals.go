@@ -0,0 +1,38 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServicePrincipalRouter struct {
+	controller *controller.ServicePrincipalController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewServicePrincipalRouter(controller *controller.ServicePrincipalController, config *config.Config, permission *middleware.PermissionMiddleware) *ServicePrincipalRouter {
+	return &ServicePrincipalRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (spr *ServicePrincipalRouter) SetupServicePrincipalRoutes(v1 *gin.RouterGroup) {
+	principalGroup := v1.Group("/service-principals").Use(middleware.AuthMiddleWare(&spr.config.OAuth))
+	{
+		principalGroup.GET("", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionRead), spr.controller.GetServicePrincipals)
+		principalGroup.POST("", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionCreate), spr.controller.CreateServicePrincipal)
+		principalGroup.GET("/:servicePrincipalId", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionRead), spr.controller.GetServicePrincipalByID)
+		principalGroup.DELETE("/:servicePrincipalId", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionDelete), spr.controller.DeleteServicePrincipal)
+		principalGroup.GET("/:servicePrincipalId/api-keys", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionRead), spr.controller.GetAPIKeys)
+		principalGroup.POST("/:servicePrincipalId/api-keys", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionUpdate), spr.controller.CreateAPIKey)
+		principalGroup.POST("/:servicePrincipalId/api-keys/:keyId/rotate", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionUpdate), spr.controller.RotateAPIKey)
+		principalGroup.DELETE("/:servicePrincipalId/api-keys/:keyId", spr.permission.RequirePermission(constants.ResourceServicePrincipals, constants.ActionUpdate), spr.controller.RevokeAPIKey)
+	}
+}
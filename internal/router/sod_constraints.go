@@ -0,0 +1,35 @@
+package router
+
+import (
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/controller"
+	"yet-another-itsm/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SodConstraintRouter struct {
+	controller *controller.SodConstraintController
+	config     *config.Config
+	permission *middleware.PermissionMiddleware
+}
+
+func NewSodConstraintRouter(controller *controller.SodConstraintController, config *config.Config, permission *middleware.PermissionMiddleware) *SodConstraintRouter {
+	return &SodConstraintRouter{
+		controller: controller,
+		config:     config,
+		permission: permission,
+	}
+}
+
+func (scr *SodConstraintRouter) SetupSodConstraintRoutes(v1 *gin.RouterGroup) {
+	sodConstraintGroup := v1.Group("/sod-constraints").Use(middleware.AuthMiddleWare(&scr.config.OAuth))
+	{
+		sodConstraintGroup.GET("", scr.permission.RequirePermission(constants.ResourceSodConstraints, constants.ActionRead), scr.controller.GetSodConstraints)
+		sodConstraintGroup.POST("", scr.permission.RequirePermission(constants.ResourceSodConstraints, constants.ActionCreate), scr.controller.CreateSodConstraint)
+		sodConstraintGroup.GET("/violations", scr.permission.RequirePermission(constants.ResourceSodConstraints, constants.ActionRead), scr.controller.GetSodViolations)
+		sodConstraintGroup.GET("/:constraintId", scr.permission.RequirePermission(constants.ResourceSodConstraints, constants.ActionRead), scr.controller.GetSodConstraintByID)
+		sodConstraintGroup.DELETE("/:constraintId", scr.permission.RequirePermission(constants.ResourceSodConstraints, constants.ActionDelete), scr.controller.DeleteSodConstraint)
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
 	"yet-another-itsm/internal/controller"
 	"yet-another-itsm/internal/middleware"
 
@@ -11,25 +12,32 @@ import (
 type UserRouter struct {
 	controller *controller.UserController
 	config     *config.Config
+	permission *middleware.PermissionMiddleware
 }
 
-func NewUserRouter(controller *controller.UserController, config *config.Config) *UserRouter {
+func NewUserRouter(controller *controller.UserController, config *config.Config, permission *middleware.PermissionMiddleware) *UserRouter {
 	return &UserRouter{
 		controller: controller,
 		config:     config,
+		permission: permission,
 	}
 }
 
 func (ur *UserRouter) SetupUserRoutes(v1 *gin.RouterGroup) {
 	userGroup := v1.Group("/users").Use(middleware.AuthMiddleWare(&ur.config.OAuth))
 	{
+		userGroup.GET("", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ur.controller.SearchUsers)
 		userGroup.GET("/me", ur.controller.GetCurrentUser)
-		userGroup.GET("/:userId", ur.controller.GetUserByID)
-		userGroup.GET("/email", ur.controller.GetUserByEmail)
+		userGroup.GET("/:userId", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ur.controller.GetUserByID)
+		userGroup.GET("/email", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ur.controller.GetUserByEmail)
+		userGroup.GET("/:userId/status-changes", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ur.controller.GetUserStatusChanges)
+		userGroup.GET("/:userId/profile-changes", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ur.controller.GetUserProfileChanges)
+		userGroup.POST("/:userId/suspend", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionUpdate), ur.controller.SuspendUser)
+		userGroup.POST("/:userId/unlock", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionUpdate), ur.controller.UnlockUser)
 	}
 
 	departmentGroup := v1.Group("/departments").Use(middleware.AuthMiddleWare(&ur.config.OAuth))
 	{
-		departmentGroup.GET("/:departmentId/users", ur.controller.GetAllUsersInDepartment)
+		departmentGroup.GET("/:departmentId/users", ur.permission.RequirePermission(constants.ResourceUsers, constants.ActionRead), ur.controller.GetAllUsersInDepartment)
 	}
 }
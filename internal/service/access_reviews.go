@@ -0,0 +1,523 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AccessReviewService runs recertification campaigns over role assignments.
+// Each assignment in scope becomes an item reviewed by the assignee's manager;
+// items still pending when the campaign ends are revoked.
+type AccessReviewService interface {
+	CreateCampaign(ctx context.Context, req *dtos.CreateAccessReviewCampaignRequest) (*dtos.AccessReviewCampaignResponse, error)
+	GetCampaigns(ctx context.Context) ([]*dtos.AccessReviewCampaignResponse, error)
+	GetCampaignByID(ctx context.Context, id string) (*dtos.AccessReviewCampaignResponse, error)
+	GetCampaignItems(ctx context.Context, id string, page dtos.PageRequest) ([]*dtos.AccessReviewItemResponse, int64, error)
+	GetMyPendingItems(ctx context.Context, page dtos.PageRequest) ([]*dtos.AccessReviewItemResponse, int64, error)
+	DecideItem(ctx context.Context, itemID string, req *dtos.DecideAccessReviewItemRequest) (*dtos.AccessReviewItemResponse, error)
+	CompleteCampaign(ctx context.Context, id string) (*dtos.AccessReviewCampaignResponse, error)
+	CloseDueCampaigns(ctx context.Context) (int, error)
+	ExportCampaignCSV(ctx context.Context, id string) ([]byte, error)
+}
+
+// exportPageSize is the number of items the CSV export reads per query.
+const exportPageSize = 500
+
+type accessReviewService struct {
+	db   *database.Database
+	repo *repository.Queries
+}
+
+func NewAccessReviewService(db *database.Database, repo *repository.Queries) AccessReviewService {
+	return &accessReviewService{
+		db:   db,
+		repo: repo,
+	}
+}
+
+// CreateCampaign starts a campaign and generates its review items in one transaction.
+func (s *accessReviewService) CreateCampaign(ctx context.Context, req *dtos.CreateAccessReviewCampaignRequest) (*dtos.AccessReviewCampaignResponse, error) {
+	log.Info().
+		Str("service", "AccessReviewService").
+		Str("method", "CreateCampaign").
+		Str("name", req.Name).
+		Strs("role_ids", req.RoleIDs).
+		Strs("business_unit_ids", req.BusinessUnitIDs).
+		Msg("Starting access review campaign")
+
+	createdBy, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, req.EndsAt)
+	if err != nil || !endsAt.After(time.Now()) {
+		return nil, constants.ErrInvalidEndsAt
+	}
+
+	params := repository.CreateAccessReviewCampaignParams{
+		Name:            req.Name,
+		RoleIds:         req.RoleIDs,
+		BusinessUnitIds: make([]pgtype.UUID, len(req.BusinessUnitIDs)),
+		EndsAt:          pgtype.Timestamptz{Time: endsAt, Valid: true},
+		CreatedBy:       createdBy,
+		TenantID:        tenantID,
+	}
+	if params.RoleIds == nil {
+		params.RoleIds = []string{}
+	}
+	if req.Description != "" {
+		params.Description = pgtype.Text{String: req.Description, Valid: true}
+	}
+	for i, id := range req.BusinessUnitIDs {
+		if err := params.BusinessUnitIds[i].Scan(id); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	campaign, err := qtx.CreateAccessReviewCampaign(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Interface("params", params).Msg("Failed to create access review campaign in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateAccessReviewCampaign, err)
+	}
+
+	count, err := qtx.CreateAccessReviewItems(ctx, campaign.ID)
+	if err != nil {
+		log.Error().Err(err).Str("campaign_id", campaign.ID.String()).Msg("Failed to create access review items in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateAccessReviewItems, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "AccessReviewService").
+		Str("method", "CreateCampaign").
+		Str("campaign_id", campaign.ID.String()).
+		Int64("items", count).
+		Msg("Successfully started access review campaign")
+
+	return dtos.NewAccessReviewCampaignResponse(campaign, repository.GetAccessReviewCampaignProgressRow{Total: count, Pending: count}), nil
+}
+
+func (s *accessReviewService) GetCampaigns(ctx context.Context) ([]*dtos.AccessReviewCampaignResponse, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	campaigns, err := s.repo.ListAccessReviewCampaigns(ctx, tenantID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get access review campaigns from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewCampaigns, err)
+	}
+
+	result := make([]*dtos.AccessReviewCampaignResponse, len(campaigns))
+	for i, campaign := range campaigns {
+		if result[i], err = s.withProgress(ctx, campaign); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *accessReviewService) GetCampaignByID(ctx context.Context, id string) (*dtos.AccessReviewCampaignResponse, error) {
+	campaign, err := s.getCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withProgress(ctx, campaign)
+}
+
+func (s *accessReviewService) GetCampaignItems(ctx context.Context, id string, page dtos.PageRequest) ([]*dtos.AccessReviewItemResponse, int64, error) {
+	campaign, err := s.getCampaign(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.pageItems(ctx, repository.ListAccessReviewItemsParams{CampaignID: campaign.ID}, page)
+}
+
+// GetMyPendingItems returns a page of the caller's undecided items across active campaigns.
+func (s *accessReviewService) GetMyPendingItems(ctx context.Context, page dtos.PageRequest) ([]*dtos.AccessReviewItemResponse, int64, error) {
+	reviewerID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.pageItems(ctx, repository.ListAccessReviewItemsParams{ReviewerID: reviewerID}, page)
+}
+
+// DecideItem records the reviewer's decision until the campaign's ends_at. A
+// revoke takes effect on the role assignment immediately. Nobody decides the
+// review of their own assignment.
+func (s *accessReviewService) DecideItem(ctx context.Context, itemID string, req *dtos.DecideAccessReviewItemRequest) (*dtos.AccessReviewItemResponse, error) {
+	log.Info().
+		Str("service", "AccessReviewService").
+		Str("method", "DecideItem").
+		Str("item_id", itemID).
+		Str("decision", req.Decision).
+		Msg("Recording access review decision")
+
+	reviewerID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, err := utils.ParseUUID(itemID)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	id := pgtype.UUID{Bytes: uuid, Valid: true}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	item, err := s.repo.GetAccessReviewItemByID(ctx, repository.GetAccessReviewItemByIDParams{
+		ID:       id,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrAccessReviewItemNotFound
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewItem, err)
+	}
+	if item.ReviewerID != reviewerID {
+		return nil, constants.ErrNotAccessReviewer
+	}
+	if item.AssigneeID == reviewerID {
+		return nil, constants.ErrAccessReviewSelfReview
+	}
+
+	campaign, err := s.repo.GetAccessReviewCampaignByID(ctx, repository.GetAccessReviewCampaignByIDParams{
+		ID:       item.CampaignID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewCampaign, err)
+	}
+	if campaign.Status != repository.AccessReviewStatusActive {
+		return nil, constants.ErrAccessReviewCampaignClosed
+	}
+	// Past ends_at the pending items belong to the close job, which revokes them.
+	if !time.Now().Before(campaign.EndsAt.Time) {
+		return nil, constants.ErrAccessReviewCampaignEnded
+	}
+
+	params := repository.DecideAccessReviewItemParams{
+		ID:        id,
+		Decision:  repository.AccessReviewDecision(req.Decision),
+		DecidedBy: reviewerID,
+	}
+	if req.Comment != "" {
+		params.Comment = pgtype.Text{String: req.Comment, Valid: true}
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	decided, err := qtx.DecideAccessReviewItem(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrAccessReviewItemDecided
+		}
+		log.Error().Err(err).Str("item_id", itemID).Msg("Failed to record access review decision in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDecideAccessReviewItem, err)
+	}
+
+	if decided.Decision == repository.AccessReviewDecisionRevoked {
+		if err := revokeReviewedAssignment(ctx, qtx, decided); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	items, err := s.listItems(ctx, repository.ListAccessReviewItemsParams{
+		CampaignID: decided.CampaignID,
+		ID:         decided.ID,
+		PageLimit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, constants.ErrAccessReviewItemNotFound
+	}
+	return items[0], nil
+}
+
+// CompleteCampaign ends an active campaign early, revoking every item still pending.
+func (s *accessReviewService) CompleteCampaign(ctx context.Context, id string) (*dtos.AccessReviewCampaignResponse, error) {
+	campaign, err := s.getCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != repository.AccessReviewStatusActive {
+		return nil, constants.ErrAccessReviewCampaignClosed
+	}
+
+	completed, err := s.completeCampaign(ctx, campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withProgress(ctx, completed)
+}
+
+// CloseDueCampaigns completes every active campaign past its ends_at and returns how many were closed.
+func (s *accessReviewService) CloseDueCampaigns(ctx context.Context) (int, error) {
+	campaigns, err := s.repo.GetDueAccessReviewCampaigns(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get due access review campaigns from repository")
+		return 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewCampaigns, err)
+	}
+
+	closed := 0
+	for _, campaign := range campaigns {
+		if _, err := s.completeCampaign(ctx, campaign.ID); err != nil {
+			if errors.Is(err, constants.ErrAccessReviewCampaignClosed) {
+				continue
+			}
+			return closed, err
+		}
+		closed++
+	}
+
+	return closed, nil
+}
+
+// ExportCampaignCSV renders every item of the campaign, with its decision, as CSV.
+func (s *accessReviewService) ExportCampaignCSV(ctx context.Context, id string) ([]byte, error) {
+	campaign, err := s.getCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(dtos.AccessReviewExportHeader); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToWriteAccessReviewExport, err)
+	}
+	params := repository.ListAccessReviewItemsParams{CampaignID: campaign.ID, PageLimit: exportPageSize}
+	for {
+		items, err := s.listItems(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if err := writer.Write(item.CSVRecord()); err != nil {
+				return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToWriteAccessReviewExport, err)
+			}
+		}
+		if len(items) < exportPageSize {
+			break
+		}
+		params.PageOffset += exportPageSize
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToWriteAccessReviewExport, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// completeCampaign closes the campaign, auto-revokes its pending items and the
+// assignments behind them in one transaction.
+func (s *accessReviewService) completeCampaign(ctx context.Context, id pgtype.UUID) (repository.AccessReviewCampaign, error) {
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return repository.AccessReviewCampaign{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	campaign, err := qtx.CompleteAccessReviewCampaign(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.AccessReviewCampaign{}, constants.ErrAccessReviewCampaignClosed
+		}
+		return repository.AccessReviewCampaign{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCompleteAccessReviewCampaign, err)
+	}
+
+	revoked, err := qtx.AutoRevokeAccessReviewItems(ctx, id)
+	if err != nil {
+		return repository.AccessReviewCampaign{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDecideAccessReviewItem, err)
+	}
+	for _, item := range revoked {
+		if err := revokeReviewedAssignment(ctx, qtx, item); err != nil {
+			return repository.AccessReviewCampaign{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return repository.AccessReviewCampaign{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "AccessReviewService").
+		Str("method", "completeCampaign").
+		Str("campaign_id", id.String()).
+		Int("auto_revoked", len(revoked)).
+		Msg("Access review campaign completed")
+
+	return campaign, nil
+}
+
+func (s *accessReviewService) getCampaign(ctx context.Context, id string) (repository.AccessReviewCampaign, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return repository.AccessReviewCampaign{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.AccessReviewCampaign{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	campaign, err := s.repo.GetAccessReviewCampaignByID(ctx, repository.GetAccessReviewCampaignByIDParams{
+		ID:       pgtype.UUID{Bytes: uuid, Valid: true},
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.AccessReviewCampaign{}, constants.ErrAccessReviewCampaignNotFound
+		}
+		log.Error().Err(err).Str("campaign_id", id).Msg("Failed to get access review campaign from repository")
+		return repository.AccessReviewCampaign{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewCampaign, err)
+	}
+
+	return campaign, nil
+}
+
+func (s *accessReviewService) withProgress(ctx context.Context, campaign repository.AccessReviewCampaign) (*dtos.AccessReviewCampaignResponse, error) {
+	progress, err := s.repo.GetAccessReviewCampaignProgress(ctx, campaign.ID)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewItems, err)
+	}
+
+	return dtos.NewAccessReviewCampaignResponse(campaign, progress), nil
+}
+
+// pageItems lists one page of the review items matching the filters of params
+// and counts all of them.
+func (s *accessReviewService) pageItems(ctx context.Context, params repository.ListAccessReviewItemsParams, page dtos.PageRequest) ([]*dtos.AccessReviewItemResponse, int64, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	total, err := s.repo.CountAccessReviewItems(ctx, repository.CountAccessReviewItemsParams{
+		TenantID:   tenantID,
+		CampaignID: params.CampaignID,
+		ReviewerID: params.ReviewerID,
+	})
+	if err != nil {
+		log.Error().Err(err).Interface("params", params).Msg("Failed to count access review items in repository")
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewItems, err)
+	}
+
+	params.PageLimit = page.Limit()
+	params.PageOffset = page.Offset()
+	items, err := s.listItems(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// listItems lists the review items matching params within the caller's tenant.
+func (s *accessReviewService) listItems(ctx context.Context, params repository.ListAccessReviewItemsParams) ([]*dtos.AccessReviewItemResponse, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+	params.TenantID = tenantID
+
+	rows, err := s.repo.ListAccessReviewItems(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Interface("params", params).Msg("Failed to get access review items from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccessReviewItems, err)
+	}
+
+	result := make([]*dtos.AccessReviewItemResponse, len(rows))
+	for i, row := range rows {
+		result[i] = dtos.NewAccessReviewItemResponse(row)
+	}
+
+	return result, nil
+}
+
+// getCurrentUserID returns the internal ID of the authenticated caller.
+func (s *accessReviewService) getCurrentUserID(ctx context.Context) (pgtype.UUID, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
+
+// revokeReviewedAssignment revokes the role assignment behind a revoked item.
+// An assignment that was already revoked elsewhere is left as is.
+func revokeReviewedAssignment(ctx context.Context, q *repository.Queries, item repository.AccessReviewItem) error {
+	if _, err := q.RevokeRoleAssignment(ctx, item.RoleAssignmentID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Error().Err(err).
+			Str("item_id", item.ID.String()).
+			Str("role_assignment_id", item.RoleAssignmentID.String()).
+			Msg("Failed to revoke reviewed role assignment")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeRoleAssignment, err)
+	}
+
+	log.Info().
+		Str("item_id", item.ID.String()).
+		Str("role_assignment_id", item.RoleAssignmentID.String()).
+		Bool("auto_revoked", item.AutoRevoked).
+		Msg("Role assignment revoked by access review")
+	return nil
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// AuthorizationService evaluates scoped permissions (own, department,
+// business unit, tenant) for the authenticated caller.
+type AuthorizationService interface {
+	GetPermissionGrants(ctx context.Context, userID, resource, action string) ([]*dtos.PermissionGrant, error)
+	FindGrant(ctx context.Context, userID, resource, action string, target *dtos.AuthorizationTarget) (*dtos.PermissionGrant, error)
+	Authorize(ctx context.Context, resource, action string, target *dtos.AuthorizationTarget) (bool, error)
+	GetScopeFilter(ctx context.Context, resource, action string) (*dtos.ScopeFilter, error)
+	GetEffectivePermissions(ctx context.Context, userID string) ([]*dtos.EffectivePermission, error)
+	Check(ctx context.Context, checks []dtos.AuthorizationCheck) ([]dtos.AuthorizationCheckResult, error)
+}
+
+type authorizationService struct {
+	repo *repository.Queries
+}
+
+func NewAuthorizationService(repo *repository.Queries) AuthorizationService {
+	return &authorizationService{
+		repo: repo,
+	}
+}
+
+// GetPermissionGrants returns every active, non-expired assignment granting resource/action to the user,
+// either directly or through an ancestor of the assigned role. Direct grants come first.
+func (s *authorizationService) GetPermissionGrants(ctx context.Context, userID, resource, action string) ([]*dtos.PermissionGrant, error) {
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Invalid user ID format")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	rows, err := s.repo.GetUserPermissionGrants(ctx, repository.GetUserPermissionGrantsParams{
+		AssigneeID: pgtype.UUID{Bytes: uuid, Valid: true},
+		Resource:   resource,
+		Action:     action,
+	})
+	if err != nil {
+		log.Error().Err(err).
+			Str("user_id", userID).
+			Str("resource", resource).
+			Str("action", action).
+			Msg("Failed to get permission grants from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetPermissionGrants, err)
+	}
+
+	grants := make([]*dtos.PermissionGrant, len(rows))
+	for i, row := range rows {
+		grants[i] = newPermissionGrant(row.RoleAssignmentID, row.RoleID, row.RoleName, row.SourceRoleID, row.ScopeID, row.BusinessUnitID, row.DepartmentID, row.TenantID, row.ExpiresAt)
+	}
+
+	return grants, nil
+}
+
+// FindGrant returns the first grant that covers target, or nil if none does.
+func (s *authorizationService) FindGrant(ctx context.Context, userID, resource, action string, target *dtos.AuthorizationTarget) (*dtos.PermissionGrant, error) {
+	grants, err := s.GetPermissionGrants(ctx, userID, resource, action)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, grant := range grants {
+		if grantCoversTarget(grant, userID, target) {
+			return grant, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Authorize checks whether the authenticated caller may perform action on a resource owned by target.
+func (s *authorizationService) Authorize(ctx context.Context, resource, action string, target *dtos.AuthorizationTarget) (bool, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return false, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	grant, err := s.FindGrant(ctx, userID, resource, action, target)
+	if err != nil {
+		return false, err
+	}
+
+	log.Info().
+		Str("service", "AuthorizationService").
+		Str("method", "Authorize").
+		Str("user_id", userID).
+		Str("resource", resource).
+		Str("action", action).
+		Bool("allowed", grant != nil).
+		Msg("Evaluated scoped permission")
+
+	return grant != nil, nil
+}
+
+// GetScopeFilter merges the caller's grants into a filter suitable for list queries.
+// The filter is always bounded to the caller's home tenant.
+func (s *authorizationService) GetScopeFilter(ctx context.Context, resource, action string) (*dtos.ScopeFilter, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	grants, err := s.GetPermissionGrants(ctx, userID, resource, action)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &dtos.ScopeFilter{TenantID: homeTenantID.String()}
+	for _, grant := range grants {
+		switch grant.ScopeID {
+		case constants.ScopeTenant:
+			filter.AllAccess = true
+		case constants.ScopeBusinessUnit:
+			if grant.BusinessUnitID != "" {
+				filter.BusinessUnitIDs = append(filter.BusinessUnitIDs, grant.BusinessUnitID)
+			}
+		case constants.ScopeDepartment:
+			if grant.DepartmentID != "" {
+				filter.DepartmentIDs = append(filter.DepartmentIDs, grant.DepartmentID)
+			}
+		case constants.ScopeOwn:
+			filter.OwnerID = userID
+		default:
+			log.Warn().Str("scope_id", grant.ScopeID).Str("role_id", grant.RoleID).Msg("Unknown permission scope, ignoring grant")
+		}
+	}
+
+	return filter, nil
+}
+
+// GetEffectivePermissions flattens the user's active, non-expired assignments into
+// resource/action/scope tuples, grouping the assignments that grant the same tuple.
+func (s *authorizationService) GetEffectivePermissions(ctx context.Context, userID string) ([]*dtos.EffectivePermission, error) {
+	log.Info().
+		Str("service", "AuthorizationService").
+		Str("method", "GetEffectivePermissions").
+		Str("user_id", userID).
+		Msg("Getting effective permissions")
+
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Invalid user ID format")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	rows, err := s.repo.GetUserEffectivePermissions(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to get effective permissions from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetPermissionGrants, err)
+	}
+
+	result := make([]*dtos.EffectivePermission, 0, len(rows))
+	index := make(map[string]*dtos.EffectivePermission, len(rows))
+	for _, row := range rows {
+		grant := newPermissionGrant(row.RoleAssignmentID, row.RoleID, row.RoleName, row.SourceRoleID, row.ScopeID, row.BusinessUnitID, row.DepartmentID, row.TenantID, row.ExpiresAt)
+
+		// Business unit and department only narrow the tuple for the scopes that use them.
+		permission := &dtos.EffectivePermission{
+			Resource: row.Resource,
+			Action:   row.Action,
+			ScopeID:  grant.ScopeID,
+		}
+		switch grant.ScopeID {
+		case constants.ScopeBusinessUnit:
+			permission.BusinessUnitID = grant.BusinessUnitID
+		case constants.ScopeDepartment:
+			permission.DepartmentID = grant.DepartmentID
+		}
+
+		key := strings.Join([]string{permission.Resource, permission.Action, permission.ScopeID, permission.BusinessUnitID, permission.DepartmentID}, "|")
+		if existing, ok := index[key]; ok {
+			existing.Grants = append(existing.Grants, *grant)
+			continue
+		}
+
+		permission.Grants = []dtos.PermissionGrant{*grant}
+		index[key] = permission
+		result = append(result, permission)
+	}
+
+	return result, nil
+}
+
+// Check evaluates a batch of resource/action/target tuples for the authenticated caller.
+// A check without a target is allowed by any grant, matching RequirePermission. A target
+// without a tenant is taken to be in the caller's home tenant.
+func (s *authorizationService) Check(ctx context.Context, checks []dtos.AuthorizationCheck) ([]dtos.AuthorizationCheckResult, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	grantsByPermission := make(map[string][]*dtos.PermissionGrant)
+	results := make([]dtos.AuthorizationCheckResult, len(checks))
+	for i, check := range checks {
+		key := check.Resource + "." + check.Action
+		grants, ok := grantsByPermission[key]
+		if !ok {
+			grants, err = s.GetPermissionGrants(ctx, userID, check.Resource, check.Action)
+			if err != nil {
+				return nil, err
+			}
+			grantsByPermission[key] = grants
+		}
+
+		results[i] = dtos.AuthorizationCheckResult{
+			Resource: check.Resource,
+			Action:   check.Action,
+		}
+
+		if len(grants) == 0 {
+			results[i].Reason = fmt.Sprintf(constants.ReasonNoActiveGrant, check.Resource, check.Action)
+			continue
+		}
+
+		var grant *dtos.PermissionGrant
+		if check.Target == nil {
+			grant = grants[0]
+		} else {
+			target := *check.Target
+			if target.TenantID == "" {
+				target.TenantID = homeTenantID.String()
+			}
+			for _, candidate := range grants {
+				if grantCoversTarget(candidate, userID, &target) {
+					grant = candidate
+					break
+				}
+			}
+		}
+
+		if grant == nil {
+			results[i].Reason = fmt.Sprintf(constants.ReasonScopeNotCovered, check.Resource, check.Action)
+			continue
+		}
+
+		results[i].Allowed = true
+		results[i].Grant = grant
+		results[i].Reason = fmt.Sprintf(constants.ReasonPermissionGranted, grant.RoleName, grant.RoleAssignmentID, grant.ScopeID)
+		if grant.InheritedFromRoleID != "" {
+			results[i].Reason = fmt.Sprintf(constants.ReasonPermissionInherited, grant.InheritedFromRoleID, grant.RoleName, grant.RoleAssignmentID, grant.ScopeID)
+		}
+	}
+
+	log.Info().
+		Str("service", "AuthorizationService").
+		Str("method", "Check").
+		Str("user_id", userID).
+		Int("count", len(results)).
+		Msg("Evaluated authorization checks")
+
+	return results, nil
+}
+
+// newPermissionGrant builds a grant from the columns shared by the grant queries.
+// A role permission without a scope is tenant-wide, bounded by the assignee's tenantID.
+// sourceRoleID is the role that owns the permission, which differs from roleID when it
+// is inherited from an ancestor.
+func newPermissionGrant(roleAssignmentID pgtype.UUID, roleID, roleName, sourceRoleID string, scopeID pgtype.Text, businessUnitID, departmentID, tenantID pgtype.UUID, expiresAt pgtype.Timestamptz) *dtos.PermissionGrant {
+	grant := &dtos.PermissionGrant{
+		RoleAssignmentID: roleAssignmentID.String(),
+		RoleID:           roleID,
+		RoleName:         roleName,
+		ScopeID:          constants.ScopeTenant,
+	}
+	if tenantID.Valid {
+		grant.TenantID = tenantID.String()
+	}
+	if scopeID.Valid {
+		grant.ScopeID = scopeID.String
+	}
+	if sourceRoleID != "" && sourceRoleID != roleID {
+		grant.InheritedFromRoleID = sourceRoleID
+	}
+	if businessUnitID.Valid {
+		grant.BusinessUnitID = businessUnitID.String()
+	}
+	if departmentID.Valid {
+		grant.DepartmentID = departmentID.String()
+	}
+	if expiresAt.Valid {
+		grant.ExpiresAt = utils.FormatTime(expiresAt.Time)
+	}
+	return grant
+}
+
+// grantCoversTarget applies a grant's scope to the owner of the target resource.
+// No grant reaches outside the tenant it was given in.
+func grantCoversTarget(grant *dtos.PermissionGrant, userID string, target *dtos.AuthorizationTarget) bool {
+	if target == nil || grant.TenantID == "" || grant.TenantID != target.TenantID {
+		return false
+	}
+
+	switch grant.ScopeID {
+	case constants.ScopeTenant:
+		return true
+	case constants.ScopeBusinessUnit:
+		return grant.BusinessUnitID != "" && grant.BusinessUnitID == target.BusinessUnitID
+	case constants.ScopeDepartment:
+		return grant.DepartmentID != "" && grant.DepartmentID == target.DepartmentID
+	case constants.ScopeOwn:
+		return target.OwnerID != "" && target.OwnerID == userID
+	default:
+		return false
+	}
+}
+
+// scopeFilterUUIDs converts the string IDs of a scope filter to pgtype values for list queries.
+func scopeFilterUUIDs(ids []string) ([]pgtype.UUID, error) {
+	result := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		uuid, err := utils.ParseUUID(id)
+		if err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+		result = append(result, pgtype.UUID{Bytes: uuid, Valid: true})
+	}
+	return result, nil
+}
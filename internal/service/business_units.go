@@ -65,8 +65,13 @@ func (s *businessUnitService) GetAllBusinessUnitsInTenant(ctx context.Context) (
 	return businessUnits, nil
 }
 
-// GetBusinessUnitByDomainName gets a business unit by domain name.
+// GetBusinessUnitByDomainName gets a business unit of the caller's tenant by domain name.
 func (s *businessUnitService) GetBusinessUnitByDomainName(ctx context.Context, domainName string) (*dtos.BusinessUnit, error) {
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
@@ -79,7 +84,10 @@ func (s *businessUnitService) GetBusinessUnitByDomainName(ctx context.Context, d
 		Str("user_id", userID).
 		Msg("Getting business unit by domain name")
 
-	repoBusinessUnit, err := s.repo.GetBusinessUnitByDomainName(ctx, domainName)
+	repoBusinessUnit, err := s.repo.GetBusinessUnitByDomainName(ctx, repository.GetBusinessUnitByDomainNameParams{
+		DomainName: domainName,
+		TenantID:   tenantID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetBusinessUnit, err)
 	}
@@ -88,8 +96,13 @@ func (s *businessUnitService) GetBusinessUnitByDomainName(ctx context.Context, d
 	return dto, nil
 }
 
-// GetBusinessUnitByID gets a business unit by ID.
+// GetBusinessUnitByID gets a business unit of the caller's tenant by ID.
 func (s *businessUnitService) GetBusinessUnitByID(ctx context.Context, id string) (*dtos.BusinessUnit, error) {
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
@@ -108,7 +121,10 @@ func (s *businessUnitService) GetBusinessUnitByID(ctx context.Context, id string
 		Str("user_id", userID).
 		Msg("Getting business unit by ID")
 
-	repoBusinessUnit, err := s.repo.GetBusinessUnitByID(ctx, uuid)
+	repoBusinessUnit, err := s.repo.GetBusinessUnitByID(ctx, repository.GetBusinessUnitByIDParams{
+		ID:       uuid,
+		TenantID: tenantID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetBusinessUnit, err)
 	}
@@ -117,8 +133,16 @@ func (s *businessUnitService) GetBusinessUnitByID(ctx context.Context, id string
 	return dto, nil
 }
 
-// CreateBusinessUnit creates a new business unit
+// CreateBusinessUnit creates a new business unit in the caller's tenant
 func (s *businessUnitService) CreateBusinessUnit(ctx context.Context, req *dtos.CreateBusinessUnitRequest) (*dtos.BusinessUnit, error) {
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+	if req.TenantID != tenantID {
+		return nil, constants.ErrBusinessUnitTenantMismatch
+	}
+
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
@@ -2,86 +2,159 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
 	"yet-another-itsm/internal/dtos"
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/utils"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog/log"
 )
 
 type DepartmentService interface {
+	ListDepartments(ctx context.Context, businessUnitID, parentID string) ([]*dtos.Department, error)
 	GetDepartmentByID(ctx context.Context, id string) (*dtos.Department, error)
-	GetDepartmentByName(ctx context.Context, name string) (*dtos.Department, error)
+	GetDepartmentByName(ctx context.Context, businessUnitID, name string) (*dtos.Department, error)
+	GetDepartmentSubtree(ctx context.Context, id string) ([]*dtos.Department, error)
 	CreateDepartment(ctx context.Context, req *dtos.CreateDepartmentRequest) (*dtos.Department, error)
-	GetOrCreateDepartmentByName(ctx context.Context, name string) (*dtos.Department, error)
+	UpdateDepartment(ctx context.Context, id string, req *dtos.UpdateDepartmentRequest) (*dtos.Department, error)
+	MoveDepartment(ctx context.Context, id string, req *dtos.MoveDepartmentRequest) (*dtos.Department, error)
+	DeleteDepartment(ctx context.Context, id string) (*dtos.Department, error)
+	GetOrCreateDepartmentByName(ctx context.Context, businessUnitID pgtype.UUID, name string) (*dtos.Department, error)
 }
 
 type departmentService struct {
+	db   *database.Database
 	repo *repository.Queries
 }
 
-func NewDepartmentService(repo *repository.Queries) DepartmentService {
+func NewDepartmentService(db *database.Database, repo *repository.Queries) DepartmentService {
 	return &departmentService{
+		db:   db,
 		repo: repo,
 	}
 }
 
-// GetDepartmentByID gets a department by ID.
-func (s *departmentService) GetDepartmentByID(ctx context.Context, id string) (*dtos.Department, error) {
-	userID, err := utils.GetUserID(ctx)
+// ListDepartments lists the departments of the caller's tenant, optionally
+// narrowed to a business unit and to the direct children of a department.
+func (s *departmentService) ListDepartments(ctx context.Context, businessUnitID, parentID string) ([]*dtos.Department, error) {
+	tenantID, err := utils.GetTenantID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
 	}
 
-	var uuid pgtype.UUID
-	err = uuid.Scan(id)
+	log.Info().
+		Str("service", "DepartmentService").
+		Str("endpoint", "ListDepartments").
+		Str("business_unit_id", businessUnitID).
+		Str("parent_id", parentID).
+		Msg("Listing departments")
+
+	params := repository.ListDepartmentsParams{TenantID: tenantID}
+	if businessUnitID != "" {
+		if err := params.BusinessUnitID.Scan(businessUnitID); err != nil {
+			return nil, constants.ErrInvalidDepartmentReference
+		}
+	}
+	if parentID != "" {
+		if err := params.ParentID.Scan(parentID); err != nil {
+			return nil, constants.ErrInvalidDepartmentReference
+		}
+	}
+
+	repoDepartments, err := s.repo.ListDepartments(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		log.Error().Err(err).Msg("Failed to list departments from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartments, err)
 	}
 
+	result := make([]*dtos.Department, len(repoDepartments))
+	for i, repoDepartment := range repoDepartments {
+		result[i] = (&dtos.Department{}).FromRepositoryModel(repoDepartment)
+	}
+	return result, nil
+}
+
+// GetDepartmentByID gets a department of the caller's tenant by ID.
+func (s *departmentService) GetDepartmentByID(ctx context.Context, id string) (*dtos.Department, error) {
 	log.Info().
 		Str("service", "DepartmentService").
 		Str("endpoint", "GetDepartmentByID").
 		Str("id", id).
-		Str("user_id", userID).
 		Msg("Getting department by ID")
 
-	repoDepartment, err := s.repo.GetDepartmentByID(ctx, uuid)
+	repoDepartment, err := s.getDepartment(ctx, s.repo, id, constants.ErrDepartmentNotFound)
 	if err != nil {
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartment, err)
+		return nil, err
 	}
 
-	dto := (&dtos.Department{}).FromRepositoryModel(repoDepartment)
-	return dto, nil
+	return (&dtos.Department{}).FromRepositoryModel(repoDepartment), nil
 }
 
-// GetDepartmentByName gets a department by name.
-func (s *departmentService) GetDepartmentByName(ctx context.Context, name string) (*dtos.Department, error) {
-	userID, err := utils.GetUserID(ctx)
-	if err != nil {
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
-	}
-
+// GetDepartmentByName gets a department by name within a business unit of
+// the caller's tenant.
+func (s *departmentService) GetDepartmentByName(ctx context.Context, businessUnitID, name string) (*dtos.Department, error) {
 	log.Info().
 		Str("service", "DepartmentService").
 		Str("endpoint", "GetDepartmentByName").
+		Str("business_unit_id", businessUnitID).
 		Str("name", name).
-		Str("user_id", userID).
 		Msg("Getting department by name")
 
-	repoDepartment, err := s.repo.GetDepartmentByName(ctx, name)
+	businessUnit, err := s.tenantBusinessUnit(ctx, businessUnitID)
 	if err != nil {
+		return nil, err
+	}
+
+	repoDepartment, err := s.repo.GetDepartmentByName(ctx, repository.GetDepartmentByNameParams{
+		BusinessUnitID: businessUnit,
+		Name:           name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrDepartmentNotFound
+		}
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartment, err)
 	}
 
-	dto := (&dtos.Department{}).FromRepositoryModel(repoDepartment)
-	return dto, nil
+	return (&dtos.Department{}).FromRepositoryModel(repoDepartment), nil
+}
+
+// GetDepartmentSubtree returns the department followed by its descendants,
+// depth first.
+func (s *departmentService) GetDepartmentSubtree(ctx context.Context, id string) ([]*dtos.Department, error) {
+	log.Info().
+		Str("service", "DepartmentService").
+		Str("endpoint", "GetDepartmentSubtree").
+		Str("id", id).
+		Msg("Getting department subtree")
+
+	department, err := s.getDepartment(ctx, s.repo, id, constants.ErrDepartmentNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListDepartmentSubtree(ctx, department.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get department subtree from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartments, err)
+	}
+
+	result := make([]*dtos.Department, len(rows))
+	for i, row := range rows {
+		result[i] = (&dtos.Department{}).FromSubtreeRow(row)
+	}
+	return result, nil
 }
 
+// CreateDepartment creates a department in a business unit of the caller's
+// tenant. A parent department must belong to the same business unit.
 func (s *departmentService) CreateDepartment(ctx context.Context, req *dtos.CreateDepartmentRequest) (*dtos.Department, error) {
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
@@ -91,72 +164,380 @@ func (s *departmentService) CreateDepartment(ctx context.Context, req *dtos.Crea
 	log.Info().
 		Str("service", "DepartmentService").
 		Str("endpoint", "CreateDepartment").
+		Str("business_unit_id", req.BusinessUnitID).
+		Str("parent_id", req.ParentID).
 		Str("name", req.Name).
 		Str("user_id", userID).
 		Msg("Creating new department")
 
+	status, err := parseDepartmentStatus(req.Status, repository.StatusEnumActive)
+	if err != nil {
+		return nil, err
+	}
+
+	businessUnit, err := s.tenantBusinessUnit(ctx, req.BusinessUnitID)
+	if err != nil {
+		return nil, err
+	}
+
 	params := repository.CreateDepartmentParams{
-		Name:   req.Name,
-		Status: repository.NullStatusEnum{StatusEnum: repository.StatusEnum(req.Status), Valid: true},
+		BusinessUnitID: businessUnit,
+		Name:           req.Name,
+		Status:         repository.NullStatusEnum{StatusEnum: status, Valid: true},
+	}
+	if req.ParentID != "" {
+		parent, err := s.getDepartment(ctx, s.repo, req.ParentID, constants.ErrParentDepartmentNotFound)
+		if err != nil {
+			return nil, err
+		}
+		if parent.BusinessUnitID != businessUnit {
+			return nil, constants.ErrDepartmentBusinessUnitMismatch
+		}
+		params.ParentID = parent.ID
 	}
 
 	repoDepartment, err := s.repo.CreateDepartment(ctx, params)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, constants.ErrDepartmentNameTaken
+		}
+		log.Error().Err(err).Str("name", req.Name).Msg("Failed to create department in repository")
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateDepartment, err)
 	}
 
-	dto := (&dtos.Department{}).FromRepositoryModel(repoDepartment)
-	return dto, nil
+	return (&dtos.Department{}).FromRepositoryModel(repoDepartment), nil
 }
 
-// GetOrCreateDepartmentByName gets a department by name or creates it if it doesn't exist
-func (s *departmentService) GetOrCreateDepartmentByName(ctx context.Context, name string) (*dtos.Department, error) {
-	if name == "" {
-		return nil, fmt.Errorf("department name cannot be empty")
+// UpdateDepartment renames a department or changes its status. An empty
+// status keeps the current one.
+func (s *departmentService) UpdateDepartment(ctx context.Context, id string, req *dtos.UpdateDepartmentRequest) (*dtos.Department, error) {
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	log.Info().
+		Str("service", "DepartmentService").
+		Str("endpoint", "UpdateDepartment").
+		Str("id", id).
+		Str("name", req.Name).
+		Str("user_id", userID).
+		Msg("Updating department")
+
+	current, err := s.getDepartment(ctx, s.repo, id, constants.ErrDepartmentNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := parseDepartmentStatus(req.Status, current.Status.StatusEnum)
+	if err != nil {
+		return nil, err
+	}
+
+	repoDepartment, err := s.repo.UpdateDepartment(ctx, repository.UpdateDepartmentParams{
+		Name:   req.Name,
+		Status: repository.NullStatusEnum{StatusEnum: status, Valid: true},
+		ID:     current.ID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, constants.ErrDepartmentNotFound
+		case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+			return nil, constants.ErrDepartmentNameTaken
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to update department in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateDepartment, err)
 	}
 
+	return (&dtos.Department{}).FromRepositoryModel(repoDepartment), nil
+}
+
+// MoveDepartment moves a department and its subtree below another department,
+// or to the root of a business unit, and moves the subtree and its users into
+// the business unit of its new parent. It rejects parents that would close a
+// cycle, and business unit changes while role assignments are scoped to the
+// subtree, since those were granted for the old business unit.
+func (s *departmentService) MoveDepartment(ctx context.Context, id string, req *dtos.MoveDepartmentRequest) (*dtos.Department, error) {
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
 	}
 
+	log.Info().
+		Str("service", "DepartmentService").
+		Str("endpoint", "MoveDepartment").
+		Str("id", id).
+		Str("parent_id", req.ParentID).
+		Str("business_unit_id", req.BusinessUnitID).
+		Str("user_id", userID).
+		Msg("Moving department")
+
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The hierarchy is read after the lock, so a concurrent move cannot
+	// invalidate the cycle check before this one commits.
+	qtx := s.repo.WithTx(tx)
+	if err := qtx.LockTenantDepartmentHierarchy(ctx, tenantID); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to lock department hierarchy")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToMoveDepartment, err)
+	}
+
+	current, err := s.getDepartment(ctx, qtx, id, constants.ErrDepartmentNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.MoveDepartmentParams{
+		ID:             current.ID,
+		BusinessUnitID: current.BusinessUnitID,
+	}
+	if req.BusinessUnitID != "" {
+		if params.BusinessUnitID, err = s.tenantBusinessUnit(ctx, req.BusinessUnitID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ParentID != "" {
+		parent, err := s.getDepartment(ctx, qtx, req.ParentID, constants.ErrParentDepartmentNotFound)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ID == current.ID {
+			return nil, constants.ErrDepartmentHierarchyCycle
+		}
+		if !parent.BusinessUnitID.Valid || (req.BusinessUnitID != "" && parent.BusinessUnitID != params.BusinessUnitID) {
+			return nil, constants.ErrDepartmentBusinessUnitMismatch
+		}
+
+		subtree, err := qtx.ListDepartmentSubtree(ctx, current.ID)
+		if err != nil {
+			log.Error().Err(err).Str("id", id).Msg("Failed to get department subtree from repository")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartments, err)
+		}
+		for _, descendant := range subtree {
+			if descendant.ID == parent.ID {
+				return nil, constants.ErrDepartmentHierarchyCycle
+			}
+		}
+
+		params.ParentID = parent.ID
+		params.BusinessUnitID = parent.BusinessUnitID
+	}
+
+	if !params.BusinessUnitID.Valid {
+		return nil, constants.ErrDepartmentBusinessUnitRequired
+	}
+
+	changesBusinessUnit := params.BusinessUnitID != current.BusinessUnitID
+	if changesBusinessUnit {
+		assignments, err := qtx.CountDepartmentSubtreeRoleAssignments(ctx, current.ID)
+		if err != nil {
+			log.Error().Err(err).Str("id", id).Msg("Failed to count department role assignments in repository")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignments, err)
+		}
+		if assignments > 0 {
+			return nil, constants.ErrDepartmentHasRoleAssignments
+		}
+	}
+
+	repoDepartment, err := qtx.MoveDepartment(ctx, params)
+	if err == nil {
+		err = qtx.SetDepartmentSubtreeBusinessUnit(ctx, repository.SetDepartmentSubtreeBusinessUnitParams{
+			ID:             current.ID,
+			BusinessUnitID: params.BusinessUnitID,
+		})
+	}
+	if err == nil && changesBusinessUnit {
+		_, err = qtx.SetDepartmentSubtreeUsersBusinessUnit(ctx, repository.SetDepartmentSubtreeUsersBusinessUnitParams{
+			ID:             current.ID,
+			BusinessUnitID: params.BusinessUnitID,
+		})
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, constants.ErrDepartmentNotFound
+		case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+			return nil, constants.ErrDepartmentNameTaken
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to move department in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToMoveDepartment, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	return (&dtos.Department{}).FromRepositoryModel(repoDepartment), nil
+}
+
+// DeleteDepartment soft deletes a department without active children.
+func (s *departmentService) DeleteDepartment(ctx context.Context, id string) (*dtos.Department, error) {
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	log.Info().
+		Str("service", "DepartmentService").
+		Str("endpoint", "DeleteDepartment").
+		Str("id", id).
+		Str("user_id", userID).
+		Msg("Deleting department")
+
+	current, err := s.getDepartment(ctx, s.repo, id, constants.ErrDepartmentNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := s.repo.CountDepartmentChildren(ctx, current.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to count department children in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartments, err)
+	}
+	if children > 0 {
+		return nil, constants.ErrDepartmentHasChildren
+	}
+
+	repoDepartment, err := s.repo.DeleteDepartment(ctx, current.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrDepartmentNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to delete department in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteDepartment, err)
+	}
+
+	return (&dtos.Department{}).FromRepositoryModel(repoDepartment), nil
+}
+
+// GetOrCreateDepartmentByName gets a department by name within a business unit
+// or creates it as a root department of the business unit if it doesn't exist.
+// Callers resolve the business unit from the user's mail domain.
+func (s *departmentService) GetOrCreateDepartmentByName(ctx context.Context, businessUnitID pgtype.UUID, name string) (*dtos.Department, error) {
+	if name == "" {
+		return nil, fmt.Errorf("department name cannot be empty")
+	}
+	if !businessUnitID.Valid {
+		return nil, constants.ErrDepartmentBusinessUnitRequired
+	}
+
 	log.Info().
 		Str("service", "DepartmentService").
 		Str("endpoint", "GetOrCreateDepartmentByName").
+		Str("business_unit_id", businessUnitID.String()).
 		Str("name", name).
-		Str("user_id", userID).
 		Msg("Getting or creating department by name")
 
-	department, err := s.GetDepartmentByName(ctx, name)
+	params := repository.GetDepartmentByNameParams{BusinessUnitID: businessUnitID, Name: name}
+	department, err := s.repo.GetDepartmentByName(ctx, params)
 	if err == nil {
-		log.Info().
-			Str("department_name", name).
-			Str("department_id", department.ID).
-			Msg("Successfully found existing department")
-		return department, nil
+		return (&dtos.Department{}).FromRepositoryModel(department), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartment, err)
 	}
 
 	log.Info().
 		Str("department_name", name).
 		Msg("Department not found, creating new department")
 
-	createReq := &dtos.CreateDepartmentRequest{
-		Name:   name,
-		Status: "active",
-	}
-
-	newDepartment, createErr := s.CreateDepartment(ctx, createReq)
-	if createErr != nil {
-		log.Error().Err(createErr).
-			Str("department_name", name).
-			Msg("Failed to create department")
-		return nil, fmt.Errorf(utils.ErrorFormat, "failed to create department", createErr)
+	department, err = s.repo.CreateDepartment(ctx, repository.CreateDepartmentParams{
+		BusinessUnitID: businessUnitID,
+		Name:           name,
+		Status:         repository.NullStatusEnum{StatusEnum: repository.StatusEnumActive, Valid: true},
+	})
+	if err != nil {
+		// A concurrent login may have created the department first.
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			department, err = s.repo.GetDepartmentByName(ctx, params)
+		}
+		if err != nil {
+			log.Error().Err(err).
+				Str("department_name", name).
+				Msg("Failed to create department")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateDepartment, err)
+		}
 	}
 
 	log.Info().
 		Str("department_name", name).
-		Str("department_id", newDepartment.ID).
-		Msg("Successfully created new department")
+		Str("department_id", department.ID.String()).
+		Msg("Successfully resolved department")
+
+	return (&dtos.Department{}).FromRepositoryModel(department), nil
+}
 
-	return newDepartment, nil
+// getDepartment gets a department of the caller's tenant, returning notFound
+// when the ID is malformed, unknown or belongs to another tenant.
+func (s *departmentService) getDepartment(ctx context.Context, q *repository.Queries, id string, notFound error) (repository.Department, error) {
+	var uuid pgtype.UUID
+	if err := uuid.Scan(id); err != nil {
+		return repository.Department{}, notFound
+	}
+
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return repository.Department{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	department, err := q.GetDepartmentByID(ctx, repository.GetDepartmentByIDParams{ID: uuid, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Department{}, notFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get department from repository")
+		return repository.Department{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartment, err)
+	}
+	return department, nil
+}
+
+// tenantBusinessUnit checks that the business unit belongs to the caller's
+// tenant.
+func (s *departmentService) tenantBusinessUnit(ctx context.Context, id string) (pgtype.UUID, error) {
+	var uuid pgtype.UUID
+	if err := uuid.Scan(id); err != nil {
+		return uuid, constants.ErrInvalidDepartmentReference
+	}
+
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return uuid, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	if _, err := s.repo.GetBusinessUnitByID(ctx, repository.GetBusinessUnitByIDParams{ID: uuid, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid, constants.ErrBusinessUnitNotFound
+		}
+		return uuid, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetBusinessUnit, err)
+	}
+	return uuid, nil
+}
+
+// parseDepartmentStatus accepts active and inactive; deleting a department
+// goes through DeleteDepartment. An empty status falls back to def.
+func parseDepartmentStatus(status string, def repository.StatusEnum) (repository.StatusEnum, error) {
+	switch repository.StatusEnum(status) {
+	case "":
+		return def, nil
+	case repository.StatusEnumActive, repository.StatusEnumInactive:
+		return repository.StatusEnum(status), nil
+	}
+	return "", constants.ErrInvalidDepartmentStatus
 }
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DirectoryRoleMappingService maps Entra app roles and groups to internal roles
+// and keeps each user's directory managed role assignments in line with the
+// claims in their token.
+type DirectoryRoleMappingService interface {
+	GetMappings(ctx context.Context) ([]*dtos.DirectoryRoleMappingResponse, error)
+	GetMappingByID(ctx context.Context, id string) (*dtos.DirectoryRoleMappingResponse, error)
+	CreateMapping(ctx context.Context, req *dtos.CreateDirectoryRoleMappingRequest) (*dtos.DirectoryRoleMappingResponse, error)
+	DeleteMapping(ctx context.Context, id string) (*dtos.DirectoryRoleMappingResponse, error)
+	SyncUserRoleAssignments(ctx context.Context, userID string, claims utils.DirectoryClaims) (*dtos.DirectoryRoleSyncResult, error)
+}
+
+type directoryRoleMappingService struct {
+	db   *database.Database
+	repo *repository.Queries
+}
+
+func NewDirectoryRoleMappingService(db *database.Database, repo *repository.Queries) DirectoryRoleMappingService {
+	return &directoryRoleMappingService{
+		db:   db,
+		repo: repo,
+	}
+}
+
+// GetMappings lists the mappings of the caller's tenant.
+func (s *directoryRoleMappingService) GetMappings(ctx context.Context) ([]*dtos.DirectoryRoleMappingResponse, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	mappings, err := s.repo.ListDirectoryRoleMappings(ctx, tenantID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get directory role mappings from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDirectoryRoleMappings, err)
+	}
+
+	result := make([]*dtos.DirectoryRoleMappingResponse, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = dtos.NewDirectoryRoleMappingResponse(mapping)
+	}
+
+	return result, nil
+}
+
+func (s *directoryRoleMappingService) GetMappingByID(ctx context.Context, id string) (*dtos.DirectoryRoleMappingResponse, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	mapping, err := s.repo.GetDirectoryRoleMappingByID(ctx, repository.GetDirectoryRoleMappingByIDParams{
+		ID:       pgtype.UUID{Bytes: uuid, Valid: true},
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrDirectoryRoleMappingNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get directory role mapping from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDirectoryRoleMapping, err)
+	}
+
+	return dtos.NewDirectoryRoleMappingResponse(mapping), nil
+}
+
+func (s *directoryRoleMappingService) CreateMapping(ctx context.Context, req *dtos.CreateDirectoryRoleMappingRequest) (*dtos.DirectoryRoleMappingResponse, error) {
+	log.Info().
+		Str("service", "DirectoryRoleMappingService").
+		Str("method", "CreateMapping").
+		Str("claim_type", req.ClaimType).
+		Str("claim_value", req.ClaimValue).
+		Str("role_id", req.RoleID).
+		Msg("Creating directory role mapping")
+
+	params := repository.CreateDirectoryRoleMappingParams{
+		ClaimType:  repository.DirectoryClaimType(req.ClaimType),
+		ClaimValue: req.ClaimValue,
+		RoleID:     req.RoleID,
+	}
+	if req.Description != "" {
+		params.Description = pgtype.Text{String: req.Description, Valid: true}
+	}
+
+	// A mapping only ever matches tokens of the tenant it was created in.
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+	params.TenantID = tenantID
+
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+	if err := params.CreatedBy.Scan(userID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	role, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: req.RoleID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", constants.ErrRoleNotFound, req.RoleID)
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+	}
+	if role.DeletedAt.Valid {
+		return nil, fmt.Errorf("%w: %s", constants.ErrRoleNotFound, req.RoleID)
+	}
+
+	// SCIM group mappings name the group by its ID, which must exist in the
+	// caller's tenant.
+	if params.ClaimType == repository.DirectoryClaimTypeScimGroup {
+		var groupID pgtype.UUID
+		if err := groupID.Scan(req.ClaimValue); err != nil {
+			return nil, constants.ErrSCIMGroupNotFound
+		}
+		if _, err := s.repo.GetScimGroup(ctx, repository.GetScimGroupParams{ID: groupID, TenantID: tenantID}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, constants.ErrSCIMGroupNotFound
+			}
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSCIMGroup, err)
+		}
+		params.ClaimValue = groupID.String()
+	}
+
+	if req.BusinessUnitID != "" {
+		if err := params.BusinessUnitID.Scan(req.BusinessUnitID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+		businessUnitTenantID, err := utils.GetTenantID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+		}
+		if _, err := s.repo.GetBusinessUnitByID(ctx, repository.GetBusinessUnitByIDParams{
+			ID:       params.BusinessUnitID,
+			TenantID: businessUnitTenantID,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, constants.ErrBusinessUnitNotFound
+			}
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetBusinessUnit, err)
+		}
+	}
+
+	mapping, err := s.repo.CreateDirectoryRoleMapping(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, constants.ErrDirectoryRoleMappingAlreadyExists
+		}
+		log.Error().Err(err).Interface("params", params).Msg("Failed to create directory role mapping in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateDirectoryRoleMapping, err)
+	}
+
+	log.Info().
+		Str("service", "DirectoryRoleMappingService").
+		Str("method", "CreateMapping").
+		Str("id", mapping.ID.String()).
+		Msg("Successfully created directory role mapping")
+	return dtos.NewDirectoryRoleMappingResponse(mapping), nil
+}
+
+// DeleteMapping removes the mapping and revokes every assignment it created.
+func (s *directoryRoleMappingService) DeleteMapping(ctx context.Context, id string) (*dtos.DirectoryRoleMappingResponse, error) {
+	log.Info().
+		Str("service", "DirectoryRoleMappingService").
+		Str("method", "DeleteMapping").
+		Str("id", id).
+		Msg("Deleting directory role mapping")
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	mappingID := pgtype.UUID{Bytes: uuid, Valid: true}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	mapping, err := qtx.DeleteDirectoryRoleMapping(ctx, repository.DeleteDirectoryRoleMappingParams{
+		ID:       mappingID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrDirectoryRoleMappingNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to delete directory role mapping in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteDirectoryRoleMapping, err)
+	}
+
+	revoked, err := qtx.RevokeDirectoryMappingAssignments(ctx, mappingID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to revoke directory managed role assignments")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeRoleAssignment, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "DirectoryRoleMappingService").
+		Str("method", "DeleteMapping").
+		Str("id", id).
+		Int64("revoked_assignments", revoked).
+		Msg("Successfully deleted directory role mapping")
+	return dtos.NewDirectoryRoleMappingResponse(mapping), nil
+}
+
+// SyncUserRoleAssignments grants the role permissions of every mapping of the
+// token's tenant matched by claims and revokes directory managed assignments that no longer match.
+// Manually created assignments are never touched. When the token reports a
+// groups overage, group mappings are neither granted nor revoked. Token claims
+// never touch scim_group mappings, and SCIM claims touch nothing else.
+func (s *directoryRoleMappingService) SyncUserRoleAssignments(ctx context.Context, userID string, claims utils.DirectoryClaims) (*dtos.DirectoryRoleSyncResult, error) {
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	assigneeID := pgtype.UUID{Bytes: uuid, Valid: true}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	params := repository.GetDirectoryRoleGrantsParams{
+		TenantID:   tenantID,
+		AppRoles:   claims.AppRoles,
+		Groups:     claims.Groups,
+		ScimGroups: claims.SCIMGroups,
+	}
+	if params.AppRoles == nil || claims.SCIM {
+		params.AppRoles = []string{}
+	}
+	if params.Groups == nil || claims.GroupsOverage || claims.SCIM {
+		params.Groups = []string{}
+	}
+	if params.ScimGroups == nil || !claims.SCIM {
+		params.ScimGroups = []string{}
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	grants, err := qtx.GetDirectoryRoleGrants(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to get directory role grants from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDirectoryRoleGrants, err)
+	}
+
+	current, err := qtx.GetUserDirectorySyncAssignments(ctx, assigneeID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to get user role assignments from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignments, err)
+	}
+
+	held := make(map[string]bool, len(current))
+	for _, assignment := range current {
+		held[assignmentKey(assignment.RolePermissionsID, assignment.BusinessUnitID)] = true
+	}
+
+	result := &dtos.DirectoryRoleSyncResult{}
+	desired := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		key := assignmentKey(grant.RolePermissionsID, grant.BusinessUnitID)
+		if desired[key] {
+			continue
+		}
+		desired[key] = true
+		if held[key] {
+			continue
+		}
+
+		if err := checkSodConflicts(ctx, qtx, assigneeID, grant.RoleID); err != nil {
+			if !errors.Is(err, constants.ErrSodConstraintViolation) {
+				return nil, err
+			}
+			log.Warn().Err(err).
+				Str("user_id", userID).
+				Str("mapping_id", grant.MappingID.String()).
+				Str("role_id", grant.RoleID).
+				Msg("Skipping directory role grant that violates separation of duties")
+			result.Skipped++
+			continue
+		}
+
+		_, err := qtx.CreateDirectoryRoleAssignment(ctx, repository.CreateDirectoryRoleAssignmentParams{
+			RolePermissionsID:  grant.RolePermissionsID,
+			AssigneeID:         assigneeID,
+			BusinessUnitID:     grant.BusinessUnitID,
+			DirectoryMappingID: grant.MappingID,
+		})
+		if err != nil {
+			// No row means an active assignment already covers this grant.
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			log.Error().Err(err).Str("user_id", userID).Str("mapping_id", grant.MappingID.String()).Msg("Failed to create directory managed role assignment")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateRoleAssignment, err)
+		}
+		result.Granted++
+	}
+
+	for _, assignment := range current {
+		if !assignment.DirectoryMappingID.Valid || desired[assignmentKey(assignment.RolePermissionsID, assignment.BusinessUnitID)] {
+			continue
+		}
+		if claims.GroupsOverage && assignment.ClaimType.DirectoryClaimType == repository.DirectoryClaimTypeGroup {
+			continue
+		}
+		if claims.SCIM != (assignment.ClaimType.DirectoryClaimType == repository.DirectoryClaimTypeScimGroup) {
+			continue
+		}
+
+		if _, err := qtx.RevokeRoleAssignment(ctx, assignment.ID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			log.Error().Err(err).Str("user_id", userID).Str("role_assignment_id", assignment.ID.String()).Msg("Failed to revoke directory managed role assignment")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeRoleAssignment, err)
+		}
+		result.Revoked++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	if result.Granted > 0 || result.Revoked > 0 || result.Skipped > 0 {
+		log.Info().
+			Str("service", "DirectoryRoleMappingService").
+			Str("method", "SyncUserRoleAssignments").
+			Str("user_id", userID).
+			Int("granted", result.Granted).
+			Int("revoked", result.Revoked).
+			Int("skipped", result.Skipped).
+			Bool("groups_overage", claims.GroupsOverage).
+			Msg("Synced directory role assignments")
+	}
+	return result, nil
+}
+
+// assignmentKey identifies an assignment by what it grants, matching the
+// role_assignment unique key apart from the assignee.
+func assignmentKey(rolePermissionsID, businessUnitID pgtype.UUID) string {
+	return rolePermissionsID.String() + "/" + businessUnitID.String()
+}
@@ -0,0 +1,432 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// directorySyncResourceUsers identifies the users delta link in directory_sync_state.
+const directorySyncResourceUsers = "users"
+
+// DirectoryClient reads user changes from the directory. Graph implements it
+// with app-only credentials; tests can substitute a fake.
+type DirectoryClient interface {
+	// UserDelta returns one page of user changes. An empty link starts a full
+	// sync; otherwise link is a next or delta link returned earlier. It returns
+	// constants.ErrDirectoryDeltaExpired when the directory no longer accepts
+	// the delta link and a full sync is required.
+	UserDelta(ctx context.Context, link string) (*DirectoryDeltaPage, error)
+}
+
+// DirectoryDeltaPage is one page of a delta query. Exactly one of NextLink and
+// DeltaLink is set: NextLink while pages remain, DeltaLink on the last page.
+type DirectoryDeltaPage struct {
+	Users     []DirectoryUser
+	NextLink  string
+	DeltaLink string
+}
+
+// DirectoryUser is a changed user. Removed users only carry ObjectID. Delta
+// pages may omit unchanged properties, so nil means "unchanged" and keeps the
+// stored value; ManagerObjectID points to "" when the manager was removed.
+type DirectoryUser struct {
+	ObjectID          string
+	Removed           bool
+	AccountEnabled    *bool
+	Mail              *string
+	UserPrincipalName *string
+	DisplayName       *string
+	GivenName         *string
+	Surname           *string
+	JobTitle          *string
+	Department        *string
+	OfficeLocation    *string
+	ManagerObjectID   *string
+}
+
+// DirectorySyncService mirrors the directory's users into the users table so
+// people can be assigned tickets and roles before they ever sign in.
+type DirectorySyncService interface {
+	SyncUsers(ctx context.Context) (*dtos.DirectorySyncResult, error)
+}
+
+// directorySyncStore is the storage the sync writes to. The repository
+// implements it; tests substitute an in-memory store.
+type directorySyncStore interface {
+	GetDirectorySyncState(ctx context.Context, arg repository.GetDirectorySyncStateParams) (repository.DirectorySyncState, error)
+	SaveDirectorySyncState(ctx context.Context, arg repository.SaveDirectorySyncStateParams) error
+	GetUserByObjectID(ctx context.Context, arg repository.GetUserByObjectIDParams) (repository.User, error)
+	CreateUser(ctx context.Context, arg repository.CreateUserParams) (repository.User, error)
+	UpdateDirectoryUser(ctx context.Context, arg repository.UpdateDirectoryUserParams) (repository.User, error)
+	SetUserManagerByObjectID(ctx context.Context, arg repository.SetUserManagerByObjectIDParams) (int64, error)
+	// SetDirectoryUserActive deactivates or reactivates the user and records
+	// the status change in one transaction. It reports whether the status changed.
+	SetDirectoryUserActive(ctx context.Context, userID pgtype.UUID, active bool, reason string) (bool, error)
+}
+
+// directorySyncRepository adds the status change transaction to the repository.
+type directorySyncRepository struct {
+	*repository.Queries
+	db *database.Database
+}
+
+func (r *directorySyncRepository) SetDirectoryUserActive(ctx context.Context, userID pgtype.UUID, active bool, reason string) (bool, error) {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.WithTx(tx)
+	action := userStatusActionDeactivate
+	var changed int64
+	if active {
+		action = userStatusActionReactivate
+		changed, err = qtx.ReactivateDirectoryUser(ctx, userID)
+	} else {
+		changed, err = qtx.DeactivateDirectoryUser(ctx, userID)
+	}
+	if err != nil {
+		return false, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateAccountStatus, err)
+	}
+	if changed == 0 {
+		return false, nil
+	}
+
+	if _, err := qtx.CreateUserStatusChange(ctx, repository.CreateUserStatusChangeParams{
+		UserID: userID,
+		Action: action,
+		Reason: reason,
+	}); err != nil {
+		return false, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateAccountStatus, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+	return true, nil
+}
+
+type directorySyncService struct {
+	store         directorySyncStore
+	client        DirectoryClient
+	departments   DepartmentService
+	businessUnits BusinessUnitService
+	tenantID      string
+	// actorID identifies the sync in the request context of the services it
+	// calls, which expect an authenticated caller.
+	actorID string
+}
+
+func NewDirectorySyncService(db *database.Database, repo *repository.Queries, client DirectoryClient, departments DepartmentService, businessUnits BusinessUnitService, oauth *config.OAuthConfig) DirectorySyncService {
+	return &directorySyncService{
+		store:         &directorySyncRepository{Queries: repo, db: db},
+		client:        client,
+		departments:   departments,
+		businessUnits: businessUnits,
+		tenantID:      oauth.TenantID,
+		actorID:       oauth.ClientID,
+	}
+}
+
+// directorySyncRun holds the state of one sync run.
+type directorySyncRun struct {
+	result *dtos.DirectorySyncResult
+	// departments caches department IDs by business unit and name for the run.
+	departments map[string]pgtype.UUID
+	// businessUnits caches business unit IDs by mail domain for the run.
+	businessUnits map[string]pgtype.UUID
+	// managers collects manager changes, applied once every page is stored so
+	// managers created later in the run are found.
+	managers map[string]string
+}
+
+// SyncUsers applies the user changes since the stored delta link, or every
+// user when there is none or it expired, and stores the new delta link.
+func (s *directorySyncService) SyncUsers(ctx context.Context) (*dtos.DirectorySyncResult, error) {
+	ctx = utils.SetTenantContext(ctx, s.tenantID, s.actorID, "directory sync", "")
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	deltaLink := ""
+	state, err := s.store.GetDirectorySyncState(ctx, repository.GetDirectorySyncStateParams{
+		TenantID: homeTenantID,
+		Resource: directorySyncResourceUsers,
+	})
+	switch {
+	case err == nil:
+		deltaLink = state.DeltaLink.String
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDirectorySyncState, err)
+	}
+
+	run, newDeltaLink, err := s.syncPages(ctx, homeTenantID, deltaLink)
+	if errors.Is(err, constants.ErrDirectoryDeltaExpired) && deltaLink != "" {
+		log.Warn().Str("tenant_id", s.tenantID).Msg("Directory delta link expired, starting a full sync")
+		run, newDeltaLink, err = s.syncPages(ctx, homeTenantID, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SaveDirectorySyncState(ctx, repository.SaveDirectorySyncStateParams{
+		TenantID:  homeTenantID,
+		Resource:  directorySyncResourceUsers,
+		DeltaLink: pgtype.Text{String: newDeltaLink, Valid: newDeltaLink != ""},
+		FullSync:  run.result.FullSync,
+	}); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSaveDirectorySyncState, err)
+	}
+
+	return run.result, nil
+}
+
+// syncPages follows next links from link until the delta link and applies
+// every page. Users are stored as they arrive; manager links are applied last.
+func (s *directorySyncService) syncPages(ctx context.Context, homeTenantID pgtype.UUID, link string) (*directorySyncRun, string, error) {
+	run := &directorySyncRun{
+		result:        &dtos.DirectorySyncResult{FullSync: link == ""},
+		departments:   make(map[string]pgtype.UUID),
+		businessUnits: make(map[string]pgtype.UUID),
+		managers:      make(map[string]string),
+	}
+
+	for {
+		page, err := s.client.UserDelta(ctx, link)
+		if err != nil {
+			if errors.Is(err, constants.ErrDirectoryDeltaExpired) {
+				return nil, "", err
+			}
+			return nil, "", fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToQueryDirectory, err)
+		}
+
+		for _, user := range page.Users {
+			if err := s.applyUser(ctx, run, homeTenantID, user); err != nil {
+				return nil, "", err
+			}
+		}
+
+		if page.NextLink == "" {
+			link = page.DeltaLink
+			break
+		}
+		link = page.NextLink
+	}
+
+	for objectID, managerObjectID := range run.managers {
+		linked, err := s.store.SetUserManagerByObjectID(ctx, repository.SetUserManagerByObjectIDParams{
+			ManagerObjectID: pgtype.Text{String: managerObjectID, Valid: managerObjectID != ""},
+			AzureAdObjectID: objectID,
+			HomeTenantID:    homeTenantID,
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncDirectoryUser, err)
+		}
+		run.result.ManagersUpdated += int(linked)
+	}
+
+	return run, link, nil
+}
+
+func (s *directorySyncService) applyUser(ctx context.Context, run *directorySyncRun, homeTenantID pgtype.UUID, user DirectoryUser) error {
+	existing, err := s.store.GetUserByObjectID(ctx, repository.GetUserByObjectIDParams{
+		AzureAdObjectID: user.ObjectID,
+		HomeTenantID:    homeTenantID,
+	})
+	found := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncDirectoryUser, err)
+	}
+
+	if user.Removed {
+		if found {
+			return s.setActive(ctx, run, existing, false, "Account deleted in Entra ID")
+		}
+		return nil
+	}
+
+	mail := firstNonEmpty(user.Mail, user.UserPrincipalName)
+	if !found && (mail == "" || stringValue(user.DisplayName) == "") {
+		// Without the identifying properties a user cannot be created.
+		run.result.Skipped++
+		return nil
+	}
+
+	businessUnitID := s.businessUnitID(ctx, run, mail)
+	if found && !businessUnitID.Valid {
+		// Delta pages omit an unchanged mail; the department is then
+		// resolved in the business unit the user already belongs to.
+		businessUnitID = existing.BusinessUnitID
+	}
+	if found {
+		existing, err = s.store.UpdateDirectoryUser(ctx, repository.UpdateDirectoryUserParams{
+			DepartmentID:   s.mergeDepartment(ctx, run, user.Department, businessUnitID, existing.DepartmentID),
+			BusinessUnitID: businessUnitID,
+			Mail:           mergeString(mail, existing.Mail),
+			DisplayName:    mergeString(stringValue(user.DisplayName), existing.DisplayName),
+			GivenName:      mergeText(user.GivenName, existing.GivenName),
+			SurName:        mergeText(user.Surname, existing.SurName),
+			JobTitle:       mergeText(user.JobTitle, existing.JobTitle),
+			OfficeLocation: mergeText(user.OfficeLocation, existing.OfficeLocation),
+			ID:             existing.ID,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("azure_ad_object_id", user.ObjectID).Msg("Failed to update directory user in repository")
+			return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncDirectoryUser, err)
+		}
+		run.result.Updated++
+	} else {
+		existing, err = s.store.CreateUser(ctx, repository.CreateUserParams{
+			AzureAdObjectID: user.ObjectID,
+			HomeTenantID:    homeTenantID,
+			DepartmentID:    s.mergeDepartment(ctx, run, user.Department, businessUnitID, pgtype.UUID{}),
+			BusinessUnitID:  businessUnitID,
+			Mail:            mail,
+			DisplayName:     *user.DisplayName,
+			GivenName:       mergeText(user.GivenName, pgtype.Text{}),
+			SurName:         mergeText(user.Surname, pgtype.Text{}),
+			JobTitle:        mergeText(user.JobTitle, pgtype.Text{}),
+			OfficeLocation:  mergeText(user.OfficeLocation, pgtype.Text{}),
+			Status:          repository.NullStatusEnum{StatusEnum: repository.StatusEnumActive, Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Str("azure_ad_object_id", user.ObjectID).Msg("Failed to create directory user in repository")
+			return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncDirectoryUser, err)
+		}
+		run.result.Created++
+	}
+
+	if user.ManagerObjectID != nil {
+		run.managers[user.ObjectID] = *user.ManagerObjectID
+	}
+
+	if user.AccountEnabled != nil {
+		reason := "Account disabled in Entra ID"
+		if *user.AccountEnabled {
+			reason = "Account enabled in Entra ID"
+		}
+		return s.setActive(ctx, run, existing, *user.AccountEnabled, reason)
+	}
+	return nil
+}
+
+// setActive deactivates or reactivates a user and records the change. Users
+// suspended by an administrator are never reactivated by the sync.
+func (s *directorySyncService) setActive(ctx context.Context, run *directorySyncRun, user repository.User, active bool, reason string) error {
+	changed, err := s.store.SetDirectoryUserActive(ctx, user.ID, active, reason)
+	if err != nil || !changed {
+		return err
+	}
+
+	if active {
+		run.result.Reactivated++
+	} else {
+		run.result.Deactivated++
+	}
+	log.Info().
+		Str("service", "DirectorySyncService").
+		Str("user_id", user.ID.String()).
+		Bool("active", active).
+		Str("reason", reason).
+		Msg("Directory sync changed user status")
+	return nil
+}
+
+// mergeDepartment resolves a changed department name within the user's
+// business unit, keeping current when the department did not change. Failures
+// clear the department rather than failing the run.
+func (s *directorySyncService) mergeDepartment(ctx context.Context, run *directorySyncRun, name *string, businessUnitID, current pgtype.UUID) pgtype.UUID {
+	if name == nil {
+		return current
+	}
+	if *name == "" || !businessUnitID.Valid {
+		return pgtype.UUID{}
+	}
+	key := businessUnitID.String() + "/" + *name
+	if id, ok := run.departments[key]; ok {
+		return id
+	}
+
+	var id pgtype.UUID
+	department, err := s.departments.GetOrCreateDepartmentByName(ctx, businessUnitID, *name)
+	if err != nil {
+		log.Error().Err(err).Str("department_name", *name).Msg("Failed to get or create department for directory user")
+	} else if err := id.Scan(department.ID); err != nil {
+		log.Error().Err(err).Str("department_id", department.ID).Msg(constants.ErrInvalidDepartmentUUIDFormat)
+	}
+
+	run.departments[key] = id
+	return id
+}
+
+// businessUnitID resolves the business unit of a mail domain like the first
+// login does; failures keep the user's current business unit.
+func (s *directorySyncService) businessUnitID(ctx context.Context, run *directorySyncRun, mail string) pgtype.UUID {
+	domainName, isValid := utils.ExtractDomainFromEmail(mail)
+	if !isValid {
+		return pgtype.UUID{}
+	}
+	domainName = strings.ToLower(domainName)
+	if id, ok := run.businessUnits[domainName]; ok {
+		return id
+	}
+
+	var id pgtype.UUID
+	businessUnit, err := s.businessUnits.GetOrCreateBusinessUnitByDomainName(ctx, domainName)
+	if err != nil {
+		log.Error().Err(err).Str("domain_name", domainName).Msg("Failed to get or create business unit for directory user")
+	} else if err := id.Scan(businessUnit.ID); err != nil {
+		log.Error().Err(err).Str("business_unit_id", businessUnit.ID).Msg(constants.ErrInvalidUUIDFormat)
+	}
+
+	run.businessUnits[domainName] = id
+	return id
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func firstNonEmpty(values ...*string) string {
+	for _, value := range values {
+		if v := stringValue(value); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// mergeString keeps current when value is empty, for NOT NULL columns.
+func mergeString(value, current string) string {
+	if value == "" {
+		return current
+	}
+	return value
+}
+
+// mergeText keeps current when value is nil and clears the column when it is empty.
+func mergeText(value *string, current pgtype.Text) pgtype.Text {
+	if value == nil {
+		return current
+	}
+	return pgtype.Text{String: *value, Valid: *value != ""}
+}
@@ -0,0 +1,476 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/model"
+	"yet-another-itsm/internal/repository"
+)
+
+const testDirectoryTenantID = "6f1c2a3b-4d5e-4f60-8a7b-9c0d1e2f3a4b"
+
+// fakeDirectoryClient serves delta pages by link and records the links asked for.
+type fakeDirectoryClient struct {
+	pages map[string]*DirectoryDeltaPage
+	errs  map[string]error
+	links []string
+}
+
+func (c *fakeDirectoryClient) UserDelta(ctx context.Context, link string) (*DirectoryDeltaPage, error) {
+	c.links = append(c.links, link)
+	if err, ok := c.errs[link]; ok {
+		return nil, err
+	}
+	page, ok := c.pages[link]
+	if !ok {
+		return nil, fmt.Errorf("unexpected link %q", link)
+	}
+	return page, nil
+}
+
+// fakeDirectorySyncStore keeps users by object ID and the last status change
+// action per user, mirroring the rules of the directory sync queries.
+type fakeDirectorySyncStore struct {
+	users      map[string]*repository.User
+	lastAction map[pgtype.UUID]string
+	deltaLink  string
+	saved      *repository.SaveDirectorySyncStateParams
+}
+
+func newFakeDirectorySyncStore(deltaLink string) *fakeDirectorySyncStore {
+	return &fakeDirectorySyncStore{
+		users:      make(map[string]*repository.User),
+		lastAction: make(map[pgtype.UUID]string),
+		deltaLink:  deltaLink,
+	}
+}
+
+func (f *fakeDirectorySyncStore) addUser(objectID string, status repository.StatusEnum) *repository.User {
+	user := &repository.User{
+		ID:              newTestUUID(),
+		AzureAdObjectID: objectID,
+		Mail:            objectID + "@contoso.com",
+		DisplayName:     objectID,
+		Status:          repository.NullStatusEnum{StatusEnum: status, Valid: true},
+	}
+	f.users[objectID] = user
+	return user
+}
+
+func (f *fakeDirectorySyncStore) userByID(id pgtype.UUID) *repository.User {
+	for _, user := range f.users {
+		if user.ID == id {
+			return user
+		}
+	}
+	return nil
+}
+
+func (f *fakeDirectorySyncStore) GetDirectorySyncState(ctx context.Context, arg repository.GetDirectorySyncStateParams) (repository.DirectorySyncState, error) {
+	if f.deltaLink == "" {
+		return repository.DirectorySyncState{}, pgx.ErrNoRows
+	}
+	return repository.DirectorySyncState{
+		TenantID:  arg.TenantID,
+		Resource:  arg.Resource,
+		DeltaLink: pgtype.Text{String: f.deltaLink, Valid: true},
+	}, nil
+}
+
+func (f *fakeDirectorySyncStore) SaveDirectorySyncState(ctx context.Context, arg repository.SaveDirectorySyncStateParams) error {
+	f.saved = &arg
+	f.deltaLink = arg.DeltaLink.String
+	return nil
+}
+
+func (f *fakeDirectorySyncStore) GetUserByObjectID(ctx context.Context, arg repository.GetUserByObjectIDParams) (repository.User, error) {
+	user, ok := f.users[arg.AzureAdObjectID]
+	if !ok {
+		return repository.User{}, pgx.ErrNoRows
+	}
+	return *user, nil
+}
+
+func (f *fakeDirectorySyncStore) CreateUser(ctx context.Context, arg repository.CreateUserParams) (repository.User, error) {
+	user := &repository.User{
+		ID:              newTestUUID(),
+		AzureAdObjectID: arg.AzureAdObjectID,
+		HomeTenantID:    arg.HomeTenantID,
+		DepartmentID:    arg.DepartmentID,
+		BusinessUnitID:  arg.BusinessUnitID,
+		Mail:            arg.Mail,
+		DisplayName:     arg.DisplayName,
+		GivenName:       arg.GivenName,
+		SurName:         arg.SurName,
+		JobTitle:        arg.JobTitle,
+		OfficeLocation:  arg.OfficeLocation,
+		Status:          arg.Status,
+	}
+	f.users[arg.AzureAdObjectID] = user
+	return *user, nil
+}
+
+func (f *fakeDirectorySyncStore) UpdateDirectoryUser(ctx context.Context, arg repository.UpdateDirectoryUserParams) (repository.User, error) {
+	user := f.userByID(arg.ID)
+	if user == nil {
+		return repository.User{}, pgx.ErrNoRows
+	}
+	user.DepartmentID = arg.DepartmentID
+	if arg.BusinessUnitID.Valid {
+		user.BusinessUnitID = arg.BusinessUnitID
+	}
+	user.Mail = arg.Mail
+	user.DisplayName = arg.DisplayName
+	user.GivenName = arg.GivenName
+	user.SurName = arg.SurName
+	user.JobTitle = arg.JobTitle
+	user.OfficeLocation = arg.OfficeLocation
+	return *user, nil
+}
+
+func (f *fakeDirectorySyncStore) SetUserManagerByObjectID(ctx context.Context, arg repository.SetUserManagerByObjectIDParams) (int64, error) {
+	user, ok := f.users[arg.AzureAdObjectID]
+	if !ok {
+		return 0, nil
+	}
+	user.ManagerID = pgtype.UUID{}
+	if manager, ok := f.users[arg.ManagerObjectID.String]; ok && arg.ManagerObjectID.Valid {
+		user.ManagerID = manager.ID
+	}
+	return 1, nil
+}
+
+func (f *fakeDirectorySyncStore) SetDirectoryUserActive(ctx context.Context, userID pgtype.UUID, active bool, reason string) (bool, error) {
+	user := f.userByID(userID)
+	if user == nil {
+		return false, nil
+	}
+	if active {
+		if user.Status.StatusEnum != repository.StatusEnumInactive || f.lastAction[userID] != userStatusActionDeactivate {
+			return false, nil
+		}
+		user.Status.StatusEnum = repository.StatusEnumActive
+		f.lastAction[userID] = userStatusActionReactivate
+		return true, nil
+	}
+	if user.Status.Valid && user.Status.StatusEnum != repository.StatusEnumActive {
+		return false, nil
+	}
+	user.Status = repository.NullStatusEnum{StatusEnum: repository.StatusEnumInactive, Valid: true}
+	f.lastAction[userID] = userStatusActionDeactivate
+	return true, nil
+}
+
+// fakeDepartments hands out one ID per business unit and department name.
+type fakeDepartments struct {
+	DepartmentService
+	ids map[string]string
+}
+
+func (f *fakeDepartments) GetOrCreateDepartmentByName(ctx context.Context, businessUnitID pgtype.UUID, name string) (*dtos.Department, error) {
+	key := businessUnitID.String() + "/" + name
+	if _, ok := f.ids[key]; !ok {
+		f.ids[key] = uuid.NewString()
+	}
+	return &dtos.Department{BaseModel: model.BaseModel{ID: f.ids[key]}, Name: name, BusinessUnitID: businessUnitID.String()}, nil
+}
+
+// fakeBusinessUnits hands out one ID per mail domain.
+type fakeBusinessUnits struct {
+	BusinessUnitService
+	ids map[string]string
+}
+
+func (f *fakeBusinessUnits) GetOrCreateBusinessUnitByDomainName(ctx context.Context, domainName string) (*dtos.BusinessUnit, error) {
+	if _, ok := f.ids[domainName]; !ok {
+		f.ids[domainName] = uuid.NewString()
+	}
+	return &dtos.BusinessUnit{BaseModel: model.BaseModel{ID: f.ids[domainName]}, DomainName: domainName}, nil
+}
+
+func newTestDirectorySyncService(store *fakeDirectorySyncStore, client *fakeDirectoryClient) (*directorySyncService, *fakeDepartments, *fakeBusinessUnits) {
+	departments := &fakeDepartments{ids: make(map[string]string)}
+	businessUnits := &fakeBusinessUnits{ids: make(map[string]string)}
+	return &directorySyncService{
+		store:         store,
+		client:        client,
+		departments:   departments,
+		businessUnits: businessUnits,
+		tenantID:      testDirectoryTenantID,
+		actorID:       "directory-sync-test",
+	}, departments, businessUnits
+}
+
+func newTestUUID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestSyncUsersFullSync(t *testing.T) {
+	store := newFakeDirectorySyncStore("")
+	client := &fakeDirectoryClient{pages: map[string]*DirectoryDeltaPage{
+		"": {
+			Users: []DirectoryUser{
+				{ObjectID: "alice", AccountEnabled: ptr(true), Mail: ptr("alice@contoso.com"), DisplayName: ptr("Alice"), JobTitle: ptr("Engineer"), Department: ptr("IT")},
+				{ObjectID: "nameless", Mail: ptr("nameless@contoso.com")},
+			},
+			NextLink: "page-2",
+		},
+		"page-2": {
+			Users: []DirectoryUser{
+				{ObjectID: "bob", UserPrincipalName: ptr("bob@contoso.com"), DisplayName: ptr("Bob"), Department: ptr("IT")},
+			},
+			DeltaLink: "delta-1",
+		},
+	}}
+	svc, departments, businessUnits := newTestDirectorySyncService(store, client)
+
+	result, err := svc.SyncUsers(context.Background())
+	if err != nil {
+		t.Fatalf("SyncUsers() error = %v", err)
+	}
+
+	want := dtos.DirectorySyncResult{FullSync: true, Created: 2, Skipped: 1}
+	if *result != want {
+		t.Errorf("SyncUsers() result = %+v, want %+v", *result, want)
+	}
+	if !slices.Equal(client.links, []string{"", "page-2"}) {
+		t.Errorf("links = %q, want the initial and next link", client.links)
+	}
+	if store.saved == nil || store.saved.DeltaLink.String != "delta-1" || !store.saved.FullSync {
+		t.Errorf("saved state = %+v, want delta-1 after a full sync", store.saved)
+	}
+
+	alice, bob := store.users["alice"], store.users["bob"]
+	if alice == nil || bob == nil {
+		t.Fatalf("users = %v, want alice and bob created", store.users)
+	}
+	if _, ok := store.users["nameless"]; ok {
+		t.Error("a user without display name was created")
+	}
+	if alice.Mail != "alice@contoso.com" || alice.JobTitle.String != "Engineer" {
+		t.Errorf("alice = %+v, want the directory profile", alice)
+	}
+	if bob.Mail != "bob@contoso.com" {
+		t.Errorf("bob mail = %q, want the user principal name", bob.Mail)
+	}
+
+	businessUnitID := businessUnits.ids["contoso.com"]
+	if alice.BusinessUnitID.String() != businessUnitID || bob.BusinessUnitID.String() != businessUnitID {
+		t.Errorf("business units = %s, %s, want %s", alice.BusinessUnitID, bob.BusinessUnitID, businessUnitID)
+	}
+	departmentID := departments.ids[businessUnitID+"/IT"]
+	if alice.DepartmentID.String() != departmentID || bob.DepartmentID.String() != departmentID {
+		t.Errorf("departments = %s, %s, want %s", alice.DepartmentID, bob.DepartmentID, departmentID)
+	}
+	if alice.Status.StatusEnum != repository.StatusEnumActive {
+		t.Errorf("alice status = %q, want active", alice.Status.StatusEnum)
+	}
+}
+
+func TestSyncUsersDelta(t *testing.T) {
+	store := newFakeDirectorySyncStore("delta-1")
+	alice := store.addUser("alice", repository.StatusEnumActive)
+	alice.BusinessUnitID = newTestUUID()
+	alice.DepartmentID = newTestUUID()
+	alice.JobTitle = pgtype.Text{String: "Engineer", Valid: true}
+	carol := store.addUser("carol", repository.StatusEnumActive)
+	carol.BusinessUnitID = newTestUUID()
+	carol.DepartmentID = newTestUUID()
+
+	client := &fakeDirectoryClient{pages: map[string]*DirectoryDeltaPage{
+		"delta-1": {
+			Users: []DirectoryUser{
+				// Only the changed properties are sent.
+				{ObjectID: "alice", JobTitle: ptr("Architect")},
+				{ObjectID: "carol", Department: ptr("Finance")},
+			},
+			DeltaLink: "delta-2",
+		},
+	}}
+	svc, departments, _ := newTestDirectorySyncService(store, client)
+
+	result, err := svc.SyncUsers(context.Background())
+	if err != nil {
+		t.Fatalf("SyncUsers() error = %v", err)
+	}
+
+	want := dtos.DirectorySyncResult{Updated: 2}
+	if *result != want {
+		t.Errorf("SyncUsers() result = %+v, want %+v", *result, want)
+	}
+	if !slices.Equal(client.links, []string{"delta-1"}) {
+		t.Errorf("links = %q, want the stored delta link", client.links)
+	}
+	if store.saved == nil || store.saved.DeltaLink.String != "delta-2" || store.saved.FullSync {
+		t.Errorf("saved state = %+v, want delta-2 after an incremental sync", store.saved)
+	}
+
+	if alice.JobTitle.String != "Architect" || alice.Mail != "alice@contoso.com" {
+		t.Errorf("alice = %+v, want the new job title and the stored mail", alice)
+	}
+	if !alice.DepartmentID.Valid {
+		t.Error("alice department was cleared by a page without a department")
+	}
+
+	departmentID, ok := departments.ids[carol.BusinessUnitID.String()+"/Finance"]
+	if !ok {
+		t.Fatalf("departments = %v, want Finance resolved in carol's business unit", departments.ids)
+	}
+	if carol.DepartmentID.String() != departmentID {
+		t.Errorf("carol department = %s, want %s", carol.DepartmentID, departmentID)
+	}
+}
+
+func TestSyncUsersStatusChanges(t *testing.T) {
+	store := newFakeDirectorySyncStore("delta-1")
+	store.addUser("removed", repository.StatusEnumActive)
+	store.addUser("disabled", repository.StatusEnumActive)
+	reenabled := store.addUser("reenabled", repository.StatusEnumInactive)
+	store.lastAction[reenabled.ID] = userStatusActionDeactivate
+	suspended := store.addUser("suspended", repository.StatusEnumInactive)
+	store.lastAction[suspended.ID] = userStatusActionSuspend
+
+	client := &fakeDirectoryClient{pages: map[string]*DirectoryDeltaPage{
+		"delta-1": {
+			Users: []DirectoryUser{
+				{ObjectID: "removed", Removed: true},
+				{ObjectID: "unknown", Removed: true},
+				{ObjectID: "disabled", AccountEnabled: ptr(false)},
+				{ObjectID: "reenabled", AccountEnabled: ptr(true)},
+				{ObjectID: "suspended", AccountEnabled: ptr(true)},
+			},
+			DeltaLink: "delta-2",
+		},
+	}}
+	svc, _, _ := newTestDirectorySyncService(store, client)
+
+	result, err := svc.SyncUsers(context.Background())
+	if err != nil {
+		t.Fatalf("SyncUsers() error = %v", err)
+	}
+
+	want := dtos.DirectorySyncResult{Updated: 3, Deactivated: 2, Reactivated: 1}
+	if *result != want {
+		t.Errorf("SyncUsers() result = %+v, want %+v", *result, want)
+	}
+
+	tests := []struct {
+		objectID string
+		status   repository.StatusEnum
+	}{
+		{"removed", repository.StatusEnumInactive},
+		{"disabled", repository.StatusEnumInactive},
+		{"reenabled", repository.StatusEnumActive},
+		{"suspended", repository.StatusEnumInactive},
+	}
+	for _, tt := range tests {
+		if got := store.users[tt.objectID].Status.StatusEnum; got != tt.status {
+			t.Errorf("%s status = %q, want %q", tt.objectID, got, tt.status)
+		}
+	}
+	if _, ok := store.users["unknown"]; ok {
+		t.Error("a removed user that was never synced was created")
+	}
+}
+
+func TestSyncUsersExpiredDeltaLinkStartsFullSync(t *testing.T) {
+	store := newFakeDirectorySyncStore("expired")
+	client := &fakeDirectoryClient{
+		errs: map[string]error{"expired": constants.ErrDirectoryDeltaExpired},
+		pages: map[string]*DirectoryDeltaPage{
+			"": {
+				Users:     []DirectoryUser{{ObjectID: "alice", Mail: ptr("alice@contoso.com"), DisplayName: ptr("Alice")}},
+				DeltaLink: "delta-1",
+			},
+		},
+	}
+	svc, _, _ := newTestDirectorySyncService(store, client)
+
+	result, err := svc.SyncUsers(context.Background())
+	if err != nil {
+		t.Fatalf("SyncUsers() error = %v", err)
+	}
+
+	want := dtos.DirectorySyncResult{FullSync: true, Created: 1}
+	if *result != want {
+		t.Errorf("SyncUsers() result = %+v, want %+v", *result, want)
+	}
+	if !slices.Equal(client.links, []string{"expired", ""}) {
+		t.Errorf("links = %q, want the expired link and then a full sync", client.links)
+	}
+	if store.saved == nil || store.saved.DeltaLink.String != "delta-1" || !store.saved.FullSync {
+		t.Errorf("saved state = %+v, want delta-1 after a full sync", store.saved)
+	}
+}
+
+func TestSyncUsersDirectoryError(t *testing.T) {
+	store := newFakeDirectorySyncStore("delta-1")
+	client := &fakeDirectoryClient{errs: map[string]error{"delta-1": fmt.Errorf("service unavailable")}}
+	svc, _, _ := newTestDirectorySyncService(store, client)
+
+	if _, err := svc.SyncUsers(context.Background()); err == nil {
+		t.Fatal("SyncUsers() error = nil, want the directory error")
+	}
+	if !slices.Equal(client.links, []string{"delta-1"}) {
+		t.Errorf("links = %q, want no full sync after other errors", client.links)
+	}
+	if store.saved != nil {
+		t.Errorf("saved state = %+v, want the delta link kept", store.saved)
+	}
+}
+
+func TestSyncUsersManagers(t *testing.T) {
+	store := newFakeDirectorySyncStore("delta-1")
+	dave := store.addUser("dave", repository.StatusEnumActive)
+	dave.ManagerID = newTestUUID()
+
+	client := &fakeDirectoryClient{pages: map[string]*DirectoryDeltaPage{
+		"delta-1": {
+			Users: []DirectoryUser{
+				// The manager only arrives on the next page.
+				{ObjectID: "alice", Mail: ptr("alice@contoso.com"), DisplayName: ptr("Alice"), ManagerObjectID: ptr("bob")},
+				{ObjectID: "dave", ManagerObjectID: ptr("")},
+			},
+			NextLink: "page-2",
+		},
+		"page-2": {
+			Users: []DirectoryUser{
+				{ObjectID: "bob", Mail: ptr("bob@contoso.com"), DisplayName: ptr("Bob")},
+			},
+			DeltaLink: "delta-2",
+		},
+	}}
+	svc, _, _ := newTestDirectorySyncService(store, client)
+
+	result, err := svc.SyncUsers(context.Background())
+	if err != nil {
+		t.Fatalf("SyncUsers() error = %v", err)
+	}
+
+	want := dtos.DirectorySyncResult{Created: 2, Updated: 1, ManagersUpdated: 2}
+	if *result != want {
+		t.Errorf("SyncUsers() result = %+v, want %+v", *result, want)
+	}
+	if store.users["alice"].ManagerID != store.users["bob"].ID {
+		t.Errorf("alice manager = %s, want bob %s", store.users["alice"].ManagerID, store.users["bob"].ID)
+	}
+	if dave.ManagerID.Valid {
+		t.Errorf("dave manager = %s, want the removed manager cleared", dave.ManagerID)
+	}
+	if store.users["bob"].ManagerID.Valid {
+		t.Error("bob got a manager without a manager change")
+	}
+}
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ElevationRequestService implements just-in-time role elevation: a user requests
+// a role for a bounded duration, an approver grants it as expiring role
+// assignments, and the grant is revoked when the request expires.
+type ElevationRequestService interface {
+	CreateRequest(ctx context.Context, req *dtos.CreateElevationRequest) (*dtos.ElevationRequestResponse, error)
+	GetRequests(ctx context.Context, filter dtos.ElevationRequestFilter, page dtos.PageRequest) ([]*dtos.ElevationRequestResponse, int64, error)
+	GetMyRequests(ctx context.Context, page dtos.PageRequest) ([]*dtos.ElevationRequestResponse, int64, error)
+	GetRequestByID(ctx context.Context, id string) (*dtos.ElevationRequestResponse, error)
+	ApproveRequest(ctx context.Context, id string, req *dtos.DecideElevationRequest) (*dtos.ElevationRequestResponse, error)
+	RejectRequest(ctx context.Context, id string, req *dtos.DecideElevationRequest) (*dtos.ElevationRequestResponse, error)
+	CancelRequest(ctx context.Context, id string) (*dtos.ElevationRequestResponse, error)
+	ExpireElevations(ctx context.Context) (int, error)
+}
+
+type elevationRequestService struct {
+	db   *database.Database
+	repo *repository.Queries
+}
+
+func NewElevationRequestService(db *database.Database, repo *repository.Queries) ElevationRequestService {
+	return &elevationRequestService{
+		db:   db,
+		repo: repo,
+	}
+}
+
+func (s *elevationRequestService) CreateRequest(ctx context.Context, req *dtos.CreateElevationRequest) (*dtos.ElevationRequestResponse, error) {
+	log.Info().
+		Str("service", "ElevationRequestService").
+		Str("method", "CreateRequest").
+		Str("role_id", req.RoleID).
+		Int32("duration_minutes", req.DurationMinutes).
+		Msg("Creating elevation request")
+
+	requesterID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.CreateElevationRequestParams{
+		RequesterID:     requesterID,
+		RoleID:          req.RoleID,
+		Justification:   req.Justification,
+		DurationMinutes: req.DurationMinutes,
+	}
+	if req.BusinessUnitID != "" {
+		if err := params.BusinessUnitID.Scan(req.BusinessUnitID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+		tenantID, err := utils.GetTenantID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+		}
+		if _, err := s.repo.GetBusinessUnitByID(ctx, repository.GetBusinessUnitByIDParams{
+			ID:       params.BusinessUnitID,
+			TenantID: tenantID,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, constants.ErrBusinessUnitNotFound
+			}
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetBusinessUnit, err)
+		}
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	role, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: req.RoleID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", constants.ErrRoleNotFound, req.RoleID)
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+	}
+	if role.DeletedAt.Valid {
+		return nil, fmt.Errorf("%w: %s", constants.ErrRoleNotFound, req.RoleID)
+	}
+
+	permissions, err := s.repo.GetPermissionsByRole(ctx, req.RoleID)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRolePermissions, err)
+	}
+	hasActive := false
+	for _, permission := range permissions {
+		if permission.Status.StatusEnum == repository.StatusEnumActive {
+			hasActive = true
+			break
+		}
+	}
+	if !hasActive {
+		return nil, constants.ErrElevationRoleEmpty
+	}
+
+	request, err := s.repo.CreateElevationRequest(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Interface("params", params).Msg("Failed to create elevation request in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateElevationRequest, err)
+	}
+
+	log.Info().
+		Str("service", "ElevationRequestService").
+		Str("method", "CreateRequest").
+		Str("id", request.ID.String()).
+		Str("requester_id", requesterID.String()).
+		Msg("Elevation request submitted")
+	return s.getRequest(ctx, request.ID)
+}
+
+// GetRequests lists one page of elevation requests for audit, newest first.
+func (s *elevationRequestService) GetRequests(ctx context.Context, filter dtos.ElevationRequestFilter, page dtos.PageRequest) ([]*dtos.ElevationRequestResponse, int64, error) {
+	params := repository.ListElevationRequestsParams{}
+	if filter.Status != "" {
+		status := repository.ElevationRequestStatus(filter.Status)
+		switch status {
+		case repository.ElevationRequestStatusPending, repository.ElevationRequestStatusApproved,
+			repository.ElevationRequestStatusRejected, repository.ElevationRequestStatusCancelled,
+			repository.ElevationRequestStatusExpired:
+			params.Status = repository.NullElevationRequestStatus{ElevationRequestStatus: status, Valid: true}
+		default:
+			return nil, 0, constants.ErrInvalidElevationStatus
+		}
+	}
+	if filter.RequesterID != "" {
+		if err := params.RequesterID.Scan(filter.RequesterID); err != nil {
+			return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+	}
+	if filter.RoleID != "" {
+		params.RoleID = pgtype.Text{String: filter.RoleID, Valid: true}
+	}
+
+	return s.pageRequests(ctx, params, page)
+}
+
+func (s *elevationRequestService) GetMyRequests(ctx context.Context, page dtos.PageRequest) ([]*dtos.ElevationRequestResponse, int64, error) {
+	requesterID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.pageRequests(ctx, repository.ListElevationRequestsParams{RequesterID: requesterID}, page)
+}
+
+func (s *elevationRequestService) GetRequestByID(ctx context.Context, id string) (*dtos.ElevationRequestResponse, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return s.getRequest(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+}
+
+// ApproveRequest approves a pending request and assigns the role's permissions
+// to the requester until the elevation expires. Requesters cannot approve
+// their own requests.
+func (s *elevationRequestService) ApproveRequest(ctx context.Context, id string, req *dtos.DecideElevationRequest) (*dtos.ElevationRequestResponse, error) {
+	log.Info().
+		Str("service", "ElevationRequestService").
+		Str("method", "ApproveRequest").
+		Str("id", id).
+		Msg("Approving elevation request")
+
+	requestID, approverID, err := s.prepareDecision(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	request, err := qtx.ApproveElevationRequest(ctx, repository.ApproveElevationRequestParams{
+		ID:              requestID,
+		DecidedBy:       approverID,
+		DecisionComment: pgtype.Text{String: req.Comment, Valid: req.Comment != ""},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrElevationRequestNotPending
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to approve elevation request in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDecideElevationRequest, err)
+	}
+
+	if err := checkSodConflicts(ctx, qtx, request.RequesterID, request.RoleID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := qtx.CreateElevationRoleAssignments(ctx, request.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to create elevation role assignments in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGrantElevation, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "ElevationRequestService").
+		Str("method", "ApproveRequest").
+		Str("id", id).
+		Str("requester_id", request.RequesterID.String()).
+		Str("approved_by", approverID.String()).
+		Str("role_id", request.RoleID).
+		Time("expires_at", request.ExpiresAt.Time).
+		Int("assignments", len(assignments)).
+		Msg("Elevation request approved")
+	return s.getRequest(ctx, request.ID)
+}
+
+func (s *elevationRequestService) RejectRequest(ctx context.Context, id string, req *dtos.DecideElevationRequest) (*dtos.ElevationRequestResponse, error) {
+	log.Info().
+		Str("service", "ElevationRequestService").
+		Str("method", "RejectRequest").
+		Str("id", id).
+		Msg("Rejecting elevation request")
+
+	requestID, approverID, err := s.prepareDecision(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.repo.RejectElevationRequest(ctx, repository.RejectElevationRequestParams{
+		ID:              requestID,
+		DecidedBy:       approverID,
+		DecisionComment: pgtype.Text{String: req.Comment, Valid: req.Comment != ""},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrElevationRequestNotPending
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to reject elevation request in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDecideElevationRequest, err)
+	}
+
+	log.Info().
+		Str("service", "ElevationRequestService").
+		Str("method", "RejectRequest").
+		Str("id", id).
+		Str("rejected_by", approverID.String()).
+		Msg("Elevation request rejected")
+	return s.getRequest(ctx, request.ID)
+}
+
+// CancelRequest withdraws one of the caller's own pending requests.
+func (s *elevationRequestService) CancelRequest(ctx context.Context, id string) (*dtos.ElevationRequestResponse, error) {
+	requesterID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	requestID := pgtype.UUID{Bytes: uuid, Valid: true}
+
+	existing, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.RequesterID != requesterID.String() {
+		return nil, constants.ErrElevationRequestNotFound
+	}
+
+	request, err := s.repo.CancelElevationRequest(ctx, repository.CancelElevationRequestParams{
+		ID:          requestID,
+		RequesterID: requesterID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrElevationRequestNotPending
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCancelElevationRequest, err)
+	}
+
+	return s.getRequest(ctx, request.ID)
+}
+
+// ExpireElevations marks approved requests past their expires_at as expired,
+// revokes the role assignments they granted and returns how many expired.
+func (s *elevationRequestService) ExpireElevations(ctx context.Context) (int, error) {
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	expired, err := qtx.ExpireElevationRequests(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to expire elevation requests in repository")
+		return 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToExpireElevationRequests, err)
+	}
+
+	for _, request := range expired {
+		revoked, err := qtx.RevokeElevationRoleAssignments(ctx, request.ID)
+		if err != nil {
+			log.Error().Err(err).Str("id", request.ID.String()).Msg("Failed to revoke elevation role assignments")
+			return 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeRoleAssignment, err)
+		}
+
+		log.Info().
+			Str("service", "ElevationRequestService").
+			Str("method", "ExpireElevations").
+			Str("id", request.ID.String()).
+			Str("requester_id", request.RequesterID.String()).
+			Str("role_id", request.RoleID).
+			Int64("revoked_assignments", revoked).
+			Msg("Elevation expired")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	return len(expired), nil
+}
+
+// prepareDecision parses the request ID and returns it with the approver's
+// internal ID, rejecting approvers deciding their own request.
+func (s *elevationRequestService) prepareDecision(ctx context.Context, id string) (pgtype.UUID, pgtype.UUID, error) {
+	approverID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	requestID := pgtype.UUID{Bytes: uuid, Valid: true}
+
+	request, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+	if request.RequesterID == approverID.String() {
+		return pgtype.UUID{}, pgtype.UUID{}, constants.ErrElevationSelfApproval
+	}
+
+	return requestID, approverID, nil
+}
+
+func (s *elevationRequestService) getRequest(ctx context.Context, id pgtype.UUID) (*dtos.ElevationRequestResponse, error) {
+	requests, err := s.listRequests(ctx, repository.ListElevationRequestsParams{ID: id, PageLimit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, constants.ErrElevationRequestNotFound
+	}
+
+	return requests[0], nil
+}
+
+// pageRequests lists one page of the requests matching the filters of params
+// and counts all of them.
+func (s *elevationRequestService) pageRequests(ctx context.Context, params repository.ListElevationRequestsParams, page dtos.PageRequest) ([]*dtos.ElevationRequestResponse, int64, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	total, err := s.repo.CountElevationRequests(ctx, repository.CountElevationRequestsParams{
+		TenantID:    tenantID,
+		Status:      params.Status,
+		RequesterID: params.RequesterID,
+		RoleID:      params.RoleID,
+	})
+	if err != nil {
+		log.Error().Err(err).Interface("params", params).Msg("Failed to count elevation requests in repository")
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetElevationRequests, err)
+	}
+
+	params.PageLimit = page.Limit()
+	params.PageOffset = page.Offset()
+	requests, err := s.listRequests(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+// listRequests lists the requests matching params among those raised by users
+// of the caller's tenant.
+func (s *elevationRequestService) listRequests(ctx context.Context, params repository.ListElevationRequestsParams) ([]*dtos.ElevationRequestResponse, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+	params.TenantID = tenantID
+
+	rows, err := s.repo.ListElevationRequests(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Interface("params", params).Msg("Failed to get elevation requests from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetElevationRequests, err)
+	}
+
+	result := make([]*dtos.ElevationRequestResponse, len(rows))
+	for i, row := range rows {
+		result[i] = dtos.NewElevationRequestResponse(row)
+	}
+
+	return result, nil
+}
+
+// getCurrentUserID returns the internal ID of the authenticated caller.
+func (s *elevationRequestService) getCurrentUserID(ctx context.Context) (pgtype.UUID, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
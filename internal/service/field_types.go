@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// fieldTypeNamePattern keeps type names usable as identifiers in configs and
+// client code.
+var fieldTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type FieldTypeService interface {
+	GetFieldTypes(ctx context.Context) ([]*dtos.FieldType, error)
+	GetFieldTypeByID(ctx context.Context, id string) (*dtos.FieldType, error)
+	CreateFieldType(ctx context.Context, req *dtos.CreateFieldTypeRequest) (*dtos.FieldType, error)
+	UpdateFieldType(ctx context.Context, id string, req *dtos.UpdateFieldTypeRequest) (*dtos.FieldType, error)
+	DeleteFieldType(ctx context.Context, id string) error
+}
+
+type fieldTypeService struct {
+	repo *repository.Queries
+}
+
+func NewFieldTypeService(repo *repository.Queries) FieldTypeService {
+	return &fieldTypeService{
+		repo: repo,
+	}
+}
+
+func (s *fieldTypeService) GetFieldTypes(ctx context.Context) ([]*dtos.FieldType, error) {
+	log.Info().
+		Str("service", "FieldTypeService").
+		Str("method", "GetFieldTypes").
+		Msg("Getting field types")
+
+	fieldTypes, err := s.repo.GetFieldTypes(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get field types from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFieldTypes, err)
+	}
+
+	result := make([]*dtos.FieldType, len(fieldTypes))
+	for i, fieldType := range fieldTypes {
+		result[i] = &dtos.FieldType{}
+		*result[i] = result[i].FromRepositoryModel(fieldType)
+	}
+
+	return result, nil
+}
+
+func (s *fieldTypeService) GetFieldTypeByID(ctx context.Context, id string) (*dtos.FieldType, error) {
+	log.Info().
+		Str("service", "FieldTypeService").
+		Str("method", "GetFieldTypeByID").
+		Str("id", id).
+		Msg("Getting field type by ID")
+
+	fieldType, err := s.getFieldType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.FieldType{}
+	*result = result.FromRepositoryModel(fieldType)
+	return result, nil
+}
+
+func (s *fieldTypeService) CreateFieldType(ctx context.Context, req *dtos.CreateFieldTypeRequest) (*dtos.FieldType, error) {
+	log.Info().
+		Str("service", "FieldTypeService").
+		Str("method", "CreateFieldType").
+		Str("name", req.TypeName).
+		Msg("Creating field type")
+
+	if !fieldTypeNamePattern.MatchString(req.TypeName) {
+		return nil, constants.ErrInvalidFieldTypeName
+	}
+
+	schema, err := checkFieldTypeSchema(req.ValidationSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldType, err := s.repo.CreateFieldType(ctx, repository.CreateFieldTypeParams{
+		TypeName:         req.TypeName,
+		Description:      pgtype.Text{String: req.Description, Valid: req.Description != ""},
+		ValidationSchema: schema,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, constants.ErrFieldTypeAlreadyExists
+		}
+		log.Error().Err(err).Msg("Failed to create field type in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateFieldType, err)
+	}
+
+	result := &dtos.FieldType{}
+	*result = result.FromRepositoryModel(fieldType)
+	return result, nil
+}
+
+// UpdateFieldType replaces the description and schema of a custom type; the
+// schema may not be empty. Built-in types are shared by every tenant and are
+// refused. Existing fields are not revalidated; the new schema applies the
+// next time one of them is written.
+func (s *fieldTypeService) UpdateFieldType(ctx context.Context, id string, req *dtos.UpdateFieldTypeRequest) (*dtos.FieldType, error) {
+	log.Info().
+		Str("service", "FieldTypeService").
+		Str("method", "UpdateFieldType").
+		Str("id", id).
+		Msg("Updating field type")
+
+	current, err := s.getFieldType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsBuiltin {
+		return nil, constants.ErrFieldTypeBuiltIn
+	}
+
+	schema, err := checkFieldTypeSchema(req.ValidationSchema)
+	if err != nil {
+		return nil, err
+	}
+	if len(schema) == 0 || isEmptyJSONObject(schema) {
+		return nil, constants.ErrFieldTypeSchemaEmpty
+	}
+
+	fieldType, err := s.repo.UpdateFieldType(ctx, repository.UpdateFieldTypeParams{
+		ID:               current.ID,
+		Description:      pgtype.Text{String: req.Description, Valid: req.Description != ""},
+		ValidationSchema: schema,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrFieldTypeNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to update field type in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateFieldType, err)
+	}
+
+	result := &dtos.FieldType{}
+	*result = result.FromRepositoryModel(fieldType)
+	return result, nil
+}
+
+// DeleteFieldType refuses built-in types and types still used by live fields.
+func (s *fieldTypeService) DeleteFieldType(ctx context.Context, id string) error {
+	log.Info().
+		Str("service", "FieldTypeService").
+		Str("method", "DeleteFieldType").
+		Str("id", id).
+		Msg("Deleting field type")
+
+	fieldType, err := s.getFieldType(ctx, id)
+	if err != nil {
+		return err
+	}
+	if fieldType.IsBuiltin {
+		return constants.ErrFieldTypeBuiltIn
+	}
+
+	fields, err := s.repo.CountFormFieldsByType(ctx, fieldType.TypeName)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to count form fields of field type")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteFieldType, err)
+	}
+	if fields > 0 {
+		return fmt.Errorf("%w: %d fields", constants.ErrFieldTypeInUse, fields)
+	}
+
+	deleted, err := s.repo.DeleteFieldType(ctx, fieldType.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to delete field type from repository")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteFieldType, err)
+	}
+	if deleted == 0 {
+		return constants.ErrFieldTypeNotFound
+	}
+
+	return nil
+}
+
+func (s *fieldTypeService) getFieldType(ctx context.Context, id string) (repository.FieldType, error) {
+	fieldTypeID, err := parseFieldTypeID(id)
+	if err != nil {
+		return repository.FieldType{}, err
+	}
+
+	fieldType, err := s.repo.GetFieldTypeByID(ctx, fieldTypeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.FieldType{}, constants.ErrFieldTypeNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get field type from repository")
+		return repository.FieldType{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFieldType, err)
+	}
+	return fieldType, nil
+}
+
+// checkFieldTypeSchema accepts a missing schema and otherwise requires one the
+// config validator can enforce, reporting each problem at its path in the
+// request body.
+func checkFieldTypeSchema(schema json.RawMessage) ([]byte, error) {
+	if len(schema) == 0 || string(schema) == "null" {
+		return nil, nil
+	}
+
+	violations, err := utils.CheckJSONSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", constants.ErrInvalidFieldTypeSchema, err.Error())
+	}
+	if len(violations) > 0 {
+		return nil, utils.NewSchemaValidationError(constants.ErrInvalidFieldTypeSchema, "/validation_schema", violations)
+	}
+
+	return schema, nil
+}
+
+// isEmptyJSONObject reports whether raw is an object without keys, a schema
+// that accepts everything.
+func isEmptyJSONObject(raw []byte) bool {
+	var object map[string]json.RawMessage
+	return json.Unmarshal(raw, &object) == nil && object != nil && len(object) == 0
+}
+
+func parseFieldTypeID(id string) (pgtype.UUID, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return pgtype.UUID{}, constants.ErrFieldTypeNotFound
+	}
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+const bytesPerMB = 1024 * 1024
+
+// formFieldConfig holds the keys of a field's config that answers are
+// validated against. Keys of the wrong type are ignored, since the config of a
+// custom field type need not follow the built-in schemas.
+type formFieldConfig struct {
+	Required    bool
+	MinLength   *int
+	MaxLength   *int
+	Pattern     *string
+	Min         any
+	Max         any
+	Step        *float64
+	Integer     bool
+	Options     []formFieldOption
+	MinSelected *int
+	MaxSelected *int
+	Accept      []string
+	MaxSizeMB   *float64
+	MaxFiles    *int
+}
+
+type formFieldOption struct {
+	Value any `json:"value"`
+}
+
+// formFileAnswer is one file of a file field's answer. Files are uploaded
+// elsewhere; the answer records what was attached.
+type formFileAnswer struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+}
+
+// validateFormAnswers checks answers, a JSON object keyed by field name,
+// against the fields of a template version and returns it without null
+// answers. Field names are unique within a template, so each answer belongs
+// to exactly one field. Violations carry JSON Pointer paths into the answers object; a draft may
+// leave required fields unanswered.
+func validateFormAnswers(fields []repository.FormField, answers json.RawMessage, draft bool) ([]byte, []utils.SchemaError) {
+	var values map[string]json.RawMessage
+	if trimmed := bytes.TrimSpace(answers); len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		values = map[string]json.RawMessage{}
+	} else if err := json.Unmarshal(trimmed, &values); err != nil || values == nil {
+		return nil, []utils.SchemaError{{Path: "", Message: "must be of type object"}}
+	}
+
+	var violations []utils.SchemaError
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.FieldName] = true
+		pointer := "/" + utils.EscapeJSONPointer(field.FieldName)
+		config := parseFormFieldConfig(field.Config)
+
+		raw, answered := values[field.FieldName]
+		var value any
+		if answered {
+			value = decodeFormAnswer(raw)
+		}
+		if isEmptyFormAnswer(field.FieldType, value) {
+			if config.Required && !draft {
+				violations = append(violations, utils.SchemaError{Path: pointer, Message: "is required"})
+			}
+			continue
+		}
+
+		schema, _ := json.Marshal(formAnswerSchema(field.FieldType, config))
+		fieldViolations, err := utils.ValidateJSONSchema(schema, raw)
+		if err != nil {
+			violations = append(violations, utils.SchemaError{Path: pointer, Message: "is not valid JSON"})
+			continue
+		}
+		if len(fieldViolations) == 0 {
+			fieldViolations = checkFormAnswerBounds(field.FieldType, config, value)
+		}
+		for _, violation := range fieldViolations {
+			violations = append(violations, utils.SchemaError{Path: pointer + violation.Path, Message: violation.Message})
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stored := make(map[string]json.RawMessage, len(values))
+	for _, name := range names {
+		raw := values[name]
+		if !known[name] {
+			violations = append(violations, utils.SchemaError{Path: "/" + utils.EscapeJSONPointer(name), Message: "is not a field of the form"})
+			continue
+		}
+		if decodeFormAnswer(raw) != nil {
+			stored[name] = raw
+		}
+	}
+	if len(violations) > 0 {
+		return nil, violations
+	}
+
+	result, err := json.Marshal(stored)
+	if err != nil {
+		return nil, []utils.SchemaError{{Path: "", Message: "is not valid JSON"}}
+	}
+	return result, nil
+}
+
+// formAnswerSchema builds the JSON Schema an answer to a field must satisfy
+// from its type and config. String, number and array keywords only apply to
+// values of their kind, so the generic keys are safe for custom types too.
+func formAnswerSchema(fieldType string, config formFieldConfig) map[string]any {
+	schema := map[string]any{}
+	switch fieldType {
+	case "text", "textarea", "select", "radio", "signature":
+		schema["type"] = "string"
+	case "email":
+		schema["type"] = "string"
+		schema["format"] = "email"
+	case "url":
+		schema["type"] = "string"
+		schema["format"] = "uri"
+	case "date":
+		schema["type"] = "string"
+		schema["format"] = "date"
+	case "datetime":
+		schema["type"] = "string"
+		schema["format"] = "date-time"
+	case "time":
+		schema["type"] = "string"
+		schema["format"] = "time"
+	case "number":
+		schema["type"] = "number"
+		if config.Integer {
+			schema["type"] = "integer"
+		}
+	case "checkbox":
+		schema["type"] = "boolean"
+	case "multiselect":
+		schema["type"] = "array"
+		schema["uniqueItems"] = true
+		schema["items"] = map[string]any{"type": "string"}
+	case "file":
+		file := map[string]any{
+			"type":                 "object",
+			"required":             []string{"name"},
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"name":         map[string]any{"type": "string", "minLength": 1},
+				"content_type": map[string]any{"type": "string"},
+				"size_bytes":   map[string]any{"type": "integer", "minimum": 0},
+				"url":          map[string]any{"type": "string", "format": "uri"},
+			},
+		}
+		if config.MaxSizeMB != nil {
+			file["properties"].(map[string]any)["size_bytes"].(map[string]any)["maximum"] = math.Floor(*config.MaxSizeMB * bytesPerMB)
+		}
+		schema["type"] = "array"
+		schema["items"] = file
+		if config.MaxFiles != nil {
+			schema["maxItems"] = *config.MaxFiles
+		}
+	}
+
+	if config.MinLength != nil {
+		schema["minLength"] = *config.MinLength
+	}
+	if config.MaxLength != nil {
+		schema["maxLength"] = *config.MaxLength
+	}
+	if config.Pattern != nil {
+		schema["pattern"] = *config.Pattern
+	}
+	if !isTemporalFieldType(fieldType) {
+		if min, ok := config.Min.(float64); ok {
+			schema["minimum"] = min
+		}
+		if max, ok := config.Max.(float64); ok {
+			schema["maximum"] = max
+		}
+	}
+	if config.MinSelected != nil {
+		schema["minItems"] = *config.MinSelected
+	}
+	if config.MaxSelected != nil {
+		schema["maxItems"] = *config.MaxSelected
+	}
+	if len(config.Options) > 0 {
+		values := make([]any, len(config.Options))
+		for i, option := range config.Options {
+			values[i] = option.Value
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			items["enum"] = values
+		} else {
+			schema["enum"] = values
+		}
+	}
+	return schema
+}
+
+// checkFormAnswerBounds applies the config keys JSON Schema cannot express:
+// date and time bounds, number steps and accepted file types. value has
+// already passed formAnswerSchema.
+func checkFormAnswerBounds(fieldType string, config formFieldConfig, value any) []utils.SchemaError {
+	var violations []utils.SchemaError
+	fail := func(pointer, message string) {
+		violations = append(violations, utils.SchemaError{Path: pointer, Message: message})
+	}
+
+	switch fieldType {
+	case "date", "datetime":
+		layout := time.DateOnly
+		if fieldType == "datetime" {
+			layout = time.RFC3339
+		}
+		answer, err := time.Parse(layout, value.(string))
+		if err != nil {
+			return nil
+		}
+		if min, ok := config.Min.(string); ok {
+			if bound, err := time.Parse(layout, min); err == nil && answer.Before(bound) {
+				fail("", "must not be before "+min)
+			}
+		}
+		if max, ok := config.Max.(string); ok {
+			if bound, err := time.Parse(layout, max); err == nil && answer.After(bound) {
+				fail("", "must not be after "+max)
+			}
+		}
+	case "number":
+		number, ok := value.(json.Number)
+		if !ok || config.Step == nil || *config.Step <= 0 {
+			return nil
+		}
+		n, _ := number.Float64()
+		base, _ := config.Min.(float64)
+		if q := (n - base) / *config.Step; math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("", "must be a multiple of "+strconv.FormatFloat(*config.Step, 'f', -1, 64)+" from "+strconv.FormatFloat(base, 'f', -1, 64))
+		}
+	case "file":
+		if len(config.Accept) == 0 {
+			return nil
+		}
+		raw, _ := json.Marshal(value)
+		var files []formFileAnswer
+		if err := json.Unmarshal(raw, &files); err != nil {
+			return nil
+		}
+		for i, file := range files {
+			if !fileAccepted(file, config.Accept) {
+				fail("/"+strconv.Itoa(i), "must be one of the accepted file types "+strings.Join(config.Accept, ", "))
+			}
+		}
+	}
+	return violations
+}
+
+// fileAccepted matches a file against accept entries in the style of the
+// HTML accept attribute: extensions such as ".pdf", MIME types such as
+// "application/pdf" and wildcards such as "image/*".
+func fileAccepted(file formFileAnswer, accept []string) bool {
+	extension := strings.ToLower(path.Ext(file.Name))
+	contentType := strings.ToLower(strings.TrimSpace(strings.SplitN(file.ContentType, ";", 2)[0]))
+	for _, entry := range accept {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case strings.HasPrefix(entry, "."):
+			if extension == entry {
+				return true
+			}
+		case strings.HasSuffix(entry, "/*"):
+			if contentType != "" && strings.HasPrefix(contentType, strings.TrimSuffix(entry, "*")) {
+				return true
+			}
+		case contentType != "" && contentType == entry:
+			return true
+		}
+	}
+	return false
+}
+
+func parseFormFieldConfig(raw []byte) formFieldConfig {
+	var keys map[string]json.RawMessage
+	_ = json.Unmarshal(raw, &keys)
+
+	var config formFieldConfig
+	decode := func(key string, target any) {
+		if value, ok := keys[key]; ok {
+			_ = json.Unmarshal(value, target)
+		}
+	}
+	decode("required", &config.Required)
+	decode("min_length", &config.MinLength)
+	decode("max_length", &config.MaxLength)
+	decode("pattern", &config.Pattern)
+	decode("min", &config.Min)
+	decode("max", &config.Max)
+	decode("step", &config.Step)
+	decode("integer", &config.Integer)
+	decode("options", &config.Options)
+	decode("min_selected", &config.MinSelected)
+	decode("max_selected", &config.MaxSelected)
+	decode("accept", &config.Accept)
+	decode("max_size_mb", &config.MaxSizeMB)
+	decode("max_files", &config.MaxFiles)
+	return config
+}
+
+// decodeFormAnswer decodes an answer keeping numbers as json.Number; invalid
+// JSON cannot occur since the answers object has already been decoded.
+func decodeFormAnswer(raw json.RawMessage) any {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	_ = decoder.Decode(&value)
+	return value
+}
+
+// isEmptyFormAnswer reports whether value leaves the field unanswered: null,
+// a blank string, an empty list or an unticked checkbox.
+func isEmptyFormAnswer(fieldType string, value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []any:
+		return len(v) == 0
+	case bool:
+		return fieldType == "checkbox" && !v
+	}
+	return false
+}
+
+func isTemporalFieldType(fieldType string) bool {
+	return fieldType == "date" || fieldType == "datetime" || fieldType == "time"
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+)
+
+func TestValidateFormAnswers(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType string
+		config    string
+		answer    string
+		draft     bool
+		want      []utils.SchemaError
+	}{
+		{"required answered", "text", `{"required": true}`, `"x"`, false, nil},
+		{"required missing", "text", `{"required": true}`, ``, false, []utils.SchemaError{{Path: "/f", Message: "is required"}}},
+		{"required null", "text", `{"required": true}`, `null`, false, []utils.SchemaError{{Path: "/f", Message: "is required"}}},
+		{"required blank", "text", `{"required": true}`, `"  "`, false, []utils.SchemaError{{Path: "/f", Message: "is required"}}},
+		{"required empty list", "multiselect", `{"required": true, "options": [{"value": "a"}]}`, `[]`, false, []utils.SchemaError{{Path: "/f", Message: "is required"}}},
+		{"required unticked checkbox", "checkbox", `{"required": true}`, `false`, false, []utils.SchemaError{{Path: "/f", Message: "is required"}}},
+		{"optional missing", "text", `{}`, ``, false, nil},
+		{"draft skips required", "text", `{"required": true}`, ``, true, nil},
+		{"draft still checks answers", "text", `{"required": true, "max_length": 2}`, `"abc"`, true, []utils.SchemaError{{Path: "/f", Message: "must be at most 2 characters long"}}},
+
+		{"type", "text", `{}`, `1`, false, []utils.SchemaError{{Path: "/f", Message: "must be of type string"}}},
+		{"min length", "text", `{"min_length": 3}`, `"ab"`, false, []utils.SchemaError{{Path: "/f", Message: "must be at least 3 characters long"}}},
+		{"max length", "textarea", `{"max_length": 3}`, `"abcd"`, false, []utils.SchemaError{{Path: "/f", Message: "must be at most 3 characters long"}}},
+		{"pattern", "text", `{"pattern": "^[A-Z]{3}$"}`, `"ABC"`, false, nil},
+		{"pattern mismatch", "text", `{"pattern": "^[A-Z]{3}$"}`, `"abc"`, false, []utils.SchemaError{{Path: "/f", Message: "must match pattern ^[A-Z]{3}$"}}},
+		{"email", "email", `{}`, `"nope"`, false, []utils.SchemaError{{Path: "/f", Message: "must be a valid email"}}},
+
+		{"number within bounds", "number", `{"min": 1, "max": 10}`, `10`, false, nil},
+		{"number below min", "number", `{"min": 1, "max": 10}`, `0`, false, []utils.SchemaError{{Path: "/f", Message: "must be greater than or equal to 1"}}},
+		{"number above max", "number", `{"min": 1, "max": 10}`, `10.5`, false, []utils.SchemaError{{Path: "/f", Message: "must be less than or equal to 10"}}},
+		{"integer", "number", `{"integer": true}`, `1.5`, false, []utils.SchemaError{{Path: "/f", Message: "must be of type integer"}}},
+		{"step", "number", `{"step": 0.5}`, `2.5`, false, nil},
+		{"step mismatch", "number", `{"step": 0.5}`, `2.25`, false, []utils.SchemaError{{Path: "/f", Message: "must be a multiple of 0.5 from 0"}}},
+		{"step from min", "number", `{"min": 1, "step": 2}`, `5`, false, nil},
+		{"step from min mismatch", "number", `{"min": 1, "step": 2}`, `4`, false, []utils.SchemaError{{Path: "/f", Message: "must be a multiple of 2 from 1"}}},
+
+		{"select option", "select", `{"options": [{"value": "a"}, {"value": "b"}]}`, `"b"`, false, nil},
+		{"select unknown option", "select", `{"options": [{"value": "a"}, {"value": "b"}]}`, `"c"`, false, []utils.SchemaError{{Path: "/f", Message: `must be one of "a", "b"`}}},
+		{"multiselect options", "multiselect", `{"options": [{"value": "a"}, {"value": "b"}]}`, `["a", "b"]`, false, nil},
+		{"multiselect unknown option", "multiselect", `{"options": [{"value": "a"}, {"value": "b"}]}`, `["a", "c"]`, false, []utils.SchemaError{{Path: "/f/1", Message: `must be one of "a", "b"`}}},
+		{"multiselect duplicate", "multiselect", `{"options": [{"value": "a"}]}`, `["a", "a"]`, false, []utils.SchemaError{{Path: "/f/1", Message: "duplicates item 0"}}},
+		{"multiselect min selected", "multiselect", `{"min_selected": 2}`, `["a"]`, false, []utils.SchemaError{{Path: "/f", Message: "must have at least 2 items"}}},
+		{"multiselect max selected", "multiselect", `{"max_selected": 1}`, `["a", "b"]`, false, []utils.SchemaError{{Path: "/f", Message: "must have at most 1 items"}}},
+
+		{"file accepted", "file", `{"accept": [".pdf", "image/*"]}`, `[{"name": "a.PDF"}, {"name": "b", "content_type": "image/png"}]`, false, nil},
+		{"file mime type", "file", `{"accept": ["application/pdf"]}`, `[{"name": "a", "content_type": "application/pdf; charset=binary"}]`, false, nil},
+		{"file not accepted", "file", `{"accept": [".pdf", "image/*"]}`, `[{"name": "a.pdf"}, {"name": "b.exe", "content_type": "application/octet-stream"}]`, false, []utils.SchemaError{{Path: "/f/1", Message: "must be one of the accepted file types .pdf, image/*"}}},
+		{"file without name", "file", `{}`, `[{"content_type": "image/png"}]`, false, []utils.SchemaError{{Path: "/f/0/name", Message: "is required"}}},
+		{"file unknown key", "file", `{}`, `[{"name": "a", "path": "/tmp/a"}]`, false, []utils.SchemaError{{Path: "/f/0/path", Message: "is not allowed"}}},
+		{"file max files", "file", `{"max_files": 1}`, `[{"name": "a"}, {"name": "b"}]`, false, []utils.SchemaError{{Path: "/f", Message: "must have at most 1 items"}}},
+
+		{"date", "date", `{}`, `"2024-02-29"`, false, nil},
+		{"date invalid", "date", `{}`, `"2025-02-29"`, false, []utils.SchemaError{{Path: "/f", Message: "must be a valid date"}}},
+		{"date with time", "date", `{}`, `"2025-02-28T10:00:00Z"`, false, []utils.SchemaError{{Path: "/f", Message: "must be a valid date"}}},
+		{"date before min", "date", `{"min": "2025-01-01", "max": "2025-12-31"}`, `"2024-12-31"`, false, []utils.SchemaError{{Path: "/f", Message: "must not be before 2025-01-01"}}},
+		{"date after max", "date", `{"min": "2025-01-01", "max": "2025-12-31"}`, `"2026-01-01"`, false, []utils.SchemaError{{Path: "/f", Message: "must not be after 2025-12-31"}}},
+		{"datetime", "datetime", `{"min": "2025-01-01T00:00:00Z"}`, `"2025-01-01T01:00:00+01:00"`, false, nil},
+		{"datetime before min", "datetime", `{"min": "2025-01-01T00:00:00Z"}`, `"2025-01-01T00:30:00+01:00"`, false, []utils.SchemaError{{Path: "/f", Message: "must not be before 2025-01-01T00:00:00Z"}}},
+		{"datetime invalid", "datetime", `{}`, `"2025-01-01"`, false, []utils.SchemaError{{Path: "/f", Message: "must be a valid date-time"}}},
+		{"time invalid", "time", `{}`, `"24:00:00Z"`, false, []utils.SchemaError{{Path: "/f", Message: "must be a valid time"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := []repository.FormField{{FieldName: "f", FieldType: tt.fieldType, Config: []byte(tt.config)}}
+			answers := `{}`
+			if tt.answer != "" {
+				answers = `{"f": ` + tt.answer + `}`
+			}
+
+			stored, got := validateFormAnswers(fields, json.RawMessage(answers), tt.draft)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("validateFormAnswers(%s) = %v, want %v", answers, got, tt.want)
+			}
+			if got == nil && stored == nil {
+				t.Errorf("validateFormAnswers(%s) stored nothing", answers)
+			}
+		})
+	}
+}
+
+func TestValidateFormAnswersDocument(t *testing.T) {
+	fields := []repository.FormField{
+		{FieldName: "title", FieldType: "text", Config: []byte(`{"required": true}`)},
+		{FieldName: "notes", FieldType: "textarea", Config: []byte(`{}`)},
+		{FieldName: "a/b", FieldType: "number", Config: []byte(`{"max": 1}`)},
+	}
+
+	tests := []struct {
+		name    string
+		answers string
+		want    []utils.SchemaError
+		stored  string
+	}{
+		{"nulls are dropped", `{"title": "x", "notes": null}`, nil, `{"title":"x"}`},
+		{"empty body", ``, []utils.SchemaError{{Path: "/title", Message: "is required"}}, ``},
+		{"not an object", `[]`, []utils.SchemaError{{Path: "", Message: "must be of type object"}}, ``},
+		{"unknown field", `{"title": "x", "other": 1}`, []utils.SchemaError{{Path: "/other", Message: "is not a field of the form"}}, ``},
+		{"escaped path", `{"title": "x", "a/b": 2}`, []utils.SchemaError{{Path: "/a~1b", Message: "must be less than or equal to 1"}}, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, got := validateFormAnswers(fields, json.RawMessage(tt.answers), false)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("validateFormAnswers(%s) = %v, want %v", tt.answers, got, tt.want)
+			}
+			if string(stored) != tt.stored {
+				t.Errorf("validateFormAnswers(%s) stored %s, want %s", tt.answers, stored, tt.stored)
+			}
+		})
+	}
+}
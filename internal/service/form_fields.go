@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+type FormFieldService interface {
+	GetFormFields(ctx context.Context, templateID, sectionID string) ([]*dtos.FormField, error)
+	GetFormFieldByID(ctx context.Context, templateID, id string) (*dtos.FormField, error)
+	CreateFormField(ctx context.Context, templateID string, req *dtos.CreateFormFieldRequest) (*dtos.FormField, error)
+	UpdateFormField(ctx context.Context, templateID, id string, req *dtos.UpdateFormFieldRequest) (*dtos.FormField, error)
+	DeleteFormField(ctx context.Context, templateID, id string) error
+}
+
+type formFieldService struct {
+	repo *repository.Queries
+}
+
+func NewFormFieldService(repo *repository.Queries) FormFieldService {
+	return &formFieldService{
+		repo: repo,
+	}
+}
+
+// formFieldInput is a create or update request after validation.
+type formFieldInput struct {
+	sectionID pgtype.UUID
+	config    []byte
+}
+
+// GetFormFields lists the template's fields by field_order, optionally only
+// those of one section.
+func (s *formFieldService) GetFormFields(ctx context.Context, templateID, sectionID string) ([]*dtos.FormField, error) {
+	log.Info().
+		Str("service", "FormFieldService").
+		Str("method", "GetFormFields").
+		Str("templateID", templateID).
+		Str("sectionID", sectionID).
+		Msg("Getting form fields")
+
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []repository.FormField
+	if sectionID != "" {
+		section, err := s.getSection(ctx, template, sectionID)
+		if err != nil {
+			return nil, err
+		}
+		fields, err = s.repo.GetFormFieldsBySection(ctx, repository.GetFormFieldsBySectionParams{
+			FormTemplateID: template.ID,
+			FormSectionID:  section,
+		})
+	} else {
+		fields, err = s.repo.GetFormFields(ctx, template.ID)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("templateID", templateID).Msg("Failed to get form fields from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormFields, err)
+	}
+
+	result := make([]*dtos.FormField, len(fields))
+	for i, field := range fields {
+		result[i] = &dtos.FormField{}
+		*result[i] = result[i].FromRepositoryModel(field)
+	}
+
+	return result, nil
+}
+
+func (s *formFieldService) GetFormFieldByID(ctx context.Context, templateID, id string) (*dtos.FormField, error) {
+	log.Info().
+		Str("service", "FormFieldService").
+		Str("method", "GetFormFieldByID").
+		Str("templateID", templateID).
+		Str("id", id).
+		Msg("Getting form field by ID")
+
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldID, err := parseFormFieldID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.repo.GetFormFieldByID(ctx, repository.GetFormFieldByIDParams{ID: fieldID, FormTemplateID: template.ID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrFormFieldNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get form field from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormField, err)
+	}
+
+	result := &dtos.FormField{}
+	*result = result.FromRepositoryModel(field)
+	return result, nil
+}
+
+func (s *formFieldService) CreateFormField(ctx context.Context, templateID string, req *dtos.CreateFormFieldRequest) (*dtos.FormField, error) {
+	log.Info().
+		Str("service", "FormFieldService").
+		Str("method", "CreateFormField").
+		Str("templateID", templateID).
+		Str("name", req.FieldName).
+		Str("fieldType", req.FieldType).
+		Msg("Creating form field")
+
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireDraftFormTemplate(template); err != nil {
+		return nil, err
+	}
+
+	input, err := s.validateFormField(ctx, template, req.FormSectionID, req.FieldType, req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.repo.CreateFormField(ctx, repository.CreateFormFieldParams{
+		FormTemplateID: template.ID,
+		FormSectionID:  input.sectionID,
+		FieldName:      req.FieldName,
+		FieldType:      req.FieldType,
+		FieldOrder:     req.FieldOrder,
+		Config:         input.config,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, constants.ErrFormFieldAlreadyExists
+		}
+		log.Error().Err(err).Msg("Failed to create form field in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateFormField, err)
+	}
+
+	result := &dtos.FormField{}
+	*result = result.FromRepositoryModel(field)
+	return result, nil
+}
+
+func (s *formFieldService) UpdateFormField(ctx context.Context, templateID, id string, req *dtos.UpdateFormFieldRequest) (*dtos.FormField, error) {
+	log.Info().
+		Str("service", "FormFieldService").
+		Str("method", "UpdateFormField").
+		Str("templateID", templateID).
+		Str("id", id).
+		Msg("Updating form field")
+
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireDraftFormTemplate(template); err != nil {
+		return nil, err
+	}
+
+	fieldID, err := parseFormFieldID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := s.validateFormField(ctx, template, req.FormSectionID, req.FieldType, req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.repo.UpdateFormField(ctx, repository.UpdateFormFieldParams{
+		ID:             fieldID,
+		FormTemplateID: template.ID,
+		FormSectionID:  input.sectionID,
+		FieldName:      req.FieldName,
+		FieldType:      req.FieldType,
+		FieldOrder:     req.FieldOrder,
+		Config:         input.config,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, constants.ErrFormFieldNotFound
+		case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+			return nil, constants.ErrFormFieldAlreadyExists
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to update form field in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateFormField, err)
+	}
+
+	result := &dtos.FormField{}
+	*result = result.FromRepositoryModel(field)
+	return result, nil
+}
+
+func (s *formFieldService) DeleteFormField(ctx context.Context, templateID, id string) error {
+	log.Info().
+		Str("service", "FormFieldService").
+		Str("method", "DeleteFormField").
+		Str("templateID", templateID).
+		Str("id", id).
+		Msg("Deleting form field")
+
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return err
+	}
+	if err := requireDraftFormTemplate(template); err != nil {
+		return err
+	}
+
+	fieldID, err := parseFormFieldID(id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteFormField(ctx, repository.DeleteFormFieldParams{ID: fieldID, FormTemplateID: template.ID})
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to delete form field from repository")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteFormField, err)
+	}
+	if deleted == 0 {
+		return constants.ErrFormFieldNotFound
+	}
+
+	return nil
+}
+
+// validateFormField checks that the section belongs to the template and that
+// config satisfies the validation schema of the registered field type. Schema
+// violations come back as a *utils.SchemaValidationError with paths into the
+// request body.
+func (s *formFieldService) validateFormField(ctx context.Context, template repository.FormTemplate, sectionID, fieldType string, config json.RawMessage) (formFieldInput, error) {
+	var input formFieldInput
+
+	if sectionID != "" {
+		section, err := s.getSection(ctx, template, sectionID)
+		if err != nil {
+			return input, err
+		}
+		input.sectionID = section
+	}
+
+	registered, err := s.repo.GetFieldTypeByName(ctx, fieldType)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return input, fmt.Errorf("%w: %s", constants.ErrFormFieldTypeNotFound, fieldType)
+		}
+		log.Error().Err(err).Str("fieldType", fieldType).Msg("Failed to get field type from repository")
+		return input, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFieldType, err)
+	}
+
+	if len(config) > 0 && string(config) != "null" {
+		if !strings.HasPrefix(strings.TrimSpace(string(config)), "{") {
+			return input, utils.NewSchemaValidationError(constants.ErrInvalidFormFieldConfig, "/config", []utils.SchemaError{{Message: "must be of type object"}})
+		}
+		input.config = config
+	}
+
+	if len(registered.ValidationSchema) > 0 {
+		document := input.config
+		if document == nil {
+			document = []byte("{}")
+		}
+		violations, err := utils.ValidateJSONSchema(registered.ValidationSchema, document)
+		if err != nil {
+			log.Error().Err(err).Str("fieldType", fieldType).Msg("Failed to validate form field config")
+			return input, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFieldType, err)
+		}
+		if len(violations) > 0 {
+			return input, utils.NewSchemaValidationError(constants.ErrInvalidFormFieldConfig, "/config", violations)
+		}
+	}
+
+	return input, nil
+}
+
+// getTemplate gets the active template the fields belong to.
+func (s *formFieldService) getTemplate(ctx context.Context, templateID string) (repository.FormTemplate, error) {
+	uuid, err := utils.ParseUUID(templateID)
+	if err != nil {
+		return repository.FormTemplate{}, constants.ErrFormFieldTemplateNotFound
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	template, err := s.repo.GetFormTemplateByID(ctx, repository.GetFormTemplateByIDParams{
+		ID:       pgtype.UUID{Bytes: uuid, Valid: true},
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.FormTemplate{}, constants.ErrFormFieldTemplateNotFound
+		}
+		log.Error().Err(err).Str("templateID", templateID).Msg("Failed to get form template from repository")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplate, err)
+	}
+	return template, nil
+}
+
+// getSection checks that the section is an active section of the template.
+func (s *formFieldService) getSection(ctx context.Context, template repository.FormTemplate, sectionID string) (pgtype.UUID, error) {
+	uuid, err := utils.ParseUUID(sectionID)
+	if err != nil {
+		return pgtype.UUID{}, constants.ErrInvalidFormSectionReference
+	}
+
+	section, err := s.repo.GetFormSectionByID(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, constants.ErrFormFieldSectionNotFound
+		}
+		log.Error().Err(err).Str("sectionID", sectionID).Msg("Failed to get form section from repository")
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormSection, err)
+	}
+	if section.FormTemplateID != template.ID {
+		return pgtype.UUID{}, constants.ErrFormFieldSectionNotFound
+	}
+	return section.ID, nil
+}
+
+func parseFormFieldID(id string) (pgtype.UUID, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return pgtype.UUID{}, constants.ErrFormFieldNotFound
+	}
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"yet-another-itsm/internal/constants"
@@ -9,6 +10,7 @@ import (
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/utils"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog/log"
 )
@@ -96,6 +98,10 @@ func (s *formSectionService) CreateFormSection(ctx context.Context, req *dtos.Cr
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 	}
 
+	if err := s.requireDraftTemplate(ctx, pgtype.UUID{Bytes: templateUUID, Valid: true}); err != nil {
+		return nil, err
+	}
+
 	params := repository.CreateFormSectionParams{
 		SectionName:    req.SectionName,
 		Description:    pgtype.Text{String: req.Description, Valid: req.Description != ""},
@@ -128,6 +134,10 @@ func (s *formSectionService) UpdateFormSection(ctx context.Context, id string, r
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 	}
 
+	if err := s.requireDraftSection(ctx, pgtype.UUID{Bytes: uuid, Valid: true}); err != nil {
+		return nil, err
+	}
+
 	params := repository.UpdateFormSectionParams{
 		ID:           pgtype.UUID{Bytes: uuid, Valid: true},
 		SectionName:  req.SectionName,
@@ -159,6 +169,10 @@ func (s *formSectionService) DeleteFormSection(ctx context.Context, id string) e
 		return fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 	}
 
+	if err := s.requireDraftSection(ctx, pgtype.UUID{Bytes: uuid, Valid: true}); err != nil {
+		return err
+	}
+
 	err = s.repo.DeleteFormSection(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
 	if err != nil {
 		log.Error().Err(err).Str("id", id).Msg("Failed to delete form section from repository")
@@ -167,3 +181,32 @@ func (s *formSectionService) DeleteFormSection(ctx context.Context, id string) e
 
 	return nil
 }
+
+// requireDraftSection refuses changes to a section whose template is no longer
+// a draft.
+func (s *formSectionService) requireDraftSection(ctx context.Context, id pgtype.UUID) error {
+	section, err := s.repo.GetFormSectionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constants.ErrFormSectionMissing
+		}
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormSection, err)
+	}
+	return s.requireDraftTemplate(ctx, section.FormTemplateID)
+}
+
+func (s *formSectionService) requireDraftTemplate(ctx context.Context, templateID pgtype.UUID) error {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	template, err := s.repo.GetFormTemplateByID(ctx, repository.GetFormTemplateByIDParams{ID: templateID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constants.ErrFormFieldTemplateNotFound
+		}
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplate, err)
+	}
+	return requireDraftFormTemplate(template)
+}
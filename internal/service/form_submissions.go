@@ -0,0 +1,490 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// Page sizes of form submission lists.
+const (
+	defaultFormSubmissionPageSize = 20
+	maxFormSubmissionPageSize     = 100
+)
+
+// formSubmissionSubmitterMe filters submissions by the caller.
+const formSubmissionSubmitterMe = "me"
+
+type FormSubmissionService interface {
+	GetFormSubmissions(ctx context.Context, query dtos.FormSubmissionQuery) (*dtos.FormSubmissionSearchResult, error)
+	GetFormSubmissionByID(ctx context.Context, id string) (*dtos.FormSubmission, error)
+	CreateFormSubmission(ctx context.Context, templateID string, req *dtos.CreateFormSubmissionRequest) (*dtos.FormSubmission, error)
+	UpdateFormSubmission(ctx context.Context, id string, req *dtos.UpdateFormSubmissionRequest) (*dtos.FormSubmission, error)
+	DeleteFormSubmission(ctx context.Context, id string) error
+}
+
+type formSubmissionService struct {
+	repo          *repository.Queries
+	authorization AuthorizationService
+}
+
+func NewFormSubmissionService(repo *repository.Queries, authorization AuthorizationService) FormSubmissionService {
+	return &formSubmissionService{
+		repo:          repo,
+		authorization: authorization,
+	}
+}
+
+// GetFormSubmissions returns one page of the submissions the caller may read,
+// newest first. Drafts are only listed for their submitter.
+func (s *formSubmissionService) GetFormSubmissions(ctx context.Context, query dtos.FormSubmissionQuery) (*dtos.FormSubmissionSearchResult, error) {
+	log.Info().
+		Str("service", "FormSubmissionService").
+		Str("method", "GetFormSubmissions").
+		Str("templateID", query.TemplateID).
+		Str("submittedBy", query.SubmittedBy).
+		Msg("Getting form submissions")
+
+	page, size, err := parseFormSubmissionPage(query.Page, query.Size)
+	if err != nil {
+		return nil, err
+	}
+	result := &dtos.FormSubmissionSearchResult{Submissions: []*dtos.FormSubmission{}, Page: page, Size: size}
+
+	callerID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.CountFormSubmissionsParams{CallerID: callerID}
+	if query.TemplateID != "" {
+		var templateID pgtype.UUID
+		if err := templateID.Scan(query.TemplateID); err != nil {
+			return nil, constants.ErrInvalidFormSubmissionFilter
+		}
+		template, err := s.getTemplate(ctx, templateID)
+		if err != nil {
+			return nil, err
+		}
+		params.LineageID = template.LineageID
+	}
+	switch query.SubmittedBy {
+	case "":
+	case formSubmissionSubmitterMe:
+		params.SubmittedBy = callerID
+	default:
+		if err := params.SubmittedBy.Scan(query.SubmittedBy); err != nil {
+			return nil, constants.ErrInvalidFormSubmissionFilter
+		}
+	}
+	if query.State != "" {
+		state := repository.FormSubmissionState(query.State)
+		if state != repository.FormSubmissionStateDraft && state != repository.FormSubmissionStateSubmitted {
+			return nil, constants.ErrInvalidFormSubmissionFilter
+		}
+		params.State = repository.NullFormSubmissionState{FormSubmissionState: state, Valid: true}
+	}
+	if params.CreatedFrom, err = parseFormSubmissionDate(query.From, false); err != nil {
+		return nil, err
+	}
+	if params.CreatedTo, err = parseFormSubmissionDate(query.To, true); err != nil {
+		return nil, err
+	}
+
+	filter, err := s.authorization.GetScopeFilter(ctx, constants.ResourceFormSubmissions, constants.ActionRead)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to resolve form submission scope filter")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormSubmissions, err)
+	}
+	if filter.IsEmpty() {
+		return result, nil
+	}
+	if err := params.TenantID.Scan(filter.TenantID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	params.AllAccess = filter.AllAccess
+	if params.BusinessUnitIds, err = scopeFilterUUIDs(filter.BusinessUnitIDs); err != nil {
+		return nil, err
+	}
+	if params.DepartmentIds, err = scopeFilterUUIDs(filter.DepartmentIDs); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID != "" {
+		if err := params.OwnerID.Scan(filter.OwnerID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+	}
+
+	total, err := s.repo.CountFormSubmissions(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count form submissions in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormSubmissions, err)
+	}
+	result.TotalItems = total
+
+	submissions, err := s.repo.ListFormSubmissions(ctx, repository.ListFormSubmissionsParams{
+		TenantID:        params.TenantID,
+		LineageID:       params.LineageID,
+		SubmittedBy:     params.SubmittedBy,
+		State:           params.State,
+		CreatedFrom:     params.CreatedFrom,
+		CreatedTo:       params.CreatedTo,
+		CallerID:        params.CallerID,
+		AllAccess:       params.AllAccess,
+		BusinessUnitIds: params.BusinessUnitIds,
+		DepartmentIds:   params.DepartmentIds,
+		OwnerID:         params.OwnerID,
+		PageLimit:       int32(size),
+		PageOffset:      int32((page - 1) * size),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get form submissions from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormSubmissions, err)
+	}
+
+	for _, submission := range submissions {
+		item := &dtos.FormSubmission{}
+		*item = item.FromRepositoryModel(submission)
+		result.Submissions = append(result.Submissions, item)
+	}
+	return result, nil
+}
+
+// GetFormSubmissionByID returns a submission the caller may read; another
+// user's draft does not exist for the caller.
+func (s *formSubmissionService) GetFormSubmissionByID(ctx context.Context, id string) (*dtos.FormSubmission, error) {
+	log.Info().
+		Str("service", "FormSubmissionService").
+		Str("method", "GetFormSubmissionByID").
+		Str("id", id).
+		Msg("Getting form submission by ID")
+
+	callerID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.getSubmission(ctx, id, callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, constants.ActionRead, submission.TenantID, submission.BusinessUnitID, submission.DepartmentID, submission.SubmittedBy); err != nil {
+		return nil, err
+	}
+
+	result := &dtos.FormSubmission{}
+	*result = result.FromRepositoryModel(submission)
+	return result, nil
+}
+
+// CreateFormSubmission stores the caller's answers to a published template
+// version, as a draft or validated in full and submitted.
+func (s *formSubmissionService) CreateFormSubmission(ctx context.Context, templateID string, req *dtos.CreateFormSubmissionRequest) (*dtos.FormSubmission, error) {
+	log.Info().
+		Str("service", "FormSubmissionService").
+		Str("method", "CreateFormSubmission").
+		Str("templateID", templateID).
+		Bool("draft", req.Draft).
+		Msg("Creating form submission")
+
+	uuid, err := utils.ParseUUID(templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateID", templateID).Msg("Invalid UUID format")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	template, err := s.getTemplate(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	if template.State != repository.FormTemplateStatePublished {
+		return nil, constants.ErrFormSubmissionTemplateNotPublished
+	}
+
+	callerID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, constants.ActionCreate, template.TenantID, template.BusinessUnitID, template.DepartmentID, callerID); err != nil {
+		return nil, err
+	}
+
+	answers, err := s.validateAnswers(ctx, template.ID, req.Answers, req.Draft)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.repo.CreateFormSubmission(ctx, repository.CreateFormSubmissionParams{
+		SubmittedBy:    callerID,
+		State:          formSubmissionState(req.Draft),
+		Answers:        answers,
+		FormTemplateID: template.ID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("templateID", templateID).Msg("Failed to create form submission in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateFormSubmission, err)
+	}
+
+	result := &dtos.FormSubmission{}
+	*result = result.FromRepositoryModel(submission)
+	return result, nil
+}
+
+// UpdateFormSubmission replaces the answers of the caller's draft, submitting
+// it unless req keeps it a draft. The draft's template version must still be
+// the published one.
+func (s *formSubmissionService) UpdateFormSubmission(ctx context.Context, id string, req *dtos.UpdateFormSubmissionRequest) (*dtos.FormSubmission, error) {
+	log.Info().
+		Str("service", "FormSubmissionService").
+		Str("method", "UpdateFormSubmission").
+		Str("id", id).
+		Bool("draft", req.Draft).
+		Msg("Updating form submission")
+
+	callerID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := s.getOwnDraft(ctx, id, callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, constants.ActionUpdate, draft.TenantID, draft.BusinessUnitID, draft.DepartmentID, draft.SubmittedBy); err != nil {
+		return nil, err
+	}
+
+	template, err := s.getTemplate(ctx, draft.FormTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.State != repository.FormTemplateStatePublished {
+		return nil, constants.ErrFormSubmissionTemplateNotPublished
+	}
+
+	answers, err := s.validateAnswers(ctx, template.ID, req.Answers, req.Draft)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.repo.UpdateFormSubmissionDraft(ctx, repository.UpdateFormSubmissionDraftParams{
+		Answers: answers,
+		State:   formSubmissionState(req.Draft),
+		ID:      draft.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrFormSubmissionNotEditable
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to update form submission in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateFormSubmission, err)
+	}
+
+	result := &dtos.FormSubmission{}
+	*result = result.FromRepositoryModel(submission)
+	return result, nil
+}
+
+// DeleteFormSubmission discards the caller's draft; submitted answers are kept.
+func (s *formSubmissionService) DeleteFormSubmission(ctx context.Context, id string) error {
+	log.Info().
+		Str("service", "FormSubmissionService").
+		Str("method", "DeleteFormSubmission").
+		Str("id", id).
+		Msg("Deleting form submission")
+
+	callerID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	draft, err := s.getOwnDraft(ctx, id, callerID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authorize(ctx, constants.ActionDelete, draft.TenantID, draft.BusinessUnitID, draft.DepartmentID, draft.SubmittedBy); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteFormSubmissionDraft(ctx, draft.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to delete form submission from repository")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteFormSubmission, err)
+	}
+	if deleted == 0 {
+		return constants.ErrFormSubmissionNotEditable
+	}
+
+	return nil
+}
+
+// validateAnswers checks answers against the fields of the template version
+// and returns them as stored. Violations come back as a
+// *utils.SchemaValidationError with paths into the request body.
+func (s *formSubmissionService) validateAnswers(ctx context.Context, templateID pgtype.UUID, answers []byte, draft bool) ([]byte, error) {
+	fields, err := s.repo.GetFormFields(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("templateID", templateID.String()).Msg("Failed to get form fields from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormFields, err)
+	}
+
+	stored, violations := validateFormAnswers(fields, answers, draft)
+	if len(violations) > 0 {
+		return nil, utils.NewSchemaValidationError(constants.ErrInvalidFormSubmissionAnswers, "/answers", violations)
+	}
+	return stored, nil
+}
+
+func (s *formSubmissionService) authorize(ctx context.Context, action string, tenantID, businessUnitID, departmentID, ownerID pgtype.UUID) error {
+	target := &dtos.AuthorizationTarget{OwnerID: ownerID.String(), TenantID: tenantID.String()}
+	if businessUnitID.Valid {
+		target.BusinessUnitID = businessUnitID.String()
+	}
+	if departmentID.Valid {
+		target.DepartmentID = departmentID.String()
+	}
+
+	allowed, err := s.authorization.Authorize(ctx, constants.ResourceFormSubmissions, action, target)
+	if err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCheckPermissionsMsg, err)
+	}
+	if !allowed {
+		return constants.ErrFormSubmissionForbidden
+	}
+	return nil
+}
+
+func (s *formSubmissionService) getTemplate(ctx context.Context, id pgtype.UUID) (repository.FormTemplate, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	template, err := s.repo.GetFormTemplateByID(ctx, repository.GetFormTemplateByIDParams{ID: id, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.FormTemplate{}, constants.ErrFormFieldTemplateNotFound
+		}
+		log.Error().Err(err).Str("templateID", id.String()).Msg("Failed to get form template from repository")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplate, err)
+	}
+	return template, nil
+}
+
+// getSubmission loads a submission visible to callerID: submitted, or a draft
+// of their own.
+func (s *formSubmissionService) getSubmission(ctx context.Context, id string, callerID pgtype.UUID) (repository.FormSubmission, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Invalid UUID format")
+		return repository.FormSubmission{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.FormSubmission{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	submission, err := s.repo.GetFormSubmissionByID(ctx, repository.GetFormSubmissionByIDParams{
+		ID:       pgtype.UUID{Bytes: uuid, Valid: true},
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.FormSubmission{}, constants.ErrFormSubmissionNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get form submission from repository")
+		return repository.FormSubmission{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormSubmission, err)
+	}
+	if submission.State == repository.FormSubmissionStateDraft && submission.SubmittedBy != callerID {
+		return repository.FormSubmission{}, constants.ErrFormSubmissionNotFound
+	}
+	return submission, nil
+}
+
+func (s *formSubmissionService) getOwnDraft(ctx context.Context, id string, callerID pgtype.UUID) (repository.FormSubmission, error) {
+	submission, err := s.getSubmission(ctx, id, callerID)
+	if err != nil {
+		return repository.FormSubmission{}, err
+	}
+	if submission.State != repository.FormSubmissionStateDraft || submission.SubmittedBy != callerID {
+		return repository.FormSubmission{}, constants.ErrFormSubmissionNotEditable
+	}
+	return submission, nil
+}
+
+// getCurrentUserID returns the internal ID of the authenticated caller.
+func (s *formSubmissionService) getCurrentUserID(ctx context.Context) (pgtype.UUID, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
+
+func formSubmissionState(draft bool) repository.FormSubmissionState {
+	if draft {
+		return repository.FormSubmissionStateDraft
+	}
+	return repository.FormSubmissionStateSubmitted
+}
+
+func parseFormSubmissionPage(pageValue, sizeValue string) (int, int, error) {
+	page, size := 1, defaultFormSubmissionPageSize
+	var err error
+	if pageValue != "" {
+		if page, err = strconv.Atoi(pageValue); err != nil || page < 1 {
+			return 0, 0, constants.ErrInvalidFormSubmissionPage
+		}
+	}
+	if sizeValue != "" {
+		if size, err = strconv.Atoi(sizeValue); err != nil || size < 1 || size > maxFormSubmissionPageSize {
+			return 0, 0, constants.ErrInvalidFormSubmissionPage
+		}
+	}
+	if (page-1)*size > math.MaxInt32 {
+		return 0, 0, constants.ErrInvalidFormSubmissionPage
+	}
+	return page, size, nil
+}
+
+// parseFormSubmissionDate parses a from or to filter, a date or an RFC 3339
+// timestamp. A date as the upper bound includes the whole day.
+func parseFormSubmissionDate(value string, upper bool) (pgtype.Timestamptz, error) {
+	if value == "" {
+		return pgtype.Timestamptz{}, nil
+	}
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		if upper {
+			date = date.AddDate(0, 0, 1)
+		}
+		return pgtype.Timestamptz{Time: date, Valid: true}, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return pgtype.Timestamptz{}, constants.ErrInvalidFormSubmissionFilter
+	}
+	return pgtype.Timestamptz{Time: timestamp, Valid: true}, nil
+}
@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
 	"yet-another-itsm/internal/dtos"
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/utils"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog/log"
 )
@@ -19,17 +24,31 @@ type FormTemplateService interface {
 	GetFormTemplatesByCategory(ctx context.Context, categoryID string) ([]*dtos.FormTemplate, error)
 	CreateFormTemplate(ctx context.Context, req *dtos.CreateFormTemplateRequest) (*dtos.FormTemplate, error)
 	UpdateFormTemplate(ctx context.Context, id string, req *dtos.UpdateFormTemplateRequest) (*dtos.FormTemplate, error)
-	// PublishFormTemplate(ctx context.Context, id string, req *dtos.PublishFormTemplateRequest) (*dtos.FormTemplate, error)
 	DeleteFormTemplate(ctx context.Context, id string) error
+	GetFormTemplateVersions(ctx context.Context, id string) ([]*dtos.FormTemplate, error)
+	GetFormTemplateVersion(ctx context.Context, id, version string) (*dtos.FormTemplate, error)
+	CreateFormTemplateVersion(ctx context.Context, id string) (*dtos.FormTemplate, error)
+	SubmitFormTemplateForReview(ctx context.Context, id string) (*dtos.FormTemplate, error)
+	RejectFormTemplate(ctx context.Context, id string) (*dtos.FormTemplate, error)
+	PublishFormTemplate(ctx context.Context, id string) (*dtos.FormTemplate, error)
+	RetireFormTemplate(ctx context.Context, id string) (*dtos.FormTemplate, error)
+	GetFormDefinition(ctx context.Context, id string) (*dtos.FormDefinitionResponse, error)
 }
 
+// formTemplateVersionLatest addresses the published version of a form.
+const formTemplateVersionLatest = "latest"
+
 type formTemplateService struct {
-	repo *repository.Queries
+	db            *database.Database
+	repo          *repository.Queries
+	authorization AuthorizationService
 }
 
-func NewFormTemplateService(repo *repository.Queries) FormTemplateService {
+func NewFormTemplateService(db *database.Database, repo *repository.Queries, authorization AuthorizationService) FormTemplateService {
 	return &formTemplateService{
-		repo: repo,
+		db:            db,
+		repo:          repo,
+		authorization: authorization,
 	}
 }
 
@@ -39,7 +58,43 @@ func (s *formTemplateService) GetFormTemplates(ctx context.Context) ([]*dtos.For
 		Str("method", "GetFormTemplates").
 		Msg("Getting all form templates")
 
-	templates, err := s.repo.GetFormTemplates(ctx)
+	filter, err := s.authorization.GetScopeFilter(ctx, constants.ResourceFormTemplates, constants.ActionRead)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to resolve form template scope filter")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplates, err)
+	}
+	if filter.IsEmpty() {
+		return []*dtos.FormTemplate{}, nil
+	}
+
+	businessUnitIDs, err := scopeFilterUUIDs(filter.BusinessUnitIDs)
+	if err != nil {
+		return nil, err
+	}
+	departmentIDs, err := scopeFilterUUIDs(filter.DepartmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenantID pgtype.UUID
+	if err := tenantID.Scan(filter.TenantID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	var ownerID pgtype.UUID
+	if filter.OwnerID != "" {
+		if err := ownerID.Scan(filter.OwnerID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+	}
+
+	templates, err := s.repo.GetFormTemplates(ctx, repository.GetFormTemplatesParams{
+		TenantID:        tenantID,
+		AllAccess:       filter.AllAccess,
+		BusinessUnitIds: businessUnitIDs,
+		DepartmentIds:   departmentIDs,
+		OwnerID:         ownerID,
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get form templates from repository")
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplates, err)
@@ -48,7 +103,7 @@ func (s *formTemplateService) GetFormTemplates(ctx context.Context) ([]*dtos.For
 	result := make([]*dtos.FormTemplate, len(templates))
 	for i, template := range templates {
 		result[i] = &dtos.FormTemplate{}
-		result[i].FromRepositoryModel(template)
+		*result[i] = result[i].FromRepositoryModel(template)
 	}
 
 	return result, nil
@@ -67,14 +122,22 @@ func (s *formTemplateService) GetFormTemplateByID(ctx context.Context, id string
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 	}
 
-	template, err := s.repo.GetFormTemplateByID(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	template, err := s.repo.GetFormTemplateByID(ctx, repository.GetFormTemplateByIDParams{
+		ID:       pgtype.UUID{Bytes: uuid, Valid: true},
+		TenantID: tenantID,
+	})
 	if err != nil {
 		log.Error().Err(err).Str("id", id).Msg("Failed to get form template from repository")
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplate, err)
 	}
 
 	result := &dtos.FormTemplate{}
-	result.FromRepositoryModel(template)
+	*result = result.FromRepositoryModel(template)
 	return result, nil
 }
 
@@ -91,7 +154,44 @@ func (s *formTemplateService) GetFormTemplatesByCategory(ctx context.Context, ca
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 	}
 
-	templates, err := s.repo.GetFormTemplatesByCategory(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	filter, err := s.authorization.GetScopeFilter(ctx, constants.ResourceFormTemplates, constants.ActionRead)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to resolve form template scope filter")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplates, err)
+	}
+	if filter.IsEmpty() {
+		return []*dtos.FormTemplate{}, nil
+	}
+
+	businessUnitIDs, err := scopeFilterUUIDs(filter.BusinessUnitIDs)
+	if err != nil {
+		return nil, err
+	}
+	departmentIDs, err := scopeFilterUUIDs(filter.DepartmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenantID pgtype.UUID
+	if err := tenantID.Scan(filter.TenantID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	var ownerID pgtype.UUID
+	if filter.OwnerID != "" {
+		if err := ownerID.Scan(filter.OwnerID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+	}
+
+	templates, err := s.repo.GetFormTemplatesByCategory(ctx, repository.GetFormTemplatesByCategoryParams{
+		FormCategoryID:  pgtype.UUID{Bytes: uuid, Valid: true},
+		TenantID:        tenantID,
+		AllAccess:       filter.AllAccess,
+		BusinessUnitIds: businessUnitIDs,
+		DepartmentIds:   departmentIDs,
+		OwnerID:         ownerID,
+	})
 	if err != nil {
 		log.Error().Err(err).Str("categoryID", categoryID).Msg("Failed to get form templates by category from repository")
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplates, err)
@@ -100,7 +200,7 @@ func (s *formTemplateService) GetFormTemplatesByCategory(ctx context.Context, ca
 	result := make([]*dtos.FormTemplate, len(templates))
 	for i, template := range templates {
 		result[i] = &dtos.FormTemplate{}
-		result[i].FromRepositoryModel(template)
+		*result[i] = result[i].FromRepositoryModel(template)
 	}
 
 	return result, nil
@@ -113,7 +213,7 @@ func (s *formTemplateService) CreateFormTemplate(ctx context.Context, req *dtos.
 		Str("name", req.Name).
 		Msg("Creating form template")
 
-	userID, err := utils.GetUserID(ctx)
+	userID, err := utils.GetInternalUserID(ctx)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get user ID from context")
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
@@ -137,12 +237,25 @@ func (s *formTemplateService) CreateFormTemplate(ctx context.Context, req *dtos.
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 	}
 
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
 	params := repository.CreateFormTemplateParams{
 		Name:           req.Name,
 		Description:    pgtype.Text{String: req.Description, Valid: req.Description != ""},
 		FormCategoryID: pgtype.UUID{Bytes: categoryUUID, Valid: true},
 		BusinessUnitID: pgtype.UUID{Bytes: businessUnitUUID, Valid: true},
 		CreatedBy:      pgtype.UUID{Bytes: userUUID, Valid: true},
+		TenantID:       tenantID,
+	}
+
+	if req.DepartmentID != "" {
+		if err := params.DepartmentID.Scan(req.DepartmentID); err != nil {
+			log.Error().Err(err).Str("departmentID", req.DepartmentID).Msg("Invalid department UUID format")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidDepartmentUUIDFormat, err)
+		}
 	}
 
 	template, err := s.repo.CreateFormTemplate(ctx, params)
@@ -152,10 +265,13 @@ func (s *formTemplateService) CreateFormTemplate(ctx context.Context, req *dtos.
 	}
 
 	result := &dtos.FormTemplate{}
-	result.FromRepositoryModel(template)
+	*result = result.FromRepositoryModel(template)
 	return result, nil
 }
 
+// UpdateFormTemplate changes a draft in place. A published or retired version
+// is frozen, so the changes go into the next draft version of the form, which
+// is returned instead.
 func (s *formTemplateService) UpdateFormTemplate(ctx context.Context, id string, req *dtos.UpdateFormTemplateRequest) (*dtos.FormTemplate, error) {
 	log.Info().
 		Str("service", "FormTemplateService").
@@ -163,76 +279,348 @@ func (s *formTemplateService) UpdateFormTemplate(ctx context.Context, id string,
 		Str("id", id).
 		Msg("Updating form template")
 
-	uuid, err := utils.ParseUUID(id)
+	current, err := s.getTemplate(ctx, id)
 	if err != nil {
-		log.Error().Err(err).Str("id", id).Msg("Invalid UUID format")
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		return nil, err
+	}
+
+	// Fields left empty are not sent, so the update keeps their stored values.
+	params := repository.UpdateFormTemplateParams{
+		ID:          current.ID,
+		Name:        req.Name,
+		Description: pgtype.Text{String: req.Description, Valid: req.Description != ""},
 	}
 
-	categoryUUID, err := utils.ParseUUID(req.FormCategoryID)
 	if req.FormCategoryID != "" {
-		if err != nil {
+		if err := params.FormCategoryID.Scan(req.FormCategoryID); err != nil {
 			log.Error().Err(err).Str("categoryID", req.FormCategoryID).Msg("Invalid category UUID format")
 			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 		}
 	}
 
-	businessUnitUUID, err := utils.ParseUUID(req.BusinessUnitID)
 	if req.BusinessUnitID != "" {
-		if err != nil {
+		if err := params.BusinessUnitID.Scan(req.BusinessUnitID); err != nil {
 			log.Error().Err(err).Str("businessUnitID", req.BusinessUnitID).Msg("Invalid business unit UUID format")
 			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 		}
 	}
 
-	params := repository.UpdateFormTemplateParams{
-		ID:             pgtype.UUID{Bytes: uuid, Valid: true},
-		Name:           req.Name,
-		Description:    pgtype.Text{String: req.Description, Valid: req.Description != ""},
-		FormCategoryID: pgtype.UUID{Bytes: categoryUUID, Valid: true},
-		BusinessUnitID: pgtype.UUID{Bytes: businessUnitUUID, Valid: true},
+	if req.DepartmentID != "" {
+		if err := params.DepartmentID.Scan(req.DepartmentID); err != nil {
+			log.Error().Err(err).Str("departmentID", req.DepartmentID).Msg("Invalid department UUID format")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidDepartmentUUIDFormat, err)
+		}
 	}
 
-	template, err := s.repo.UpdateFormTemplate(ctx, params)
+	var template repository.FormTemplate
+	switch current.State {
+	case repository.FormTemplateStateDraft:
+		template, err = s.repo.UpdateFormTemplate(ctx, params)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrFormTemplateNotEditable
+		}
+	case repository.FormTemplateStateInReview:
+		return nil, constants.ErrFormTemplateNotEditable
+	default:
+		template, err = s.updateAsNewVersion(ctx, current, params)
+	}
 	if err != nil {
+		if isFormTemplateLifecycleError(err) {
+			return nil, err
+		}
 		log.Error().Err(err).Str("id", id).Msg("Failed to update form template in repository")
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateFormTemplate, err)
 	}
 
 	result := &dtos.FormTemplate{}
-	result.FromRepositoryModel(template)
+	*result = result.FromRepositoryModel(template)
 	return result, nil
 }
 
-// func (s *formTemplateService) PublishFormTemplate(ctx context.Context, id string, req *dtos.PublishFormTemplateRequest) (*dtos.FormTemplate, error) {
-// 	log.Info().
-// 		Str("service", "FormTemplateService").
-// 		Str("method", "PublishFormTemplate").
-// 		Str("id", id).
-// 		Msg("Publishing form template")
-
-// 	uuid, err := utils.ParseUUID(id)
-// 	if err != nil {
-// 		log.Error().Err(err).Str("id", id).Msg("Invalid UUID format")
-// 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
-// 	}
-
-// 	params := repository.PublishFormTemplateParams{
-// 		ID:      pgtype.UUID{Bytes: uuid, Valid: true},
-// 		Version: pgtype.Text{String: req.Version, Valid: req.Version != ""},
-// 	}
-
-// 	template, err := s.repo.PublishFormTemplate(ctx, params)
-// 	if err != nil {
-// 		log.Error().Err(err).Str("id", id).Msg("Failed to publish form template in repository")
-// 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToPublishFormTemplate, err)
-// 	}
-
-// 	result := &dtos.FormTemplate{}
-// 	result.FromRepositoryModel(template)
-// 	return result, nil
-// }
+// updateAsNewVersion starts the next draft version of a frozen template and
+// applies params to it.
+func (s *formTemplateService) updateAsNewVersion(ctx context.Context, source repository.FormTemplate, params repository.UpdateFormTemplateParams) (repository.FormTemplate, error) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return repository.FormTemplate{}, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
 
+	qtx := s.repo.WithTx(tx)
+	draft, err := createFormTemplateVersion(ctx, qtx, source, userID)
+	if err != nil {
+		return repository.FormTemplate{}, err
+	}
+
+	params.ID = draft.ID
+	template, err := qtx.UpdateFormTemplate(ctx, params)
+	if err != nil {
+		return repository.FormTemplate{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	return template, nil
+}
+
+// GetFormTemplateVersions lists every version of the form the template belongs
+// to, newest first.
+func (s *formTemplateService) GetFormTemplateVersions(ctx context.Context, id string) ([]*dtos.FormTemplate, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "GetFormTemplateVersions").
+		Str("id", id).
+		Msg("Getting form template versions")
+
+	current, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.repo.GetFormTemplateVersions(ctx, current.LineageID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get form template versions from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTemplateVersions, err)
+	}
+
+	result := make([]*dtos.FormTemplate, len(templates))
+	for i, template := range templates {
+		result[i] = &dtos.FormTemplate{}
+		*result[i] = result[i].FromRepositoryModel(template)
+	}
+
+	return result, nil
+}
+
+// GetFormTemplateVersion gets one version of the form the template belongs to;
+// version "latest" is the published one.
+func (s *formTemplateService) GetFormTemplateVersion(ctx context.Context, id, version string) (*dtos.FormTemplate, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "GetFormTemplateVersion").
+		Str("id", id).
+		Str("version", version).
+		Msg("Getting form template version")
+
+	current, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.resolveVersion(ctx, current, version)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.FormTemplate{}
+	*result = result.FromRepositoryModel(template)
+	return result, nil
+}
+
+// CreateFormTemplateVersion starts the next draft version of the form from the
+// template, copying its sections and fields.
+func (s *formTemplateService) CreateFormTemplateVersion(ctx context.Context, id string) (*dtos.FormTemplate, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "CreateFormTemplateVersion").
+		Str("id", id).
+		Msg("Creating form template version")
+
+	source, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	template, err := createFormTemplateVersion(ctx, s.repo.WithTx(tx), source, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	result := &dtos.FormTemplate{}
+	*result = result.FromRepositoryModel(template)
+	return result, nil
+}
+
+// SubmitFormTemplateForReview freezes a draft that has at least one field for
+// review.
+func (s *formTemplateService) SubmitFormTemplateForReview(ctx context.Context, id string) (*dtos.FormTemplate, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "SubmitFormTemplateForReview").
+		Str("id", id).
+		Msg("Submitting form template for review")
+
+	current, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.State != repository.FormTemplateStateDraft {
+		return nil, formTemplateTransitionError(current, "submit for review")
+	}
+
+	fields, err := s.repo.GetFormFields(ctx, current.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get form fields from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSubmitFormTemplate, err)
+	}
+	if len(fields) == 0 {
+		return nil, constants.ErrFormTemplateHasNoFields
+	}
+
+	template, err := s.repo.SubmitFormTemplateForReview(ctx, current.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, formTemplateTransitionError(current, "submit for review")
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to submit form template for review in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSubmitFormTemplate, err)
+	}
+
+	result := &dtos.FormTemplate{}
+	*result = result.FromRepositoryModel(template)
+	return result, nil
+}
+
+// RejectFormTemplate sends a template in review back to draft.
+func (s *formTemplateService) RejectFormTemplate(ctx context.Context, id string) (*dtos.FormTemplate, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "RejectFormTemplate").
+		Str("id", id).
+		Msg("Rejecting form template")
+
+	current, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.repo.RejectFormTemplate(ctx, current.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, formTemplateTransitionError(current, "reject")
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to reject form template in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRejectFormTemplate, err)
+	}
+
+	result := &dtos.FormTemplate{}
+	*result = result.FromRepositoryModel(template)
+	return result, nil
+}
+
+// PublishFormTemplate approves a template in review on behalf of the caller.
+// It becomes the published version of the form and the previously published
+// version is retired.
+func (s *formTemplateService) PublishFormTemplate(ctx context.Context, id string) (*dtos.FormTemplate, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "PublishFormTemplate").
+		Str("id", id).
+		Msg("Publishing form template")
+
+	current, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.State != repository.FormTemplateStateInReview {
+		return nil, formTemplateTransitionError(current, "publish")
+	}
+
+	approverID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	if err := qtx.RetirePublishedFormTemplates(ctx, current.LineageID); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to retire published form template versions")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToPublishFormTemplate, err)
+	}
+
+	template, err := qtx.PublishFormTemplate(ctx, repository.PublishFormTemplateParams{
+		ID:         current.ID,
+		ApprovedBy: approverID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, formTemplateTransitionError(current, "publish")
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to publish form template in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToPublishFormTemplate, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	result := &dtos.FormTemplate{}
+	*result = result.FromRepositoryModel(template)
+	return result, nil
+}
+
+// RetireFormTemplate withdraws the published version without a successor.
+func (s *formTemplateService) RetireFormTemplate(ctx context.Context, id string) (*dtos.FormTemplate, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "RetireFormTemplate").
+		Str("id", id).
+		Msg("Retiring form template")
+
+	current, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.repo.RetireFormTemplate(ctx, current.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, formTemplateTransitionError(current, "retire")
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to retire form template in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRetireFormTemplate, err)
+	}
+
+	result := &dtos.FormTemplate{}
+	*result = result.FromRepositoryModel(template)
+	return result, nil
+}
+
+// DeleteFormTemplate deletes a draft or a template in review; published and
+// retired versions are kept.
 func (s *formTemplateService) DeleteFormTemplate(ctx context.Context, id string) error {
 	log.Info().
 		Str("service", "FormTemplateService").
@@ -240,17 +628,185 @@ func (s *formTemplateService) DeleteFormTemplate(ctx context.Context, id string)
 		Str("id", id).
 		Msg("Deleting form template")
 
-	uuid, err := utils.ParseUUID(id)
+	template, err := s.getTemplate(ctx, id)
 	if err != nil {
-		log.Error().Err(err).Str("id", id).Msg("Invalid UUID format")
-		return fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		return err
 	}
 
-	err = s.repo.DeleteFormTemplate(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	deleted, err := s.repo.DeleteFormTemplate(ctx, repository.DeleteFormTemplateParams{
+		ID:       template.ID,
+		TenantID: template.TenantID,
+	})
 	if err != nil {
 		log.Error().Err(err).Str("id", id).Msg("Failed to delete form template from repository")
 		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteFormTemplate, err)
 	}
+	if deleted == 0 {
+		return constants.ErrFormTemplateNotDeletable
+	}
 
 	return nil
 }
+
+// GetFormDefinition assembles the template with its sections, fields and
+// field types. Callers pick another version of the form with
+// GetFormTemplateVersion first, so they can authorize it.
+func (s *formTemplateService) GetFormDefinition(ctx context.Context, id string) (*dtos.FormDefinitionResponse, error) {
+	log.Info().
+		Str("service", "FormTemplateService").
+		Str("method", "GetFormDefinition").
+		Str("id", id).
+		Msg("Getting form definition")
+
+	template, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := s.repo.GetFormSections(ctx, template.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get form sections from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormDefinition, err)
+	}
+
+	fields, err := s.repo.GetFormFields(ctx, template.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get form fields from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormDefinition, err)
+	}
+
+	typeNames := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !seen[field.FieldType] {
+			seen[field.FieldType] = true
+			typeNames = append(typeNames, field.FieldType)
+		}
+	}
+
+	fieldTypes, err := s.repo.GetFieldTypesByNames(ctx, typeNames)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get field types from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormDefinition, err)
+	}
+
+	return dtos.NewFormDefinitionResponse(template, sections, fields, fieldTypes), nil
+}
+
+// resolveVersion finds version of the form current belongs to; "latest" is
+// the published version.
+func (s *formTemplateService) resolveVersion(ctx context.Context, current repository.FormTemplate, version string) (repository.FormTemplate, error) {
+	var template repository.FormTemplate
+	var err error
+	if version == formTemplateVersionLatest {
+		template, err = s.repo.GetPublishedFormTemplate(ctx, current.LineageID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.FormTemplate{}, constants.ErrFormTemplateNotPublished
+		}
+	} else {
+		number, parseErr := strconv.ParseInt(version, 10, 32)
+		if parseErr != nil || number < 1 {
+			return repository.FormTemplate{}, constants.ErrInvalidFormTemplateVersion
+		}
+		template, err = s.repo.GetFormTemplateVersion(ctx, repository.GetFormTemplateVersionParams{
+			LineageID: current.LineageID,
+			Version:   int32(number),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.FormTemplate{}, constants.ErrFormTemplateVersionNotFound
+		}
+	}
+	if err != nil {
+		log.Error().Err(err).Str("lineageID", current.LineageID.String()).Str("version", version).Msg("Failed to get form template version from repository")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplate, err)
+	}
+	return template, nil
+}
+
+func (s *formTemplateService) getTemplate(ctx context.Context, id string) (repository.FormTemplate, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Invalid UUID format")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	template, err := s.repo.GetFormTemplateByID(ctx, repository.GetFormTemplateByIDParams{
+		ID:       pgtype.UUID{Bytes: uuid, Valid: true},
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.FormTemplate{}, constants.ErrFormTemplateMissing
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get form template from repository")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetFormTemplate, err)
+	}
+	return template, nil
+}
+
+// getCurrentUserID returns the internal ID of the authenticated caller.
+func (s *formTemplateService) getCurrentUserID(ctx context.Context) (pgtype.UUID, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
+
+// createFormTemplateVersion starts the next draft version of source with
+// copies of its sections and fields, on the caller's transaction.
+func createFormTemplateVersion(ctx context.Context, qtx *repository.Queries, source repository.FormTemplate, createdBy pgtype.UUID) (repository.FormTemplate, error) {
+	template, err := qtx.CreateFormTemplateVersion(ctx, repository.CreateFormTemplateVersionParams{
+		CreatedBy: createdBy,
+		SourceID:  source.ID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return repository.FormTemplate{}, constants.ErrFormTemplateDraftExists
+		}
+		log.Error().Err(err).Str("sourceID", source.ID.String()).Msg("Failed to create form template version in repository")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateFormVersion, err)
+	}
+
+	copyParams := repository.CopyFormSectionsParams{TargetTemplateID: template.ID, SourceTemplateID: source.ID}
+	if err := qtx.CopyFormSections(ctx, copyParams); err != nil {
+		log.Error().Err(err).Str("sourceID", source.ID.String()).Msg("Failed to copy form sections")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateFormVersion, err)
+	}
+	if err := qtx.CopyFormFields(ctx, repository.CopyFormFieldsParams(copyParams)); err != nil {
+		log.Error().Err(err).Str("sourceID", source.ID.String()).Msg("Failed to copy form fields")
+		return repository.FormTemplate{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateFormVersion, err)
+	}
+
+	return template, nil
+}
+
+// requireDraftFormTemplate refuses changes to the sections and fields of a
+// template that is no longer a draft.
+func requireDraftFormTemplate(template repository.FormTemplate) error {
+	if template.State != repository.FormTemplateStateDraft {
+		return fmt.Errorf("%w: version %d is %s", constants.ErrFormTemplateNotEditable, template.Version, template.State)
+	}
+	return nil
+}
+
+func formTemplateTransitionError(template repository.FormTemplate, transition string) error {
+	return fmt.Errorf("%w: cannot %s a template that is %s", constants.ErrFormTemplateInvalidTransition, transition, template.State)
+}
+
+func isFormTemplateLifecycleError(err error) bool {
+	return errors.Is(err, constants.ErrFormTemplateNotEditable) || errors.Is(err, constants.ErrFormTemplateDraftExists) ||
+		errors.Is(err, constants.ErrFormTemplateInvalidTransition)
+}
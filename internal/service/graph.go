@@ -8,7 +8,9 @@ import (
 	"yet-another-itsm/internal/utils"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/golang-jwt/jwt/v4"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/rs/zerolog/log"
 )
@@ -47,3 +49,34 @@ func (g *GraphService) GetGraphTokenOnBehalfOf(tokenStr string) (*msgraphsdk.Gra
 
 	return client, nil
 }
+
+// currentUserFromClaims builds the Graph profile of the caller from the claims
+// of an access token, for identity providers without Graph. The token has
+// already been verified by AuthMiddleWare.
+func currentUserFromClaims(tokenStr string) (models.Userable, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenStr, claims); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetCurrentUser, err)
+	}
+
+	claim := func(names ...string) *string {
+		for _, name := range names {
+			if value, ok := claims[name].(string); ok && value != "" {
+				return &value
+			}
+		}
+		return nil
+	}
+
+	user := models.NewUser()
+	user.SetId(claim("oid", "sub"))
+	user.SetDisplayName(claim("name", "preferred_username"))
+	user.SetMail(claim("email", "upn", "preferred_username"))
+	user.SetGivenName(claim("given_name"))
+	user.SetSurname(claim("family_name"))
+	user.SetJobTitle(claim("job_title"))
+	user.SetDepartment(claim("department"))
+	user.SetOfficeLocation(claim("office_location"))
+
+	return user, nil
+}
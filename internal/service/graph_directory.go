@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	msgraphsdkUser "github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// graphAppScope requests the application permissions granted to the app
+// registration; the sync needs User.Read.All.
+const graphAppScope = "https://graph.microsoft.com/.default"
+
+// directoryUserProperties are selected in the users delta query. Selecting
+// manager returns manager changes as manager@delta.
+var directoryUserProperties = []string{
+	"id", "accountEnabled", "mail", "userPrincipalName", "displayName", "givenName",
+	"surname", "jobTitle", "department", "officeLocation", "manager",
+}
+
+// graphDirectoryClient reads users through Graph delta queries with the app
+// registration's own client credentials.
+type graphDirectoryClient struct {
+	client *msgraphsdk.GraphServiceClient
+}
+
+// NewGraphDirectoryClient creates an app-only Graph client for the home tenant.
+func NewGraphDirectoryClient(cfg *config.OAuthConfig) (DirectoryClient, error) {
+	cred, err := azidentity.NewClientSecretCredential(cfg.TenantID, cfg.ClientID, cfg.ClientSecret, nil)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrCouldNotCreateAppOnlyCredential, err)
+	}
+
+	client, err := msgraphsdk.NewGraphServiceClientWithCredentials(cred, []string{graphAppScope})
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrCouldNotCreateGraphClient, err)
+	}
+
+	return &graphDirectoryClient{client: client}, nil
+}
+
+func (g *graphDirectoryClient) UserDelta(ctx context.Context, link string) (*DirectoryDeltaPage, error) {
+	builder := g.client.Users().Delta()
+	var requestConfig *msgraphsdkUser.DeltaRequestBuilderGetRequestConfiguration
+	if link == "" {
+		requestConfig = &msgraphsdkUser.DeltaRequestBuilderGetRequestConfiguration{
+			QueryParameters: &msgraphsdkUser.DeltaRequestBuilderGetQueryParameters{
+				Select: directoryUserProperties,
+			},
+		}
+	} else {
+		// Next and delta links already carry the query.
+		builder = builder.WithUrl(link)
+	}
+
+	response, err := builder.GetAsDeltaGetResponse(ctx, requestConfig)
+	if err != nil {
+		// Graph answers 410 Gone once a delta link has expired.
+		var apiErr interface{ GetStatusCode() int }
+		if errors.As(err, &apiErr) && apiErr.GetStatusCode() == http.StatusGone {
+			return nil, constants.ErrDirectoryDeltaExpired
+		}
+		return nil, err
+	}
+
+	page := &DirectoryDeltaPage{
+		NextLink:  utils.GetStringValue(response.GetOdataNextLink()),
+		DeltaLink: utils.GetStringValue(response.GetOdataDeltaLink()),
+	}
+	for _, user := range response.GetValue() {
+		if directoryUser, ok := toDirectoryUser(user); ok {
+			page.Users = append(page.Users, directoryUser)
+		}
+	}
+
+	return page, nil
+}
+
+func toDirectoryUser(user models.Userable) (DirectoryUser, bool) {
+	objectID := utils.GetStringValue(user.GetId())
+	if objectID == "" {
+		return DirectoryUser{}, false
+	}
+
+	additionalData := user.GetAdditionalData()
+	if _, removed := additionalData["@removed"]; removed {
+		return DirectoryUser{ObjectID: objectID, Removed: true}, true
+	}
+
+	directoryUser := DirectoryUser{
+		ObjectID:          objectID,
+		AccountEnabled:    user.GetAccountEnabled(),
+		Mail:              user.GetMail(),
+		UserPrincipalName: user.GetUserPrincipalName(),
+		DisplayName:       user.GetDisplayName(),
+		GivenName:         user.GetGivenName(),
+		Surname:           user.GetSurname(),
+		JobTitle:          user.GetJobTitle(),
+		Department:        user.GetDepartment(),
+		OfficeLocation:    user.GetOfficeLocation(),
+	}
+
+	if manager := user.GetManager(); manager != nil {
+		directoryUser.ManagerObjectID = manager.GetId()
+	} else if managerDelta, ok := additionalData["manager@delta"]; ok {
+		directoryUser.ManagerObjectID = managerObjectIDFromDelta(managerDelta)
+	}
+
+	return directoryUser, true
+}
+
+// managerObjectIDFromDelta reads a manager@delta annotation: a one-element list
+// holding the new manager's id, or an entry marked @removed when the manager
+// was removed.
+func managerObjectIDFromDelta(value any) *string {
+	entries, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+
+	removed := ""
+	for _, entry := range entries {
+		fields, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, isRemoved := fields["@removed"]; isRemoved {
+			continue
+		}
+		switch id := fields["id"].(type) {
+		case *string:
+			return id
+		case string:
+			return &id
+		}
+	}
+	return &removed
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Depth limits of the hierarchy queries.
+const (
+	defaultOrgChartDepth = 3
+	maxOrgChartDepth     = 10
+	// maxManagementChainDepth bounds the walk up the chain; real chains are far
+	// shorter.
+	maxManagementChainDepth = 50
+)
+
+// OrgChartService reads the management hierarchy formed by users.manager_id.
+// Approval routing and escalation resolve "manager of requester" through
+// GetManager and GetManagementChain.
+type OrgChartService interface {
+	GetManager(ctx context.Context, userID string) (*dtos.User, error)
+	GetManagementChain(ctx context.Context, userID string) ([]*dtos.OrgChartUser, error)
+	GetDirectReports(ctx context.Context, userID string) ([]*dtos.OrgChartUser, error)
+	GetReports(ctx context.Context, userID, depth string) ([]*dtos.OrgChartUser, error)
+	GetOrgChart(ctx context.Context, filter dtos.OrgChartFilter) (*dtos.OrgChartResponse, error)
+}
+
+type orgChartService struct {
+	repo *repository.Queries
+}
+
+func NewOrgChartService(repo *repository.Queries) OrgChartService {
+	return &orgChartService{repo: repo}
+}
+
+// GetManager returns the direct manager of a user of the caller's tenant, or
+// ErrUserHasNoManager.
+func (s *orgChartService) GetManager(ctx context.Context, userID string) (*dtos.User, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.ManagerID.Valid {
+		return nil, constants.ErrUserHasNoManager
+	}
+
+	manager, err := s.repo.GetUserByID(ctx, repository.GetUserByIDParams{
+		ID:           user.ManagerID,
+		HomeTenantID: user.HomeTenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrUserHasNoManager
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+
+	return (&dtos.User{}).FromRepositoryModel(manager), nil
+}
+
+// GetManagementChain lists a user's managers from the direct manager (depth 1)
+// up to the top of the hierarchy.
+func (s *orgChartService) GetManagementChain(ctx context.Context, userID string) ([]*dtos.OrgChartUser, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetManagementChain(ctx, repository.GetManagementChainParams{
+		ID:           user.ID,
+		HomeTenantID: user.HomeTenantID,
+		MaxDepth:     maxManagementChainDepth,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to get management chain from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetManagementChain, err)
+	}
+
+	chain := make([]*dtos.OrgChartUser, len(rows))
+	for i, row := range rows {
+		chain[i] = dtos.NewOrgChartUser(repository.ListReportsRow(row))
+	}
+	return chain, nil
+}
+
+// GetDirectReports lists the users whose manager is userID.
+func (s *orgChartService) GetDirectReports(ctx context.Context, userID string) ([]*dtos.OrgChartUser, error) {
+	return s.listReports(ctx, userID, 1)
+}
+
+// GetReports lists the direct and transitive reports of a user down to depth
+// levels, ordered by level.
+func (s *orgChartService) GetReports(ctx context.Context, userID, depth string) ([]*dtos.OrgChartUser, error) {
+	maxDepth, err := parseOrgChartDepth(depth)
+	if err != nil {
+		return nil, err
+	}
+	return s.listReports(ctx, userID, maxDepth)
+}
+
+// GetOrgChart builds the reporting tree of a department and/or business unit.
+// Members whose manager is outside the selection are the roots.
+func (s *orgChartService) GetOrgChart(ctx context.Context, filter dtos.OrgChartFilter) (*dtos.OrgChartResponse, error) {
+	if filter.DepartmentID == "" && filter.BusinessUnitID == "" {
+		return nil, constants.ErrOrgChartScopeRequired
+	}
+
+	maxDepth, err := parseOrgChartDepth(filter.Depth)
+	if err != nil {
+		return nil, err
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	params := repository.ListOrgChartUsersParams{
+		HomeTenantID: homeTenantID,
+		MaxDepth:     maxDepth,
+	}
+	if filter.DepartmentID != "" {
+		if err := params.DepartmentID.Scan(filter.DepartmentID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidDepartmentUUIDFormat, err)
+		}
+	}
+	if filter.BusinessUnitID != "" {
+		if err := params.BusinessUnitID.Scan(filter.BusinessUnitID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+	}
+
+	rows, err := s.repo.ListOrgChartUsers(ctx, params)
+	if err != nil {
+		log.Error().Err(err).
+			Str("department_id", filter.DepartmentID).
+			Str("business_unit_id", filter.BusinessUnitID).
+			Msg("Failed to get org chart from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetOrgChart, err)
+	}
+
+	// Rows come ordered by depth, so every manager is placed before their reports.
+	roots := make([]*dtos.OrgChartUser, 0)
+	placed := make(map[string]*dtos.OrgChartUser, len(rows))
+	for _, row := range rows {
+		user := dtos.NewOrgChartUser(repository.ListReportsRow(row))
+		if manager, ok := placed[user.ManagerID]; ok && user.Depth > 1 {
+			manager.Reports = append(manager.Reports, user)
+		} else {
+			roots = append(roots, user)
+		}
+		placed[user.ID] = user
+	}
+
+	return &dtos.OrgChartResponse{
+		DepartmentID:   filter.DepartmentID,
+		BusinessUnitID: filter.BusinessUnitID,
+		Depth:          int(maxDepth),
+		Roots:          roots,
+	}, nil
+}
+
+func (s *orgChartService) listReports(ctx context.Context, userID string, maxDepth int32) ([]*dtos.OrgChartUser, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListReports(ctx, repository.ListReportsParams{
+		ManagerID:    user.ID,
+		HomeTenantID: user.HomeTenantID,
+		MaxDepth:     maxDepth,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to get reports from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetReports, err)
+	}
+
+	reports := make([]*dtos.OrgChartUser, len(rows))
+	for i, row := range rows {
+		reports[i] = dtos.NewOrgChartUser(row)
+	}
+	return reports, nil
+}
+
+// getUser loads a user of the caller's tenant.
+func (s *orgChartService) getUser(ctx context.Context, userID string) (repository.User, error) {
+	id, homeTenantID, err := parseUserInTenant(ctx, userID)
+	if err != nil {
+		return repository.User{}, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, repository.GetUserByIDParams{ID: id, HomeTenantID: homeTenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, constants.ErrUserNotFound
+		}
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+	return user, nil
+}
+
+// parseOrgChartDepth parses an optional depth query value.
+func parseOrgChartDepth(value string) (int32, error) {
+	if value == "" {
+		return defaultOrgChartDepth, nil
+	}
+
+	depth, err := strconv.Atoi(value)
+	if err != nil || depth < 1 || depth > maxOrgChartDepth {
+		return 0, constants.ErrInvalidOrgChartDepth
+	}
+	return int32(depth), nil
+}
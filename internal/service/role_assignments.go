@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
 	"yet-another-itsm/internal/dtos"
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/utils"
@@ -16,20 +20,29 @@ import (
 
 // RoleAssignmentService defines the interface for role assignment operations
 type RoleAssignmentService interface {
-	GetUserRoleAssignments(ctx context.Context, userID string) ([]*dtos.RoleAssignment, error)
+	GetUserRoleAssignments(ctx context.Context, userID string, page dtos.PageRequest) ([]*dtos.RoleAssignment, int64, error)
+	CheckUserPermission(ctx context.Context, userID, resource, action string) (bool, error)
+	GetRoleAssignmentByID(ctx context.Context, id string) (*dtos.RoleAssignment, error)
+	CreateRoleAssignment(ctx context.Context, req *dtos.CreateRoleAssignmentRequest) (*dtos.RoleAssignment, error)
+	BulkCreateRoleAssignments(ctx context.Context, req *dtos.BulkCreateRoleAssignmentRequest) ([]*dtos.RoleAssignment, error)
+	UpdateRoleAssignment(ctx context.Context, id string, req *dtos.UpdateRoleAssignmentRequest) (*dtos.RoleAssignment, error)
+	RevokeRoleAssignment(ctx context.Context, id string) (*dtos.RoleAssignment, error)
+	ExpireRoleAssignments(ctx context.Context) (int, error)
 }
 
 type roleAssignmentService struct {
+	db   *database.Database
 	repo *repository.Queries
 }
 
-func NewRoleAssignmentService(repo *repository.Queries) RoleAssignmentService {
+func NewRoleAssignmentService(db *database.Database, repo *repository.Queries) RoleAssignmentService {
 	return &roleAssignmentService{
+		db:   db,
 		repo: repo,
 	}
 }
 
-func (s *roleAssignmentService) GetUserRoleAssignments(ctx context.Context, userID string) ([]*dtos.RoleAssignment, error) {
+func (s *roleAssignmentService) GetUserRoleAssignments(ctx context.Context, userID string, page dtos.PageRequest) ([]*dtos.RoleAssignment, int64, error) {
 	log.Info().
 		Str("service", "RoleAssignmentService").
 		Str("method", "GetUserRoleAssignments").
@@ -40,13 +53,33 @@ func (s *roleAssignmentService) GetUserRoleAssignments(ctx context.Context, user
 
 	if err != nil {
 		log.Error().Err(err).Str("user_id", userID).Msg("Invalid user ID format")
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	assigneeID := pgtype.UUID{Bytes: uuid, Valid: true}
+	total, err := s.repo.CountUserRoleAssignments(ctx, repository.CountUserRoleAssignmentsParams{
+		AssigneeID:   assigneeID,
+		HomeTenantID: homeTenantID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to count role assignments in repository")
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignments, err)
 	}
 
-	roleAssignments, err := s.repo.GetUserRoleAssignments(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	roleAssignments, err := s.repo.GetUserRoleAssignments(ctx, repository.GetUserRoleAssignmentsParams{
+		AssigneeID:   assigneeID,
+		HomeTenantID: homeTenantID,
+		Limit:        page.Limit(),
+		Offset:       page.Offset(),
+	})
 	if err != nil {
 		log.Error().Err(err).Str("user_id", userID).Msg("Failed to get role assignments from repository")
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignments, err)
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignments, err)
 	}
 
 	result := make([]*dtos.RoleAssignment, len(roleAssignments))
@@ -80,6 +113,12 @@ func (s *roleAssignmentService) GetUserRoleAssignments(ctx context.Context, user
 		if ra.DeletedAt.Valid {
 			result[i].DeletedAt = utils.FormatTime(ra.DeletedAt.Time)
 		}
+		if ra.DirectoryMappingID.Valid {
+			result[i].DirectoryMappingID = ra.DirectoryMappingID.String()
+		}
+		if ra.ElevationRequestID.Valid {
+			result[i].ElevationRequestID = ra.ElevationRequestID.String()
+		}
 	}
 
 	log.Info().
@@ -88,7 +127,7 @@ func (s *roleAssignmentService) GetUserRoleAssignments(ctx context.Context, user
 		Str("user_id", userID).
 		Int("count", len(result)).
 		Msg("Successfully retrieved user role assignments")
-	return result, nil
+	return result, total, nil
 }
 
 func (s *roleAssignmentService) CheckUserPermission(ctx context.Context, userID, resource, action string) (bool, error) {
@@ -128,3 +167,417 @@ func (s *roleAssignmentService) CheckUserPermission(ctx context.Context, userID,
 		Msg("Successfully checked user permission")
 	return hasPermission, nil
 }
+
+func (s *roleAssignmentService) GetRoleAssignmentByID(ctx context.Context, id string) (*dtos.RoleAssignment, error) {
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "GetRoleAssignmentByID").
+		Str("id", id).
+		Msg("Getting role assignment by ID")
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Invalid role assignment ID format")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	roleAssignment, err := s.repo.GetRoleAssignmentByID(ctx, repository.GetRoleAssignmentByIDParams{
+		ID:           pgtype.UUID{Bytes: uuid, Valid: true},
+		HomeTenantID: homeTenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleAssignmentNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get role assignment from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignment, err)
+	}
+
+	return (&dtos.RoleAssignment{}).FromRepositoryModel(roleAssignment), nil
+}
+
+func (s *roleAssignmentService) CreateRoleAssignment(ctx context.Context, req *dtos.CreateRoleAssignmentRequest) (*dtos.RoleAssignment, error) {
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "CreateRoleAssignment").
+		Str("role_permissions_id", req.RolePermissionsID).
+		Str("assignee_id", req.AssigneeID).
+		Msg("Creating role assignment")
+
+	assignedBy, err := s.getAssignedBy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	roleAssignment, err := s.createRoleAssignment(ctx, s.repo.WithTx(tx), req, assignedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "CreateRoleAssignment").
+		Str("id", roleAssignment.ID.String()).
+		Str("assigned_by", assignedBy.String()).
+		Msg("Successfully created role assignment")
+	return roleAssignment, nil
+}
+
+// BulkCreateRoleAssignments creates every assignment in a single transaction; one failure rolls back the batch.
+func (s *roleAssignmentService) BulkCreateRoleAssignments(ctx context.Context, req *dtos.BulkCreateRoleAssignmentRequest) ([]*dtos.RoleAssignment, error) {
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "BulkCreateRoleAssignments").
+		Int("count", len(req.Assignments)).
+		Msg("Bulk creating role assignments")
+
+	assignedBy, err := s.getAssignedBy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	result := make([]*dtos.RoleAssignment, len(req.Assignments))
+	for i := range req.Assignments {
+		roleAssignment, err := s.createRoleAssignment(ctx, qtx, &req.Assignments[i], assignedBy)
+		if err != nil {
+			return nil, fmt.Errorf("assignments[%d]: %w", i, err)
+		}
+		result[i] = roleAssignment
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "BulkCreateRoleAssignments").
+		Str("assigned_by", assignedBy.String()).
+		Int("count", len(result)).
+		Msg("Successfully bulk created role assignments")
+	return result, nil
+}
+
+func (s *roleAssignmentService) UpdateRoleAssignment(ctx context.Context, id string, req *dtos.UpdateRoleAssignmentRequest) (*dtos.RoleAssignment, error) {
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "UpdateRoleAssignment").
+		Str("id", id).
+		Msg("Updating role assignment")
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Invalid role assignment ID format")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	params := repository.UpdateRoleAssignmentParams{
+		ID: pgtype.UUID{Bytes: uuid, Valid: true},
+	}
+	if req.ExpiresAt != "" {
+		if params.ExpiresAt, err = parseExpiresAt(req.ExpiresAt); err != nil {
+			return nil, err
+		}
+	}
+	if req.Status != "" {
+		params.Status = repository.NullStatusEnum{StatusEnum: repository.StatusEnum(req.Status), Valid: true}
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	current, err := qtx.GetRoleAssignmentByIDForUpdate(ctx, repository.GetRoleAssignmentByIDForUpdateParams{
+		ID:           params.ID,
+		HomeTenantID: homeTenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleAssignmentNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get role assignment from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignment, err)
+	}
+	if current.DirectoryMappingID.Valid {
+		return nil, constants.ErrRoleAssignmentExternallyManaged
+	}
+
+	// An assignment left active, or reactivated, must still satisfy separation
+	// of duties; it may have been created inactive to get past the check.
+	status := current.Status.StatusEnum
+	if params.Status.Valid {
+		status = params.Status.StatusEnum
+	}
+	if status == repository.StatusEnumActive {
+		rolePermission, err := qtx.GetRolePermissionByID(ctx, current.RolePermissionsID)
+		if err != nil {
+			log.Error().Err(err).Str("id", id).Msg("Failed to get role permission from repository")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRolePermission, err)
+		}
+		if err := checkSodConflicts(ctx, qtx, current.AssigneeID, rolePermission.RoleID); err != nil {
+			return nil, err
+		}
+	}
+
+	roleAssignment, err := qtx.UpdateRoleAssignment(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleAssignmentNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to update role assignment in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateRoleAssignment, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "UpdateRoleAssignment").
+		Str("id", id).
+		Msg("Successfully updated role assignment")
+	return (&dtos.RoleAssignment{}).FromRepositoryModel(roleAssignment), nil
+}
+
+func (s *roleAssignmentService) RevokeRoleAssignment(ctx context.Context, id string) (*dtos.RoleAssignment, error) {
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "RevokeRoleAssignment").
+		Str("id", id).
+		Msg("Revoking role assignment")
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Invalid role assignment ID format")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	assignmentID := pgtype.UUID{Bytes: uuid, Valid: true}
+	if err := s.ensureManuallyManaged(ctx, assignmentID); err != nil {
+		return nil, err
+	}
+
+	roleAssignment, err := s.repo.RevokeRoleAssignment(ctx, assignmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleAssignmentNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to revoke role assignment in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeRoleAssignment, err)
+	}
+
+	log.Info().
+		Str("service", "RoleAssignmentService").
+		Str("method", "RevokeRoleAssignment").
+		Str("id", id).
+		Str("assignee_id", roleAssignment.AssigneeID.String()).
+		Msg("Successfully revoked role assignment")
+	return (&dtos.RoleAssignment{}).FromRepositoryModel(roleAssignment), nil
+}
+
+// ExpireRoleAssignments marks every active assignment past its expires_at as inactive and returns how many were expired.
+func (s *roleAssignmentService) ExpireRoleAssignments(ctx context.Context) (int, error) {
+	expired, err := s.repo.ExpireRoleAssignments(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to expire role assignments in repository")
+		return 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToExpireRoleAssignments, err)
+	}
+
+	for _, ra := range expired {
+		log.Info().
+			Str("service", "RoleAssignmentService").
+			Str("method", "ExpireRoleAssignments").
+			Str("id", ra.ID.String()).
+			Str("assignee_id", ra.AssigneeID.String()).
+			Str("role_permissions_id", ra.RolePermissionsID.String()).
+			Time("expires_at", ra.ExpiresAt.Time).
+			Msg("Role assignment expired")
+	}
+
+	return len(expired), nil
+}
+
+// ensureManuallyManaged rejects changes to assignments owned by a directory
+// role mapping; those follow the user's Entra claims at login. Assignments of
+// another tenant are not found.
+func (s *roleAssignmentService) ensureManuallyManaged(ctx context.Context, id pgtype.UUID) error {
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	roleAssignment, err := s.repo.GetRoleAssignmentByID(ctx, repository.GetRoleAssignmentByIDParams{
+		ID:           id,
+		HomeTenantID: homeTenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constants.ErrRoleAssignmentNotFound
+		}
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRoleAssignment, err)
+	}
+	if roleAssignment.DirectoryMappingID.Valid {
+		return constants.ErrRoleAssignmentExternallyManaged
+	}
+	return nil
+}
+
+// getAssignedBy returns the internal ID of the authenticated caller for assigned_by.
+func (s *roleAssignmentService) getAssignedBy(ctx context.Context) (pgtype.UUID, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
+
+// createRoleAssignment validates req and inserts it using q, which must be bound to a transaction.
+func (s *roleAssignmentService) createRoleAssignment(ctx context.Context, q *repository.Queries, req *dtos.CreateRoleAssignmentRequest, assignedBy pgtype.UUID) (*dtos.RoleAssignment, error) {
+	params := repository.CreateRoleAssignmentParams{
+		AssignedBy: assignedBy,
+		Status:     repository.NullStatusEnum{StatusEnum: repository.StatusEnumActive, Valid: true},
+	}
+
+	if err := params.RolePermissionsID.Scan(req.RolePermissionsID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	if err := params.AssigneeID.Scan(req.AssigneeID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	if req.BusinessUnitID != "" {
+		if err := params.BusinessUnitID.Scan(req.BusinessUnitID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+		}
+	}
+	if req.DepartmentID != "" {
+		if err := params.DepartmentID.Scan(req.DepartmentID); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidDepartmentUUIDFormat, err)
+		}
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := parseExpiresAt(req.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		params.ExpiresAt = expiresAt
+	}
+	if req.Status != "" {
+		params.Status.StatusEnum = repository.StatusEnum(req.Status)
+	}
+
+	rolePermission, err := q.GetRolePermissionByID(ctx, params.RolePermissionsID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRolePermissionNotActive
+		}
+		log.Error().Err(err).Str("role_permissions_id", req.RolePermissionsID).Msg("Failed to get role permission from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRolePermission, err)
+	}
+	if rolePermission.Status.StatusEnum != repository.StatusEnumActive || rolePermission.DeletedAt.Valid {
+		return nil, constants.ErrRolePermissionNotActive
+	}
+
+	// Assignees and business units are resolved within the caller's tenant, so
+	// access can never be granted across tenants.
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	if _, err := q.GetUserByID(ctx, repository.GetUserByIDParams{
+		ID:           params.AssigneeID,
+		HomeTenantID: homeTenantID,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrAssigneeNotFound
+		}
+		log.Error().Err(err).Str("assignee_id", req.AssigneeID).Msg("Failed to get assignee from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+
+	if params.BusinessUnitID.Valid {
+		if _, err := q.GetBusinessUnitByID(ctx, repository.GetBusinessUnitByIDParams{
+			ID:       params.BusinessUnitID,
+			TenantID: tenantID,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, constants.ErrBusinessUnitNotFound
+			}
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetBusinessUnit, err)
+		}
+	}
+
+	if params.Status.StatusEnum == repository.StatusEnumActive {
+		if err := checkSodConflicts(ctx, q, params.AssigneeID, rolePermission.RoleID); err != nil {
+			return nil, err
+		}
+	}
+
+	roleAssignment, err := q.CreateRoleAssignment(ctx, params)
+	if err != nil {
+		// The upsert only reactivates revoked or expired rows, so no row means an active duplicate.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleAssignmentAlreadyExists
+		}
+		log.Error().Err(err).Interface("params", params).Msg("Failed to create role assignment in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateRoleAssignment, err)
+	}
+
+	return (&dtos.RoleAssignment{}).FromRepositoryModel(roleAssignment), nil
+}
+
+// parseExpiresAt parses an RFC 3339 expiry and rejects values that are not in the future.
+func parseExpiresAt(value string) (pgtype.Timestamptz, error) {
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil || !expiresAt.After(time.Now()) {
+		return pgtype.Timestamptz{}, constants.ErrInvalidExpiresAt
+	}
+	return pgtype.Timestamptz{Time: expiresAt, Valid: true}, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"yet-another-itsm/internal/constants"
@@ -10,12 +11,14 @@ import (
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/utils"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog/log"
 )
 
 type RolePermissionService interface {
 	GetPermissionsByRole(ctx context.Context, roleID string) ([]*dtos.RolePermissionDetailResponse, error)
+	GetResolvedPermissionsByRole(ctx context.Context, roleID string) ([]*dtos.RolePermissionDetailResponse, error)
 	GetRolePermissionByID(ctx context.Context, id string) (*dtos.RolePermissionDetailResponse, error)
 	CreateRolePermission(ctx context.Context, req *dtos.CreateRolePermissionRequest) (*dtos.RolePermissionResponse, error)
 }
@@ -84,6 +87,30 @@ func (s *rolePermissionService) GetPermissionsByRole(ctx context.Context, roleID
 	return result, nil
 }
 
+// GetResolvedPermissionsByRole returns the permissions granted to a role directly
+// and those inherited from its ancestors, with inherited entries flagged.
+func (s *rolePermissionService) GetResolvedPermissionsByRole(ctx context.Context, roleID string) ([]*dtos.RolePermissionDetailResponse, error) {
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	log.Info().
+		Str("service", "RolePermissionService").
+		Str("method", "GetResolvedPermissionsByRole").
+		Str("role_id", roleID).
+		Str("user_id", userID).
+		Msg("Getting resolved role permissions")
+
+	rows, err := s.repo.GetResolvedRolePermissions(ctx, roleID)
+	if err != nil {
+		log.Error().Err(err).Str("role_id", roleID).Msg("Failed to get resolved role permissions from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRolePermissions, err)
+	}
+
+	return resolvedRolePermissions(rows), nil
+}
+
 func (s *rolePermissionService) GetRolePermissionByID(ctx context.Context, id string) (*dtos.RolePermissionDetailResponse, error) {
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
@@ -142,6 +169,8 @@ func (s *rolePermissionService) GetRolePermissionByID(ctx context.Context, id st
 	return response, nil
 }
 
+// CreateRolePermission grants a permission to a role of the caller's tenant.
+// Shared roles apply to every tenant, so they are treated as not found.
 func (s *rolePermissionService) CreateRolePermission(ctx context.Context, req *dtos.CreateRolePermissionRequest) (*dtos.RolePermissionResponse, error) {
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
@@ -156,6 +185,22 @@ func (s *rolePermissionService) CreateRolePermission(ctx context.Context, req *d
 		Str("user_id", userID).
 		Msg("Creating role permission")
 
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	role, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: req.RoleID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+	}
+	if !role.TenantID.Valid {
+		return nil, constants.ErrRoleNotFound
+	}
+
 	params := repository.CreateRolePermissionParams{
 		RoleID:       req.RoleID,
 		PermissionID: req.PermissionID,
@@ -196,3 +241,37 @@ func (s *rolePermissionService) CreateRolePermission(ctx context.Context, req *d
 
 	return response, nil
 }
+
+// resolvedRolePermissions maps the rows of GetResolvedRolePermissions, where a
+// non-zero depth means the permission is inherited from an ancestor role.
+func resolvedRolePermissions(rows []repository.GetResolvedRolePermissionsRow) []*dtos.RolePermissionDetailResponse {
+	result := make([]*dtos.RolePermissionDetailResponse, 0, len(rows))
+	for _, rp := range rows {
+		response := &dtos.RolePermissionDetailResponse{
+			ID:             rp.ID.String(),
+			RoleID:         rp.RoleID,
+			RoleName:       rp.RoleName,
+			PermissionID:   rp.PermissionID,
+			PermissionName: rp.PermissionName,
+			Resource:       rp.Resource,
+			Action:         rp.Action,
+			Inherited:      rp.Depth > 0,
+			Status:         string(rp.Status.StatusEnum),
+			CreatedAt:      utils.FormatTime(rp.CreatedAt.Time),
+			UpdatedAt:      utils.FormatTime(rp.UpdatedAt.Time),
+		}
+
+		if rp.ScopeID.Valid {
+			response.ScopeID = rp.ScopeID.String
+		}
+		if rp.ScopeName.Valid {
+			response.ScopeName = rp.ScopeName.String
+		}
+		if rp.DeletedAt.Valid {
+			response.DeletedAt = utils.FormatTime(rp.DeletedAt.Time)
+		}
+
+		result = append(result, response)
+	}
+	return result
+}
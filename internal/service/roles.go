@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"yet-another-itsm/internal/constants"
@@ -9,6 +10,7 @@ import (
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/utils"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog/log"
 )
@@ -18,6 +20,8 @@ type RoleService interface {
 	GetRoleByID(ctx context.Context, id string) (*dtos.Role, error)
 	GetSystemRoles(ctx context.Context) ([]*dtos.Role, error)
 	CreateRole(ctx context.Context, req *dtos.CreateRoleRequest) (*dtos.Role, error)
+	SetRoleParent(ctx context.Context, id string, req *dtos.SetRoleParentRequest) (*dtos.Role, error)
+	GetResolvedPermissions(ctx context.Context, id string) ([]*dtos.RolePermissionDetailResponse, error)
 }
 
 type roleService struct {
@@ -42,7 +46,12 @@ func (s *roleService) GetAllRoles(ctx context.Context) ([]*dtos.Role, error) {
 		Str("user_id", userID).
 		Msg("Getting all roles")
 
-	roles, err := s.repo.GetAllRoles(ctx)
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	roles, err := s.repo.GetAllRoles(ctx, tenantID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get roles from repository")
 		return nil, fmt.Errorf("failed to get roles from repository: %w", err)
@@ -74,7 +83,12 @@ func (s *roleService) GetRoleByID(ctx context.Context, id string) (*dtos.Role, e
 		Str("user_id", userID).
 		Msg("Getting role by ID")
 
-	role, err := s.repo.GetRoleByID(ctx, id)
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	role, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: id, TenantID: tenantID})
 	if err != nil {
 		log.Error().Err(err).Str("role_id", id).Msg("Failed to get role from repository")
 		return nil, fmt.Errorf("failed to get role from repository: %w", err)
@@ -103,7 +117,12 @@ func (s *roleService) GetSystemRoles(ctx context.Context) ([]*dtos.Role, error)
 		Str("user_id", userID).
 		Msg("Getting system roles")
 
-	roles, err := s.repo.GetSystemRoles(ctx)
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	roles, err := s.repo.GetSystemRoles(ctx, tenantID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get system roles from repository")
 		return nil, fmt.Errorf("failed to get system roles from repository: %w", err)
@@ -131,12 +150,28 @@ func (s *roleService) CreateRole(ctx context.Context, req *dtos.CreateRoleReques
 		Str("user_id", userID).
 		Msg("Creating role")
 
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
 	params := repository.CreateRoleParams{
 		ID:           req.ID,
 		Name:         req.Name,
 		Description:  pgtype.Text{String: req.Description, Valid: true},
 		IsSystemRole: pgtype.Bool{Bool: req.IsSystemRole, Valid: true},
 		Status:       repository.NullStatusEnum{StatusEnum: repository.StatusEnum(req.Status), Valid: true},
+		TenantID:     tenantID,
+	}
+
+	if req.ParentRoleID != "" {
+		if _, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: req.ParentRoleID, TenantID: tenantID}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, constants.ErrParentRoleNotFound
+			}
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+		}
+		params.ParentRoleID = pgtype.Text{String: req.ParentRoleID, Valid: true}
 	}
 
 	role, err := s.repo.CreateRole(ctx, params)
@@ -155,3 +190,99 @@ func (s *roleService) CreateRole(ctx context.Context, req *dtos.CreateRoleReques
 
 	return result, nil
 }
+
+// SetRoleParent makes the role inherit every permission of the parent role and,
+// transitively, of the parent's ancestors. It rejects parents that would close a cycle.
+// Only roles of the caller's tenant can be changed; shared roles are not found.
+func (s *roleService) SetRoleParent(ctx context.Context, id string, req *dtos.SetRoleParentRequest) (*dtos.Role, error) {
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	log.Info().
+		Str("service", "RoleService").
+		Str("method", "SetRoleParent").
+		Str("role_id", id).
+		Str("parent_role_id", req.ParentRoleID).
+		Str("user_id", userID).
+		Msg("Setting parent role")
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	params := repository.SetRoleParentParams{ID: id, TenantID: tenantID}
+	if req.ParentRoleID != "" {
+		if req.ParentRoleID == id {
+			return nil, constants.ErrRoleHierarchyCycle
+		}
+
+		parent, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: req.ParentRoleID, TenantID: tenantID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, constants.ErrParentRoleNotFound
+			}
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+		}
+		if parent.DeletedAt.Valid {
+			return nil, constants.ErrParentRoleNotFound
+		}
+
+		ancestors, err := s.repo.GetRoleAncestors(ctx, req.ParentRoleID)
+		if err != nil {
+			log.Error().Err(err).Str("role_id", req.ParentRoleID).Msg("Failed to get role ancestors from repository")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+		}
+		for _, ancestor := range ancestors {
+			if ancestor.ID == id {
+				return nil, constants.ErrRoleHierarchyCycle
+			}
+		}
+
+		params.ParentRoleID = pgtype.Text{String: req.ParentRoleID, Valid: true}
+	}
+
+	role, err := s.repo.SetRoleParent(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleNotFound
+		}
+		log.Error().Err(err).Str("role_id", id).Msg("Failed to set parent role in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateRole, err)
+	}
+
+	roleDTO := &dtos.Role{}
+	return roleDTO.FromRepositoryModel(role), nil
+}
+
+// GetResolvedPermissions returns the role's own permissions followed by those
+// inherited from its ancestors.
+func (s *roleService) GetResolvedPermissions(ctx context.Context, id string) ([]*dtos.RolePermissionDetailResponse, error) {
+	log.Info().
+		Str("service", "RoleService").
+		Str("method", "GetResolvedPermissions").
+		Str("role_id", id).
+		Msg("Getting resolved role permissions")
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	if _, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: id, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+	}
+
+	rows, err := s.repo.GetResolvedRolePermissions(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Str("role_id", id).Msg("Failed to get resolved role permissions from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRolePermissions, err)
+	}
+
+	return resolvedRolePermissions(rows), nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Page sizes of SCIM list requests.
+const (
+	defaultSCIMCount = 100
+	maxSCIMCount     = constants.SCIMMaxResults
+)
+
+// SCIM PATCH operations, compared case-insensitively.
+const (
+	scimOpAdd     = "add"
+	scimOpReplace = "replace"
+	scimOpRemove  = "remove"
+)
+
+// SCIMService provisions users and groups for SCIM 2.0 clients such as Entra
+// ID. Users map onto the users table, with the enterprise department onto
+// departments. Groups are kept in scim_groups; membership grants roles through
+// the directory role mappings of claim type scim_group, matched on the group's
+// own ID.
+type SCIMService interface {
+	ListUsers(ctx context.Context, query dtos.SCIMListQuery) (*dtos.SCIMUserListResponse, error)
+	GetUser(ctx context.Context, id string) (*dtos.SCIMUser, error)
+	CreateUser(ctx context.Context, user *dtos.SCIMUser) (*dtos.SCIMUser, error)
+	ReplaceUser(ctx context.Context, id string, user *dtos.SCIMUser) (*dtos.SCIMUser, error)
+	PatchUser(ctx context.Context, id string, patch *dtos.SCIMPatchRequest) (*dtos.SCIMUser, error)
+	DeleteUser(ctx context.Context, id string) error
+	ListGroups(ctx context.Context, query dtos.SCIMListQuery) (*dtos.SCIMGroupListResponse, error)
+	GetGroup(ctx context.Context, id string, excludeMembers bool) (*dtos.SCIMGroup, error)
+	CreateGroup(ctx context.Context, group *dtos.SCIMGroup) (*dtos.SCIMGroup, error)
+	ReplaceGroup(ctx context.Context, id string, group *dtos.SCIMGroup) (*dtos.SCIMGroup, error)
+	PatchGroup(ctx context.Context, id string, patch *dtos.SCIMPatchRequest) (*dtos.SCIMGroup, error)
+	DeleteGroup(ctx context.Context, id string) error
+}
+
+type scimService struct {
+	db            *database.Database
+	repo          *repository.Queries
+	departments   DepartmentService
+	businessUnits BusinessUnitService
+	roleMappings  DirectoryRoleMappingService
+}
+
+func NewSCIMService(db *database.Database, repo *repository.Queries, departments DepartmentService, businessUnits BusinessUnitService, roleMappings DirectoryRoleMappingService) SCIMService {
+	return &scimService{
+		db:            db,
+		repo:          repo,
+		departments:   departments,
+		businessUnits: businessUnits,
+		roleMappings:  roleMappings,
+	}
+}
+
+// syncGroupRoles re-evaluates the scim_group role mappings of users whose SCIM
+// group memberships changed. Assignments from token claims are left alone.
+func (s *scimService) syncGroupRoles(ctx context.Context, userIDs []pgtype.UUID) error {
+	for _, userID := range userIDs {
+		groups, err := s.repo.ListUserScimGroups(ctx, userID)
+		if err != nil {
+			return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncSCIMGroupRoles, err)
+		}
+
+		claims := utils.DirectoryClaims{SCIM: true, SCIMGroups: make([]string, len(groups))}
+		for i, group := range groups {
+			claims.SCIMGroups[i] = group.ID.String()
+		}
+
+		if _, err := s.roleMappings.SyncUserRoleAssignments(ctx, userID.String(), claims); err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to sync role assignments of SCIM group member")
+			return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncSCIMGroupRoles, err)
+		}
+	}
+	return nil
+}
+
+// scimPage is the window of a list request. StartIndex is 1-based.
+type scimPage struct {
+	startIndex int
+	offset     int32
+	limit      int32
+}
+
+// parseSCIMPage reads startIndex and count. As RFC 7644 requires, a
+// startIndex below 1 is read as 1 and a negative count as 0.
+func parseSCIMPage(query dtos.SCIMListQuery) (scimPage, error) {
+	page := scimPage{startIndex: 1, limit: defaultSCIMCount}
+	if query.StartIndex != "" {
+		startIndex, err := strconv.Atoi(query.StartIndex)
+		if err != nil {
+			return scimPage{}, fmt.Errorf("%w: startIndex must be an integer", constants.ErrSCIMInvalidValue)
+		}
+		page.startIndex = max(startIndex, 1)
+	}
+	if query.Count != "" {
+		count, err := strconv.Atoi(query.Count)
+		if err != nil {
+			return scimPage{}, fmt.Errorf("%w: count must be an integer", constants.ErrSCIMInvalidValue)
+		}
+		page.limit = int32(min(max(count, 0), maxSCIMCount))
+	}
+	page.offset = int32(page.startIndex - 1)
+	return page, nil
+}
+
+// scimFilterToken is a word or a quoted string of a filter expression.
+type scimFilterToken struct {
+	value  string
+	quoted bool
+}
+
+// parseSCIMFilter parses the filters SCIM clients use to look resources up:
+// one or more `attribute eq "value"` comparisons joined by `and`. attributes
+// maps the accepted attribute names, in lower case, to the key they are
+// returned under.
+func parseSCIMFilter(filter string, attributes map[string]string) (map[string]string, error) {
+	result := make(map[string]string)
+	if strings.TrimSpace(filter) == "" {
+		return result, nil
+	}
+
+	tokens, err := tokenizeSCIMFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens)%4 != 3 {
+		return nil, fmt.Errorf("%w: %s", constants.ErrSCIMInvalidFilter, filter)
+	}
+
+	for i := 0; i < len(tokens); i += 4 {
+		attribute, op, value := tokens[i], tokens[i+1], tokens[i+2]
+		if attribute.quoted || op.quoted || !value.quoted || !strings.EqualFold(op.value, "eq") {
+			return nil, fmt.Errorf("%w: only eq comparisons with string values are supported", constants.ErrSCIMInvalidFilter)
+		}
+		if i+3 < len(tokens) && (tokens[i+3].quoted || !strings.EqualFold(tokens[i+3].value, "and")) {
+			return nil, fmt.Errorf("%w: only and is supported between comparisons", constants.ErrSCIMInvalidFilter)
+		}
+
+		key, ok := attributes[strings.ToLower(trimSCIMSchema(attribute.value))]
+		if !ok {
+			return nil, fmt.Errorf("%w: filtering on %s is not supported", constants.ErrSCIMInvalidFilter, attribute.value)
+		}
+		if previous, seen := result[key]; seen && previous != value.value {
+			return nil, fmt.Errorf("%w: %s is compared twice", constants.ErrSCIMInvalidFilter, attribute.value)
+		}
+		result[key] = value.value
+	}
+	return result, nil
+}
+
+func tokenizeSCIMFilter(filter string) ([]scimFilterToken, error) {
+	var tokens []scimFilterToken
+	for i := 0; i < len(filter); {
+		switch filter[i] {
+		case ' ', '\t':
+			i++
+		case '"':
+			end := i + 1
+			for end < len(filter) && filter[end] != '"' {
+				if filter[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(filter) {
+				return nil, fmt.Errorf("%w: unterminated string", constants.ErrSCIMInvalidFilter)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &value); err != nil {
+				return nil, fmt.Errorf("%w: invalid string %s", constants.ErrSCIMInvalidFilter, filter[i:end+1])
+			}
+			tokens = append(tokens, scimFilterToken{value: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && filter[end] != ' ' && filter[end] != '\t' && filter[end] != '"' {
+				end++
+			}
+			tokens = append(tokens, scimFilterToken{value: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// trimSCIMSchema drops the core schema URN from a fully qualified attribute
+// name such as urn:ietf:params:scim:schemas:core:2.0:User:userName.
+func trimSCIMSchema(attribute string) string {
+	lower := strings.ToLower(attribute)
+	for _, schema := range []string{constants.SCIMSchemaUser, constants.SCIMSchemaGroup} {
+		prefix := strings.ToLower(schema) + ":"
+		if strings.HasPrefix(lower, prefix) {
+			return attribute[len(prefix):]
+		}
+	}
+	return attribute
+}
+
+// parseSCIMID parses a resource ID. IDs that are not UUIDs cannot exist, so
+// they are reported as notFound.
+func parseSCIMID(id string, notFound error) (pgtype.UUID, error) {
+	var uuid pgtype.UUID
+	if err := uuid.Scan(id); err != nil {
+		return pgtype.UUID{}, notFound
+	}
+	return uuid, nil
+}
+
+// checkPatchRequest validates the envelope of a PATCH request.
+func checkPatchRequest(patch *dtos.SCIMPatchRequest) error {
+	if len(patch.Operations) == 0 {
+		return fmt.Errorf("%w: Operations is required", constants.ErrSCIMInvalidSyntax)
+	}
+	for _, op := range patch.Operations {
+		switch strings.ToLower(op.Op) {
+		case scimOpAdd, scimOpReplace, scimOpRemove:
+		default:
+			return fmt.Errorf("%w: unknown op %q", constants.ErrSCIMInvalidSyntax, op.Op)
+		}
+	}
+	return nil
+}
+
+// scimString reads a string PATCH value. Some clients wrap single values in an
+// object with a value member, or send null to clear them.
+func scimString(value json.RawMessage) (string, error) {
+	if len(value) == 0 || string(value) == "null" {
+		return "", nil
+	}
+
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		return text, nil
+	}
+	var wrapped struct {
+		Value *string `json:"value"`
+	}
+	if err := json.Unmarshal(value, &wrapped); err == nil && wrapped.Value != nil {
+		return *wrapped.Value, nil
+	}
+	return "", fmt.Errorf("%w: expected a string, got %s", constants.ErrSCIMInvalidValue, string(value))
+}
+
+// scimBool reads a boolean PATCH value. Entra ID sends booleans as the strings
+// "True" and "False" unless its SCIM compliance flag is set.
+func scimBool(value json.RawMessage) (bool, error) {
+	var flag bool
+	if err := json.Unmarshal(value, &flag); err == nil {
+		return flag, nil
+	}
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		if flag, err := strconv.ParseBool(text); err == nil {
+			return flag, nil
+		}
+	}
+	return false, fmt.Errorf("%w: expected a boolean, got %s", constants.ErrSCIMInvalidValue, string(value))
+}
+
+// scimObject reads a PATCH value that must be a JSON object.
+func scimObject(value json.RawMessage) (map[string]json.RawMessage, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(value, &object); err != nil || object == nil {
+		return nil, fmt.Errorf("%w: expected an object", constants.ErrSCIMInvalidValue)
+	}
+	return object, nil
+}
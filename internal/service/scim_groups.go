@@ -0,0 +1,530 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// scimGroupFilterAttributes are the group attributes SCIM filters may compare.
+var scimGroupFilterAttributes = map[string]string{
+	"id":            "id",
+	"displayname":   "displayName",
+	"externalid":    "externalId",
+	"members":       "member",
+	"members.value": "member",
+}
+
+// scimGroupState is the part of a group that SCIM clients write.
+type scimGroupState struct {
+	externalID  string
+	displayName string
+	members     map[pgtype.UUID]bool
+}
+
+// ListGroups returns one page of the caller's tenant's groups matching the
+// filter, in creation order.
+func (s *scimService) ListGroups(ctx context.Context, query dtos.SCIMListQuery) (*dtos.SCIMGroupListResponse, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	filter, err := parseSCIMFilter(query.Filter, scimGroupFilterAttributes)
+	if err != nil {
+		return nil, err
+	}
+	page, err := parseSCIMPage(query)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.SCIMGroupListResponse{
+		Schemas:    []string{constants.SCIMSchemaListResponse},
+		StartIndex: page.startIndex,
+		Resources:  []*dtos.SCIMGroup{},
+	}
+
+	params := repository.CountScimGroupsParams{
+		TenantID:    tenantID,
+		DisplayName: scimFilterText(filter, "displayName"),
+		ExternalID:  scimFilterText(filter, "externalId"),
+	}
+	if id, ok := filter["id"]; ok {
+		if err := params.ID.Scan(id); err != nil {
+			return response, nil
+		}
+	}
+	if member, ok := filter["member"]; ok {
+		if err := params.MemberID.Scan(member); err != nil {
+			return response, nil
+		}
+	}
+
+	response.TotalResults, err = s.repo.CountScimGroups(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Str("filter", query.Filter).Msg("Failed to count SCIM groups in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToListSCIMResources, err)
+	}
+	if page.limit == 0 {
+		return response, nil
+	}
+
+	groups, err := s.repo.ListScimGroups(ctx, repository.ListScimGroupsParams{
+		TenantID:    params.TenantID,
+		ID:          params.ID,
+		DisplayName: params.DisplayName,
+		ExternalID:  params.ExternalID,
+		MemberID:    params.MemberID,
+		PageOffset:  page.offset,
+		PageLimit:   page.limit,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("filter", query.Filter).Msg("Failed to list SCIM groups from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToListSCIMResources, err)
+	}
+
+	excludeMembers := query.ExcludesMembers()
+	for _, group := range groups {
+		resource, err := s.toSCIMGroup(ctx, group, excludeMembers)
+		if err != nil {
+			return nil, err
+		}
+		response.Resources = append(response.Resources, resource)
+	}
+	response.ItemsPerPage = len(response.Resources)
+	return response, nil
+}
+
+func (s *scimService) GetGroup(ctx context.Context, id string, excludeMembers bool) (*dtos.SCIMGroup, error) {
+	group, err := s.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSCIMGroup(ctx, group, excludeMembers)
+}
+
+func (s *scimService) CreateGroup(ctx context.Context, resource *dtos.SCIMGroup) (*dtos.SCIMGroup, error) {
+	state, err := scimGroupStateFromResource(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.saveGroup(ctx, nil, nil, state)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "CreateGroup").
+		Str("group_id", group.ID.String()).
+		Int("members", len(state.members)).
+		Msg("Provisioned group")
+	return s.toSCIMGroup(ctx, group, false)
+}
+
+// ReplaceGroup overwrites the attributes and the members of a group.
+func (s *scimService) ReplaceGroup(ctx context.Context, id string, resource *dtos.SCIMGroup) (*dtos.SCIMGroup, error) {
+	existing, err := s.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.groupMembers(ctx, existing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := scimGroupStateFromResource(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.saveGroup(ctx, &existing, members, state)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "ReplaceGroup").
+		Str("group_id", id).
+		Int("members", len(state.members)).
+		Msg("Replaced provisioned group")
+	return s.toSCIMGroup(ctx, group, false)
+}
+
+// PatchGroup applies add, replace and remove operations to a group, most
+// often to add or remove members.
+func (s *scimService) PatchGroup(ctx context.Context, id string, patch *dtos.SCIMPatchRequest) (*dtos.SCIMGroup, error) {
+	if err := checkPatchRequest(patch); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.groupMembers(ctx, existing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := scimGroupState{
+		externalID:  existing.ExternalID.String,
+		displayName: existing.DisplayName,
+		members:     make(map[pgtype.UUID]bool, len(members)),
+	}
+	for member := range members {
+		state.members[member] = true
+	}
+	for _, op := range patch.Operations {
+		if err := applySCIMGroupOperation(&state, op); err != nil {
+			return nil, err
+		}
+	}
+
+	group, err := s.saveGroup(ctx, &existing, members, state)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "PatchGroup").
+		Str("group_id", id).
+		Int("operations", len(patch.Operations)).
+		Msg("Patched provisioned group")
+	return s.toSCIMGroup(ctx, group, false)
+}
+
+// DeleteGroup deletes a group and revokes the roles its members had through
+// it.
+func (s *scimService) DeleteGroup(ctx context.Context, id string) error {
+	group, err := s.getGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+	members, err := s.groupMembers(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.repo.DeleteScimGroup(ctx, repository.DeleteScimGroupParams{ID: group.ID, TenantID: group.TenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constants.ErrSCIMGroupNotFound
+		}
+		log.Error().Err(err).Str("group_id", id).Msg("Failed to delete SCIM group in repository")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteSCIMResource, err)
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "DeleteGroup").
+		Str("group_id", id).
+		Msg("Deprovisioned group")
+	return s.syncGroupRoles(ctx, memberIDs(members))
+}
+
+// getGroup loads a group of the caller's tenant.
+func (s *scimService) getGroup(ctx context.Context, id string) (repository.ScimGroup, error) {
+	groupID, err := parseSCIMID(id, constants.ErrSCIMGroupNotFound)
+	if err != nil {
+		return repository.ScimGroup{}, err
+	}
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	group, err := s.repo.GetScimGroup(ctx, repository.GetScimGroupParams{ID: groupID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ScimGroup{}, constants.ErrSCIMGroupNotFound
+		}
+		return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSCIMGroup, err)
+	}
+	return group, nil
+}
+
+func (s *scimService) groupMembers(ctx context.Context, groupID pgtype.UUID) (map[pgtype.UUID]bool, error) {
+	rows, err := s.repo.ListScimGroupMembers(ctx, groupID)
+	if err != nil {
+		log.Error().Err(err).Str("group_id", groupID.String()).Msg("Failed to list SCIM group members from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSCIMGroup, err)
+	}
+
+	members := make(map[pgtype.UUID]bool, len(rows))
+	for _, row := range rows {
+		members[row.ID] = true
+	}
+	return members, nil
+}
+
+// saveGroup creates a group when existing is nil, or updates existing and
+// its members from current to state.members. The roles of members who joined
+// or left are synced afterwards; all of them when the externalId, and so the
+// group claim, changed.
+func (s *scimService) saveGroup(ctx context.Context, existing *repository.ScimGroup, current map[pgtype.UUID]bool, state scimGroupState) (repository.ScimGroup, error) {
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+	if state.displayName == "" {
+		return repository.ScimGroup{}, fmt.Errorf("%w: displayName is required", constants.ErrSCIMInvalidValue)
+	}
+
+	var existingID pgtype.UUID
+	if existing != nil {
+		existingID = existing.ID
+	}
+	taken, err := s.repo.ScimGroupNameExists(ctx, repository.ScimGroupNameExistsParams{
+		TenantID:    tenantID,
+		DisplayName: state.displayName,
+		ExcludeID:   existingID,
+	})
+	if err != nil {
+		return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSCIMGroup, err)
+	}
+	if taken {
+		return repository.ScimGroup{}, fmt.Errorf("%w: displayName %s", constants.ErrSCIMUniqueness, state.displayName)
+	}
+
+	var added, removed []pgtype.UUID
+	for member := range state.members {
+		if !current[member] {
+			added = append(added, member)
+		}
+	}
+	for member := range current {
+		if !state.members[member] {
+			removed = append(removed, member)
+		}
+	}
+	for _, member := range added {
+		if _, err := s.repo.GetScimUser(ctx, repository.GetScimUserParams{ID: member, HomeTenantID: tenantID}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ScimGroup{}, fmt.Errorf("%w: member %s is not a provisioned user", constants.ErrSCIMInvalidValue, member.String())
+			}
+			return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+		}
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	var group repository.ScimGroup
+	if existing == nil {
+		group, err = qtx.CreateScimGroup(ctx, repository.CreateScimGroupParams{
+			TenantID:    tenantID,
+			ExternalID:  optionalText(state.externalID),
+			DisplayName: state.displayName,
+		})
+	} else {
+		group, err = qtx.UpdateScimGroup(ctx, repository.UpdateScimGroupParams{
+			ExternalID:  optionalText(state.externalID),
+			DisplayName: state.displayName,
+			ID:          existing.ID,
+			TenantID:    tenantID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ScimGroup{}, constants.ErrSCIMGroupNotFound
+		}
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return repository.ScimGroup{}, fmt.Errorf("%w: displayName %s", constants.ErrSCIMUniqueness, state.displayName)
+		}
+		log.Error().Err(err).Str("display_name", state.displayName).Msg("Failed to save SCIM group in repository")
+		return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSaveSCIMGroup, err)
+	}
+
+	for _, member := range added {
+		if err := qtx.AddScimGroupMember(ctx, repository.AddScimGroupMemberParams{GroupID: group.ID, UserID: member}); err != nil {
+			log.Error().Err(err).Str("group_id", group.ID.String()).Msg("Failed to add SCIM group member in repository")
+			return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSaveSCIMGroup, err)
+		}
+	}
+	for _, member := range removed {
+		if err := qtx.RemoveScimGroupMember(ctx, repository.RemoveScimGroupMemberParams{GroupID: group.ID, UserID: member}); err != nil {
+			log.Error().Err(err).Str("group_id", group.ID.String()).Msg("Failed to remove SCIM group member in repository")
+			return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSaveSCIMGroup, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return repository.ScimGroup{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	changed := append(added, removed...)
+	if existing != nil && existing.ExternalID.String != state.externalID {
+		changed = append(memberIDs(state.members), removed...)
+	}
+	if err := s.syncGroupRoles(ctx, changed); err != nil {
+		return repository.ScimGroup{}, err
+	}
+	return group, nil
+}
+
+func (s *scimService) toSCIMGroup(ctx context.Context, group repository.ScimGroup, excludeMembers bool) (*dtos.SCIMGroup, error) {
+	if excludeMembers {
+		return dtos.NewSCIMGroup(group, nil), nil
+	}
+
+	members, err := s.repo.ListScimGroupMembers(ctx, group.ID)
+	if err != nil {
+		log.Error().Err(err).Str("group_id", group.ID.String()).Msg("Failed to list SCIM group members from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSCIMGroup, err)
+	}
+	return dtos.NewSCIMGroup(group, members), nil
+}
+
+func scimGroupStateFromResource(resource *dtos.SCIMGroup) (scimGroupState, error) {
+	state := scimGroupState{
+		externalID:  strings.TrimSpace(resource.ExternalID),
+		displayName: strings.TrimSpace(resource.DisplayName),
+		members:     make(map[pgtype.UUID]bool, len(resource.Members)),
+	}
+	for _, member := range resource.Members {
+		id, err := parseSCIMMemberID(member.Value)
+		if err != nil {
+			return scimGroupState{}, err
+		}
+		state.members[id] = true
+	}
+	return state, nil
+}
+
+// applySCIMGroupOperation applies one PATCH operation. Without a path the
+// value is an object of attributes to set.
+func applySCIMGroupOperation(state *scimGroupState, op dtos.SCIMPatchOperation) error {
+	operation := strings.ToLower(op.Op)
+	if op.Path == "" {
+		if operation == scimOpRemove {
+			return fmt.Errorf("%w: remove requires a path", constants.ErrSCIMNoTarget)
+		}
+		object, err := scimObject(op.Value)
+		if err != nil {
+			return err
+		}
+		for attribute, value := range object {
+			if err := setSCIMGroupAttribute(state, operation, attribute, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return setSCIMGroupAttribute(state, operation, op.Path, op.Value)
+}
+
+func setSCIMGroupAttribute(state *scimGroupState, operation, path string, value json.RawMessage) error {
+	path = trimSCIMSchema(path)
+	attribute := strings.ToLower(path)
+	remove := operation == scimOpRemove
+
+	switch attribute {
+	case "displayname":
+		if remove {
+			return fmt.Errorf("%w: displayName is required", constants.ErrSCIMInvalidValue)
+		}
+		return setSCIMText(&state.displayName, value, false)
+	case "externalid":
+		return setSCIMText(&state.externalID, value, remove)
+	case "members":
+		if remove && (len(value) == 0 || string(value) == "null") {
+			clear(state.members)
+			return nil
+		}
+		members, err := parseSCIMMembers(value)
+		if err != nil {
+			return err
+		}
+		if operation == scimOpReplace {
+			clear(state.members)
+		}
+		for _, member := range members {
+			if remove {
+				delete(state.members, member)
+			} else {
+				state.members[member] = true
+			}
+		}
+		return nil
+	}
+
+	// members[value eq "id"] addresses a single member.
+	if strings.HasPrefix(attribute, "members[") && strings.HasSuffix(attribute, "]") {
+		filter, err := parseSCIMFilter(path[len("members["):len(path)-1], map[string]string{"value": "value"})
+		if err != nil {
+			return fmt.Errorf("%w: %s", constants.ErrSCIMInvalidPath, path)
+		}
+		member, err := parseSCIMMemberID(filter["value"])
+		if err != nil {
+			return err
+		}
+		if remove {
+			delete(state.members, member)
+		} else {
+			state.members[member] = true
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s", constants.ErrSCIMInvalidPath, path)
+}
+
+// parseSCIMMembers reads a members PATCH value: a list of member objects, or
+// a single one.
+func parseSCIMMembers(value json.RawMessage) ([]pgtype.UUID, error) {
+	var references []dtos.SCIMReference
+	if err := json.Unmarshal(value, &references); err != nil {
+		var reference dtos.SCIMReference
+		if err := json.Unmarshal(value, &reference); err != nil {
+			return nil, fmt.Errorf("%w: members must be a list of members", constants.ErrSCIMInvalidValue)
+		}
+		references = []dtos.SCIMReference{reference}
+	}
+
+	members := make([]pgtype.UUID, 0, len(references))
+	for _, reference := range references {
+		member, err := parseSCIMMemberID(reference.Value)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func parseSCIMMemberID(value string) (pgtype.UUID, error) {
+	var id pgtype.UUID
+	if err := id.Scan(value); err != nil {
+		return pgtype.UUID{}, fmt.Errorf("%w: member %s is not a provisioned user", constants.ErrSCIMInvalidValue, value)
+	}
+	return id, nil
+}
+
+func memberIDs(members map[pgtype.UUID]bool) []pgtype.UUID {
+	ids := make([]pgtype.UUID, 0, len(members))
+	for member := range members {
+		ids = append(ids, member)
+	}
+	return ids
+}
@@ -0,0 +1,821 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// scimUserFilterAttributes are the user attributes SCIM filters may compare.
+var scimUserFilterAttributes = map[string]string{
+	"id":           "id",
+	"username":     "userName",
+	"emails.value": "userName",
+	"externalid":   "externalId",
+	"displayname":  "displayName",
+}
+
+// ignoredSCIMUserAttributes are attributes of the core and enterprise user
+// schemas that are accepted in PATCH requests but not stored. Emails are
+// reported from userName.
+var ignoredSCIMUserAttributes = map[string]bool{
+	"emails":            true,
+	"nickname":          true,
+	"profileurl":        true,
+	"usertype":          true,
+	"preferredlanguage": true,
+	"locale":            true,
+	"timezone":          true,
+	"password":          true,
+	"phonenumbers":      true,
+	"ims":               true,
+	"photos":            true,
+	"entitlements":      true,
+	"roles":             true,
+	"x509certificates":  true,
+	"employeenumber":    true,
+	"costcenter":        true,
+	"organization":      true,
+	"division":          true,
+}
+
+// scimUserState is the part of a user that SCIM clients write. An empty
+// externalID keeps the stored object ID.
+type scimUserState struct {
+	externalID     string
+	userName       string
+	displayName    string
+	givenName      string
+	familyName     string
+	title          string
+	officeLocation string
+	department     string
+	managerID      string
+	active         bool
+}
+
+// ListUsers returns one page of the caller's tenant's users matching the
+// filter, in creation order.
+func (s *scimService) ListUsers(ctx context.Context, query dtos.SCIMListQuery) (*dtos.SCIMUserListResponse, error) {
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	filter, err := parseSCIMFilter(query.Filter, scimUserFilterAttributes)
+	if err != nil {
+		return nil, err
+	}
+	page, err := parseSCIMPage(query)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.SCIMUserListResponse{
+		Schemas:    []string{constants.SCIMSchemaListResponse},
+		StartIndex: page.startIndex,
+		Resources:  []*dtos.SCIMUser{},
+	}
+
+	params := repository.CountScimUsersParams{
+		HomeTenantID: homeTenantID,
+		UserName:     scimFilterText(filter, "userName"),
+		ExternalID:   scimFilterText(filter, "externalId"),
+		DisplayName:  scimFilterText(filter, "displayName"),
+	}
+	if id, ok := filter["id"]; ok {
+		if err := params.ID.Scan(id); err != nil {
+			// No user has an ID that is not a UUID.
+			return response, nil
+		}
+	}
+
+	response.TotalResults, err = s.repo.CountScimUsers(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Str("filter", query.Filter).Msg("Failed to count SCIM users in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToListSCIMResources, err)
+	}
+	if page.limit == 0 {
+		return response, nil
+	}
+
+	users, err := s.repo.ListScimUsers(ctx, repository.ListScimUsersParams{
+		HomeTenantID: params.HomeTenantID,
+		ID:           params.ID,
+		UserName:     params.UserName,
+		ExternalID:   params.ExternalID,
+		DisplayName:  params.DisplayName,
+		PageOffset:   page.offset,
+		PageLimit:    page.limit,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("filter", query.Filter).Msg("Failed to list SCIM users from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToListSCIMResources, err)
+	}
+
+	for _, user := range users {
+		resource, err := s.toSCIMUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		response.Resources = append(response.Resources, resource)
+	}
+	response.ItemsPerPage = len(response.Resources)
+	return response, nil
+}
+
+func (s *scimService) GetUser(ctx context.Context, id string) (*dtos.SCIMUser, error) {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSCIMUser(ctx, user)
+}
+
+// CreateUser provisions a user. Without an externalId the user gets a
+// generated object ID and cannot sign in until one is set.
+func (s *scimService) CreateUser(ctx context.Context, resource *dtos.SCIMUser) (*dtos.SCIMUser, error) {
+	state, err := scimUserStateFromResource(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.saveUser(ctx, nil, state)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "CreateUser").
+		Str("user_id", user.ID.String()).
+		Msg("Provisioned user")
+	return s.toSCIMUser(ctx, user)
+}
+
+// ReplaceUser overwrites the attributes of a user. Omitting externalId keeps
+// the stored object ID.
+func (s *scimService) ReplaceUser(ctx context.Context, id string, resource *dtos.SCIMUser) (*dtos.SCIMUser, error) {
+	existing, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := scimUserStateFromResource(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.saveUser(ctx, &existing, state)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "ReplaceUser").
+		Str("user_id", id).
+		Msg("Replaced provisioned user")
+	return s.toSCIMUser(ctx, user)
+}
+
+// PatchUser applies add, replace and remove operations to a user.
+func (s *scimService) PatchUser(ctx context.Context, id string, patch *dtos.SCIMPatchRequest) (*dtos.SCIMUser, error) {
+	if err := checkPatchRequest(patch); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	department, err := s.departmentName(ctx, existing.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := scimUserStateFromUser(existing, department)
+	for _, op := range patch.Operations {
+		if err := applySCIMUserOperation(&state, op); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.saveUser(ctx, &existing, state)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "PatchUser").
+		Str("user_id", id).
+		Int("operations", len(patch.Operations)).
+		Msg("Patched provisioned user")
+	return s.toSCIMUser(ctx, user)
+}
+
+// DeleteUser soft-deletes a user and drops their group memberships, which
+// revokes the roles granted through them.
+func (s *scimService) DeleteUser(ctx context.Context, id string) error {
+	userID, err := parseSCIMID(id, constants.ErrUserNotFound)
+	if err != nil {
+		return err
+	}
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	if _, err := s.getUser(ctx, id); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	if _, err := qtx.DeleteScimUser(ctx, repository.DeleteScimUserParams{ID: userID, HomeTenantID: homeTenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constants.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", id).Msg("Failed to delete SCIM user in repository")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteSCIMResource, err)
+	}
+	if err := qtx.RemoveUserScimGroupMemberships(ctx, userID); err != nil {
+		log.Error().Err(err).Str("user_id", id).Msg("Failed to remove SCIM group memberships in repository")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteSCIMResource, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "SCIMService").
+		Str("method", "DeleteUser").
+		Str("user_id", id).
+		Msg("Deprovisioned user")
+	return s.syncGroupRoles(ctx, []pgtype.UUID{userID})
+}
+
+// getUser loads a SCIM-visible user of the caller's tenant.
+func (s *scimService) getUser(ctx context.Context, id string) (repository.User, error) {
+	userID, err := parseSCIMID(id, constants.ErrUserNotFound)
+	if err != nil {
+		return repository.User{}, err
+	}
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	user, err := s.repo.GetScimUser(ctx, repository.GetScimUserParams{ID: userID, HomeTenantID: homeTenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, constants.ErrUserNotFound
+		}
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+	return user, nil
+}
+
+// saveUser creates a user when existing is nil, or replaces existing, and
+// applies the active flag. Deactivation is recorded like a directory sync
+// deactivation, so only users deactivated that way are reactivated and an
+// administrator's suspension stands.
+func (s *scimService) saveUser(ctx context.Context, existing *repository.User, state scimUserState) (repository.User, error) {
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+	changedBy, err := scimChangedBy(ctx)
+	if err != nil {
+		return repository.User{}, err
+	}
+
+	var existingID pgtype.UUID
+	if existing != nil {
+		existingID = existing.ID
+	}
+
+	taken, err := s.repo.ScimUserNameExists(ctx, repository.ScimUserNameExistsParams{
+		HomeTenantID: homeTenantID,
+		UserName:     state.userName,
+		ExcludeID:    existingID,
+	})
+	if err != nil {
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+	if taken {
+		return repository.User{}, fmt.Errorf("%w: userName %s", constants.ErrSCIMUniqueness, state.userName)
+	}
+
+	objectID, err := s.scimObjectID(ctx, homeTenantID, existing, state.externalID)
+	if err != nil {
+		return repository.User{}, err
+	}
+	businessUnitID := s.scimBusinessUnitID(ctx, state.userName)
+	departmentID, err := s.scimDepartmentID(ctx, businessUnitID, state.department)
+	if err != nil {
+		return repository.User{}, err
+	}
+	managerID, err := s.scimManagerID(ctx, homeTenantID, state.managerID, existingID)
+	if err != nil {
+		return repository.User{}, err
+	}
+
+	displayName := state.displayName
+	if displayName == "" {
+		displayName = strings.TrimSpace(state.givenName + " " + state.familyName)
+	}
+	if displayName == "" {
+		displayName = state.userName
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	var user repository.User
+	if existing == nil {
+		status := repository.StatusEnumActive
+		if !state.active {
+			status = repository.StatusEnumInactive
+		}
+		user, err = qtx.CreateUser(ctx, repository.CreateUserParams{
+			AzureAdObjectID: objectID,
+			HomeTenantID:    homeTenantID,
+			DepartmentID:    departmentID,
+			BusinessUnitID:  businessUnitID,
+			ManagerID:       managerID,
+			Mail:            state.userName,
+			DisplayName:     displayName,
+			GivenName:       optionalText(state.givenName),
+			SurName:         optionalText(state.familyName),
+			JobTitle:        optionalText(state.title),
+			OfficeLocation:  optionalText(state.officeLocation),
+			Status:          repository.NullStatusEnum{StatusEnum: status, Valid: true},
+		})
+		if err == nil && !state.active {
+			err = recordSCIMStatusChange(ctx, qtx, user.ID, userStatusActionDeactivate, changedBy)
+		}
+	} else {
+		user, err = qtx.ReplaceScimUser(ctx, repository.ReplaceScimUserParams{
+			AzureAdObjectID: objectID,
+			DepartmentID:    departmentID,
+			BusinessUnitID:  businessUnitID,
+			ManagerID:       managerID,
+			Mail:            state.userName,
+			DisplayName:     displayName,
+			GivenName:       optionalText(state.givenName),
+			SurName:         optionalText(state.familyName),
+			JobTitle:        optionalText(state.title),
+			OfficeLocation:  optionalText(state.officeLocation),
+			ID:              existing.ID,
+			HomeTenantID:    homeTenantID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, constants.ErrUserNotFound
+		}
+		if err == nil {
+			user, err = setSCIMUserActive(ctx, qtx, user, state.active, changedBy)
+		}
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return repository.User{}, fmt.Errorf("%w: userName %s", constants.ErrSCIMUniqueness, state.userName)
+		}
+		log.Error().Err(err).Str("user_name", state.userName).Msg("Failed to save SCIM user in repository")
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSaveSCIMUser, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+	return user, nil
+}
+
+// setSCIMUserActive deactivates or reactivates user and records the change.
+func setSCIMUserActive(ctx context.Context, qtx *repository.Queries, user repository.User, active bool, changedBy pgtype.UUID) (repository.User, error) {
+	isActive := user.Status.Valid && user.Status.StatusEnum == repository.StatusEnumActive
+	if active == isActive {
+		return user, nil
+	}
+
+	action := userStatusActionDeactivate
+	status := repository.StatusEnumInactive
+	var changed int64
+	var err error
+	if active {
+		action = userStatusActionReactivate
+		status = repository.StatusEnumActive
+		changed, err = qtx.ReactivateDirectoryUser(ctx, user.ID)
+	} else {
+		changed, err = qtx.DeactivateDirectoryUser(ctx, user.ID)
+	}
+	if err != nil || changed == 0 {
+		return user, err
+	}
+
+	if err := recordSCIMStatusChange(ctx, qtx, user.ID, action, changedBy); err != nil {
+		return user, err
+	}
+	user.Status = repository.NullStatusEnum{StatusEnum: status, Valid: true}
+	return user, nil
+}
+
+func recordSCIMStatusChange(ctx context.Context, qtx *repository.Queries, userID pgtype.UUID, action string, changedBy pgtype.UUID) error {
+	reason := "Deactivated by SCIM provisioning"
+	if action == userStatusActionReactivate {
+		reason = "Reactivated by SCIM provisioning"
+	}
+	_, err := qtx.CreateUserStatusChange(ctx, repository.CreateUserStatusChangeParams{
+		UserID:    userID,
+		Action:    action,
+		Reason:    reason,
+		ChangedBy: changedBy,
+	})
+	return err
+}
+
+// scimChangedBy is the internal user ID of the SCIM client, recorded on status
+// changes.
+func scimChangedBy(ctx context.Context) (pgtype.UUID, error) {
+	internalUserID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+	var changedBy pgtype.UUID
+	if err := changedBy.Scan(internalUserID); err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	return changedBy, nil
+}
+
+// scimObjectID picks the object ID to store: the externalId, which must not
+// belong to another user, else the stored or a generated one.
+func (s *scimService) scimObjectID(ctx context.Context, homeTenantID pgtype.UUID, existing *repository.User, externalID string) (string, error) {
+	if externalID == "" {
+		if existing != nil {
+			return existing.AzureAdObjectID, nil
+		}
+		return constants.SCIMLocalObjectIDPrefix + uuid.NewString(), nil
+	}
+
+	other, err := s.repo.GetUserByObjectID(ctx, repository.GetUserByObjectIDParams{
+		AzureAdObjectID: externalID,
+		HomeTenantID:    homeTenantID,
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return externalID, nil
+	case err != nil:
+		return "", fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	case existing == nil || other.ID != existing.ID:
+		return "", fmt.Errorf("%w: externalId %s", constants.ErrSCIMUniqueness, externalID)
+	}
+	return externalID, nil
+}
+
+// scimDepartmentID gets or creates the named department within the user's
+// business unit. Users without a business unit get no department.
+func (s *scimService) scimDepartmentID(ctx context.Context, businessUnitID pgtype.UUID, name string) (pgtype.UUID, error) {
+	var id pgtype.UUID
+	if name == "" || !businessUnitID.Valid {
+		return id, nil
+	}
+
+	department, err := s.departments.GetOrCreateDepartmentByName(ctx, businessUnitID, name)
+	if err != nil {
+		log.Error().Err(err).Str("department_name", name).Msg("Failed to get or create department for SCIM user")
+		return id, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSaveSCIMUser, err)
+	}
+	if err := id.Scan(department.ID); err != nil {
+		return id, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidDepartmentUUIDFormat, err)
+	}
+	return id, nil
+}
+
+// scimManagerID checks that the manager is another provisioned user of the
+// tenant.
+func (s *scimService) scimManagerID(ctx context.Context, homeTenantID pgtype.UUID, managerID string, userID pgtype.UUID) (pgtype.UUID, error) {
+	var id pgtype.UUID
+	if managerID == "" {
+		return id, nil
+	}
+
+	invalid := fmt.Errorf("%w: manager %s is not a provisioned user", constants.ErrSCIMInvalidValue, managerID)
+	if err := id.Scan(managerID); err != nil {
+		return pgtype.UUID{}, invalid
+	}
+	if id == userID {
+		return pgtype.UUID{}, fmt.Errorf("%w: a user cannot be their own manager", constants.ErrSCIMInvalidValue)
+	}
+
+	if _, err := s.repo.GetScimUser(ctx, repository.GetScimUserParams{ID: id, HomeTenantID: homeTenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, invalid
+		}
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToResolveUserManager, err)
+	}
+	return id, nil
+}
+
+// scimBusinessUnitID resolves the business unit of the mail domain like the
+// first login does. Failures leave the business unit unchanged.
+func (s *scimService) scimBusinessUnitID(ctx context.Context, mail string) pgtype.UUID {
+	var id pgtype.UUID
+	domainName, isValid := utils.ExtractDomainFromEmail(mail)
+	if !isValid {
+		return id
+	}
+
+	businessUnit, err := s.businessUnits.GetOrCreateBusinessUnitByDomainName(ctx, strings.ToLower(domainName))
+	if err != nil {
+		log.Error().Err(err).Str("domain_name", domainName).Msg("Failed to get or create business unit for SCIM user")
+		return id
+	}
+	if err := id.Scan(businessUnit.ID); err != nil {
+		log.Error().Err(err).Str("business_unit_id", businessUnit.ID).Msg(constants.ErrInvalidUUIDFormat)
+	}
+	return id
+}
+
+func (s *scimService) toSCIMUser(ctx context.Context, user repository.User) (*dtos.SCIMUser, error) {
+	department, err := s.departmentName(ctx, user.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.repo.ListUserScimGroups(ctx, user.ID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to get SCIM groups of user from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSCIMGroup, err)
+	}
+
+	return dtos.NewSCIMUser(user, department, groups), nil
+}
+
+func (s *scimService) departmentName(ctx context.Context, departmentID pgtype.UUID) (string, error) {
+	if !departmentID.Valid {
+		return "", nil
+	}
+
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return "", fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	department, err := s.repo.GetDepartmentByID(ctx, repository.GetDepartmentByIDParams{ID: departmentID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetDepartment, err)
+	}
+	return department.Name, nil
+}
+
+func scimUserStateFromResource(resource *dtos.SCIMUser) (scimUserState, error) {
+	state := scimUserState{
+		externalID:     strings.TrimSpace(resource.ExternalID),
+		userName:       strings.TrimSpace(resource.UserName),
+		displayName:    resource.DisplayName,
+		title:          resource.Title,
+		officeLocation: workAddress(resource.Addresses),
+		active:         resource.Active == nil || *resource.Active,
+	}
+	if state.userName == "" {
+		return scimUserState{}, fmt.Errorf("%w: userName is required", constants.ErrSCIMInvalidValue)
+	}
+	if resource.Name != nil {
+		state.givenName = resource.Name.GivenName
+		state.familyName = resource.Name.FamilyName
+	}
+	if resource.Enterprise != nil {
+		state.department = resource.Enterprise.Department
+		if resource.Enterprise.Manager != nil {
+			state.managerID = resource.Enterprise.Manager.Value
+		}
+	}
+	return state, nil
+}
+
+func scimUserStateFromUser(user repository.User, department string) scimUserState {
+	state := scimUserState{
+		userName:       user.Mail,
+		displayName:    user.DisplayName,
+		givenName:      user.GivenName.String,
+		familyName:     user.SurName.String,
+		title:          user.JobTitle.String,
+		officeLocation: user.OfficeLocation.String,
+		department:     department,
+		active:         user.Status.Valid && user.Status.StatusEnum == repository.StatusEnumActive,
+	}
+	if !strings.HasPrefix(user.AzureAdObjectID, constants.SCIMLocalObjectIDPrefix) {
+		state.externalID = user.AzureAdObjectID
+	}
+	if user.ManagerID.Valid {
+		state.managerID = user.ManagerID.String()
+	}
+	return state
+}
+
+// applySCIMUserOperation applies one PATCH operation. Without a path the value
+// is an object of attributes to set.
+func applySCIMUserOperation(state *scimUserState, op dtos.SCIMPatchOperation) error {
+	remove := strings.EqualFold(op.Op, scimOpRemove)
+	if op.Path == "" {
+		if remove {
+			return fmt.Errorf("%w: remove requires a path", constants.ErrSCIMNoTarget)
+		}
+		object, err := scimObject(op.Value)
+		if err != nil {
+			return err
+		}
+		for attribute, value := range object {
+			if err := setSCIMUserAttribute(state, attribute, value, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return setSCIMUserAttribute(state, op.Path, op.Value, remove)
+}
+
+func setSCIMUserAttribute(state *scimUserState, path string, value json.RawMessage, remove bool) error {
+	path = trimSCIMSchema(path)
+	attribute := strings.ToLower(path)
+
+	enterprise := strings.ToLower(constants.SCIMSchemaEnterpriseUser)
+	if strings.HasPrefix(attribute, enterprise) {
+		return setSCIMEnterpriseAttribute(state, strings.TrimPrefix(attribute[len(enterprise):], ":"), value, remove)
+	}
+
+	switch attribute {
+	case "externalid":
+		return setSCIMText(&state.externalID, value, remove)
+	case "username":
+		if err := setSCIMText(&state.userName, value, remove); err != nil {
+			return err
+		}
+		if state.userName == "" {
+			return fmt.Errorf("%w: userName is required", constants.ErrSCIMInvalidValue)
+		}
+		return nil
+	case "displayname":
+		return setSCIMText(&state.displayName, value, remove)
+	case "title":
+		return setSCIMText(&state.title, value, remove)
+	case "active":
+		if remove {
+			return fmt.Errorf("%w: active cannot be removed", constants.ErrSCIMInvalidValue)
+		}
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		state.active = active
+		return nil
+	case "name":
+		if remove {
+			state.givenName, state.familyName = "", ""
+			return nil
+		}
+		object, err := scimObject(value)
+		if err != nil {
+			return err
+		}
+		for subAttribute, subValue := range object {
+			if err := setSCIMUserAttribute(state, "name."+subAttribute, subValue, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "name.givenname":
+		return setSCIMText(&state.givenName, value, remove)
+	case "name.familyname":
+		return setSCIMText(&state.familyName, value, remove)
+	case "name.formatted", "name.middlename", "name.honorificprefix", "name.honorificsuffix":
+		return nil
+	case "addresses":
+		if remove {
+			state.officeLocation = ""
+			return nil
+		}
+		var addresses []dtos.SCIMAddress
+		if err := json.Unmarshal(value, &addresses); err != nil {
+			return fmt.Errorf("%w: addresses must be a list of addresses", constants.ErrSCIMInvalidValue)
+		}
+		state.officeLocation = workAddress(addresses)
+		return nil
+	case `addresses[type eq "work"].formatted`:
+		return setSCIMText(&state.officeLocation, value, remove)
+	}
+
+	if strings.HasPrefix(attribute, "addresses[") || ignoredSCIMUserAttributes[scimAttributeBase(attribute)] {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", constants.ErrSCIMInvalidPath, path)
+}
+
+// setSCIMEnterpriseAttribute sets an attribute of the enterprise extension;
+// an empty attribute addresses the whole extension.
+func setSCIMEnterpriseAttribute(state *scimUserState, attribute string, value json.RawMessage, remove bool) error {
+	switch attribute {
+	case "":
+		if remove {
+			state.department, state.managerID = "", ""
+			return nil
+		}
+		object, err := scimObject(value)
+		if err != nil {
+			return err
+		}
+		for subAttribute, subValue := range object {
+			if err := setSCIMEnterpriseAttribute(state, strings.ToLower(subAttribute), subValue, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "department":
+		return setSCIMText(&state.department, value, remove)
+	case "manager", "manager.value":
+		return setSCIMText(&state.managerID, value, remove)
+	}
+
+	if ignoredSCIMUserAttributes[scimAttributeBase(attribute)] {
+		return nil
+	}
+	return fmt.Errorf("%w: %s:%s", constants.ErrSCIMInvalidPath, constants.SCIMSchemaEnterpriseUser, attribute)
+}
+
+func setSCIMText(dst *string, value json.RawMessage, remove bool) error {
+	if remove {
+		*dst = ""
+		return nil
+	}
+	text, err := scimString(value)
+	if err != nil {
+		return err
+	}
+	*dst = strings.TrimSpace(text)
+	return nil
+}
+
+// scimAttributeBase is the attribute name before any value filter or
+// sub-attribute, e.g. phonenumbers for phonenumbers[type eq "work"].value.
+func scimAttributeBase(attribute string) string {
+	if i := strings.IndexAny(attribute, "[."); i >= 0 {
+		return attribute[:i]
+	}
+	return attribute
+}
+
+// workAddress picks the formatted office address: the primary one, else the
+// work one, else the first.
+func workAddress(addresses []dtos.SCIMAddress) string {
+	if len(addresses) == 0 {
+		return ""
+	}
+	for _, address := range addresses {
+		if address.Primary {
+			return address.Formatted
+		}
+	}
+	for _, address := range addresses {
+		if strings.EqualFold(address.Type, "work") {
+			return address.Formatted
+		}
+	}
+	return addresses[0].Formatted
+}
+
+// scimFilterText is the value compared with key in a parsed filter, if any.
+func scimFilterText(filter map[string]string, key string) pgtype.Text {
+	value, ok := filter[key]
+	return pgtype.Text{String: value, Valid: ok}
+}
@@ -4,38 +4,93 @@ import (
 	"yet-another-itsm/internal/config"
 	"yet-another-itsm/internal/database"
 	"yet-another-itsm/internal/repository"
+
+	"github.com/rs/zerolog/log"
 )
 
 type Services struct {
-	Health         HealthService
-	Graph          *GraphService
-	BusinessUnit   BusinessUnitService
-	Department     DepartmentService
-	User           UserService
-	Role           RoleService
-	Permission     PermissionService
-	Scope          ScopeService
-	RolePermission RolePermissionService
-	RoleAssignment RoleAssignmentService
-	FormCategory   FormCategoryService
-	FormTemplate   FormTemplateService
-	FormSection    FormSectionService
+	Health               HealthService
+	Graph                *GraphService
+	BusinessUnit         BusinessUnitService
+	Department           DepartmentService
+	User                 UserService
+	Role                 RoleService
+	Permission           PermissionService
+	Scope                ScopeService
+	RolePermission       RolePermissionService
+	RoleAssignment       RoleAssignmentService
+	FormCategory         FormCategoryService
+	FormTemplate         FormTemplateService
+	FormSection          FormSectionService
+	FormField            FormFieldService
+	FormSubmission       FormSubmissionService
+	FieldType            FieldTypeService
+	Authorization        AuthorizationService
+	SodConstraint        SodConstraintService
+	AccessReview         AccessReviewService
+	DirectoryRoleMapping DirectoryRoleMappingService
+	ElevationRequest     ElevationRequestService
+	ServicePrincipal     ServicePrincipalService
+	Session              SessionService
+	OrgChart             OrgChartService
+	SCIM                 SCIMService
+	// DirectorySync is nil unless Microsoft Graph is available with app-only
+	// credentials.
+	DirectorySync DirectorySyncService
 }
 
 func NewServices(db *database.Database, repository *repository.Queries, config *config.Config) *Services {
-	return &Services{
-		Health:         NewHealthService(db),
-		Graph:          NewGraphService(&config.OAuth),
-		BusinessUnit:   NewBusinessUnitService(repository),
-		Department:     NewDepartmentService(repository),
-		User:           NewUserService(repository),
-		Role:           NewRoleService(repository),
-		Permission:     NewPermissionService(repository),
-		Scope:          NewScopeService(repository),
-		RolePermission: NewRolePermissionService(repository),
-		RoleAssignment: NewRoleAssignmentService(repository),
-		FormCategory:   NewFormCategoryService(repository),
-		FormTemplate:   NewFormTemplateService(repository),
-		FormSection:    NewFormSectionService(repository),
+	authorization := NewAuthorizationService(repository)
+	businessUnits := NewBusinessUnitService(repository)
+	departments := NewDepartmentService(db, repository)
+	directoryRoleMappings := NewDirectoryRoleMappingService(db, repository)
+
+	services := &Services{
+		Health:               NewHealthService(db),
+		Graph:                NewGraphService(&config.OAuth),
+		BusinessUnit:         businessUnits,
+		Department:           departments,
+		User:                 NewUserService(db, repository, departments, businessUnits, config.OAuth.AccountStatusCacheTTL),
+		Role:                 NewRoleService(repository),
+		Permission:           NewPermissionService(repository),
+		Scope:                NewScopeService(repository),
+		RolePermission:       NewRolePermissionService(repository),
+		RoleAssignment:       NewRoleAssignmentService(db, repository),
+		FormCategory:         NewFormCategoryService(repository),
+		FormTemplate:         NewFormTemplateService(db, repository, authorization),
+		FormSection:          NewFormSectionService(repository),
+		FormField:            NewFormFieldService(repository),
+		FormSubmission:       NewFormSubmissionService(repository, authorization),
+		FieldType:            NewFieldTypeService(repository),
+		Authorization:        authorization,
+		SodConstraint:        NewSodConstraintService(repository),
+		AccessReview:         NewAccessReviewService(db, repository),
+		DirectoryRoleMapping: directoryRoleMappings,
+		ElevationRequest:     NewElevationRequestService(db, repository),
+		ServicePrincipal:     NewServicePrincipalService(db, repository),
+		Session:              NewSessionService(repository, config),
+		OrgChart:             NewOrgChartService(repository),
+		SCIM:                 NewSCIMService(db, repository, departments, businessUnits, directoryRoleMappings),
+	}
+
+	if directoryClient := newDirectoryClient(&config.OAuth); directoryClient != nil {
+		services.DirectorySync = NewDirectorySyncService(db, repository, directoryClient, departments, businessUnits, &config.OAuth)
+	}
+
+	return services
+}
+
+// newDirectoryClient returns the Graph directory client, or nil when the
+// identity provider has no Graph or no client secret is configured.
+func newDirectoryClient(cfg *config.OAuthConfig) DirectoryClient {
+	if cfg.Provider != nil && !cfg.Provider.GraphEnabled() || cfg.ClientSecret == "" {
+		return nil
+	}
+
+	client, err := NewGraphDirectoryClient(cfg)
+	if err != nil {
+		log.Warn().Err(err).Msg("Directory sync disabled")
+		return nil
 	}
+	return client
 }
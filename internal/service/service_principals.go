@@ -0,0 +1,589 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// API keys look like itsm_<lookup><secret>: the fixed-length hex lookup part is
+// stored in clear to find the key, the whole key is stored as a SHA-256 hash.
+const (
+	apiKeyPrefix       = "itsm_"
+	apiKeyLookupBytes  = 8
+	apiKeySecretBytes  = 32
+	apiKeyLookupLength = apiKeyLookupBytes * 2
+
+	// localObjectIDPrefix marks the generated azure_ad_object_id of principals
+	// that are not backed by an Entra application.
+	localObjectIDPrefix = "sp_"
+)
+
+// ServicePrincipalService manages machine callers and their API keys. It also
+// verifies API keys for the auth middleware.
+type ServicePrincipalService interface {
+	GetServicePrincipals(ctx context.Context) ([]*dtos.ServicePrincipalResponse, error)
+	GetServicePrincipalByID(ctx context.Context, id string) (*dtos.ServicePrincipalResponse, error)
+	CreateServicePrincipal(ctx context.Context, req *dtos.CreateServicePrincipalRequest) (*dtos.ServicePrincipalResponse, error)
+	DeleteServicePrincipal(ctx context.Context, id string) (*dtos.ServicePrincipalResponse, error)
+	GetAPIKeys(ctx context.Context, servicePrincipalID string) ([]*dtos.APIKeyResponse, error)
+	CreateAPIKey(ctx context.Context, servicePrincipalID string, req *dtos.CreateAPIKeyRequest) (*dtos.APIKeySecretResponse, error)
+	RotateAPIKey(ctx context.Context, servicePrincipalID, keyID string, req *dtos.RotateAPIKeyRequest) (*dtos.APIKeySecretResponse, error)
+	RevokeAPIKey(ctx context.Context, servicePrincipalID, keyID string) (*dtos.APIKeyResponse, error)
+	VerifyAPIKey(ctx context.Context, rawKey, clientIP string) (*config.APIKeyIdentity, error)
+}
+
+type servicePrincipalService struct {
+	db   *database.Database
+	repo *repository.Queries
+}
+
+func NewServicePrincipalService(db *database.Database, repo *repository.Queries) ServicePrincipalService {
+	return &servicePrincipalService{
+		db:   db,
+		repo: repo,
+	}
+}
+
+func (s *servicePrincipalService) GetServicePrincipals(ctx context.Context) ([]*dtos.ServicePrincipalResponse, error) {
+	return s.listServicePrincipals(ctx, pgtype.UUID{})
+}
+
+func (s *servicePrincipalService) GetServicePrincipalByID(ctx context.Context, id string) (*dtos.ServicePrincipalResponse, error) {
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return s.getServicePrincipal(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+}
+
+// CreateServicePrincipal registers a machine caller together with the users row
+// its role assignments are attached to.
+func (s *servicePrincipalService) CreateServicePrincipal(ctx context.Context, req *dtos.CreateServicePrincipalRequest) (*dtos.ServicePrincipalResponse, error) {
+	log.Info().
+		Str("service", "ServicePrincipalService").
+		Str("method", "CreateServicePrincipal").
+		Str("display_name", req.DisplayName).
+		Str("app_id", req.AppID).
+		Msg("Creating service principal")
+
+	if (req.AppID == "") != (req.ObjectID == "") {
+		return nil, constants.ErrServicePrincipalEntraIDs
+	}
+
+	createdBy, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID, err := utils.GetTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	objectID := req.ObjectID
+	if objectID == "" {
+		suffix, err := randomHex(16)
+		if err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGenerateAPIKey, err)
+		}
+		objectID = localObjectIDPrefix + suffix
+	}
+
+	if _, err := s.repo.GetUserByAzureADObjectID(ctx, objectID); err == nil {
+		return nil, constants.ErrServicePrincipalAlreadyExists
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+
+	userParams := repository.CreateUserParams{
+		AzureAdObjectID: objectID,
+		DisplayName:     req.DisplayName,
+		Status:          repository.NullStatusEnum{StatusEnum: repository.StatusEnumActive, Valid: true},
+	}
+	if err := userParams.HomeTenantID.Scan(tenantID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidHomeTenantUUIDFormat, err)
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	user, err := qtx.CreateUser(ctx, userParams)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateUser, err)
+	}
+
+	sp, err := qtx.CreateServicePrincipal(ctx, repository.CreateServicePrincipalParams{
+		UserID:      user.ID,
+		DisplayName: req.DisplayName,
+		Description: pgtype.Text{String: req.Description, Valid: req.Description != ""},
+		AppID:       pgtype.Text{String: req.AppID, Valid: req.AppID != ""},
+		CreatedBy:   createdBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, constants.ErrServicePrincipalAlreadyExists
+		}
+		log.Error().Err(err).Str("display_name", req.DisplayName).Msg("Failed to create service principal in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateServicePrincipal, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "ServicePrincipalService").
+		Str("method", "CreateServicePrincipal").
+		Str("id", sp.ID.String()).
+		Str("user_id", user.ID.String()).
+		Msg("Successfully created service principal")
+	return s.getServicePrincipal(ctx, sp.ID)
+}
+
+// DeleteServicePrincipal deletes the principal, revokes its API keys and role
+// assignments and deactivates its backing user so Entra tokens stop working too.
+func (s *servicePrincipalService) DeleteServicePrincipal(ctx context.Context, id string) (*dtos.ServicePrincipalResponse, error) {
+	deletedBy, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	response, err := s.getServicePrincipal(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	sp, err := qtx.DeleteServicePrincipal(ctx, repository.DeleteServicePrincipalParams{
+		ID:           pgtype.UUID{Bytes: uuid, Valid: true},
+		HomeTenantID: homeTenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrServicePrincipalNotFound
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteServicePrincipal, err)
+	}
+
+	revoked, err := qtx.RevokeServicePrincipalAPIKeys(ctx, repository.RevokeServicePrincipalAPIKeysParams{
+		ServicePrincipalID: sp.ID,
+		RevokedBy:          deletedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeAPIKey, err)
+	}
+
+	if err := qtx.DeactivateServicePrincipalUser(ctx, sp.UserID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteServicePrincipal, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "ServicePrincipalService").
+		Str("method", "DeleteServicePrincipal").
+		Str("id", id).
+		Int64("revoked_api_keys", revoked).
+		Msg("Successfully deleted service principal")
+	response.ActiveAPIKeys = 0
+	return response, nil
+}
+
+func (s *servicePrincipalService) GetAPIKeys(ctx context.Context, servicePrincipalID string) ([]*dtos.APIKeyResponse, error) {
+	sp, err := s.GetServicePrincipalByID(ctx, servicePrincipalID)
+	if err != nil {
+		return nil, err
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	spID, _ := utils.ParseUUID(sp.ID)
+	keys, err := s.repo.ListAPIKeys(ctx, repository.ListAPIKeysParams{
+		ServicePrincipalID: pgtype.UUID{Bytes: spID, Valid: true},
+		HomeTenantID:       homeTenantID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("service_principal_id", servicePrincipalID).Msg("Failed to get API keys from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAPIKeys, err)
+	}
+
+	result := make([]*dtos.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		result[i] = dtos.NewAPIKeyResponse(key, apiKeyPrefix+key.KeyPrefix)
+	}
+
+	return result, nil
+}
+
+// CreateAPIKey issues a new key. The plaintext key is only part of this response.
+func (s *servicePrincipalService) CreateAPIKey(ctx context.Context, servicePrincipalID string, req *dtos.CreateAPIKeyRequest) (*dtos.APIKeySecretResponse, error) {
+	createdBy, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err := s.GetServicePrincipalByID(ctx, servicePrincipalID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := parseAPIKeyExpiry(req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	spID, _ := utils.ParseUUID(sp.ID)
+	response, err := s.issueAPIKey(ctx, s.repo, repository.CreateAPIKeyParams{
+		ServicePrincipalID: pgtype.UUID{Bytes: spID, Valid: true},
+		Name:               req.Name,
+		ExpiresAt:          expiresAt,
+		CreatedBy:          createdBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "ServicePrincipalService").
+		Str("method", "CreateAPIKey").
+		Str("service_principal_id", sp.ID).
+		Str("id", response.ID).
+		Str("prefix", response.Prefix).
+		Msg("Successfully created API key")
+	return response, nil
+}
+
+// RotateAPIKey issues a replacement for a key. The old key is revoked at once,
+// or keeps working for req.GracePeriodMinutes so callers can switch over.
+func (s *servicePrincipalService) RotateAPIKey(ctx context.Context, servicePrincipalID, keyID string, req *dtos.RotateAPIKeyRequest) (*dtos.APIKeySecretResponse, error) {
+	rotatedBy, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetServicePrincipalByID(ctx, servicePrincipalID); err != nil {
+		return nil, err
+	}
+
+	params, err := parseAPIKeyIDs(ctx, servicePrincipalID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	old, err := qtx.GetAPIKeyByID(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAPIKeys, err)
+	}
+	if old.RevokedAt.Valid {
+		return nil, constants.ErrAPIKeyRevoked
+	}
+
+	expiresAt, err := parseAPIKeyExpiry(req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt == "" && old.ExpiresAt.Valid && old.ExpiresAt.Time.After(time.Now()) {
+		expiresAt = old.ExpiresAt
+	}
+
+	response, err := s.issueAPIKey(ctx, qtx, repository.CreateAPIKeyParams{
+		ServicePrincipalID: old.ServicePrincipalID,
+		Name:               old.Name,
+		ExpiresAt:          expiresAt,
+		RotatedFrom:        old.ID,
+		CreatedBy:          rotatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GracePeriodMinutes > 0 {
+		graceEnd := time.Now().Add(time.Duration(req.GracePeriodMinutes) * time.Minute)
+		if err := qtx.ShortenAPIKeyExpiry(ctx, repository.ShortenAPIKeyExpiryParams{
+			ExpiresAt: pgtype.Timestamptz{Time: graceEnd, Valid: true},
+			ID:        old.ID,
+		}); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeAPIKey, err)
+		}
+	} else {
+		if _, err := qtx.RevokeAPIKey(ctx, repository.RevokeAPIKeyParams{
+			ID:                 old.ID,
+			ServicePrincipalID: old.ServicePrincipalID,
+			RevokedBy:          rotatedBy,
+			HomeTenantID:       params.HomeTenantID,
+		}); err != nil {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeAPIKey, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "ServicePrincipalService").
+		Str("method", "RotateAPIKey").
+		Str("service_principal_id", servicePrincipalID).
+		Str("rotated_from", keyID).
+		Str("id", response.ID).
+		Int32("grace_period_minutes", req.GracePeriodMinutes).
+		Msg("Successfully rotated API key")
+	return response, nil
+}
+
+func (s *servicePrincipalService) RevokeAPIKey(ctx context.Context, servicePrincipalID, keyID string) (*dtos.APIKeyResponse, error) {
+	revokedBy, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := parseAPIKeyIDs(ctx, servicePrincipalID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.repo.RevokeAPIKey(ctx, repository.RevokeAPIKeyParams{
+		ID:                 params.ID,
+		ServicePrincipalID: params.ServicePrincipalID,
+		RevokedBy:          revokedBy,
+		HomeTenantID:       params.HomeTenantID,
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeAPIKey, err)
+		}
+		if _, err := s.repo.GetAPIKeyByID(ctx, params); err == nil {
+			return nil, constants.ErrAPIKeyRevoked
+		}
+		return nil, constants.ErrAPIKeyNotFound
+	}
+
+	log.Info().
+		Str("service", "ServicePrincipalService").
+		Str("method", "RevokeAPIKey").
+		Str("service_principal_id", servicePrincipalID).
+		Str("id", keyID).
+		Msg("Successfully revoked API key")
+	return dtos.NewAPIKeyResponse(key, apiKeyPrefix+key.KeyPrefix), nil
+}
+
+// VerifyAPIKey implements config.APIKeyVerifier. Unknown, revoked and expired
+// keys all return ErrInvalidAPIKey.
+func (s *servicePrincipalService) VerifyAPIKey(ctx context.Context, rawKey, clientIP string) (*config.APIKeyIdentity, error) {
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) || len(rawKey) <= len(apiKeyPrefix)+apiKeyLookupLength {
+		return nil, constants.ErrInvalidAPIKey
+	}
+	lookup := rawKey[len(apiKeyPrefix) : len(apiKeyPrefix)+apiKeyLookupLength]
+
+	key, err := s.repo.GetActiveAPIKeyByPrefix(ctx, lookup)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToVerifyAPIKey, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(rawKey)), []byte(key.KeyHash)) != 1 {
+		return nil, constants.ErrInvalidAPIKey
+	}
+
+	if err := s.repo.TouchAPIKeyLastUsed(ctx, repository.TouchAPIKeyLastUsedParams{
+		ID:         key.ID,
+		LastUsedIp: pgtype.Text{String: clientIP, Valid: clientIP != ""},
+	}); err != nil {
+		log.Warn().Err(err).Str("api_key_id", key.ID.String()).Msg("Failed to record API key usage")
+	}
+
+	return &config.APIKeyIdentity{
+		TenantID:    key.HomeTenantID.String(),
+		ObjectID:    key.AzureAdObjectID,
+		DisplayName: key.DisplayName,
+	}, nil
+}
+
+// issueAPIKey generates a key, stores its hash with params and returns the
+// plaintext alongside the stored key.
+func (s *servicePrincipalService) issueAPIKey(ctx context.Context, q *repository.Queries, params repository.CreateAPIKeyParams) (*dtos.APIKeySecretResponse, error) {
+	lookup, err := randomHex(apiKeyLookupBytes)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGenerateAPIKey, err)
+	}
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGenerateAPIKey, err)
+	}
+	rawKey := apiKeyPrefix + lookup + base64.RawURLEncoding.EncodeToString(secret)
+
+	params.KeyPrefix = lookup
+	params.KeyHash = hashAPIKey(rawKey)
+
+	key, err := q.CreateAPIKey(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Str("service_principal_id", params.ServicePrincipalID.String()).Msg("Failed to create API key in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateAPIKey, err)
+	}
+
+	return &dtos.APIKeySecretResponse{
+		APIKeyResponse: *dtos.NewAPIKeyResponse(key, apiKeyPrefix+lookup),
+		Key:            rawKey,
+	}, nil
+}
+
+func (s *servicePrincipalService) getServicePrincipal(ctx context.Context, id pgtype.UUID) (*dtos.ServicePrincipalResponse, error) {
+	principals, err := s.listServicePrincipals(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(principals) == 0 {
+		return nil, constants.ErrServicePrincipalNotFound
+	}
+
+	return principals[0], nil
+}
+
+// listServicePrincipals lists the principals of the caller's tenant, or the one
+// with id when it is set.
+func (s *servicePrincipalService) listServicePrincipals(ctx context.Context, id pgtype.UUID) ([]*dtos.ServicePrincipalResponse, error) {
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	rows, err := s.repo.ListServicePrincipals(ctx, repository.ListServicePrincipalsParams{
+		HomeTenantID: homeTenantID,
+		ID:           id,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get service principals from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetServicePrincipals, err)
+	}
+
+	result := make([]*dtos.ServicePrincipalResponse, len(rows))
+	for i, row := range rows {
+		result[i] = dtos.NewServicePrincipalResponse(row)
+	}
+
+	return result, nil
+}
+
+// getCurrentUserID returns the internal ID of the authenticated caller.
+func (s *servicePrincipalService) getCurrentUserID(ctx context.Context) (pgtype.UUID, error) {
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+
+	uuid, err := utils.ParseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return pgtype.UUID{Bytes: uuid, Valid: true}, nil
+}
+
+// parseAPIKeyIDs builds the lookup of a key of a principal in the caller's tenant.
+func parseAPIKeyIDs(ctx context.Context, servicePrincipalID, keyID string) (repository.GetAPIKeyByIDParams, error) {
+	spUUID, err := utils.ParseUUID(servicePrincipalID)
+	if err != nil {
+		return repository.GetAPIKeyByIDParams{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	keyUUID, err := utils.ParseUUID(keyID)
+	if err != nil {
+		return repository.GetAPIKeyByIDParams{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return repository.GetAPIKeyByIDParams{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	return repository.GetAPIKeyByIDParams{
+		ID:                 pgtype.UUID{Bytes: keyUUID, Valid: true},
+		ServicePrincipalID: pgtype.UUID{Bytes: spUUID, Valid: true},
+		HomeTenantID:       homeTenantID,
+	}, nil
+}
+
+// parseAPIKeyExpiry parses an optional RFC 3339 expiry, which must be in the future.
+func parseAPIKeyExpiry(value string) (pgtype.Timestamptz, error) {
+	if value == "" {
+		return pgtype.Timestamptz{}, nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil || !expiresAt.After(time.Now()) {
+		return pgtype.Timestamptz{}, constants.ErrInvalidAPIKeyExpiresAt
+	}
+
+	return pgtype.Timestamptz{Time: expiresAt, Valid: true}, nil
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/oauth2"
+
+	"yet-another-itsm/internal/config"
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// loginStateLifetime bounds the time between /login and /callback.
+	loginStateLifetime = 10 * time.Minute
+	// accessTokenRefreshMargin refreshes session access tokens shortly before
+	// they expire, so a request never carries a token that expires in flight.
+	accessTokenRefreshMargin = 2 * time.Minute
+	csrfTokenBytes           = 32
+)
+
+// SessionService runs the backend-for-frontend login flow: the browser is sent
+// to Entra with PKCE, the callback stores the tokens server-side and the
+// browser only receives an encrypted session cookie. It also resolves session
+// cookies for the auth middleware.
+type SessionService interface {
+	BeginLogin(ctx context.Context, returnTo string) (*dtos.LoginRedirect, error)
+	CompleteLogin(ctx context.Context, req *dtos.CompleteLoginRequest) (*dtos.LoginSession, error)
+	Logout(ctx context.Context, sessionCookie, csrfToken string) (*dtos.LogoutResponse, error)
+	ResolveSession(ctx context.Context, cookieValue string) (*config.SessionIdentity, error)
+	DeleteStaleSessions(ctx context.Context) (int, error)
+}
+
+type sessionService struct {
+	repo    *repository.Queries
+	oauth   *config.OAuthConfig
+	session config.SessionConfig
+	// aead is nil while the login flow is not configured.
+	aead cipher.AEAD
+}
+
+// loginState travels in the login cookie between /login and /callback.
+type loginState struct {
+	State        string    `json:"state"`
+	CodeVerifier string    `json:"code_verifier"`
+	ReturnTo     string    `json:"return_to"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func NewSessionService(repo *repository.Queries, cfg *config.Config) SessionService {
+	s := &sessionService{
+		repo:    repo,
+		oauth:   &cfg.OAuth,
+		session: cfg.Session,
+	}
+
+	if len(cfg.Session.EncryptionKey) > 0 && cfg.OAuth.EntraConfig != nil {
+		block, err := aes.NewCipher(cfg.Session.EncryptionKey)
+		if err == nil {
+			s.aead, err = cipher.NewGCM(block)
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to initialize session encryption, browser login disabled")
+			s.aead = nil
+		}
+	}
+
+	return s
+}
+
+// BeginLogin returns the Entra authorization URL and the encrypted login
+// cookie holding the state and PKCE verifier.
+func (s *sessionService) BeginLogin(ctx context.Context, returnTo string) (*dtos.LoginRedirect, error) {
+	if s.aead == nil {
+		return nil, constants.ErrLoginNotConfigured
+	}
+
+	if !isLocalPath(returnTo) {
+		returnTo = ""
+	}
+
+	codeVerifier, codeChallenge := config.GenPKCE()
+	state := loginState{
+		State:        config.GenerateRandomState(),
+		CodeVerifier: codeVerifier,
+		ReturnTo:     returnTo,
+		ExpiresAt:    time.Now().Add(loginStateLifetime),
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginLogin, err)
+	}
+	loginCookie, err := s.seal(payload)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginLogin, err)
+	}
+
+	authURL := s.oauth.EntraConfig.AuthCodeURL(state.State,
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+
+	return &dtos.LoginRedirect{
+		AuthURL:     authURL,
+		LoginCookie: loginCookie,
+		MaxAge:      int(loginStateLifetime.Seconds()),
+	}, nil
+}
+
+// CompleteLogin validates the state against the login cookie, redeems the
+// authorization code and starts a session.
+func (s *sessionService) CompleteLogin(ctx context.Context, req *dtos.CompleteLoginRequest) (*dtos.LoginSession, error) {
+	if s.aead == nil {
+		return nil, constants.ErrLoginNotConfigured
+	}
+
+	payload, err := s.open(req.LoginCookie)
+	if err != nil {
+		return nil, constants.ErrInvalidLoginState
+	}
+	var state loginState
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return nil, constants.ErrInvalidLoginState
+	}
+	if time.Now().After(state.ExpiresAt) || subtle.ConstantTimeCompare([]byte(state.State), []byte(req.State)) != 1 {
+		return nil, constants.ErrInvalidLoginState
+	}
+
+	token, err := s.oauth.EntraConfig.Exchange(ctx, req.Code, oauth2.SetAuthURLParam("code_verifier", state.CodeVerifier))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to redeem authorization code")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToExchangeCode, err)
+	}
+
+	// The token comes straight from the token endpoint; AuthMiddleWare
+	// verifies it again on every request.
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token.AccessToken, claims); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToExchangeCode, err)
+	}
+	objectID, _ := claims["oid"].(string)
+	tenantID, _ := claims["tid"].(string)
+	if objectID == "" || !s.oauth.Provider.IsTenantAllowed(tenantID) {
+		return nil, constants.ErrLoginTenantNotAllowed
+	}
+
+	accessToken, refreshToken, err := s.sealTokens(token)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateSession, err)
+	}
+
+	csrfToken, err := randomHex(csrfTokenBytes)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateSession, err)
+	}
+
+	expiresAt := time.Now().Add(s.session.Lifetime)
+	session, err := s.repo.CreateUserSession(ctx, repository.CreateUserSessionParams{
+		ObjectID:             objectID,
+		TenantID:             tenantID,
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: pgtype.Timestamptz{Time: token.Expiry, Valid: true},
+		RefreshToken:         refreshToken,
+		CsrfToken:            csrfToken,
+		IpAddress:            pgtype.Text{String: req.IPAddress, Valid: req.IPAddress != ""},
+		UserAgent:            pgtype.Text{String: req.UserAgent, Valid: req.UserAgent != ""},
+		ExpiresAt:            pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("object_id", objectID).Msg("Failed to create session in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateSession, err)
+	}
+
+	sessionCookie, err := s.seal(session.ID.Bytes[:])
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateSession, err)
+	}
+
+	log.Info().
+		Str("session_id", session.ID.String()).
+		Str("object_id", objectID).
+		Str("tenant_id", tenantID).
+		Msg("Started browser session")
+
+	returnTo := state.ReturnTo
+	if returnTo == "" {
+		returnTo = s.session.PostLoginRedirect
+	}
+
+	return &dtos.LoginSession{
+		SessionCookie: sessionCookie,
+		CSRFToken:     csrfToken,
+		MaxAge:        int(s.session.Lifetime.Seconds()),
+		ReturnTo:      returnTo,
+	}, nil
+}
+
+// Logout revokes the session after checking its CSRF token and returns the
+// Entra logout URL that ends the single sign-on session as well.
+func (s *sessionService) Logout(ctx context.Context, sessionCookie, csrfToken string) (*dtos.LogoutResponse, error) {
+	session, err := s.getSession(ctx, sessionCookie)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(session.CsrfToken), []byte(csrfToken)) != 1 {
+		return nil, constants.ErrInvalidCSRFToken
+	}
+
+	if err := s.repo.RevokeUserSession(ctx, session.ID); err != nil {
+		log.Error().Err(err).Str("session_id", session.ID.String()).Msg("Failed to revoke session in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeSession, err)
+	}
+
+	log.Info().
+		Str("session_id", session.ID.String()).
+		Str("object_id", session.ObjectID).
+		Msg("Ended browser session")
+
+	response := &dtos.LogoutResponse{}
+	if s.oauth.LogoutURL != "" {
+		response.LogoutURL = s.oauth.LogoutURL + "?post_logout_redirect_uri=" + url.QueryEscape(s.session.PostLoginRedirect)
+	}
+	return response, nil
+}
+
+// ResolveSession returns the access token of an active session, redeeming the
+// refresh token first when the access token is about to expire. A session
+// whose refresh fails is revoked.
+func (s *sessionService) ResolveSession(ctx context.Context, cookieValue string) (*config.SessionIdentity, error) {
+	session, err := s.getSession(ctx, cookieValue)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.decrypt(session.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSession, err)
+	}
+
+	if time.Until(session.AccessTokenExpiresAt.Time) < accessTokenRefreshMargin {
+		accessToken, err = s.refresh(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &config.SessionIdentity{
+		AccessToken: string(accessToken),
+		CSRFToken:   session.CsrfToken,
+	}, nil
+}
+
+// DeleteStaleSessions removes sessions that expired or were revoked more than
+// a day ago.
+func (s *sessionService) DeleteStaleSessions(ctx context.Context) (int, error) {
+	count, err := s.repo.DeleteStaleUserSessions(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete stale sessions in repository")
+		return 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteSessions, err)
+	}
+	return int(count), nil
+}
+
+func (s *sessionService) getSession(ctx context.Context, cookieValue string) (repository.UserSession, error) {
+	if s.aead == nil {
+		return repository.UserSession{}, constants.ErrLoginNotConfigured
+	}
+
+	id, err := s.open(cookieValue)
+	if err != nil || len(id) != 16 {
+		return repository.UserSession{}, constants.ErrSessionNotFound
+	}
+
+	session, err := s.repo.GetActiveUserSession(ctx, pgtype.UUID{Bytes: [16]byte(id), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.UserSession{}, constants.ErrSessionNotFound
+		}
+		return repository.UserSession{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSession, err)
+	}
+	return session, nil
+}
+
+func (s *sessionService) refresh(ctx context.Context, session repository.UserSession) ([]byte, error) {
+	if len(session.RefreshToken) == 0 {
+		return nil, s.endSession(ctx, session, nil)
+	}
+
+	refreshToken, err := s.decrypt(session.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSession, err)
+	}
+
+	token, err := s.oauth.EntraConfig.TokenSource(ctx, &oauth2.Token{RefreshToken: string(refreshToken)}).Token()
+	if err != nil {
+		return nil, s.endSession(ctx, session, err)
+	}
+
+	accessToken, newRefreshToken, err := s.sealTokens(token)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRefreshSession, err)
+	}
+
+	if err := s.repo.UpdateUserSessionTokens(ctx, repository.UpdateUserSessionTokensParams{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: pgtype.Timestamptz{Time: token.Expiry, Valid: true},
+		RefreshToken:         newRefreshToken,
+		ID:                   session.ID,
+	}); err != nil {
+		log.Error().Err(err).Str("session_id", session.ID.String()).Msg("Failed to store refreshed tokens in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRefreshSession, err)
+	}
+
+	return []byte(token.AccessToken), nil
+}
+
+// endSession revokes a session that can no longer obtain access tokens.
+func (s *sessionService) endSession(ctx context.Context, session repository.UserSession, cause error) error {
+	log.Warn().Err(cause).
+		Str("session_id", session.ID.String()).
+		Str("object_id", session.ObjectID).
+		Msg("Session access token could not be refreshed, ending session")
+
+	if err := s.repo.RevokeUserSession(ctx, session.ID); err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToRevokeSession, err)
+	}
+	return constants.ErrSessionNotFound
+}
+
+// sealTokens encrypts the access token and, when present, the refresh token.
+func (s *sessionService) sealTokens(token *oauth2.Token) ([]byte, []byte, error) {
+	accessToken, err := s.encrypt([]byte(token.AccessToken))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var refreshToken []byte
+	if token.RefreshToken != "" {
+		if refreshToken, err = s.encrypt([]byte(token.RefreshToken)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// encrypt seals plaintext with AES-GCM, prefixing the random nonce.
+func (s *sessionService) encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (s *sessionService) decrypt(sealed []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("sealed value too short")
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	return s.aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// seal encrypts a cookie value.
+func (s *sessionService) seal(plaintext []byte) (string, error) {
+	sealed, err := s.encrypt(plaintext)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts a cookie value.
+func (s *sessionService) open(value string) ([]byte, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(sealed)
+}
+
+// isLocalPath accepts only same-origin paths as post-login targets, so /login
+// cannot be used as an open redirect.
+func isLocalPath(path string) bool {
+	return strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "//") && !strings.HasPrefix(path, "/\\")
+}
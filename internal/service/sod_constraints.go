@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pgUniqueViolation is the Postgres SQLSTATE for unique_violation.
+const pgUniqueViolation = "23505"
+
+// SodConstraintService manages separation-of-duties constraints: pairs of roles
+// that must never be held by the same user.
+type SodConstraintService interface {
+	GetSodConstraints(ctx context.Context) ([]*dtos.SodConstraint, error)
+	GetSodConstraintByID(ctx context.Context, id string) (*dtos.SodConstraint, error)
+	CreateSodConstraint(ctx context.Context, req *dtos.CreateSodConstraintRequest) (*dtos.SodConstraint, error)
+	DeleteSodConstraint(ctx context.Context, id string) (*dtos.SodConstraint, error)
+	GetSodViolations(ctx context.Context) ([]dtos.SodViolation, error)
+}
+
+type sodConstraintService struct {
+	repo *repository.Queries
+}
+
+func NewSodConstraintService(repo *repository.Queries) SodConstraintService {
+	return &sodConstraintService{
+		repo: repo,
+	}
+}
+
+func (s *sodConstraintService) GetSodConstraints(ctx context.Context) ([]*dtos.SodConstraint, error) {
+	log.Info().
+		Str("service", "SodConstraintService").
+		Str("method", "GetSodConstraints").
+		Msg("Getting separation-of-duties constraints")
+
+	constraints, err := s.repo.ListSodConstraints(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get separation-of-duties constraints from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSodConstraints, err)
+	}
+
+	result := make([]*dtos.SodConstraint, len(constraints))
+	for i, constraint := range constraints {
+		result[i] = (&dtos.SodConstraint{}).FromRepositoryModel(constraint)
+	}
+
+	return result, nil
+}
+
+func (s *sodConstraintService) GetSodConstraintByID(ctx context.Context, id string) (*dtos.SodConstraint, error) {
+	log.Info().
+		Str("service", "SodConstraintService").
+		Str("method", "GetSodConstraintByID").
+		Str("id", id).
+		Msg("Getting separation-of-duties constraint by ID")
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	constraint, err := s.repo.GetSodConstraintByID(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrSodConstraintNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to get separation-of-duties constraint from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSodConstraint, err)
+	}
+
+	return (&dtos.SodConstraint{}).FromRepositoryModel(constraint), nil
+}
+
+func (s *sodConstraintService) CreateSodConstraint(ctx context.Context, req *dtos.CreateSodConstraintRequest) (*dtos.SodConstraint, error) {
+	log.Info().
+		Str("service", "SodConstraintService").
+		Str("method", "CreateSodConstraint").
+		Str("role_a_id", req.RoleAID).
+		Str("role_b_id", req.RoleBID).
+		Msg("Creating separation-of-duties constraint")
+
+	params := repository.CreateSodConstraintParams{
+		Name:    req.Name,
+		RoleAID: req.RoleAID,
+		RoleBID: req.RoleBID,
+	}
+	if req.Description != "" {
+		params.Description = pgtype.Text{String: req.Description, Valid: true}
+	}
+
+	userID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+	if err := params.CreatedBy.Scan(userID); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	tenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	for _, roleID := range []string{req.RoleAID, req.RoleBID} {
+		role, err := s.repo.GetRoleByID(ctx, repository.GetRoleByIDParams{ID: roleID, TenantID: tenantID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("%w: %s", constants.ErrRoleNotFound, roleID)
+			}
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetRole, err)
+		}
+		if role.DeletedAt.Valid {
+			return nil, fmt.Errorf("%w: %s", constants.ErrRoleNotFound, roleID)
+		}
+	}
+
+	constraint, err := s.repo.CreateSodConstraint(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, constants.ErrSodConstraintAlreadyExists
+		}
+		log.Error().Err(err).Interface("params", params).Msg("Failed to create separation-of-duties constraint in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateSodConstraint, err)
+	}
+
+	log.Info().
+		Str("service", "SodConstraintService").
+		Str("method", "CreateSodConstraint").
+		Str("id", constraint.ID.String()).
+		Msg("Successfully created separation-of-duties constraint")
+	return (&dtos.SodConstraint{}).FromRepositoryModel(constraint), nil
+}
+
+func (s *sodConstraintService) DeleteSodConstraint(ctx context.Context, id string) (*dtos.SodConstraint, error) {
+	log.Info().
+		Str("service", "SodConstraintService").
+		Str("method", "DeleteSodConstraint").
+		Str("id", id).
+		Msg("Deleting separation-of-duties constraint")
+
+	uuid, err := utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	constraint, err := s.repo.DeleteSodConstraint(ctx, pgtype.UUID{Bytes: uuid, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, constants.ErrSodConstraintNotFound
+		}
+		log.Error().Err(err).Str("id", id).Msg("Failed to delete separation-of-duties constraint in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToDeleteSodConstraint, err)
+	}
+
+	return (&dtos.SodConstraint{}).FromRepositoryModel(constraint), nil
+}
+
+// GetSodViolations reports users whose current assignments, including inherited
+// roles, already hold both roles of an active constraint.
+func (s *sodConstraintService) GetSodViolations(ctx context.Context) ([]dtos.SodViolation, error) {
+	log.Info().
+		Str("service", "SodConstraintService").
+		Str("method", "GetSodViolations").
+		Msg("Getting separation-of-duties violations")
+
+	rows, err := s.repo.GetSodViolations(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get separation-of-duties violations from repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSodViolations, err)
+	}
+
+	result := make([]dtos.SodViolation, len(rows))
+	for i, row := range rows {
+		result[i] = dtos.SodViolation{
+			ConstraintID:      row.ConstraintID.String(),
+			ConstraintName:    row.ConstraintName,
+			RoleAID:           row.RoleAID,
+			RoleBID:           row.RoleBID,
+			UserID:            row.UserID.String(),
+			DisplayName:       row.DisplayName,
+			Mail:              row.Mail,
+			RoleAAssignmentID: row.RoleAAssignmentID.String(),
+			RoleBAssignmentID: row.RoleBAssignmentID.String(),
+		}
+	}
+
+	return result, nil
+}
+
+// checkSodConflicts rejects giving roleID to the assignee when it would pair with a
+// role they already hold under an active constraint. q must be bound to the
+// transaction that writes the assignment: the assignee is locked until it ends, so
+// concurrent grants to them are checked one after another and earlier assignments
+// in the same batch are taken into account.
+func checkSodConflicts(ctx context.Context, q *repository.Queries, assigneeID pgtype.UUID, roleID string) error {
+	if err := q.LockSodAssignee(ctx, assigneeID); err != nil {
+		log.Error().Err(err).Str("assignee_id", assigneeID.String()).Msg("Failed to lock assignee for separation-of-duties check")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSodConflicts, err)
+	}
+
+	conflicts, err := q.GetSodConflicts(ctx, repository.GetSodConflictsParams{
+		AssigneeID: assigneeID,
+		RoleID:     roleID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("assignee_id", assigneeID.String()).Str("role_id", roleID).Msg("Failed to check separation-of-duties conflicts")
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetSodConflicts, err)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		names[i] = fmt.Sprintf("%s (%s / %s)", conflict.Name, conflict.RoleAID, conflict.RoleBID)
+	}
+	return fmt.Errorf("%w: %s", constants.ErrSodConstraintViolation, strings.Join(names, ", "))
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// userProfileChange is one field of a stored user that differs from the
+// profile reported at login.
+type userProfileChange struct {
+	field    string
+	oldValue pgtype.Text
+	newValue pgtype.Text
+}
+
+// SyncUserProfile creates the caller's user from their identity provider
+// profile, or updates the stored fields that changed since the last login and
+// records each change. Department, business unit and manager are resolved to
+// internal IDs; those that cannot be resolved keep their stored value.
+func (s *userService) SyncUserProfile(ctx context.Context, profile *dtos.UserProfile) (*dtos.User, error) {
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	existing, err := s.repo.GetUserByObjectID(ctx, repository.GetUserByObjectIDParams{
+		AzureAdObjectID: profile.ObjectID,
+		HomeTenantID:    homeTenantID,
+	})
+	found := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+
+	businessUnitID := s.resolveProfileBusinessUnit(ctx, profile.Mail, existing.BusinessUnitID)
+	departmentID := s.resolveProfileDepartment(ctx, profile.Department, businessUnitID, existing.DepartmentID)
+	managerID, err := s.resolveProfileManager(ctx, profile.ManagerObjectID, homeTenantID, existing.ManagerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		user, err := s.repo.CreateUser(ctx, repository.CreateUserParams{
+			AzureAdObjectID: profile.ObjectID,
+			HomeTenantID:    homeTenantID,
+			DepartmentID:    departmentID,
+			BusinessUnitID:  businessUnitID,
+			ManagerID:       managerID,
+			Mail:            profile.Mail,
+			DisplayName:     profile.DisplayName,
+			GivenName:       optionalText(profile.GivenName),
+			SurName:         optionalText(profile.Surname),
+			JobTitle:        optionalText(profile.JobTitle),
+			OfficeLocation:  optionalText(profile.OfficeLocation),
+			Status:          repository.NullStatusEnum{StatusEnum: repository.StatusEnumActive, Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Str("azure_ad_object_id", profile.ObjectID).Msg("Failed to create user in repository")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCreateUser, err)
+		}
+
+		log.Info().
+			Str("service", "UserService").
+			Str("method", "SyncUserProfile").
+			Str("user_id", user.ID.String()).
+			Msg("Created user from login profile")
+		return (&dtos.User{}).FromRepositoryModel(user), nil
+	}
+
+	params := repository.UpdateUserProfileParams{
+		DepartmentID:   departmentID,
+		BusinessUnitID: businessUnitID,
+		ManagerID:      managerID,
+		Mail:           profile.Mail,
+		DisplayName:    profile.DisplayName,
+		GivenName:      optionalText(profile.GivenName),
+		SurName:        optionalText(profile.Surname),
+		JobTitle:       optionalText(profile.JobTitle),
+		OfficeLocation: optionalText(profile.OfficeLocation),
+		ID:             existing.ID,
+		HomeTenantID:   homeTenantID,
+	}
+	changes := diffUserProfile(existing, params)
+	if len(changes) == 0 {
+		return (&dtos.User{}).FromRepositoryModel(existing), nil
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	user, err := qtx.UpdateUserProfile(ctx, params)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", existing.ID.String()).Msg("Failed to update user profile in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncUserProfile, err)
+	}
+
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		if err := qtx.CreateUserProfileChange(ctx, repository.CreateUserProfileChangeParams{
+			UserID:   user.ID,
+			Field:    change.field,
+			OldValue: change.oldValue,
+			NewValue: change.newValue,
+		}); err != nil {
+			log.Error().Err(err).Str("field", change.field).Msg("Failed to record user profile change in repository")
+			return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSyncUserProfile, err)
+		}
+		fields[i] = change.field
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	log.Info().
+		Str("service", "UserService").
+		Str("method", "SyncUserProfile").
+		Str("user_id", user.ID.String()).
+		Strs("fields", fields).
+		Msg("Updated user profile from login")
+	return (&dtos.User{}).FromRepositoryModel(user), nil
+}
+
+// GetUserProfileChanges lists the profile fields refreshed at login for a user
+// of the caller's tenant, newest first, one page at a time.
+func (s *userService) GetUserProfileChanges(ctx context.Context, id string, page dtos.PageRequest) ([]*dtos.UserProfileChangeResponse, int64, error) {
+	userID, homeTenantID, err := parseUserInTenant(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := s.repo.GetUserByID(ctx, repository.GetUserByIDParams{ID: userID, HomeTenantID: homeTenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, constants.ErrUserNotFound
+		}
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+
+	total, err := s.repo.CountUserProfileChanges(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to count user profile changes in repository")
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetProfileChanges, err)
+	}
+
+	changes, err := s.repo.ListUserProfileChanges(ctx, repository.ListUserProfileChangesParams{
+		UserID:     userID,
+		PageLimit:  page.Limit(),
+		PageOffset: page.Offset(),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get user profile changes from repository")
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetProfileChanges, err)
+	}
+
+	responses := make([]*dtos.UserProfileChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = dtos.NewUserProfileChangeResponse(change)
+	}
+	return responses, total, nil
+}
+
+// resolveProfileDepartment gets or creates the department named in the
+// profile within the user's business unit. An empty name clears the
+// department; without a business unit the current department is kept.
+func (s *userService) resolveProfileDepartment(ctx context.Context, name string, businessUnitID, current pgtype.UUID) pgtype.UUID {
+	if name == "" {
+		return pgtype.UUID{}
+	}
+	if !businessUnitID.Valid {
+		return current
+	}
+
+	department, err := s.departments.GetOrCreateDepartmentByName(ctx, businessUnitID, name)
+	if err != nil {
+		log.Error().Err(err).Str("department_name", name).Msg("Failed to get or create department")
+		return current
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(department.ID); err != nil {
+		log.Error().Err(err).Str("department_id", department.ID).Msg(constants.ErrInvalidDepartmentUUIDFormat)
+		return current
+	}
+	return id
+}
+
+// resolveProfileBusinessUnit gets or creates the business unit of the mail
+// domain.
+func (s *userService) resolveProfileBusinessUnit(ctx context.Context, mail string, current pgtype.UUID) pgtype.UUID {
+	domainName, isValid := utils.ExtractDomainFromEmail(mail)
+	if !isValid {
+		return current
+	}
+
+	businessUnit, err := s.businessUnits.GetOrCreateBusinessUnitByDomainName(ctx, strings.ToLower(domainName))
+	if err != nil {
+		log.Error().Err(err).Str("domain_name", domainName).Msg("Failed to get or create business unit")
+		return current
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(businessUnit.ID); err != nil {
+		log.Error().Err(err).Str("business_unit_id", businessUnit.ID).Msg(constants.ErrInvalidUUIDFormat)
+		return current
+	}
+	return id
+}
+
+// resolveProfileManager maps the manager's object ID to their internal user
+// ID. The stored manager is kept when the profile does not tell the manager or
+// the manager has not been provisioned yet.
+func (s *userService) resolveProfileManager(ctx context.Context, managerObjectID *string, homeTenantID, current pgtype.UUID) (pgtype.UUID, error) {
+	if managerObjectID == nil {
+		return current, nil
+	}
+	if *managerObjectID == "" {
+		return pgtype.UUID{}, nil
+	}
+
+	manager, err := s.repo.GetUserByObjectID(ctx, repository.GetUserByObjectIDParams{
+		AzureAdObjectID: *managerObjectID,
+		HomeTenantID:    homeTenantID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Debug().Str("manager_object_id", *managerObjectID).Msg("Manager is not provisioned yet")
+		return current, nil
+	}
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToResolveUserManager, err)
+	}
+	return manager.ID, nil
+}
+
+// diffUserProfile lists the fields of user that params changes.
+func diffUserProfile(user repository.User, params repository.UpdateUserProfileParams) []userProfileChange {
+	var changes []userProfileChange
+	addText := func(field string, oldValue, newValue pgtype.Text) {
+		if oldValue.Valid != newValue.Valid || oldValue.String != newValue.String {
+			changes = append(changes, userProfileChange{field: field, oldValue: oldValue, newValue: newValue})
+		}
+	}
+	addUUID := func(field string, oldValue, newValue pgtype.UUID) {
+		if oldValue != newValue {
+			changes = append(changes, userProfileChange{field: field, oldValue: uuidText(oldValue), newValue: uuidText(newValue)})
+		}
+	}
+
+	addText("mail", pgtype.Text{String: user.Mail, Valid: true}, pgtype.Text{String: params.Mail, Valid: true})
+	addText("display_name", pgtype.Text{String: user.DisplayName, Valid: true}, pgtype.Text{String: params.DisplayName, Valid: true})
+	addText("given_name", user.GivenName, params.GivenName)
+	addText("sur_name", user.SurName, params.SurName)
+	addText("job_title", user.JobTitle, params.JobTitle)
+	addText("office_location", user.OfficeLocation, params.OfficeLocation)
+	addUUID("department_id", user.DepartmentID, params.DepartmentID)
+	addUUID("business_unit_id", user.BusinessUnitID, params.BusinessUnitID)
+	addUUID("manager_id", user.ManagerID, params.ManagerID)
+	return changes
+}
+
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func uuidText(value pgtype.UUID) pgtype.Text {
+	return pgtype.Text{String: value.String(), Valid: value.Valid}
+}
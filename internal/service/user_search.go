@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Page sizes of GET /v1/users.
+const (
+	defaultUserPageLimit = 20
+	maxUserPageLimit     = 100
+)
+
+// userSortFields are the sort keys SearchUsers understands.
+var userSortFields = map[string]bool{
+	"display_name": true,
+	"mail":         true,
+	"created_at":   true,
+	"last_login":   true,
+}
+
+// neverLoggedIn is the cursor key of a user without a last login; the
+// last_login queries sort those users as -infinity.
+const neverLoggedIn = "-infinity"
+
+// userCursor is the position after the last user of a page. Key is the sort
+// value of that user: the display name or mail as stored, or a timestamp in
+// RFC 3339. It carries the sort it was issued for so it cannot be replayed
+// against another order.
+type userCursor struct {
+	Sort string `json:"s"`
+	Key  string `json:"k"`
+	ID   string `json:"id"`
+}
+
+// SearchUsers returns one page of the caller's tenant's users matching the
+// search and filters, ordered by the requested sort and then by ID.
+func (s *userService) SearchUsers(ctx context.Context, query dtos.UserSearchQuery) (*dtos.UserSearchResult, error) {
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	sortField, descending, err := parseUserSort(query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	sort := sortField
+	if descending {
+		sort = "-" + sortField
+	}
+
+	limit, err := parseUserPageLimit(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.SearchUsersByDisplayNameParams{
+		HomeTenantID: homeTenantID,
+		// One extra row tells whether there is a next page.
+		PageLimit: limit + 1,
+	}
+	if search := buildUserSearchQuery(query.Q); search != "" {
+		params.Search = pgtype.Text{String: search, Valid: true}
+	}
+	if err := scanOptionalUUID(&params.DepartmentID, query.DepartmentID); err != nil {
+		return nil, err
+	}
+	if err := scanOptionalUUID(&params.BusinessUnitID, query.BusinessUnitID); err != nil {
+		return nil, err
+	}
+	if err := scanOptionalUUID(&params.ManagerID, query.ManagerID); err != nil {
+		return nil, err
+	}
+	if query.Status != "" {
+		status := repository.StatusEnum(query.Status)
+		if status != repository.StatusEnumActive && status != repository.StatusEnumInactive && status != repository.StatusEnumDeleted {
+			return nil, constants.ErrInvalidUserStatus
+		}
+		params.Status = repository.NullStatusEnum{StatusEnum: status, Valid: true}
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeUserCursor(query.Cursor)
+		if err != nil || cursor.Sort != sort || !validUserSortKey(sortField, cursor.Key) {
+			return nil, constants.ErrInvalidCursor
+		}
+		if err := params.AfterID.Scan(cursor.ID); err != nil {
+			return nil, constants.ErrInvalidCursor
+		}
+		params.AfterKey = pgtype.Text{String: cursor.Key, Valid: true}
+	}
+
+	rows, err := s.searchUsers(ctx, sortField, descending, params)
+	if err != nil {
+		log.Error().Err(err).Str("q", query.Q).Str("sort", sort).Msg("Failed to search users in repository")
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToSearchUsers, err)
+	}
+
+	result := &dtos.UserSearchResult{}
+	if len(rows) > int(limit) {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		result.NextCursor = encodeUserCursor(userCursor{Sort: sort, Key: userSortKey(sortField, last), ID: last.ID.String()})
+	}
+
+	result.Users = make([]*dtos.User, len(rows))
+	for i, row := range rows {
+		result.Users[i] = (&dtos.User{}).FromRepositoryModel(row)
+	}
+	return result, nil
+}
+
+// searchUsers runs the query of the sort. Every sort has its own query so that
+// its ORDER BY can use an index; they all take the same parameters.
+func (s *userService) searchUsers(ctx context.Context, sortField string, descending bool, params repository.SearchUsersByDisplayNameParams) ([]repository.User, error) {
+	switch {
+	case sortField == "mail" && descending:
+		return s.repo.SearchUsersByMailDesc(ctx, repository.SearchUsersByMailDescParams(params))
+	case sortField == "mail":
+		return s.repo.SearchUsersByMail(ctx, repository.SearchUsersByMailParams(params))
+	case sortField == "created_at" && descending:
+		return s.repo.SearchUsersByCreatedAtDesc(ctx, repository.SearchUsersByCreatedAtDescParams(params))
+	case sortField == "created_at":
+		return s.repo.SearchUsersByCreatedAt(ctx, repository.SearchUsersByCreatedAtParams(params))
+	case sortField == "last_login" && descending:
+		return s.repo.SearchUsersByLastLoginDesc(ctx, repository.SearchUsersByLastLoginDescParams(params))
+	case sortField == "last_login":
+		return s.repo.SearchUsersByLastLogin(ctx, repository.SearchUsersByLastLoginParams(params))
+	case descending:
+		return s.repo.SearchUsersByDisplayNameDesc(ctx, repository.SearchUsersByDisplayNameDescParams(params))
+	default:
+		return s.repo.SearchUsersByDisplayName(ctx, params)
+	}
+}
+
+// userSortKey is the cursor key of user for the sort.
+func userSortKey(sortField string, user repository.User) string {
+	switch sortField {
+	case "mail":
+		return user.Mail
+	case "created_at":
+		return user.CreatedAt.Time.UTC().Format(time.RFC3339Nano)
+	case "last_login":
+		if !user.LastLogin.Valid {
+			return neverLoggedIn
+		}
+		return user.LastLogin.Time.UTC().Format(time.RFC3339Nano)
+	default:
+		return user.DisplayName
+	}
+}
+
+// validUserSortKey reports whether key can be a cursor key of the sort, so a
+// tampered cursor is rejected before it reaches the query.
+func validUserSortKey(sortField, key string) bool {
+	switch sortField {
+	case "last_login":
+		if key == neverLoggedIn {
+			return true
+		}
+		fallthrough
+	case "created_at":
+		_, err := time.Parse(time.RFC3339Nano, key)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// parseUserSort parses a sort parameter such as "display_name", "+mail" or
+// "-created_at". A "+" that arrived unencoded in the URL reads as a space.
+func parseUserSort(value string) (string, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "display_name", false, nil
+	}
+
+	descending := strings.HasPrefix(value, "-")
+	field := strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+	if !userSortFields[field] {
+		return "", false, constants.ErrInvalidUserSort
+	}
+	return field, descending, nil
+}
+
+func parseUserPageLimit(value string) (int32, error) {
+	if value == "" {
+		return defaultUserPageLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > maxUserPageLimit {
+		return 0, constants.ErrInvalidPageLimit
+	}
+	return int32(limit), nil
+}
+
+// buildUserSearchQuery turns free text into a to_tsquery expression that
+// requires every word as a prefix. Characters with a meaning in tsquery syntax
+// are dropped.
+func buildUserSearchQuery(q string) string {
+	var terms []string
+	for _, word := range strings.Fields(q) {
+		term := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("@.-_", r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, word)
+		if strings.IndexFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		terms = append(terms, "'"+term+"':*")
+	}
+	return strings.Join(terms, " & ")
+}
+
+func scanOptionalUUID(dst *pgtype.UUID, value string) error {
+	if value == "" {
+		return nil
+	}
+	if err := dst.Scan(value); err != nil {
+		return constants.ErrInvalidUserFilter
+	}
+	return nil
+}
+
+func encodeUserCursor(cursor userCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeUserCursor(value string) (userCursor, error) {
+	var cursor userCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
+}
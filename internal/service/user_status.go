@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/dtos"
+	"yet-another-itsm/internal/repository"
+	"yet-another-itsm/internal/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Actions recorded in user_status_changes.
+const (
+	userStatusActionSuspend = "suspend"
+	userStatusActionLock    = "lock"
+	userStatusActionUnlock  = "unlock"
+	// Recorded by directory sync when an account is disabled or deleted in,
+	// or enabled again in, the directory.
+	userStatusActionDeactivate = "deactivate"
+	userStatusActionReactivate = "reactivate"
+)
+
+// maxAccountStatusEntries bounds the cache; expired entries are swept once it
+// is reached.
+const maxAccountStatusEntries = 10000
+
+// SuspendUser suspends a user of the caller's tenant until they are unlocked,
+// or locks them until req.LockedUntil. Callers cannot suspend themselves.
+func (s *userService) SuspendUser(ctx context.Context, id string, req *dtos.SuspendUserRequest) (*dtos.User, error) {
+	userID, homeTenantID, changedBy, err := s.prepareStatusChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if changedBy == userID {
+		return nil, constants.ErrCannotSuspendSelf
+	}
+
+	lockedUntil, err := parseLockedUntil(req.LockedUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	action := userStatusActionSuspend
+	if lockedUntil.Valid {
+		action = userStatusActionLock
+	}
+
+	user, err := s.changeStatus(ctx, action, req.Reason, lockedUntil, changedBy, func(qtx *repository.Queries) (repository.User, error) {
+		if lockedUntil.Valid {
+			return qtx.LockUser(ctx, repository.LockUserParams{
+				LockedUntil:  lockedUntil,
+				ID:           userID,
+				HomeTenantID: homeTenantID,
+			})
+		}
+		return qtx.SuspendUser(ctx, repository.SuspendUserParams{
+			ID:           userID,
+			HomeTenantID: homeTenantID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "UserService").
+		Str("method", "SuspendUser").
+		Str("id", id).
+		Str("action", action).
+		Str("changed_by", changedBy.String()).
+		Msg("User suspended")
+	return (&dtos.User{}).FromRepositoryModel(user), nil
+}
+
+// UnlockUser reactivates a suspended user and clears any lock.
+func (s *userService) UnlockUser(ctx context.Context, id string, req *dtos.UnlockUserRequest) (*dtos.User, error) {
+	userID, homeTenantID, changedBy, err := s.prepareStatusChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.changeStatus(ctx, userStatusActionUnlock, req.Reason, pgtype.Timestamptz{}, changedBy, func(qtx *repository.Queries) (repository.User, error) {
+		return qtx.UnlockUser(ctx, repository.UnlockUserParams{
+			ID:           userID,
+			HomeTenantID: homeTenantID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("service", "UserService").
+		Str("method", "UnlockUser").
+		Str("id", id).
+		Str("changed_by", changedBy.String()).
+		Msg("User unlocked")
+	return (&dtos.User{}).FromRepositoryModel(user), nil
+}
+
+// GetUserStatusChanges lists the suspensions, locks and unlocks of a user of
+// the caller's tenant, newest first, one page at a time.
+func (s *userService) GetUserStatusChanges(ctx context.Context, id string, page dtos.PageRequest) ([]*dtos.UserStatusChangeResponse, int64, error) {
+	userID, homeTenantID, err := parseUserInTenant(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := s.repo.GetUserByID(ctx, repository.GetUserByIDParams{ID: userID, HomeTenantID: homeTenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, constants.ErrUserNotFound
+		}
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+
+	total, err := s.repo.CountUserStatusChanges(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to count user status changes in repository")
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetStatusChanges, err)
+	}
+
+	changes, err := s.repo.ListUserStatusChanges(ctx, repository.ListUserStatusChangesParams{
+		UserID:     userID,
+		PageLimit:  page.Limit(),
+		PageOffset: page.Offset(),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to get user status changes from repository")
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetStatusChanges, err)
+	}
+
+	responses := make([]*dtos.UserStatusChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = dtos.NewUserStatusChangeResponse(change)
+	}
+	return responses, total, nil
+}
+
+// CheckAccountStatus rejects suspended and deleted users and users locked
+// until a future time. Statuses are cached per tenant and object ID, so
+// suspensions made on another instance take effect within the cache TTL.
+func (s *userService) CheckAccountStatus(ctx context.Context, tenantID, objectID string) error {
+	key := accountStatusKey(tenantID, objectID)
+
+	status, ok := s.accountStatus.get(key)
+	if !ok {
+		var homeTenantID pgtype.UUID
+		if err := homeTenantID.Scan(tenantID); err != nil {
+			return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+		}
+
+		row, err := s.repo.GetUserAccountStatus(ctx, repository.GetUserAccountStatusParams{
+			AzureAdObjectID: objectID,
+			HomeTenantID:    homeTenantID,
+		})
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			// Not provisioned yet; /users/me creates the user on first login.
+			status = accountStatus{}
+		case err != nil:
+			return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetAccountStatus, err)
+		default:
+			status = accountStatus{
+				suspended:   row.DeletedAt.Valid || (row.Status.Valid && row.Status.StatusEnum != repository.StatusEnumActive),
+				lockedUntil: row.LockedUntil.Time,
+			}
+		}
+		s.accountStatus.set(key, status)
+	}
+
+	if status.suspended {
+		return constants.ErrAccountSuspended
+	}
+	if status.lockedUntil.After(time.Now()) {
+		return fmt.Errorf("%w until %s", constants.ErrAccountLocked, utils.FormatTime(status.lockedUntil))
+	}
+	return nil
+}
+
+// changeStatus applies update and records the change in one transaction, then
+// drops the user's cached account status.
+func (s *userService) changeStatus(ctx context.Context, action, reason string, lockedUntil pgtype.Timestamptz, changedBy pgtype.UUID, update func(qtx *repository.Queries) (repository.User, error)) (repository.User, error) {
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToBeginTransaction, err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+	user, err := update(qtx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, constants.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("action", action).Msg("Failed to update account status in repository")
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateAccountStatus, err)
+	}
+
+	if _, err := qtx.CreateUserStatusChange(ctx, repository.CreateUserStatusChangeParams{
+		UserID:      user.ID,
+		Action:      action,
+		Reason:      reason,
+		LockedUntil: lockedUntil,
+		ChangedBy:   changedBy,
+	}); err != nil {
+		log.Error().Err(err).Str("action", action).Msg("Failed to record user status change in repository")
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToUpdateAccountStatus, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return repository.User{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToCommitTransaction, err)
+	}
+
+	s.accountStatus.delete(accountStatusKey(user.HomeTenantID.String(), user.AzureAdObjectID))
+	return user, nil
+}
+
+// prepareStatusChange resolves the target user ID, the caller's tenant and the
+// caller's internal user ID.
+func (s *userService) prepareStatusChange(ctx context.Context, id string) (pgtype.UUID, pgtype.UUID, pgtype.UUID, error) {
+	userID, homeTenantID, err := parseUserInTenant(ctx, id)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	changedByID, err := utils.GetInternalUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+	}
+	var changedBy pgtype.UUID
+	if err := changedBy.Scan(changedByID); err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	return userID, homeTenantID, changedBy, nil
+}
+
+func parseUserInTenant(ctx context.Context, id string) (pgtype.UUID, pgtype.UUID, error) {
+	var userID pgtype.UUID
+	if err := userID.Scan(id); err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
+	return userID, homeTenantID, nil
+}
+
+// parseLockedUntil parses an optional RFC 3339 lock end, which must be in the future.
+func parseLockedUntil(value string) (pgtype.Timestamptz, error) {
+	if value == "" {
+		return pgtype.Timestamptz{}, nil
+	}
+
+	lockedUntil, err := time.Parse(time.RFC3339, value)
+	if err != nil || !lockedUntil.After(time.Now()) {
+		return pgtype.Timestamptz{}, constants.ErrInvalidLockedUntil
+	}
+
+	return pgtype.Timestamptz{Time: lockedUntil, Valid: true}, nil
+}
+
+func accountStatusKey(tenantID, objectID string) string {
+	return strings.ToLower(tenantID) + "/" + objectID
+}
+
+// accountStatus is the cached state of an account. The zero value, also used
+// for users that are not provisioned, allows authentication.
+type accountStatus struct {
+	suspended   bool
+	lockedUntil time.Time
+}
+
+type accountStatusEntry struct {
+	status    accountStatus
+	expiresAt time.Time
+}
+
+// accountStatusCache keeps account statuses for a short TTL so authentication
+// does not query users on every request. A zero TTL disables it.
+type accountStatusCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]accountStatusEntry
+}
+
+func newAccountStatusCache(ttl time.Duration) *accountStatusCache {
+	return &accountStatusCache{
+		ttl:     ttl,
+		entries: make(map[string]accountStatusEntry),
+	}
+}
+
+func (c *accountStatusCache) get(key string) (accountStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return accountStatus{}, false
+	}
+	return entry.status, true
+}
+
+func (c *accountStatusCache) set(key string, status accountStatus) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= maxAccountStatusEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) >= maxAccountStatusEntries {
+		return
+	}
+	c.entries[key] = accountStatusEntry{status: status, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *accountStatusCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/repository"
+)
+
+// fakeAccountStatusDB answers GetUserAccountStatus with row, or with no rows
+// when it is nil.
+type fakeAccountStatusDB struct {
+	row *repository.GetUserAccountStatusRow
+}
+
+func (db *fakeAccountStatusDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, fmt.Errorf("unexpected exec")
+}
+
+func (db *fakeAccountStatusDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, fmt.Errorf("unexpected query")
+}
+
+func (db *fakeAccountStatusDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if db.row == nil {
+		return fakeRow{err: pgx.ErrNoRows}
+	}
+	return fakeRow{values: []any{db.row.ID, db.row.Status, db.row.LockedUntil, db.row.DeletedAt}}
+}
+
+// fakeRow copies its values into same-typed scan targets.
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, value := range r.values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func TestCheckAccountStatus(t *testing.T) {
+	active := repository.NullStatusEnum{StatusEnum: repository.StatusEnumActive, Valid: true}
+	suspended := repository.NullStatusEnum{StatusEnum: repository.StatusEnumInactive, Valid: true}
+	deletedAt := pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+	future := pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+
+	tests := []struct {
+		name string
+		row  *repository.GetUserAccountStatusRow
+		want error
+	}{
+		{"not provisioned", nil, nil},
+		{"active", &repository.GetUserAccountStatusRow{Status: active}, nil},
+		{"suspended", &repository.GetUserAccountStatusRow{Status: suspended}, constants.ErrAccountSuspended},
+		{"locked", &repository.GetUserAccountStatusRow{Status: active, LockedUntil: future}, constants.ErrAccountLocked},
+		{"deleted", &repository.GetUserAccountStatusRow{Status: active, DeletedAt: deletedAt}, constants.ErrAccountSuspended},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &userService{
+				repo:          repository.New(&fakeAccountStatusDB{row: tt.row}),
+				accountStatus: newAccountStatusCache(0),
+			}
+
+			err := s.CheckAccountStatus(context.Background(), testDirectoryTenantID, "object-id")
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("CheckAccountStatus() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"yet-another-itsm/internal/constants"
+	"yet-another-itsm/internal/database"
 	"yet-another-itsm/internal/dtos"
 	"yet-another-itsm/internal/repository"
 	"yet-another-itsm/internal/utils"
@@ -16,34 +18,55 @@ import (
 )
 
 type UserService interface {
-	GetAllUsersInDepartment(ctx context.Context, departmentID string) ([]*dtos.User, error)
+	GetAllUsersInDepartment(ctx context.Context, departmentID string, page dtos.PageRequest) ([]*dtos.User, int64, error)
 	GetUserByID(ctx context.Context, id string) (*dtos.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*dtos.User, error)
+	GetUserByAzureADObjectID(ctx context.Context, objectID string) (*dtos.User, error)
 	CreateUser(ctx context.Context, req *dtos.CreateUserRequest) (*dtos.User, error)
 	UpdateUserLastLogin(ctx context.Context, email string) error
+	SuspendUser(ctx context.Context, id string, req *dtos.SuspendUserRequest) (*dtos.User, error)
+	UnlockUser(ctx context.Context, id string, req *dtos.UnlockUserRequest) (*dtos.User, error)
+	GetUserStatusChanges(ctx context.Context, id string, page dtos.PageRequest) ([]*dtos.UserStatusChangeResponse, int64, error)
+	CheckAccountStatus(ctx context.Context, tenantID, objectID string) error
+	SyncUserProfile(ctx context.Context, profile *dtos.UserProfile) (*dtos.User, error)
+	GetUserProfileChanges(ctx context.Context, id string, page dtos.PageRequest) ([]*dtos.UserProfileChangeResponse, int64, error)
+	SearchUsers(ctx context.Context, query dtos.UserSearchQuery) (*dtos.UserSearchResult, error)
 }
 
 type userService struct {
-	repo *repository.Queries
+	db            *database.Database
+	repo          *repository.Queries
+	departments   DepartmentService
+	businessUnits BusinessUnitService
+	accountStatus *accountStatusCache
 }
 
-func NewUserService(repo *repository.Queries) UserService {
+func NewUserService(db *database.Database, repo *repository.Queries, departments DepartmentService, businessUnits BusinessUnitService, accountStatusTTL time.Duration) UserService {
 	return &userService{
-		repo: repo,
+		db:            db,
+		repo:          repo,
+		departments:   departments,
+		businessUnits: businessUnits,
+		accountStatus: newAccountStatusCache(accountStatusTTL),
 	}
 }
 
-// GetAllUsersInDepartment gets all users in a department.
-func (s *userService) GetAllUsersInDepartment(ctx context.Context, departmentID string) ([]*dtos.User, error) {
+// GetAllUsersInDepartment gets one page of the users in a department and the total number of them.
+func (s *userService) GetAllUsersInDepartment(ctx context.Context, departmentID string, page dtos.PageRequest) ([]*dtos.User, int64, error) {
 	userID, err := utils.GetUserID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
 	}
 
 	var uuid pgtype.UUID
 	err = uuid.Scan(departmentID)
 	if err != nil {
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidDepartmentUUIDFormat, err)
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidDepartmentUUIDFormat, err)
+	}
+
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
 	}
 
 	log.Info().
@@ -53,9 +76,22 @@ func (s *userService) GetAllUsersInDepartment(ctx context.Context, departmentID
 		Str("user_id", userID).
 		Msg("Getting all users in department")
 
-	repoUsers, err := s.repo.GetAllUsersInDepartment(ctx, uuid)
+	total, err := s.repo.CountUsersInDepartment(ctx, repository.CountUsersInDepartmentParams{
+		DepartmentID: uuid,
+		HomeTenantID: homeTenantID,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUsers, err)
+	}
+
+	repoUsers, err := s.repo.GetAllUsersInDepartment(ctx, repository.GetAllUsersInDepartmentParams{
+		DepartmentID: uuid,
+		HomeTenantID: homeTenantID,
+		Limit:        page.Limit(),
+		Offset:       page.Offset(),
+	})
 	if err != nil {
-		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUsers, err)
+		return nil, 0, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUsers, err)
 	}
 
 	var users []*dtos.User
@@ -64,7 +100,7 @@ func (s *userService) GetAllUsersInDepartment(ctx context.Context, departmentID
 		users = append(users, dto)
 	}
 
-	return users, nil
+	return users, total, nil
 }
 
 // GetUserByID gets a user by ID.
@@ -80,6 +116,11 @@ func (s *userService) GetUserByID(ctx context.Context, id string) (*dtos.User, e
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrInvalidUUIDFormat, err)
 	}
 
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
 	log.Info().
 		Str("service", "UserService").
 		Str("endpoint", "GetUserByID").
@@ -87,7 +128,10 @@ func (s *userService) GetUserByID(ctx context.Context, id string) (*dtos.User, e
 		Str("user_id", userID).
 		Msg("Getting user by ID")
 
-	repoUser, err := s.repo.GetUserByID(ctx, uuid)
+	repoUser, err := s.repo.GetUserByID(ctx, repository.GetUserByIDParams{
+		ID:           uuid,
+		HomeTenantID: homeTenantID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
 	}
@@ -103,6 +147,11 @@ func (s *userService) GetUserByEmail(ctx context.Context, email string) (*dtos.U
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUserID, err)
 	}
 
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
 	log.Info().
 		Str("service", "UserService").
 		Str("endpoint", "GetUserByEmail").
@@ -110,7 +159,27 @@ func (s *userService) GetUserByEmail(ctx context.Context, email string) (*dtos.U
 		Str("user_id", userID).
 		Msg("Getting user by email")
 
-	repoUser, err := s.repo.GetUserByEmail(ctx, email)
+	repoUser, err := s.repo.GetUserByEmail(ctx, repository.GetUserByEmailParams{
+		Mail:         email,
+		HomeTenantID: homeTenantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
+	}
+
+	dto := (&dtos.User{}).FromRepositoryModel(repoUser)
+	return dto, nil
+}
+
+// GetUserByAzureADObjectID gets a user by their Entra object ID (the oid claim).
+func (s *userService) GetUserByAzureADObjectID(ctx context.Context, objectID string) (*dtos.User, error) {
+	log.Info().
+		Str("service", "UserService").
+		Str("endpoint", "GetUserByAzureADObjectID").
+		Str("azure_ad_object_id", objectID).
+		Msg("Getting user by Azure AD object ID")
+
+	repoUser, err := s.repo.GetUserByAzureADObjectID(ctx, objectID)
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetUser, err)
 	}
@@ -213,6 +282,10 @@ func (g *GraphService) GetCurrentUser(tokenStr string) (models.Userable, error)
 		Str("service", "GraphService").
 		Str("endpoint", "GetCurrentUser").
 		Msg("Getting current user from user")
+	if !g.GraphEnabled() {
+		return currentUserFromClaims(tokenStr)
+	}
+
 	client, err := g.GetGraphTokenOnBehalfOf(tokenStr)
 	if err != nil {
 		return nil, fmt.Errorf(utils.ErrorFormat, constants.ErrCouldNotCreateGraphClient, err)
@@ -220,7 +293,9 @@ func (g *GraphService) GetCurrentUser(tokenStr string) (models.Userable, error)
 
 	user, err := client.Me().Get(context.Background(), &msgraphsdkUser.UserItemRequestBuilderGetRequestConfiguration{
 		QueryParameters: &msgraphsdkUser.UserItemRequestBuilderGetQueryParameters{
-			Select: []string{"id", "displayName", "surname", "givenName", "mail", "mobilePhone", "jobTitle", "officeLocation", "department", "manager"},
+			Select: []string{"id", "displayName", "surname", "givenName", "mail", "mobilePhone", "jobTitle", "officeLocation", "department"},
+			// manager is a navigation property and only returned when expanded
+			Expand: []string{"manager($select=id)"},
 		},
 	})
 	if err != nil {
@@ -230,15 +305,29 @@ func (g *GraphService) GetCurrentUser(tokenStr string) (models.Userable, error)
 	return user, nil
 }
 
-// UpdateUserLastLogin updates the last login timestamp for a user
+// GraphEnabled reports whether the caller's profile, including their manager,
+// is read from Microsoft Graph rather than from the token claims.
+func (g *GraphService) GraphEnabled() bool {
+	return g.config.Provider == nil || g.config.Provider.GraphEnabled()
+}
+
+// UpdateUserLastLogin updates the last login timestamp for a user of the caller's tenant
 func (s *userService) UpdateUserLastLogin(ctx context.Context, email string) error {
+	homeTenantID, err := utils.GetHomeTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf(utils.ErrorFormat, constants.ErrFailedToGetTenantID, err)
+	}
+
 	log.Info().
 		Str("service", "UserService").
 		Str("method", "UpdateUserLastLogin").
 		Str("email", email).
 		Msg("Updating user last login")
 
-	err := s.repo.UpdateUserLastLogin(ctx, email)
+	err = s.repo.UpdateUserLastLogin(ctx, repository.UpdateUserLastLoginParams{
+		Mail:         email,
+		HomeTenantID: homeTenantID,
+	})
 	if err != nil {
 		log.Error().Err(err).
 			Str("email", email).
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"yet-another-itsm/internal/constants"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type ContextKey string
@@ -14,8 +16,26 @@ const (
 	UserIDKey      ContextKey = "user_id"
 	UserNameKey    ContextKey = "user_name"
 	AccessTokenKey ContextKey = "access_token"
+
+	InternalUserIDKey  ContextKey = "internal_user_id"
+	DirectoryClaimsKey ContextKey = "directory_claims"
 )
 
+// DirectoryClaims carries the Entra app roles and group object IDs from the
+// access token. GroupsOverage is set when the token omitted the groups claim
+// because the user is a member of too many groups. SCIM is set when the claims
+// come from SCIM group memberships instead: SCIMGroups then holds the IDs of
+// the user's SCIM groups and only scim_group mappings are evaluated, since
+// SCIM clients choose a group's externalId and it cannot stand for a token
+// claim.
+type DirectoryClaims struct {
+	AppRoles      []string
+	Groups        []string
+	GroupsOverage bool
+	SCIM          bool
+	SCIMGroups    []string
+}
+
 func GetTenantID(ctx context.Context) (string, error) {
 	tenantID, ok := ctx.Value(TenantIDKey).(string)
 	if !ok || tenantID == "" {
@@ -24,6 +44,21 @@ func GetTenantID(ctx context.Context) (string, error) {
 	return tenantID, nil
 }
 
+// GetHomeTenantID returns the caller's tenant as the UUID stored in
+// users.home_tenant_id, which scopes every user lookup.
+func GetHomeTenantID(ctx context.Context) (pgtype.UUID, error) {
+	tenantID, err := GetTenantID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+
+	var homeTenantID pgtype.UUID
+	if err := homeTenantID.Scan(tenantID); err != nil {
+		return pgtype.UUID{}, fmt.Errorf(ErrorFormat, constants.ErrInvalidHomeTenantUUIDFormat, err)
+	}
+	return homeTenantID, nil
+}
+
 func GetUserID(ctx context.Context) (string, error) {
 	userID, ok := ctx.Value(UserIDKey).(string)
 	if !ok || userID == "" {
@@ -55,3 +90,24 @@ func SetTenantContext(ctx context.Context, tenantID, userID, userName, accessTok
 	ctx = context.WithValue(ctx, AccessTokenKey, accessToken)
 	return ctx
 }
+
+func GetInternalUserID(ctx context.Context) (string, error) {
+	internalUserID, ok := ctx.Value(InternalUserIDKey).(string)
+	if !ok || internalUserID == "" {
+		return "", fmt.Errorf(constants.ErrInternalUserIDNotFound)
+	}
+	return internalUserID, nil
+}
+
+func SetInternalUserID(ctx context.Context, internalUserID string) context.Context {
+	return context.WithValue(ctx, InternalUserIDKey, internalUserID)
+}
+
+func GetDirectoryClaims(ctx context.Context) DirectoryClaims {
+	claims, _ := ctx.Value(DirectoryClaimsKey).(DirectoryClaims)
+	return claims
+}
+
+func SetDirectoryClaims(ctx context.Context, claims DirectoryClaims) context.Context {
+	return context.WithValue(ctx, DirectoryClaimsKey, claims)
+}
@@ -15,8 +15,9 @@ type ErrorResponse struct {
 
 // ErrorDetail contains error information
 type ErrorDetail struct {
-	Detail string `json:"detail,omitempty"`
-	Code   string `json:"code,omitempty"`
+	Detail string        `json:"detail,omitempty"`
+	Code   string        `json:"code,omitempty"`
+	Errors []SchemaError `json:"errors,omitempty"`
 }
 
 // Error creates an error response
@@ -218,6 +219,21 @@ func SendValidationError(c *gin.Context, message string) {
 	SendError(c, http.StatusUnprocessableEntity, message, constants.ErrValidationMsg, constants.ErrCodeValidation)
 }
 
+// SendValidationErrors sends a 422 validation error response listing each
+// violation with the JSON Pointer path of the offending request value
+func SendValidationErrors(c *gin.Context, message string, errs []SchemaError) {
+	response := ValidationError(message)
+	response.Error.Errors = errs
+
+	NewAPIResponse().
+		SetStatus("error").
+		SetMessage(constants.ErrValidationMsg).
+		SetHeaders(NewHeaders(nil, c)).
+		SetStatusCode(http.StatusUnprocessableEntity).
+		SetError(response).
+		Respond(c)
+}
+
 // SendServiceUnavailable sends a 503 service unavailable error response
 func SendServiceUnavailable(c *gin.Context, message string) {
 	SendError(c, http.StatusServiceUnavailable, message, constants.ErrUnavailableMsg, constants.ErrCodeUnavailable)
@@ -0,0 +1,574 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SchemaError is a JSON Schema violation at a JSON Pointer (RFC 6901) path of
+// the validated document; the root is "".
+type SchemaError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e SchemaError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// SchemaValidationError carries the violations behind a validation failure so
+// controllers can return each of them with its path. It unwraps to Err.
+type SchemaValidationError struct {
+	Err    error
+	Errors []SchemaError
+}
+
+func (e *SchemaValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SchemaValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewSchemaValidationError wraps violations found at prefix, a JSON Pointer
+// into the request body, under err.
+func NewSchemaValidationError(err error, prefix string, violations []SchemaError) *SchemaValidationError {
+	errs := make([]SchemaError, len(violations))
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		errs[i] = SchemaError{Path: prefix + violation.Path, Message: violation.Message}
+		messages[i] = errs[i].Error()
+	}
+	return &SchemaValidationError{
+		Err:    fmt.Errorf("%w: %s", err, strings.Join(messages, "; ")),
+		Errors: errs,
+	}
+}
+
+var errTrailingJSON = errors.New("unexpected data after the JSON value")
+
+// schemaKeywords are the JSON Schema keywords ValidateJSONSchema understands;
+// CheckJSONSchema rejects schemas using any other keyword.
+var schemaKeywords = map[string]bool{
+	"$schema": true, "$comment": true, "title": true, "description": true, "default": true, "examples": true,
+	"type": true, "enum": true, "const": true,
+	"properties": true, "required": true, "additionalProperties": true, "minProperties": true, "maxProperties": true,
+	"items": true, "minItems": true, "maxItems": true, "uniqueItems": true,
+	"minLength": true, "maxLength": true, "pattern": true, "format": true,
+	"minimum": true, "maximum": true, "exclusiveMinimum": true, "exclusiveMaximum": true, "multipleOf": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "not": true,
+}
+
+var schemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+var schemaFormats = map[string]func(string) bool{
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		for _, layout := range []string{"15:04:05Z07:00", "15:04:05", "15:04"} {
+			if _, err := time.Parse(layout, s); err == nil {
+				return true
+			}
+		}
+		return false
+	},
+	"email": func(s string) bool {
+		address, err := mail.ParseAddress(s)
+		return err == nil && address.Address == s
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	},
+	"regex": func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	},
+}
+
+// CheckJSONSchema checks that schema is a JSON Schema ValidateJSONSchema can
+// enforce: an object using only the supported keywords, each with a value of
+// the right kind. Violations carry JSON Pointer paths into the schema. The
+// error is only set when schema is not valid JSON.
+func CheckJSONSchema(schema []byte) ([]SchemaError, error) {
+	var s any
+	if err := decodeJSON(schema, &s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	var errs []SchemaError
+	checkSchema(s, "", &errs)
+	return errs, nil
+}
+
+// ValidateJSONSchema checks document against a JSON Schema and returns every
+// violation found, with JSON Pointer paths into the document. It implements
+// the keywords listed in schemaKeywords; schemas are expected to have passed
+// CheckJSONSchema. The error is only set when schema or document is not
+// valid JSON.
+func ValidateJSONSchema(schema, document []byte) ([]SchemaError, error) {
+	var s map[string]any
+	if err := decodeJSON(schema, &s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	var doc any
+	if err := decodeJSON(document, &doc); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	var errs []SchemaError
+	validateSchemaValue(s, doc, "", &errs)
+	return errs, nil
+}
+
+// decodeJSON keeps numbers as json.Number so integers are told apart from
+// decimals, and rejects anything but whitespace after the value.
+func decodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errTrailingJSON
+	}
+	// More does not report a stray closing bracket, which Token still reads.
+	if _, err := decoder.Token(); err != io.EOF {
+		return errTrailingJSON
+	}
+	return nil
+}
+
+func checkSchema(schema any, path string, errs *[]SchemaError) {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		*errs = append(*errs, SchemaError{Path: path, Message: "must be a schema object"})
+		return
+	}
+
+	fail := func(keyword, message string) {
+		*errs = append(*errs, SchemaError{Path: path + "/" + EscapeJSONPointer(keyword), Message: message})
+	}
+
+	for _, keyword := range sortedKeys(s) {
+		value := s[keyword]
+		keywordPath := path + "/" + EscapeJSONPointer(keyword)
+		switch keyword {
+		case "type":
+			if !checkSchemaType(value) {
+				fail(keyword, "must be one of object, array, string, number, integer, boolean, null or an array of them")
+			}
+		case "enum":
+			if values, ok := value.([]any); !ok || len(values) == 0 {
+				fail(keyword, "must be a non-empty array")
+			}
+		case "properties":
+			properties, ok := value.(map[string]any)
+			if !ok {
+				fail(keyword, "must be an object of schemas")
+				continue
+			}
+			for _, name := range sortedKeys(properties) {
+				checkSchema(properties[name], keywordPath+"/"+EscapeJSONPointer(name), errs)
+			}
+		case "required":
+			names, ok := value.([]any)
+			if !ok {
+				fail(keyword, "must be an array of strings")
+				continue
+			}
+			for _, name := range names {
+				if _, ok := name.(string); !ok {
+					fail(keyword, "must be an array of strings")
+					break
+				}
+			}
+		case "additionalProperties":
+			if _, ok := value.(bool); !ok {
+				checkSchema(value, keywordPath, errs)
+			}
+		case "items", "not":
+			checkSchema(value, keywordPath, errs)
+		case "allOf", "anyOf", "oneOf":
+			schemas, ok := value.([]any)
+			if !ok || len(schemas) == 0 {
+				fail(keyword, "must be a non-empty array of schemas")
+				continue
+			}
+			for i, item := range schemas {
+				checkSchema(item, keywordPath+"/"+strconv.Itoa(i), errs)
+			}
+		case "minProperties", "maxProperties", "minItems", "maxItems", "minLength", "maxLength":
+			if n, ok := schemaInt(value); !ok || n < 0 {
+				fail(keyword, "must be a non-negative integer")
+			}
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+			if _, ok := schemaFloat(value); !ok {
+				fail(keyword, "must be a number")
+			}
+		case "multipleOf":
+			if n, ok := schemaFloat(value); !ok || n <= 0 {
+				fail(keyword, "must be a number greater than 0")
+			}
+		case "uniqueItems":
+			if _, ok := value.(bool); !ok {
+				fail(keyword, "must be a boolean")
+			}
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				fail(keyword, "must be a string")
+			} else if _, err := regexp.Compile(pattern); err != nil {
+				fail(keyword, "must be a valid regular expression")
+			}
+		case "format":
+			if format, ok := value.(string); !ok || schemaFormats[format] == nil {
+				fail(keyword, "must be one of date, date-time, time, email, uri, regex")
+			}
+		case "$schema", "$comment", "title", "description":
+			if _, ok := value.(string); !ok {
+				fail(keyword, "must be a string")
+			}
+		default:
+			if !schemaKeywords[keyword] {
+				fail(keyword, "is not a supported keyword")
+			}
+		}
+	}
+}
+
+func checkSchemaType(t any) bool {
+	switch t := t.(type) {
+	case string:
+		return schemaTypes[t]
+	case []any:
+		if len(t) == 0 {
+			return false
+		}
+		for _, name := range t {
+			if name, ok := name.(string); !ok || !schemaTypes[name] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func validateSchemaValue(schema map[string]any, value any, path string, errs *[]SchemaError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if t, ok := schema["type"]; ok && !schemaTypeMatches(t, value) {
+		fail("must be of type %s", schemaTypeNames(t))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !schemaEnumContains(enum, value) {
+		fail("must be one of %s", schemaEnumNames(enum))
+	}
+	if constant, ok := schema["const"]; ok && !jsonEqual(constant, value) {
+		fail("must be %s", schemaEnumNames([]any{constant}))
+	}
+
+	validateSchemaCombinators(schema, value, path, errs)
+
+	switch v := value.(type) {
+	case map[string]any:
+		validateSchemaObject(schema, v, path, errs)
+	case []any:
+		if minItems, ok := schemaInt(schema["minItems"]); ok && len(v) < minItems {
+			fail("must have at least %d items", minItems)
+		}
+		if maxItems, ok := schemaInt(schema["maxItems"]); ok && len(v) > maxItems {
+			fail("must have at most %d items", maxItems)
+		}
+		if unique, _ := schema["uniqueItems"].(bool); unique {
+			for i := 1; i < len(v); i++ {
+				for j := 0; j < i; j++ {
+					if jsonEqual(v[i], v[j]) {
+						*errs = append(*errs, SchemaError{Path: path + "/" + strconv.Itoa(i), Message: fmt.Sprintf("duplicates item %d", j)})
+					}
+				}
+			}
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				validateSchemaValue(items, item, path+"/"+strconv.Itoa(i), errs)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if minLength, ok := schemaInt(schema["minLength"]); ok && length < minLength {
+			fail("must be at least %d characters long", minLength)
+		}
+		if maxLength, ok := schemaInt(schema["maxLength"]); ok && length > maxLength {
+			fail("must be at most %d characters long", maxLength)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				fail("must match pattern %s", pattern)
+			}
+		}
+		if format, ok := schema["format"].(string); ok {
+			if valid := schemaFormats[format]; valid != nil && !valid(v) {
+				fail("must be a valid %s", format)
+			}
+		}
+	case json.Number:
+		n, _ := v.Float64()
+		if minimum, ok := schemaFloat(schema["minimum"]); ok && n < minimum {
+			fail("must be greater than or equal to %v", schema["minimum"])
+		}
+		if maximum, ok := schemaFloat(schema["maximum"]); ok && n > maximum {
+			fail("must be less than or equal to %v", schema["maximum"])
+		}
+		if minimum, ok := schemaFloat(schema["exclusiveMinimum"]); ok && n <= minimum {
+			fail("must be greater than %v", schema["exclusiveMinimum"])
+		}
+		if maximum, ok := schemaFloat(schema["exclusiveMaximum"]); ok && n >= maximum {
+			fail("must be less than %v", schema["exclusiveMaximum"])
+		}
+		if multipleOf, ok := schemaFloat(schema["multipleOf"]); ok && multipleOf > 0 {
+			if q := n / multipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+				fail("must be a multiple of %v", schema["multipleOf"])
+			}
+		}
+	}
+}
+
+// validateSchemaCombinators applies allOf, anyOf, oneOf and not. allOf reports
+// the violations of each subschema; the others only report that the value did
+// not match, since no single subschema is to blame.
+func validateSchemaCombinators(schema map[string]any, value any, path string, errs *[]SchemaError) {
+	matches := func(subschema any) bool {
+		s, ok := subschema.(map[string]any)
+		if !ok {
+			return true
+		}
+		var subErrs []SchemaError
+		validateSchemaValue(s, value, path, &subErrs)
+		return len(subErrs) == 0
+	}
+
+	if schemas, ok := schema["allOf"].([]any); ok {
+		for _, subschema := range schemas {
+			if s, ok := subschema.(map[string]any); ok {
+				validateSchemaValue(s, value, path, errs)
+			}
+		}
+	}
+	if schemas, ok := schema["anyOf"].([]any); ok {
+		matched := false
+		for _, subschema := range schemas {
+			if matches(subschema) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			*errs = append(*errs, SchemaError{Path: path, Message: "must match at least one of the allowed schemas"})
+		}
+	}
+	if schemas, ok := schema["oneOf"].([]any); ok {
+		matched := 0
+		for _, subschema := range schemas {
+			if matches(subschema) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			*errs = append(*errs, SchemaError{Path: path, Message: "must match exactly one of the allowed schemas"})
+		}
+	}
+	if not, ok := schema["not"]; ok && matches(not) {
+		*errs = append(*errs, SchemaError{Path: path, Message: "must not match the excluded schema"})
+	}
+}
+
+func validateSchemaObject(schema map[string]any, value map[string]any, path string, errs *[]SchemaError) {
+	if minProperties, ok := schemaInt(schema["minProperties"]); ok && len(value) < minProperties {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf("must have at least %d properties", minProperties)})
+	}
+	if maxProperties, ok := schemaInt(schema["maxProperties"]); ok && len(value) > maxProperties {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf("must have at most %d properties", maxProperties)})
+	}
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := value[name]; !present {
+					*errs = append(*errs, SchemaError{Path: path + "/" + EscapeJSONPointer(name), Message: "is required"})
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range sortedKeys(value) {
+		propertyPath := path + "/" + EscapeJSONPointer(name)
+		if propertySchema, ok := properties[name].(map[string]any); ok {
+			validateSchemaValue(propertySchema, value[name], propertyPath, errs)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*errs = append(*errs, SchemaError{Path: propertyPath, Message: "is not allowed"})
+			}
+		case map[string]any:
+			validateSchemaValue(additional, value[name], propertyPath, errs)
+		}
+	}
+}
+
+func schemaTypeMatches(t any, value any) bool {
+	switch t := t.(type) {
+	case string:
+		return schemaTypeIs(t, value)
+	case []any:
+		for _, name := range t {
+			if name, ok := name.(string); ok && schemaTypeIs(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func schemaTypeIs(name string, value any) bool {
+	switch name {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+	return false
+}
+
+func schemaTypeNames(t any) string {
+	if names, ok := t.([]any); ok {
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = fmt.Sprint(name)
+		}
+		return strings.Join(parts, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func schemaEnumContains(enum []any, value any) bool {
+	for _, candidate := range enum {
+		if jsonEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaEnumNames(enum []any) string {
+	parts := make([]string, len(enum))
+	for i, value := range enum {
+		encoded, _ := json.Marshal(value)
+		parts[i] = string(encoded)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// jsonEqual compares decoded JSON values, treating numbers by value.
+func jsonEqual(a, b any) bool {
+	if x, ok := a.(json.Number); ok {
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	}
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+func schemaInt(v any) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return int(i), err == nil
+}
+
+func schemaFloat(v any) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// EscapeJSONPointer escapes token for use as one segment of a JSON Pointer.
+func EscapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
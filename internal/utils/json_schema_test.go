@@ -0,0 +1,312 @@
+package utils
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCheckJSONSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   []SchemaError
+	}{
+		{"empty", `{}`, nil},
+		{"annotations", `{"$schema": "https://json-schema.org/draft/2020-12/schema", "$comment": "c", "title": "t", "description": "d", "default": 1, "examples": [1]}`, nil},
+		{"annotation not a string", `{"title": 1}`, []SchemaError{{"/title", "must be a string"}}},
+		{"not an object", `[]`, []SchemaError{{"", "must be a schema object"}}},
+		{"unsupported keyword", `{"$ref": "#/x"}`, []SchemaError{{"/$ref", "is not a supported keyword"}}},
+		{"unsupported keyword escaped", `{"a/b~": 1}`, []SchemaError{{"/a~1b~0", "is not a supported keyword"}}},
+
+		{"type", `{"type": "integer"}`, nil},
+		{"type array", `{"type": ["string", "null"]}`, nil},
+		{"type unknown", `{"type": "date"}`, []SchemaError{{"/type", "must be one of object, array, string, number, integer, boolean, null or an array of them"}}},
+		{"type empty array", `{"type": []}`, []SchemaError{{"/type", "must be one of object, array, string, number, integer, boolean, null or an array of them"}}},
+		{"type array unknown", `{"type": ["string", 1]}`, []SchemaError{{"/type", "must be one of object, array, string, number, integer, boolean, null or an array of them"}}},
+		{"enum", `{"enum": ["a", 1, null]}`, nil},
+		{"enum empty", `{"enum": []}`, []SchemaError{{"/enum", "must be a non-empty array"}}},
+		{"enum not an array", `{"enum": "a"}`, []SchemaError{{"/enum", "must be a non-empty array"}}},
+		{"const", `{"const": {"a": 1}}`, nil},
+
+		{"properties", `{"properties": {"a": {"type": "string"}}}`, nil},
+		{"properties not an object", `{"properties": []}`, []SchemaError{{"/properties", "must be an object of schemas"}}},
+		{"properties nested", `{"properties": {"a/b": {"type": "x"}}}`, []SchemaError{{"/properties/a~1b/type", "must be one of object, array, string, number, integer, boolean, null or an array of them"}}},
+		{"required", `{"required": ["a", "b"]}`, nil},
+		{"required not an array", `{"required": "a"}`, []SchemaError{{"/required", "must be an array of strings"}}},
+		{"required not strings", `{"required": ["a", 1, 2]}`, []SchemaError{{"/required", "must be an array of strings"}}},
+		{"additionalProperties boolean", `{"additionalProperties": false}`, nil},
+		{"additionalProperties schema", `{"additionalProperties": {"type": "string"}}`, nil},
+		{"additionalProperties invalid", `{"additionalProperties": "no"}`, []SchemaError{{"/additionalProperties", "must be a schema object"}}},
+		{"minProperties", `{"minProperties": 1}`, nil},
+		{"minProperties negative", `{"minProperties": -1}`, []SchemaError{{"/minProperties", "must be a non-negative integer"}}},
+		{"maxProperties decimal", `{"maxProperties": 1.5}`, []SchemaError{{"/maxProperties", "must be a non-negative integer"}}},
+
+		{"items", `{"items": {"type": "string"}}`, nil},
+		{"items invalid", `{"items": [{"type": "string"}]}`, []SchemaError{{"/items", "must be a schema object"}}},
+		{"minItems", `{"minItems": 0}`, nil},
+		{"minItems not a number", `{"minItems": "1"}`, []SchemaError{{"/minItems", "must be a non-negative integer"}}},
+		{"maxItems negative", `{"maxItems": -2}`, []SchemaError{{"/maxItems", "must be a non-negative integer"}}},
+		{"uniqueItems", `{"uniqueItems": true}`, nil},
+		{"uniqueItems not a boolean", `{"uniqueItems": 1}`, []SchemaError{{"/uniqueItems", "must be a boolean"}}},
+
+		{"minLength", `{"minLength": 2}`, nil},
+		{"minLength negative", `{"minLength": -1}`, []SchemaError{{"/minLength", "must be a non-negative integer"}}},
+		{"maxLength decimal", `{"maxLength": 2.5}`, []SchemaError{{"/maxLength", "must be a non-negative integer"}}},
+		{"pattern", `{"pattern": "^[a-z]+$"}`, nil},
+		{"pattern not a string", `{"pattern": 1}`, []SchemaError{{"/pattern", "must be a string"}}},
+		{"pattern invalid", `{"pattern": "("}`, []SchemaError{{"/pattern", "must be a valid regular expression"}}},
+		{"format", `{"format": "date-time"}`, nil},
+		{"format unknown", `{"format": "ipv4"}`, []SchemaError{{"/format", "must be one of date, date-time, time, email, uri, regex"}}},
+		{"format not a string", `{"format": true}`, []SchemaError{{"/format", "must be one of date, date-time, time, email, uri, regex"}}},
+
+		{"numeric bounds", `{"minimum": 0, "maximum": 1.5, "exclusiveMinimum": -1, "exclusiveMaximum": 2}`, nil},
+		{"minimum not a number", `{"minimum": "0"}`, []SchemaError{{"/minimum", "must be a number"}}},
+		{"maximum not a number", `{"maximum": null}`, []SchemaError{{"/maximum", "must be a number"}}},
+		{"exclusiveMinimum not a number", `{"exclusiveMinimum": true}`, []SchemaError{{"/exclusiveMinimum", "must be a number"}}},
+		{"exclusiveMaximum not a number", `{"exclusiveMaximum": []}`, []SchemaError{{"/exclusiveMaximum", "must be a number"}}},
+		{"multipleOf", `{"multipleOf": 0.5}`, nil},
+		{"multipleOf zero", `{"multipleOf": 0}`, []SchemaError{{"/multipleOf", "must be a number greater than 0"}}},
+		{"multipleOf not a number", `{"multipleOf": "2"}`, []SchemaError{{"/multipleOf", "must be a number greater than 0"}}},
+
+		{"combinators", `{"allOf": [{"type": "string"}], "anyOf": [{}], "oneOf": [{}, {"type": "null"}], "not": {"type": "null"}}`, nil},
+		{"allOf empty", `{"allOf": []}`, []SchemaError{{"/allOf", "must be a non-empty array of schemas"}}},
+		{"anyOf not an array", `{"anyOf": {}}`, []SchemaError{{"/anyOf", "must be a non-empty array of schemas"}}},
+		{"oneOf invalid schema", `{"oneOf": [{}, 1]}`, []SchemaError{{"/oneOf/1", "must be a schema object"}}},
+		{"not invalid", `{"not": []}`, []SchemaError{{"/not", "must be a schema object"}}},
+
+		{"violations in key order", `{"type": "x", "minItems": -1, "$ref": "#"}`, []SchemaError{
+			{"/$ref", "is not a supported keyword"},
+			{"/minItems", "must be a non-negative integer"},
+			{"/type", "must be one of object, array, string, number, integer, boolean, null or an array of them"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckJSONSchema([]byte(tt.schema))
+			if err != nil {
+				t.Fatalf("CheckJSONSchema: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CheckJSONSchema(%s) = %v, want %v", tt.schema, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckJSONSchemaInvalidJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"empty", ``},
+		{"malformed", `{"type": }`},
+		{"trailing value", `{"type": "string"} {"type": "number"}`},
+		{"trailing closing bracket", `{"type": "string"}}`},
+		{"trailing garbage", `{"type": "string"} x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CheckJSONSchema([]byte(tt.schema)); err == nil {
+				t.Errorf("CheckJSONSchema(%s) returned no error", tt.schema)
+			}
+		})
+	}
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		want     []SchemaError
+	}{
+		{"empty schema", `{}`, `{"a": [1, "b"]}`, nil},
+		{"trailing whitespace", `{} `, "1\n", nil},
+
+		{"type object", `{"type": "object"}`, `{}`, nil},
+		{"type array", `{"type": "array"}`, `[]`, nil},
+		{"type string", `{"type": "string"}`, `"a"`, nil},
+		{"type number", `{"type": "number"}`, `1.5`, nil},
+		{"type number accepts integer", `{"type": "number"}`, `2`, nil},
+		{"type integer", `{"type": "integer"}`, `2`, nil},
+		{"type integer rejects decimal", `{"type": "integer"}`, `2.5`, []SchemaError{{"", "must be of type integer"}}},
+		{"type boolean", `{"type": "boolean"}`, `false`, nil},
+		{"type null", `{"type": "null"}`, `null`, nil},
+		{"type mismatch", `{"type": "string"}`, `1`, []SchemaError{{"", "must be of type string"}}},
+		{"type list", `{"type": ["string", "null"]}`, `null`, nil},
+		{"type list mismatch", `{"type": ["string", "null"]}`, `true`, []SchemaError{{"", "must be of type string or null"}}},
+		{"type mismatch skips other keywords", `{"type": "string", "minLength": 5}`, `1`, []SchemaError{{"", "must be of type string"}}},
+
+		{"enum", `{"enum": ["a", 1]}`, `1`, nil},
+		{"enum mismatch", `{"enum": ["a", 1]}`, `"b"`, []SchemaError{{"", `must be one of "a", 1`}}},
+		{"const", `{"const": {"a": [1]}}`, `{"a": [1]}`, nil},
+		{"const number", `{"const": 1}`, `1.0`, nil},
+		{"const mismatch", `{"const": "a"}`, `"b"`, []SchemaError{{"", `must be "a"`}}},
+
+		{"properties", `{"properties": {"a": {"type": "string"}}}`, `{"a": "x", "b": 1}`, nil},
+		{"properties mismatch", `{"properties": {"a/b": {"type": "string"}}}`, `{"a/b": 1}`, []SchemaError{{"/a~1b", "must be of type string"}}},
+		{"required", `{"required": ["a"]}`, `{"a": null}`, nil},
+		{"required missing", `{"required": ["a", "b~c"]}`, `{}`, []SchemaError{{"/a", "is required"}, {"/b~0c", "is required"}}},
+		{"required ignores non-objects", `{"required": ["a"]}`, `[]`, nil},
+		{"additionalProperties false", `{"properties": {"a": {}}, "additionalProperties": false}`, `{"a": 1, "b": 2}`, []SchemaError{{"/b", "is not allowed"}}},
+		{"additionalProperties true", `{"properties": {"a": {}}, "additionalProperties": true}`, `{"a": 1, "b": 2}`, nil},
+		{"additionalProperties schema", `{"properties": {"a": {}}, "additionalProperties": {"type": "integer"}}`, `{"a": "x", "b": 2, "c": "y"}`, []SchemaError{{"/c", "must be of type integer"}}},
+		{"minProperties", `{"minProperties": 2}`, `{"a": 1}`, []SchemaError{{"", "must have at least 2 properties"}}},
+		{"maxProperties", `{"maxProperties": 1}`, `{"a": 1, "b": 2}`, []SchemaError{{"", "must have at most 1 properties"}}},
+
+		{"items", `{"items": {"type": "integer"}}`, `[1, "a", 2.5]`, []SchemaError{{"/1", "must be of type integer"}, {"/2", "must be of type integer"}}},
+		{"minItems", `{"minItems": 2}`, `[1]`, []SchemaError{{"", "must have at least 2 items"}}},
+		{"maxItems", `{"maxItems": 1}`, `[1, 2]`, []SchemaError{{"", "must have at most 1 items"}}},
+		{"uniqueItems", `{"uniqueItems": true}`, `[1, "1", {"a": 1}]`, nil},
+		{"uniqueItems duplicates", `{"uniqueItems": true}`, `[1, {"a": 1}, 1.0, {"a": 1}]`, []SchemaError{{"/2", "duplicates item 0"}, {"/3", "duplicates item 1"}}},
+		{"uniqueItems false", `{"uniqueItems": false}`, `[1, 1]`, nil},
+
+		{"minLength counts characters", `{"minLength": 2}`, `"éé"`, nil},
+		{"minLength", `{"minLength": 2}`, `"a"`, []SchemaError{{"", "must be at least 2 characters long"}}},
+		{"maxLength", `{"maxLength": 1}`, `"ab"`, []SchemaError{{"", "must be at most 1 characters long"}}},
+		{"pattern", `{"pattern": "^[a-z]+$"}`, `"abc"`, nil},
+		{"pattern mismatch", `{"pattern": "^[a-z]+$"}`, `"ABC"`, []SchemaError{{"", "must match pattern ^[a-z]+$"}}},
+		{"pattern is unanchored", `{"pattern": "b"}`, `"abc"`, nil},
+
+		{"format date", `{"format": "date"}`, `"2025-02-28"`, nil},
+		{"format date invalid", `{"format": "date"}`, `"2025-02-30"`, []SchemaError{{"", "must be a valid date"}}},
+		{"format date-time", `{"format": "date-time"}`, `"2025-02-28T10:00:00Z"`, nil},
+		{"format date-time invalid", `{"format": "date-time"}`, `"2025-02-28 10:00"`, []SchemaError{{"", "must be a valid date-time"}}},
+		{"format time", `{"format": "time"}`, `"10:00:00Z"`, nil},
+		{"format time invalid", `{"format": "time"}`, `"25:00:00Z"`, []SchemaError{{"", "must be a valid time"}}},
+		{"format email", `{"format": "email"}`, `"user@example.com"`, nil},
+		{"format email invalid", `{"format": "email"}`, `"user"`, []SchemaError{{"", "must be a valid email"}}},
+		{"format uri", `{"format": "uri"}`, `"https://example.com/a"`, nil},
+		{"format uri invalid", `{"format": "uri"}`, `"example"`, []SchemaError{{"", "must be a valid uri"}}},
+		{"format regex", `{"format": "regex"}`, `"^a+$"`, nil},
+		{"format regex invalid", `{"format": "regex"}`, `"("`, []SchemaError{{"", "must be a valid regex"}}},
+		{"format ignores non-strings", `{"format": "email"}`, `1`, nil},
+
+		{"minimum", `{"minimum": 1}`, `1`, nil},
+		{"minimum violated", `{"minimum": 1}`, `0.5`, []SchemaError{{"", "must be greater than or equal to 1"}}},
+		{"maximum", `{"maximum": 1.5}`, `1.5`, nil},
+		{"maximum violated", `{"maximum": 1.5}`, `2`, []SchemaError{{"", "must be less than or equal to 1.5"}}},
+		{"exclusiveMinimum", `{"exclusiveMinimum": 1}`, `1`, []SchemaError{{"", "must be greater than 1"}}},
+		{"exclusiveMaximum", `{"exclusiveMaximum": 1}`, `1`, []SchemaError{{"", "must be less than 1"}}},
+		{"exclusive bounds", `{"exclusiveMinimum": 0, "exclusiveMaximum": 1}`, `0.5`, nil},
+		{"multipleOf", `{"multipleOf": 0.1}`, `0.3`, nil},
+		{"multipleOf violated", `{"multipleOf": 2}`, `3`, []SchemaError{{"", "must be a multiple of 2"}}},
+
+		{"allOf", `{"allOf": [{"type": "string"}, {"minLength": 2}]}`, `"ab"`, nil},
+		{"allOf reports each violation", `{"allOf": [{"minLength": 3}, {"pattern": "^b"}]}`, `"ab"`, []SchemaError{
+			{"", "must be at least 3 characters long"},
+			{"", "must match pattern ^b"},
+		}},
+		{"anyOf", `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, `1`, nil},
+		{"anyOf none", `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, `true`, []SchemaError{{"", "must match at least one of the allowed schemas"}}},
+		{"oneOf", `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, `1`, nil},
+		{"oneOf none", `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, `true`, []SchemaError{{"", "must match exactly one of the allowed schemas"}}},
+		{"oneOf several", `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, `1`, []SchemaError{{"", "must match exactly one of the allowed schemas"}}},
+		{"not", `{"not": {"type": "null"}}`, `1`, nil},
+		{"not matched", `{"not": {"type": "null"}}`, `null`, []SchemaError{{"", "must not match the excluded schema"}}},
+
+		{"nested paths", `{"properties": {"a~b": {"items": {"properties": {"c": {"type": "string"}}}}}}`, `{"a~b": [{"c": "x"}, {"c": 1}]}`, []SchemaError{{"/a~0b/1/c", "must be of type string"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateJSONSchema([]byte(tt.schema), []byte(tt.document))
+			if err != nil {
+				t.Fatalf("ValidateJSONSchema: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateJSONSchema(%s, %s) = %v, want %v", tt.schema, tt.document, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateJSONSchemaInvalidJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		want     string
+	}{
+		{"malformed schema", `{"type": `, `1`, "invalid schema"},
+		{"schema not an object", `[]`, `1`, "invalid schema"},
+		{"schema with trailing data", `{} {}`, `1`, "invalid schema"},
+		{"malformed document", `{}`, `{"a": 1`, "invalid document"},
+		{"empty document", `{}`, ``, "invalid document"},
+		{"document with trailing value", `{}`, `1 2`, "invalid document"},
+		{"document with trailing closing bracket", `{}`, `{"a": 1}}`, "invalid document"},
+		{"document with trailing garbage", `{}`, `[1] x`, "invalid document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateJSONSchema([]byte(tt.schema), []byte(tt.document))
+			if err == nil {
+				t.Fatalf("ValidateJSONSchema(%s, %s) returned no error", tt.schema, tt.document)
+			}
+			if got := err.Error(); len(got) < len(tt.want) || got[:len(tt.want)] != tt.want {
+				t.Errorf("ValidateJSONSchema(%s, %s) error = %q, want prefix %q", tt.schema, tt.document, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaErrorError(t *testing.T) {
+	tests := []struct {
+		err  SchemaError
+		want string
+	}{
+		{SchemaError{Path: "", Message: "must be of type object"}, "must be of type object"},
+		{SchemaError{Path: "/a/0", Message: "is required"}, "/a/0: is required"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("%#v.Error() = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestNewSchemaValidationError(t *testing.T) {
+	base := errors.New("invalid submission")
+	err := NewSchemaValidationError(base, "/data", []SchemaError{
+		{Path: "", Message: "must have at least 1 properties"},
+		{Path: "/a", Message: "is required"},
+	})
+
+	if !errors.Is(err, base) {
+		t.Errorf("errors.Is(%v, %v) = false", err, base)
+	}
+	want := []SchemaError{
+		{Path: "/data", Message: "must have at least 1 properties"},
+		{Path: "/data/a", Message: "is required"},
+	}
+	if !slices.Equal(err.Errors, want) {
+		t.Errorf("Errors = %v, want %v", err.Errors, want)
+	}
+	if got := err.Error(); got != "invalid submission: /data: must have at least 1 properties; /data/a: is required" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestEscapeJSONPointer(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"a", "a"},
+		{"a/b", "a~1b"},
+		{"a~b", "a~0b"},
+		{"~/", "~0~1"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := EscapeJSONPointer(tt.token); got != tt.want {
+			t.Errorf("EscapeJSONPointer(%q) = %q, want %q", tt.token, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"yet-another-itsm/internal/constants"
+)
+
+// SCIMError is the SCIM error response body (RFC 7644 section 3.12). Status
+// is the HTTP status code as a string.
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// SendSCIM sends a SCIM resource or message as application/scim+json.
+func SendSCIM(c *gin.Context, statusCode int, body interface{}) {
+	c.Header("Content-Type", constants.SCIMContentType)
+	c.JSON(statusCode, body)
+}
+
+// SendSCIMError sends an error in the SCIM error schema. scimType is only set
+// for the 400 and 409 errors that define one.
+func SendSCIMError(c *gin.Context, statusCode int, scimType, detail string) {
+	SendSCIM(c, statusCode, SCIMError{
+		Schemas:  []string{constants.SCIMSchemaError},
+		Status:   strconv.Itoa(statusCode),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// SendSCIMNoContent answers a successful SCIM DELETE.
+func SendSCIMNoContent(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
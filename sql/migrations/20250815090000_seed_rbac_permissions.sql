@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    r.resource || '.' || a.action,
+    initcap(a.action) || ' ' || replace(r.resource, '_', ' '),
+    'Allows ' || a.action || ' on ' || replace(r.resource, '_', ' '),
+    r.resource,
+    a.action
+FROM (VALUES
+    ('business_units'),
+    ('departments'),
+    ('users'),
+    ('roles'),
+    ('permissions'),
+    ('scopes'),
+    ('role_permissions'),
+    ('role_assignments'),
+    ('form_categories'),
+    ('form_templates'),
+    ('form_sections')
+) AS r(resource)
+CROSS JOIN (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO roles (id, name, description, is_system_role)
+VALUES ('tenant_admin', 'Tenant Administrator', 'Full access to every resource in the tenant', true)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE role_id = 'tenant_admin';
+DELETE FROM roles WHERE id = 'tenant_admin';
+DELETE FROM permissions WHERE resource IN (
+    'business_units', 'departments', 'users', 'roles', 'permissions', 'scopes',
+    'role_permissions', 'role_assignments', 'form_categories', 'form_templates', 'form_sections'
+) AND action IN ('read', 'create', 'update', 'delete');
+-- +goose StatementEnd
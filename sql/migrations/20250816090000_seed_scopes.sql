@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO scopes (id, name, description)
+VALUES
+    ('own', 'Own', 'Only resources created by or assigned to the user'),
+    ('department', 'Department', 'Resources owned by the department of the role assignment'),
+    ('business_unit', 'Business Unit', 'Resources owned by the business unit of the role assignment'),
+    ('tenant', 'Tenant', 'All resources in the tenant')
+ON CONFLICT (id) DO NOTHING;
+
+UPDATE role_permissions
+SET scope_id = 'tenant', updated_at = CURRENT_TIMESTAMP
+WHERE role_id = 'tenant_admin' AND scope_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE role_permissions
+SET scope_id = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE role_id = 'tenant_admin' AND scope_id = 'tenant';
+
+DELETE FROM scopes WHERE id IN ('own', 'department', 'business_unit', 'tenant');
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE form_templates ADD COLUMN IF NOT EXISTS department_id UUID REFERENCES departments(id);
+
+-- Add indexes
+CREATE INDEX IF NOT EXISTS idx_form_templates_department ON form_templates(department_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_form_templates_created_by ON form_templates(created_by) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_form_templates_created_by;
+DROP INDEX IF EXISTS idx_form_templates_department;
+ALTER TABLE form_templates DROP COLUMN IF EXISTS department_id;
+-- +goose StatementEnd
@@ -0,0 +1,107 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE roles
+    ADD COLUMN IF NOT EXISTS parent_role_id VARCHAR(50) REFERENCES roles(id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_roles_parent_not_self CHECK (parent_role_id IS NULL OR parent_role_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_roles_parent_role_id ON roles(parent_role_id);
+
+-- Every permission a user holds through an active, non-expired assignment,
+-- including permissions inherited from ancestors of the assigned role.
+-- depth is 0 for the directly assigned role permission and n for the n-th ancestor.
+CREATE OR REPLACE FUNCTION user_permission_grants(p_assignee_id UUID)
+RETURNS TABLE (
+    role_assignment_id UUID,
+    role_id VARCHAR(50),
+    role_name VARCHAR(255),
+    source_role_id VARCHAR(50),
+    resource VARCHAR(100),
+    action VARCHAR(50),
+    scope_id VARCHAR(50),
+    business_unit_id UUID,
+    department_id UUID,
+    assigned_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    depth INTEGER
+)
+LANGUAGE sql STABLE
+AS $$
+    WITH RECURSIVE assigned AS (
+        SELECT
+            ra.id AS role_assignment_id,
+            ra.role_permissions_id,
+            rp.role_id,
+            ra.business_unit_id,
+            ra.department_id,
+            ra.assigned_at,
+            ra.expires_at
+        FROM role_assignment ra
+        JOIN role_permissions rp ON ra.role_permissions_id = rp.id
+        WHERE ra.assignee_id = p_assignee_id
+            AND ra.status = 'active'
+            AND ra.deleted_at IS NULL
+            AND rp.status = 'active'
+            AND rp.deleted_at IS NULL
+            AND (ra.expires_at IS NULL OR ra.expires_at > CURRENT_TIMESTAMP)
+    ),
+    ancestors AS (
+        SELECT a.role_assignment_id, r.parent_role_id AS role_id, 1 AS depth, ARRAY[a.role_id]::VARCHAR[] AS path
+        FROM assigned a
+        JOIN roles r ON r.id = a.role_id
+        WHERE r.parent_role_id IS NOT NULL
+        UNION ALL
+        SELECT an.role_assignment_id, r.parent_role_id, an.depth + 1, an.path || an.role_id
+        FROM ancestors an
+        JOIN roles r ON r.id = an.role_id
+        WHERE r.parent_role_id IS NOT NULL
+            AND NOT r.parent_role_id = ANY(an.path || an.role_id)
+    )
+    SELECT
+        a.role_assignment_id,
+        a.role_id,
+        r.name,
+        a.role_id,
+        p.resource,
+        p.action,
+        rp.scope_id,
+        COALESCE(a.business_unit_id, u.business_unit_id),
+        COALESCE(a.department_id, u.department_id),
+        a.assigned_at,
+        a.expires_at,
+        0
+    FROM assigned a
+    JOIN role_permissions rp ON rp.id = a.role_permissions_id
+    JOIN roles r ON r.id = a.role_id
+    JOIN permissions p ON p.id = rp.permission_id
+    LEFT JOIN users u ON u.id = p_assignee_id
+    UNION ALL
+    SELECT
+        a.role_assignment_id,
+        a.role_id,
+        r.name,
+        an.role_id,
+        p.resource,
+        p.action,
+        rp.scope_id,
+        COALESCE(a.business_unit_id, u.business_unit_id),
+        COALESCE(a.department_id, u.department_id),
+        a.assigned_at,
+        a.expires_at,
+        an.depth
+    FROM ancestors an
+    JOIN assigned a ON a.role_assignment_id = an.role_assignment_id
+    JOIN roles r ON r.id = a.role_id
+    JOIN roles ar ON ar.id = an.role_id AND ar.deleted_at IS NULL
+    JOIN role_permissions rp ON rp.role_id = an.role_id AND rp.status = 'active' AND rp.deleted_at IS NULL
+    JOIN permissions p ON p.id = rp.permission_id
+    LEFT JOIN users u ON u.id = p_assignee_id
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS user_permission_grants(UUID);
+DROP INDEX IF EXISTS idx_roles_parent_role_id;
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS chk_roles_parent_not_self;
+ALTER TABLE roles DROP COLUMN IF EXISTS parent_role_id;
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Separation-of-duties: a user may not hold both roles of an active constraint,
+-- either directly or through role inheritance.
+CREATE TABLE IF NOT EXISTS sod_constraints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    role_a_id VARCHAR(50) NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    role_b_id VARCHAR(50) NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id),
+    status status_enum DEFAULT 'active',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE NULL,
+    CONSTRAINT chk_sod_constraints_distinct_roles CHECK (role_a_id <> role_b_id)
+);
+
+-- A pair is unordered, so (a, b) and (b, a) are the same constraint.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_sod_constraints_role_pair
+    ON sod_constraints (LEAST(role_a_id, role_b_id), GREATEST(role_a_id, role_b_id))
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sod_constraints_role_a_id ON sod_constraints(role_a_id);
+CREATE INDEX IF NOT EXISTS idx_sod_constraints_role_b_id ON sod_constraints(role_b_id);
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'sod_constraints.' || a.action,
+    initcap(a.action) || ' sod constraints',
+    'Allows ' || a.action || ' on sod constraints',
+    'sod_constraints',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'sod_constraints'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'sod_constraints'
+);
+DELETE FROM permissions WHERE resource = 'sod_constraints';
+DROP INDEX IF EXISTS idx_sod_constraints_role_b_id;
+DROP INDEX IF EXISTS idx_sod_constraints_role_a_id;
+DROP INDEX IF EXISTS uq_sod_constraints_role_pair;
+DROP TABLE IF EXISTS sod_constraints;
+-- +goose StatementEnd
@@ -0,0 +1,80 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE access_review_status AS ENUM ('active', 'completed');
+CREATE TYPE access_review_decision AS ENUM ('pending', 'approved', 'revoked');
+
+-- A campaign recertifies every active role assignment matching its scope.
+-- Empty role_ids or business_unit_ids means no restriction on that dimension.
+CREATE TABLE IF NOT EXISTS access_review_campaigns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    role_ids VARCHAR(50)[] NOT NULL DEFAULT '{}',
+    business_unit_ids UUID[] NOT NULL DEFAULT '{}',
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status access_review_status NOT NULL DEFAULT 'active',
+    created_by UUID NOT NULL REFERENCES users(id),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One item per reviewed assignment. Role, permission and business unit are
+-- copied at campaign start so the audit export is stable.
+CREATE TABLE IF NOT EXISTS access_review_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    campaign_id UUID NOT NULL REFERENCES access_review_campaigns(id) ON DELETE CASCADE,
+    role_assignment_id UUID NOT NULL REFERENCES role_assignment(id) ON DELETE CASCADE,
+    assignee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reviewer_id UUID NOT NULL REFERENCES users(id),
+    role_id VARCHAR(50) NOT NULL,
+    permission_id VARCHAR(50) NOT NULL,
+    business_unit_id UUID,
+    decision access_review_decision NOT NULL DEFAULT 'pending',
+    auto_revoked BOOLEAN NOT NULL DEFAULT false,
+    decided_by UUID REFERENCES users(id),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(campaign_id, role_assignment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_review_campaigns_status_ends_at ON access_review_campaigns(status, ends_at);
+CREATE INDEX IF NOT EXISTS idx_access_review_items_campaign_id ON access_review_items(campaign_id);
+CREATE INDEX IF NOT EXISTS idx_access_review_items_reviewer_id ON access_review_items(reviewer_id, decision);
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'access_reviews.' || a.action,
+    initcap(a.action) || ' access reviews',
+    'Allows ' || a.action || ' on access reviews',
+    'access_reviews',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'access_reviews'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'access_reviews'
+);
+DELETE FROM permissions WHERE resource = 'access_reviews';
+DROP INDEX IF EXISTS idx_access_review_items_reviewer_id;
+DROP INDEX IF EXISTS idx_access_review_items_campaign_id;
+DROP INDEX IF EXISTS idx_access_review_campaigns_status_ends_at;
+DROP TABLE IF EXISTS access_review_items;
+DROP TABLE IF EXISTS access_review_campaigns;
+DROP TYPE IF EXISTS access_review_decision;
+DROP TYPE IF EXISTS access_review_status;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE directory_claim_type AS ENUM ('app_role', 'group');
+
+-- Maps an Entra app role value or group object ID to an internal role. At login
+-- every active role permission of the mapped role is assigned to users carrying
+-- the claim, and assignments whose claim disappeared are revoked.
+CREATE TABLE IF NOT EXISTS directory_role_mappings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    claim_type directory_claim_type NOT NULL,
+    claim_value VARCHAR(255) NOT NULL,
+    role_id VARCHAR(50) NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    business_unit_id UUID REFERENCES business_units(id),
+    description TEXT,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_directory_role_mappings_claim_role
+    ON directory_role_mappings (claim_type, claim_value, role_id, COALESCE(business_unit_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_directory_role_mappings_role_id ON directory_role_mappings(role_id);
+
+-- Assignments created from a mapping are owned by the directory and cannot be
+-- edited or revoked through the role assignment API.
+ALTER TABLE role_assignment
+    ADD COLUMN IF NOT EXISTS directory_mapping_id UUID REFERENCES directory_role_mappings(id);
+CREATE INDEX IF NOT EXISTS idx_role_assignment_directory_mapping_id ON role_assignment(directory_mapping_id);
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'directory_role_mappings.' || a.action,
+    initcap(a.action) || ' directory role mappings',
+    'Allows ' || a.action || ' on directory role mappings',
+    'directory_role_mappings',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'directory_role_mappings'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'directory_role_mappings'
+);
+DELETE FROM permissions WHERE resource = 'directory_role_mappings';
+DROP INDEX IF EXISTS idx_role_assignment_directory_mapping_id;
+ALTER TABLE role_assignment DROP COLUMN IF EXISTS directory_mapping_id;
+DROP INDEX IF EXISTS idx_directory_role_mappings_role_id;
+DROP INDEX IF EXISTS uq_directory_role_mappings_claim_role;
+DROP TABLE IF EXISTS directory_role_mappings;
+DROP TYPE IF EXISTS directory_claim_type;
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE elevation_request_status AS ENUM ('pending', 'approved', 'rejected', 'cancelled', 'expired');
+
+-- Just-in-time elevation: a user asks for a role for a bounded duration and an
+-- approver grants it as role assignments that expire with the request. Rows are
+-- never deleted so the table doubles as the audit trail.
+CREATE TABLE IF NOT EXISTS elevation_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    requester_id UUID NOT NULL REFERENCES users(id),
+    role_id VARCHAR(50) NOT NULL REFERENCES roles(id),
+    business_unit_id UUID REFERENCES business_units(id),
+    justification TEXT NOT NULL,
+    duration_minutes INTEGER NOT NULL,
+    status elevation_request_status NOT NULL DEFAULT 'pending',
+    decided_by UUID REFERENCES users(id),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_comment TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_elevation_requests_duration CHECK (duration_minutes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_elevation_requests_requester_id ON elevation_requests(requester_id);
+CREATE INDEX IF NOT EXISTS idx_elevation_requests_status ON elevation_requests(status);
+CREATE INDEX IF NOT EXISTS idx_elevation_requests_expires_at ON elevation_requests(expires_at) WHERE status = 'approved';
+
+ALTER TABLE role_assignment
+    ADD COLUMN IF NOT EXISTS elevation_request_id UUID REFERENCES elevation_requests(id);
+CREATE INDEX IF NOT EXISTS idx_role_assignment_elevation_request_id ON role_assignment(elevation_request_id);
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'elevation_requests.' || a.action,
+    initcap(a.action) || ' elevation requests',
+    'Allows ' || a.action || ' on elevation requests',
+    'elevation_requests',
+    a.action
+FROM (VALUES ('read'), ('approve')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'elevation_requests'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'elevation_requests'
+);
+DELETE FROM permissions WHERE resource = 'elevation_requests';
+DROP INDEX IF EXISTS idx_role_assignment_elevation_request_id;
+ALTER TABLE role_assignment DROP COLUMN IF EXISTS elevation_request_id;
+DROP INDEX IF EXISTS idx_elevation_requests_expires_at;
+DROP INDEX IF EXISTS idx_elevation_requests_status;
+DROP INDEX IF EXISTS idx_elevation_requests_requester_id;
+DROP TABLE IF EXISTS elevation_requests;
+DROP TYPE IF EXISTS elevation_request_status;
+-- +goose StatementEnd
@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Service principals are non-interactive callers such as monitoring and
+-- automation scripts. Each one is backed by a users row so role assignments and
+-- permission checks apply unchanged: the row's azure_ad_object_id is the Entra
+-- service principal object ID for app-only tokens, or a generated identifier for
+-- principals that only authenticate with API keys.
+CREATE TABLE IF NOT EXISTS service_principals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    display_name VARCHAR(255) NOT NULL,
+    description TEXT,
+    app_id VARCHAR(255),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_service_principals_app_id
+    ON service_principals(app_id)
+    WHERE app_id IS NOT NULL AND deleted_at IS NULL;
+
+-- Only a SHA-256 hash of each key is stored; key_prefix identifies the key on
+-- lookup and in listings. Rotation links the new key to the one it replaces.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_principal_id UUID NOT NULL REFERENCES service_principals(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(32) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip VARCHAR(64),
+    rotated_from UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_service_principal_id ON api_keys(service_principal_id);
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'service_principals.' || a.action,
+    initcap(a.action) || ' service principals',
+    'Allows ' || a.action || ' on service principals and their API keys',
+    'service_principals',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'service_principals'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'service_principals'
+);
+DELETE FROM permissions WHERE resource = 'service_principals';
+DROP INDEX IF EXISTS idx_api_keys_service_principal_id;
+DROP TABLE IF EXISTS api_keys;
+DROP INDEX IF EXISTS uq_service_principals_app_id;
+DROP TABLE IF EXISTS service_principals;
+-- +goose StatementEnd
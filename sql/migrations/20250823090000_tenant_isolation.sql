@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Guest and partner tenants keep their own business units, so a domain name is
+-- only unique within a tenant.
+ALTER TABLE business_units DROP CONSTRAINT IF EXISTS business_units_domain_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_business_units_tenant_domain_name ON business_units(tenant_id, domain_name);
+
+-- User lookups by email are scoped to the caller's home tenant.
+CREATE INDEX IF NOT EXISTS idx_users_home_tenant_mail ON users(home_tenant_id, mail);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_home_tenant_mail;
+DROP INDEX IF EXISTS idx_business_units_tenant_domain_name;
+ALTER TABLE business_units ADD CONSTRAINT business_units_domain_name_key UNIQUE (domain_name);
+-- +goose StatementEnd
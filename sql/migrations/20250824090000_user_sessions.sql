@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Server-side sessions of the browser login flow. The session cookie only
+-- carries the encrypted session ID; the tokens obtained from the identity
+-- provider stay here, encrypted with SESSION_ENCRYPTION_KEY.
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    object_id VARCHAR(255) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    access_token BYTEA NOT NULL,
+    access_token_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    refresh_token BYTEA,
+    csrf_token VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_object_id ON user_sessions(object_id);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_expires_at ON user_sessions(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_sessions_expires_at;
+DROP INDEX IF EXISTS idx_user_sessions_object_id;
+DROP TABLE IF EXISTS user_sessions;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Audit trail of administrative suspensions, temporary locks and unlocks.
+-- users.status and users.locked_until hold the current state; this table
+-- records who changed it and why.
+CREATE TABLE IF NOT EXISTS user_status_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('suspend', 'lock', 'unlock')),
+    reason TEXT NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_status_changes_user_id ON user_status_changes(user_id, created_at DESC);
+
+-- Authentication resolves the account status by object ID on every request.
+CREATE INDEX IF NOT EXISTS idx_users_azure_ad_object_id ON users(azure_ad_object_id, home_tenant_id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_azure_ad_object_id;
+DROP INDEX IF EXISTS idx_user_status_changes_user_id;
+DROP TABLE IF EXISTS user_status_changes;
+-- +goose StatementEnd
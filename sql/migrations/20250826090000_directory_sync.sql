@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Delta links of the Microsoft Graph directory sync, one per tenant and
+-- synced resource. A missing row or NULL delta_link starts a full sync.
+CREATE TABLE IF NOT EXISTS directory_sync_state (
+    tenant_id UUID NOT NULL,
+    resource VARCHAR(50) NOT NULL,
+    delta_link TEXT,
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    last_full_sync_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, resource)
+);
+
+-- Directory sync deactivates users whose account was disabled or deleted in
+-- Entra ID, and reactivates them when the account is enabled again.
+ALTER TABLE user_status_changes DROP CONSTRAINT IF EXISTS user_status_changes_action_check;
+ALTER TABLE user_status_changes ADD CONSTRAINT user_status_changes_action_check
+    CHECK (action IN ('suspend', 'lock', 'unlock', 'deactivate', 'reactivate'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM user_status_changes WHERE action IN ('deactivate', 'reactivate');
+ALTER TABLE user_status_changes DROP CONSTRAINT IF EXISTS user_status_changes_action_check;
+ALTER TABLE user_status_changes ADD CONSTRAINT user_status_changes_action_check
+    CHECK (action IN ('suspend', 'lock', 'unlock'));
+DROP TABLE IF EXISTS directory_sync_state;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Profile attributes refreshed from the identity provider at login. One row
+-- per changed field; department and manager changes store internal IDs.
+CREATE TABLE IF NOT EXISTS user_profile_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_profile_changes_user_id ON user_profile_changes(user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_profile_changes_user_id;
+DROP TABLE IF EXISTS user_profile_changes;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- GET /v1/users searches display name, mail and job title with prefix
+-- matching; the expression must stay identical to the one in SearchUsers.
+CREATE INDEX IF NOT EXISTS idx_users_search ON users
+    USING GIN (to_tsvector('simple', display_name || ' ' || mail || ' ' || COALESCE(job_title, '')))
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_home_tenant_business_unit ON users(home_tenant_id, business_unit_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_home_tenant_status ON users(home_tenant_id, status) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_home_tenant_display_name ON users(home_tenant_id, lower(display_name), id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_home_tenant_display_name;
+DROP INDEX IF EXISTS idx_users_home_tenant_status;
+DROP INDEX IF EXISTS idx_users_home_tenant_business_unit;
+DROP INDEX IF EXISTS idx_users_search;
+-- +goose StatementEnd
@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Groups pushed by a SCIM client such as Entra provisioning. external_id holds
+-- the directory's group object ID, so directory role mappings of claim type
+-- 'group' apply to SCIM groups the same way they apply to token group claims.
+CREATE TABLE IF NOT EXISTS scim_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    external_id VARCHAR(255),
+    display_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_scim_groups_tenant_display_name
+    ON scim_groups(tenant_id, lower(display_name));
+CREATE INDEX IF NOT EXISTS idx_scim_groups_tenant_external_id
+    ON scim_groups(tenant_id, external_id);
+
+CREATE TABLE IF NOT EXISTS scim_group_members (
+    group_id UUID NOT NULL REFERENCES scim_groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scim_group_members_user_id ON scim_group_members(user_id);
+
+-- SCIM clients authenticate with a service principal API key; these
+-- permissions let an administrator grant provisioning to one principal.
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'scim.' || a.action,
+    initcap(a.action) || ' SCIM resources',
+    'Allows ' || a.action || ' on users and groups through the SCIM provisioning endpoint',
+    'scim',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'scim'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'scim'
+);
+DELETE FROM permissions WHERE resource = 'scim';
+DROP INDEX IF EXISTS idx_scim_group_members_user_id;
+DROP TABLE IF EXISTS scim_group_members;
+DROP INDEX IF EXISTS idx_scim_groups_tenant_external_id;
+DROP INDEX IF EXISTS uq_scim_groups_tenant_display_name;
+DROP TABLE IF EXISTS scim_groups;
+-- +goose StatementEnd
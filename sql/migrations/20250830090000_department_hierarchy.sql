@@ -0,0 +1,116 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Departments belong to a business unit and may have a parent department of
+-- the same business unit. Names are unique per business unit instead of
+-- globally.
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS departments_name_key;
+
+ALTER TABLE departments
+    ADD COLUMN IF NOT EXISTS business_unit_id UUID REFERENCES business_units(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES departments(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT chk_departments_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+-- Every business unit an existing department is used in, through users, role
+-- assignments and form templates.
+CREATE TEMP TABLE department_business_units ON COMMIT DROP AS
+SELECT
+    refs.department_id,
+    refs.business_unit_id,
+    row_number() OVER (
+        PARTITION BY refs.department_id
+        ORDER BY count(*) DESC, refs.business_unit_id
+    ) AS rank
+FROM (
+    SELECT department_id, business_unit_id FROM users
+    UNION ALL
+    SELECT department_id, business_unit_id FROM role_assignment
+    UNION ALL
+    SELECT department_id, business_unit_id FROM form_templates
+) refs
+WHERE refs.department_id IS NOT NULL AND refs.business_unit_id IS NOT NULL
+GROUP BY refs.department_id, refs.business_unit_id;
+
+-- The business unit using a department most keeps it.
+UPDATE departments d
+SET business_unit_id = dbu.business_unit_id
+FROM department_business_units dbu
+WHERE dbu.department_id = d.id AND dbu.rank = 1;
+
+-- Every other business unit gets a copy of the department, and its users, role
+-- assignments and form templates move to the copy.
+CREATE TEMP TABLE department_copies ON COMMIT DROP AS
+SELECT department_id, business_unit_id, gen_random_uuid() AS copy_id
+FROM department_business_units
+WHERE rank > 1;
+
+INSERT INTO departments (id, business_unit_id, name, status, created_at, updated_at, deleted_at)
+SELECT c.copy_id, c.business_unit_id, d.name, d.status, d.created_at, d.updated_at, d.deleted_at
+FROM department_copies c
+JOIN departments d ON d.id = c.department_id;
+
+UPDATE users u
+SET department_id = c.copy_id
+FROM department_copies c
+WHERE u.department_id = c.department_id AND u.business_unit_id = c.business_unit_id;
+
+UPDATE role_assignment ra
+SET department_id = c.copy_id
+FROM department_copies c
+WHERE ra.department_id = c.department_id AND ra.business_unit_id = c.business_unit_id;
+
+UPDATE form_templates ft
+SET department_id = c.copy_id
+FROM department_copies c
+WHERE ft.department_id = c.department_id AND ft.business_unit_id = c.business_unit_id;
+
+-- Departments nothing refers to cannot be attributed and are dropped; they
+-- are recreated by name on the next sign-in that needs them. Departments only
+-- referred to without a business unit keep none until moved into one.
+DELETE FROM departments d
+WHERE d.business_unit_id IS NULL
+    AND NOT EXISTS (SELECT 1 FROM users u WHERE u.department_id = d.id)
+    AND NOT EXISTS (SELECT 1 FROM role_assignment ra WHERE ra.department_id = d.id)
+    AND NOT EXISTS (SELECT 1 FROM form_templates ft WHERE ft.department_id = d.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_departments_business_unit_name
+    ON departments(business_unit_id, name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_departments_parent_id ON departments(parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Departments sharing a name merge into the oldest of them.
+CREATE TEMP TABLE department_merges ON COMMIT DROP AS
+SELECT
+    id,
+    first_value(id) OVER (PARTITION BY name ORDER BY created_at, id) AS keep_id
+FROM departments;
+
+UPDATE users u
+SET department_id = m.keep_id
+FROM department_merges m
+WHERE u.department_id = m.id AND m.id <> m.keep_id;
+
+UPDATE role_assignment ra
+SET department_id = m.keep_id
+FROM department_merges m
+WHERE ra.department_id = m.id AND m.id <> m.keep_id;
+
+UPDATE form_templates ft
+SET department_id = m.keep_id
+FROM department_merges m
+WHERE ft.department_id = m.id AND m.id <> m.keep_id;
+
+UPDATE departments SET parent_id = NULL;
+
+DELETE FROM departments d
+USING department_merges m
+WHERE d.id = m.id AND m.id <> m.keep_id;
+
+DROP INDEX IF EXISTS idx_departments_parent_id;
+DROP INDEX IF EXISTS idx_departments_business_unit_name;
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS chk_departments_parent_not_self;
+ALTER TABLE departments DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE departments DROP COLUMN IF EXISTS business_unit_id;
+ALTER TABLE departments ADD CONSTRAINT departments_name_key UNIQUE (name);
+-- +goose StatementEnd
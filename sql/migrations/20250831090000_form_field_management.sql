@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Field names and orders are unique among the live fields of a section, so a
+-- deleted field no longer blocks its name or position. Fields without a
+-- section share one unsectioned group.
+ALTER TABLE form_fields DROP CONSTRAINT IF EXISTS form_fields_form_template_id_form_section_id_field_name_key;
+ALTER TABLE form_fields DROP CONSTRAINT IF EXISTS form_fields_form_template_id_form_section_id_field_order_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_fields_section_name
+    ON form_fields(form_template_id, form_section_id, field_name) NULLS NOT DISTINCT
+    WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_fields_section_order
+    ON form_fields(form_template_id, form_section_id, field_order) NULLS NOT DISTINCT
+    WHERE deleted_at IS NULL;
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'form_fields.' || a.action,
+    initcap(a.action) || ' form fields',
+    'Allows ' || a.action || ' on form fields',
+    'form_fields',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'form_fields'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'form_fields'
+);
+DELETE FROM permissions WHERE resource = 'form_fields';
+
+DROP INDEX IF EXISTS idx_form_fields_section_order;
+DROP INDEX IF EXISTS idx_form_fields_section_name;
+ALTER TABLE form_fields ADD CONSTRAINT form_fields_form_template_id_form_section_id_field_name_key
+    UNIQUE (form_template_id, form_section_id, field_name);
+ALTER TABLE form_fields ADD CONSTRAINT form_fields_form_template_id_form_section_id_field_order_key
+    UNIQUE (form_template_id, form_section_id, field_order);
+-- +goose StatementEnd
@@ -0,0 +1,281 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Type names are unique among live field types only, so a deleted type no
+-- longer blocks its name. Built-in types ship with the application and cannot
+-- be deleted.
+ALTER TABLE field_types DROP CONSTRAINT IF EXISTS field_types_type_name_key;
+ALTER TABLE field_types ADD COLUMN IF NOT EXISTS is_builtin BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_form_fields_field_type ON form_fields(field_type) WHERE deleted_at IS NULL;
+
+-- Every built-in config accepts label, description, placeholder and required;
+-- the remaining keys are what submissions are validated against.
+WITH builtin(type_name, description, validation_schema) AS (
+    VALUES
+    ('text', 'Single line of text', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"},
+            "default": {"type": "string"},
+            "min_length": {"type": "integer", "minimum": 0},
+            "max_length": {"type": "integer", "minimum": 1},
+            "pattern": {"type": "string", "format": "regex"}
+        }
+    }'),
+    ('textarea', 'Multiple lines of text', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"},
+            "default": {"type": "string"},
+            "min_length": {"type": "integer", "minimum": 0},
+            "max_length": {"type": "integer", "minimum": 1},
+            "rows": {"type": "integer", "minimum": 1, "maximum": 50}
+        }
+    }'),
+    ('number', 'Numeric value', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"},
+            "default": {"type": "number"},
+            "min": {"type": "number"},
+            "max": {"type": "number"},
+            "step": {"type": "number", "exclusiveMinimum": 0},
+            "integer": {"type": "boolean"}
+        }
+    }'),
+    ('email', 'Email address', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"}
+        }
+    }'),
+    ('url', 'Web address', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"}
+        }
+    }'),
+    ('select', 'One value from a drop-down list', '{
+        "type": "object",
+        "required": ["label", "options"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"},
+            "default": {"type": "string"},
+            "options": {
+                "type": "array",
+                "minItems": 1,
+                "uniqueItems": true,
+                "items": {
+                    "type": "object",
+                    "required": ["value"],
+                    "additionalProperties": false,
+                    "properties": {
+                        "value": {"type": "string", "minLength": 1},
+                        "label": {"type": "string"}
+                    }
+                }
+            }
+        }
+    }'),
+    ('multiselect', 'Any number of values from a list', '{
+        "type": "object",
+        "required": ["label", "options"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"},
+            "default": {"type": "array", "items": {"type": "string"}},
+            "min_selected": {"type": "integer", "minimum": 0},
+            "max_selected": {"type": "integer", "minimum": 1},
+            "options": {
+                "type": "array",
+                "minItems": 1,
+                "uniqueItems": true,
+                "items": {
+                    "type": "object",
+                    "required": ["value"],
+                    "additionalProperties": false,
+                    "properties": {
+                        "value": {"type": "string", "minLength": 1},
+                        "label": {"type": "string"}
+                    }
+                }
+            }
+        }
+    }'),
+    ('radio', 'One value from a list of choices', '{
+        "type": "object",
+        "required": ["label", "options"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "required": {"type": "boolean"},
+            "default": {"type": "string"},
+            "options": {
+                "type": "array",
+                "minItems": 1,
+                "uniqueItems": true,
+                "items": {
+                    "type": "object",
+                    "required": ["value"],
+                    "additionalProperties": false,
+                    "properties": {
+                        "value": {"type": "string", "minLength": 1},
+                        "label": {"type": "string"}
+                    }
+                }
+            }
+        }
+    }'),
+    ('checkbox', 'Yes or no', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "required": {"type": "boolean"},
+            "default": {"type": "boolean"}
+        }
+    }'),
+    ('date', 'Calendar date', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"},
+            "min": {"type": "string", "format": "date"},
+            "max": {"type": "string", "format": "date"}
+        }
+    }'),
+    ('datetime', 'Date and time of day', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"},
+            "min": {"type": "string", "format": "date-time"},
+            "max": {"type": "string", "format": "date-time"}
+        }
+    }'),
+    ('time', 'Time of day', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "placeholder": {"type": "string"},
+            "required": {"type": "boolean"}
+        }
+    }'),
+    ('file', 'File upload', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "required": {"type": "boolean"},
+            "accept": {"type": "array", "items": {"type": "string", "minLength": 1}, "uniqueItems": true},
+            "max_size_mb": {"type": "number", "exclusiveMinimum": 0, "maximum": 100},
+            "max_files": {"type": "integer", "minimum": 1, "maximum": 20}
+        }
+    }'),
+    ('signature', 'Hand-drawn signature', '{
+        "type": "object",
+        "required": ["label"],
+        "additionalProperties": false,
+        "properties": {
+            "label": {"type": "string", "minLength": 1, "maxLength": 255},
+            "description": {"type": "string"},
+            "required": {"type": "boolean"}
+        }
+    }')
+),
+updated AS (
+    UPDATE field_types ft
+    SET
+        description = b.description,
+        validation_schema = b.validation_schema::jsonb,
+        is_builtin = true,
+        updated_at = CURRENT_TIMESTAMP
+    FROM builtin b
+    WHERE ft.type_name = b.type_name AND ft.deleted_at IS NULL
+    RETURNING ft.type_name
+)
+INSERT INTO field_types (type_name, description, validation_schema, is_builtin)
+SELECT b.type_name, b.description, b.validation_schema::jsonb, true
+FROM builtin b
+WHERE b.type_name NOT IN (SELECT type_name FROM updated);
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'field_types.' || a.action,
+    initcap(a.action) || ' field types',
+    'Allows ' || a.action || ' on field types',
+    'field_types',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'field_types'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'field_types'
+);
+DELETE FROM permissions WHERE resource = 'field_types';
+
+DELETE FROM field_types WHERE is_builtin;
+DROP INDEX IF EXISTS idx_form_fields_field_type;
+ALTER TABLE field_types DROP COLUMN IF EXISTS is_builtin;
+ALTER TABLE field_types ADD CONSTRAINT field_types_type_name_key UNIQUE (type_name);
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every version of a form template is its own row; versions of the same form
+-- share a lineage_id, the id of the first version. Only drafts can be edited.
+-- A draft goes to review, is approved into the published version and is
+-- retired when a newer version is published or it is withdrawn.
+CREATE TYPE form_template_state AS ENUM ('draft', 'in_review', 'published', 'retired');
+
+ALTER TABLE form_templates
+    ADD COLUMN IF NOT EXISTS lineage_id UUID,
+    ADD COLUMN IF NOT EXISTS state form_template_state NOT NULL DEFAULT 'draft',
+    ADD COLUMN IF NOT EXISTS approved_by UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS approved_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS retired_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE form_templates SET lineage_id = id WHERE lineage_id IS NULL;
+UPDATE form_templates SET version = 1 WHERE version IS NULL;
+UPDATE form_templates SET state = 'published' WHERE published_at IS NOT NULL;
+
+ALTER TABLE form_templates
+    ALTER COLUMN lineage_id SET NOT NULL,
+    ALTER COLUMN version SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_templates_lineage_version
+    ON form_templates(lineage_id, version);
+-- At most one version of a form is being edited and one is published.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_templates_lineage_open
+    ON form_templates(lineage_id) WHERE state IN ('draft', 'in_review') AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_templates_lineage_published
+    ON form_templates(lineage_id) WHERE state = 'published' AND deleted_at IS NULL;
+
+INSERT INTO permissions (id, name, description, resource, action)
+VALUES (
+    'form_templates.approve',
+    'Approve form templates',
+    'Allows approve on form templates',
+    'form_templates',
+    'approve'
+)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', 'form_templates.approve'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = 'form_templates.approve'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id = 'form_templates.approve';
+DELETE FROM permissions WHERE id = 'form_templates.approve';
+
+DROP INDEX IF EXISTS idx_form_templates_lineage_published;
+DROP INDEX IF EXISTS idx_form_templates_lineage_open;
+DROP INDEX IF EXISTS idx_form_templates_lineage_version;
+
+ALTER TABLE form_templates
+    ALTER COLUMN version DROP NOT NULL,
+    DROP COLUMN IF EXISTS retired_at,
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS approved_by,
+    DROP COLUMN IF EXISTS state,
+    DROP COLUMN IF EXISTS lineage_id;
+
+DROP TYPE IF EXISTS form_template_state;
+-- +goose StatementEnd
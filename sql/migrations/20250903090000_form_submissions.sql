@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A submission holds the answers to one published version of a form, keyed by
+-- field name. Drafts are private to their submitter and validated loosely;
+-- submitting validates every field and freezes the answers. The business unit
+-- and department are the template's, for scoped permission checks.
+CREATE TYPE form_submission_state AS ENUM ('draft', 'submitted');
+
+CREATE TABLE IF NOT EXISTS form_submissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    form_template_id UUID NOT NULL REFERENCES form_templates(id),
+    lineage_id UUID NOT NULL,
+    business_unit_id UUID REFERENCES business_units(id),
+    department_id UUID REFERENCES departments(id),
+    submitted_by UUID NOT NULL REFERENCES users(id),
+    state form_submission_state NOT NULL DEFAULT 'draft',
+    answers JSONB NOT NULL DEFAULT '{}',
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    status status_enum DEFAULT 'active',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_form_submissions_lineage ON form_submissions(lineage_id, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_form_submissions_submitted_by ON form_submissions(submitted_by, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_form_submissions_template ON form_submissions(form_template_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_form_submissions_created_at ON form_submissions(created_at DESC) WHERE deleted_at IS NULL;
+
+INSERT INTO permissions (id, name, description, resource, action)
+SELECT
+    'form_submissions.' || a.action,
+    initcap(a.action) || ' form submissions',
+    'Allows ' || a.action || ' on form submissions',
+    'form_submissions',
+    a.action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS a(action)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'tenant_admin', p.id
+FROM permissions p
+WHERE p.resource = 'form_submissions'
+    AND NOT EXISTS (
+        SELECT 1 FROM role_permissions rp
+        WHERE rp.role_id = 'tenant_admin' AND rp.permission_id = p.id
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'form_submissions'
+);
+DELETE FROM permissions WHERE resource = 'form_submissions';
+
+DROP TABLE IF EXISTS form_submissions;
+DROP TYPE IF EXISTS form_submission_state;
+-- +goose StatementEnd
@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The unique constraint on role assignments treated a NULL business unit as
+-- distinct, so tenant-wide assignments could be created any number of times.
+-- Duplicates are merged into the row most worth keeping: active before
+-- revoked or inactive, then the most recently assigned.
+CREATE TEMP TABLE role_assignment_duplicates ON COMMIT DROP AS
+SELECT id, keep_id
+FROM (
+    SELECT
+        id,
+        first_value(id) OVER grant_rows AS keep_id,
+        row_number() OVER grant_rows AS rank
+    FROM role_assignment
+    WINDOW grant_rows AS (
+        PARTITION BY role_permissions_id, assignee_id, business_unit_id
+        ORDER BY (status = 'active' AND deleted_at IS NULL) DESC, assigned_at DESC, id
+    )
+) ranked
+WHERE rank > 1;
+
+-- Review items of a duplicate move to the kept row, one per campaign; the rest
+-- go with the duplicate.
+UPDATE access_review_items i
+SET role_assignment_id = moved.keep_id
+FROM (
+    SELECT DISTINCT ON (item.campaign_id, d.keep_id) item.id, d.keep_id
+    FROM access_review_items item
+    JOIN role_assignment_duplicates d ON d.id = item.role_assignment_id
+    WHERE NOT EXISTS (
+        SELECT 1 FROM access_review_items kept
+        WHERE kept.campaign_id = item.campaign_id AND kept.role_assignment_id = d.keep_id
+    )
+    ORDER BY item.campaign_id, d.keep_id, item.id
+) moved
+WHERE i.id = moved.id;
+
+DELETE FROM role_assignment ra
+USING role_assignment_duplicates d
+WHERE ra.id = d.id;
+
+DO $$
+DECLARE
+    constraint_name TEXT;
+BEGIN
+    SELECT c.conname INTO constraint_name
+    FROM pg_constraint c
+    WHERE c.conrelid = 'role_assignment'::regclass AND c.contype = 'u'
+        AND c.conkey = ARRAY(
+            SELECT a.attnum FROM pg_attribute a
+            WHERE a.attrelid = 'role_assignment'::regclass
+                AND a.attname IN ('role_permissions_id', 'assignee_id', 'business_unit_id')
+            ORDER BY a.attnum
+        )::SMALLINT[];
+    IF constraint_name IS NOT NULL THEN
+        EXECUTE format('ALTER TABLE role_assignment DROP CONSTRAINT %I', constraint_name);
+    END IF;
+END $$;
+
+ALTER TABLE role_assignment
+    ADD CONSTRAINT role_assignment_grant_key
+    UNIQUE NULLS NOT DISTINCT (role_permissions_id, assignee_id, business_unit_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE role_assignment DROP CONSTRAINT IF EXISTS role_assignment_grant_key;
+
+ALTER TABLE role_assignment
+    ADD UNIQUE (role_permissions_id, assignee_id, business_unit_id);
+-- +goose StatementEnd
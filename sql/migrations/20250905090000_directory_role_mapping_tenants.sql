@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Directory role mappings belong to a tenant and only match the claims of
+-- tokens issued by it, so a partner tenant cannot hand its users the app role
+-- or group mapped for another tenant.
+ALTER TABLE directory_role_mappings ADD COLUMN IF NOT EXISTS tenant_id UUID;
+
+-- Existing mappings take the tenant of their business unit, or else of the
+-- user who created them.
+UPDATE directory_role_mappings m
+SET tenant_id = bu.tenant_id::uuid
+FROM business_units bu
+WHERE m.business_unit_id = bu.id AND m.tenant_id IS NULL
+    AND bu.tenant_id ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$';
+
+UPDATE directory_role_mappings m
+SET tenant_id = u.home_tenant_id
+FROM users u
+WHERE m.created_by = u.id AND m.tenant_id IS NULL;
+
+-- Mappings of no known tenant are removed with the assignments they granted.
+UPDATE role_assignment ra
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+FROM directory_role_mappings m
+WHERE ra.directory_mapping_id = m.id AND m.tenant_id IS NULL AND ra.deleted_at IS NULL;
+
+UPDATE directory_role_mappings
+SET
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP)
+WHERE tenant_id IS NULL;
+
+ALTER TABLE directory_role_mappings
+    ADD CONSTRAINT chk_directory_role_mappings_tenant CHECK (tenant_id IS NOT NULL OR deleted_at IS NOT NULL);
+
+DROP INDEX IF EXISTS uq_directory_role_mappings_claim_role;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_directory_role_mappings_claim_role
+    ON directory_role_mappings (tenant_id, claim_type, claim_value, role_id, COALESCE(business_unit_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_directory_role_mappings_claim_role;
+ALTER TABLE directory_role_mappings DROP CONSTRAINT IF EXISTS chk_directory_role_mappings_tenant;
+ALTER TABLE directory_role_mappings DROP COLUMN IF EXISTS tenant_id;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_directory_role_mappings_claim_role
+    ON directory_role_mappings (claim_type, claim_value, role_id, COALESCE(business_unit_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
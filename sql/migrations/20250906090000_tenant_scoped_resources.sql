@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Form templates, their submissions, access review campaigns and custom roles
+-- belong to the tenant of the user who created them. A tenant-scoped grant only
+-- covers rows of the grantee's own tenant, and lists only show that tenant.
+ALTER TABLE form_templates ADD COLUMN IF NOT EXISTS tenant_id UUID;
+
+UPDATE form_templates t
+SET tenant_id = u.home_tenant_id
+FROM users u
+WHERE t.created_by = u.id AND t.tenant_id IS NULL;
+
+-- Later versions are created by whoever started the draft; a lineage stays in
+-- the tenant of its first version.
+UPDATE form_templates t
+SET tenant_id = first_version.tenant_id
+FROM form_templates first_version
+WHERE first_version.id = t.lineage_id AND t.id <> t.lineage_id;
+
+ALTER TABLE form_templates ALTER COLUMN tenant_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_form_templates_tenant ON form_templates(tenant_id, created_at DESC) WHERE deleted_at IS NULL;
+
+ALTER TABLE form_submissions ADD COLUMN IF NOT EXISTS tenant_id UUID;
+
+UPDATE form_submissions s
+SET tenant_id = t.tenant_id
+FROM form_templates t
+WHERE s.form_template_id = t.id AND s.tenant_id IS NULL;
+
+ALTER TABLE form_submissions ALTER COLUMN tenant_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_form_submissions_tenant ON form_submissions(tenant_id, created_at DESC) WHERE deleted_at IS NULL;
+
+ALTER TABLE access_review_campaigns ADD COLUMN IF NOT EXISTS tenant_id UUID;
+
+UPDATE access_review_campaigns c
+SET tenant_id = u.home_tenant_id
+FROM users u
+WHERE c.created_by = u.id AND c.tenant_id IS NULL;
+
+ALTER TABLE access_review_campaigns ALTER COLUMN tenant_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_access_review_campaigns_tenant ON access_review_campaigns(tenant_id, created_at DESC);
+
+-- Roles without a tenant are shared by every tenant and cannot be changed
+-- through the API; roles created from now on belong to the creator's tenant.
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS tenant_id UUID;
+CREATE INDEX IF NOT EXISTS idx_roles_tenant ON roles(tenant_id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_roles_tenant;
+ALTER TABLE roles DROP COLUMN IF EXISTS tenant_id;
+DROP INDEX IF EXISTS idx_access_review_campaigns_tenant;
+ALTER TABLE access_review_campaigns DROP COLUMN IF EXISTS tenant_id;
+DROP INDEX IF EXISTS idx_form_submissions_tenant;
+ALTER TABLE form_submissions DROP COLUMN IF EXISTS tenant_id;
+DROP INDEX IF EXISTS idx_form_templates_tenant;
+ALTER TABLE form_templates DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
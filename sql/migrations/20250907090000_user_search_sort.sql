@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Each sort of GET /v1/users has its own query ordering by the home tenant,
+-- the sort expression and id; the expressions must stay identical to the ones
+-- in the SearchUsersBy* queries. Display name is covered by
+-- idx_users_home_tenant_display_name.
+CREATE INDEX IF NOT EXISTS idx_users_home_tenant_mail_sort ON users(home_tenant_id, lower(mail), id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_home_tenant_created_at ON users(home_tenant_id, created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_home_tenant_last_login ON users(home_tenant_id, COALESCE(last_login, '-infinity'::timestamptz), id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_home_tenant_last_login;
+DROP INDEX IF EXISTS idx_users_home_tenant_created_at;
+DROP INDEX IF EXISTS idx_users_home_tenant_mail_sort;
+-- +goose StatementEnd
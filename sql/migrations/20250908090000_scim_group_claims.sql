@@ -0,0 +1,17 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+-- SCIM group memberships grant roles through mappings of claim type
+-- scim_group, whose claim value is the SCIM group's own ID. A SCIM client
+-- chooses the externalId of its groups, so group mappings keep matching Entra
+-- token claims only.
+ALTER TYPE directory_claim_type ADD VALUE IF NOT EXISTS 'scim_group';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- PostgreSQL cannot drop an enum value; retire the scim_group mappings instead.
+UPDATE directory_role_mappings
+SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE claim_type = 'scim_group' AND deleted_at IS NULL;
+-- +goose StatementEnd
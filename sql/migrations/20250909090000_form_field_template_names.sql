@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Answers are keyed by field name, so names must be unique among the live
+-- fields of a template rather than of a section. Of fields sharing a name,
+-- the first in form order keeps it and existing answers; the others
+-- get the start of their ID appended.
+UPDATE form_fields f
+SET
+    field_name = left(f.field_name, 91) || '_' || left(f.id::text, 8),
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT
+        ff.id,
+        row_number() OVER (
+            PARTITION BY ff.form_template_id, ff.field_name
+            ORDER BY fs.section_order NULLS FIRST, ff.field_order, ff.id
+        ) AS rank
+    FROM form_fields ff
+    LEFT JOIN form_sections fs ON fs.id = ff.form_section_id
+    WHERE ff.deleted_at IS NULL
+) ranked
+WHERE f.id = ranked.id AND ranked.rank > 1;
+
+DROP INDEX IF EXISTS idx_form_fields_section_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_fields_template_name
+    ON form_fields(form_template_id, field_name)
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_form_fields_template_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_fields_section_name
+    ON form_fields(form_template_id, form_section_id, field_name) NULLS NOT DISTINCT
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
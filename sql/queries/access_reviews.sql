@@ -0,0 +1,156 @@
+-- name: CreateAccessReviewCampaign :one
+INSERT INTO access_review_campaigns (
+    name,
+    description,
+    role_ids,
+    business_unit_ids,
+    ends_at,
+    created_by,
+    tenant_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetAccessReviewCampaignByID :one
+SELECT * FROM access_review_campaigns
+WHERE id = $1 AND tenant_id = $2;
+
+-- name: ListAccessReviewCampaigns :many
+SELECT * FROM access_review_campaigns
+WHERE tenant_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetDueAccessReviewCampaigns :many
+SELECT * FROM access_review_campaigns
+WHERE status = 'active' AND ends_at <= CURRENT_TIMESTAMP
+ORDER BY ends_at;
+
+-- name: CompleteAccessReviewCampaign :one
+UPDATE access_review_campaigns
+SET
+    status = 'completed',
+    completed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: CreateAccessReviewItems :execrows
+-- One item per active assignment in the campaign's scope, reviewed by the
+-- assignee's manager or, for users without a manager, the campaign creator.
+-- Nobody may decide the review of their own assignment, so an item of the
+-- creator's without a manager stays pending and is revoked at ends_at.
+-- Directory managed assignments are recertified in Entra and are skipped, and
+-- only users of the campaign's tenant are reviewed.
+INSERT INTO access_review_items (
+    campaign_id,
+    role_assignment_id,
+    assignee_id,
+    reviewer_id,
+    role_id,
+    permission_id,
+    business_unit_id
+)
+SELECT
+    c.id,
+    ra.id,
+    ra.assignee_id,
+    COALESCE(NULLIF(u.manager_id, ra.assignee_id), c.created_by),
+    rp.role_id,
+    rp.permission_id,
+    COALESCE(ra.business_unit_id, u.business_unit_id)
+FROM access_review_campaigns c
+JOIN role_assignment ra ON ra.status = 'active'
+    AND ra.deleted_at IS NULL
+    AND (ra.expires_at IS NULL OR ra.expires_at > CURRENT_TIMESTAMP)
+    AND ra.directory_mapping_id IS NULL
+JOIN role_permissions rp ON ra.role_permissions_id = rp.id
+JOIN users u ON ra.assignee_id = u.id AND u.home_tenant_id = c.tenant_id
+WHERE c.id = $1
+    AND (cardinality(c.role_ids) = 0 OR rp.role_id = ANY(c.role_ids))
+    AND (cardinality(c.business_unit_ids) = 0 OR COALESCE(ra.business_unit_id, u.business_unit_id) = ANY(c.business_unit_ids))
+ON CONFLICT (campaign_id, role_assignment_id) DO NOTHING;
+
+-- name: GetAccessReviewCampaignProgress :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE decision = 'pending') AS pending,
+    COUNT(*) FILTER (WHERE decision = 'approved') AS approved,
+    COUNT(*) FILTER (WHERE decision = 'revoked') AS revoked
+FROM access_review_items
+WHERE campaign_id = $1;
+
+-- name: ListAccessReviewItems :many
+-- Items of one campaign, or the pending items of a reviewer across active
+-- campaigns, of the caller's tenant.
+SELECT
+    i.id,
+    i.campaign_id,
+    i.role_assignment_id,
+    i.assignee_id,
+    i.reviewer_id,
+    i.role_id,
+    i.permission_id,
+    i.business_unit_id,
+    i.decision,
+    i.auto_revoked,
+    i.decided_by,
+    i.decided_at,
+    i.comment,
+    i.created_at,
+    i.updated_at,
+    c.name AS campaign_name,
+    c.ends_at,
+    r.name AS role_name,
+    u.display_name AS assignee_name,
+    u.mail AS assignee_mail,
+    rv.display_name AS reviewer_name,
+    rv.mail AS reviewer_mail
+FROM access_review_items i
+JOIN access_review_campaigns c ON i.campaign_id = c.id
+JOIN roles r ON i.role_id = r.id
+JOIN users u ON i.assignee_id = u.id
+JOIN users rv ON i.reviewer_id = rv.id
+WHERE c.tenant_id = sqlc.arg('tenant_id')
+    AND (sqlc.narg('campaign_id')::uuid IS NULL OR i.campaign_id = sqlc.narg('campaign_id'))
+    AND (sqlc.narg('reviewer_id')::uuid IS NULL OR (
+        i.reviewer_id = sqlc.narg('reviewer_id') AND i.decision = 'pending' AND c.status = 'active'
+    ))
+    AND (sqlc.narg('id')::uuid IS NULL OR i.id = sqlc.narg('id'))
+ORDER BY c.ends_at, rv.display_name, u.display_name, i.role_id, i.id
+LIMIT sqlc.arg('page_limit') OFFSET sqlc.arg('page_offset');
+
+-- name: CountAccessReviewItems :one
+SELECT COUNT(*) FROM access_review_items i
+JOIN access_review_campaigns c ON i.campaign_id = c.id
+WHERE c.tenant_id = sqlc.arg('tenant_id')
+    AND (sqlc.narg('campaign_id')::uuid IS NULL OR i.campaign_id = sqlc.narg('campaign_id'))
+    AND (sqlc.narg('reviewer_id')::uuid IS NULL OR (
+        i.reviewer_id = sqlc.narg('reviewer_id') AND i.decision = 'pending' AND c.status = 'active'
+    ));
+
+-- name: GetAccessReviewItemByID :one
+SELECT * FROM access_review_items
+WHERE id = $1
+    AND campaign_id IN (SELECT c.id FROM access_review_campaigns c WHERE c.tenant_id = $2);
+
+-- name: DecideAccessReviewItem :one
+UPDATE access_review_items
+SET
+    decision = $2,
+    decided_by = $3,
+    decided_at = CURRENT_TIMESTAMP,
+    comment = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND decision = 'pending'
+RETURNING *;
+
+-- name: AutoRevokeAccessReviewItems :many
+UPDATE access_review_items
+SET
+    decision = 'revoked',
+    auto_revoked = true,
+    decided_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE campaign_id = $1 AND decision = 'pending'
+RETURNING *;
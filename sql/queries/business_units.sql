@@ -23,7 +23,7 @@ SELECT
     updated_at,
     deleted_at
 FROM business_units 
-WHERE id = $1;
+WHERE id = $1 AND tenant_id = $2;
 
 -- name: GetBusinessUnitByDomainName :one
 SELECT 
@@ -36,7 +36,7 @@ SELECT
     updated_at,
     deleted_at
 FROM business_units 
-WHERE domain_name = $1;
+WHERE domain_name = $1 AND tenant_id = $2;
 
 -- name: CreateBusinessUnit :one
 INSERT INTO business_units (
@@ -1,36 +1,217 @@
 -- name: GetDepartmentByID :one
-SELECT 
-    id,
-    name,
-    status,
-    created_at,
-    updated_at,
-    deleted_at
-FROM departments 
-WHERE id = $1;
+-- Departments without a business unit predate business unit scoping and are
+-- visible to every tenant until moved into one.
+SELECT
+    d.id,
+    d.name,
+    d.status,
+    d.created_at,
+    d.updated_at,
+    d.deleted_at,
+    d.business_unit_id,
+    d.parent_id
+FROM departments d
+LEFT JOIN business_units bu ON bu.id = d.business_unit_id
+WHERE d.id = sqlc.arg('id')
+  AND d.deleted_at IS NULL
+  AND (d.business_unit_id IS NULL OR bu.tenant_id = sqlc.arg('tenant_id'));
 
 -- name: GetDepartmentByName :one
-SELECT 
+SELECT
     id,
     name,
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    business_unit_id,
+    parent_id
 FROM departments
-WHERE name = $1;
+WHERE business_unit_id = sqlc.arg('business_unit_id')
+  AND name = sqlc.arg('name')
+  AND deleted_at IS NULL;
+
+-- name: ListDepartments :many
+SELECT
+    d.id,
+    d.name,
+    d.status,
+    d.created_at,
+    d.updated_at,
+    d.deleted_at,
+    d.business_unit_id,
+    d.parent_id
+FROM departments d
+JOIN business_units bu ON bu.id = d.business_unit_id
+WHERE bu.tenant_id = sqlc.arg('tenant_id')
+  AND d.deleted_at IS NULL
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR d.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('parent_id')::uuid IS NULL OR d.parent_id = sqlc.narg('parent_id')::uuid)
+ORDER BY d.name, d.id;
 
 -- name: CreateDepartment :one
 INSERT INTO departments (
+    business_unit_id,
+    parent_id,
     name,
     status
 ) VALUES (
     $1,
-    $2
-) RETURNING 
+    $2,
+    $3,
+    $4
+) RETURNING
+    id,
+    name,
+    status,
+    created_at,
+    updated_at,
+    deleted_at,
+    business_unit_id,
+    parent_id;
+
+-- name: UpdateDepartment :one
+UPDATE departments
+SET
+    name = sqlc.arg('name'),
+    status = sqlc.arg('status'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    status,
+    created_at,
+    updated_at,
+    deleted_at,
+    business_unit_id,
+    parent_id;
+
+-- name: MoveDepartment :one
+UPDATE departments
+SET
+    parent_id = sqlc.narg('parent_id'),
+    business_unit_id = sqlc.arg('business_unit_id'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    status,
+    created_at,
+    updated_at,
+    deleted_at,
+    business_unit_id,
+    parent_id;
+
+-- name: SetDepartmentSubtreeBusinessUnit :exec
+-- Moves the descendants of a department into its business unit after the
+-- department itself moved.
+WITH RECURSIVE subtree AS (
+    SELECT d.id, ARRAY[d.id] AS path
+    FROM departments d
+    WHERE d.parent_id = sqlc.arg('id')
+    UNION ALL
+    SELECT d.id, s.path || d.id
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE NOT d.id = ANY(s.path)
+)
+UPDATE departments
+SET business_unit_id = sqlc.arg('business_unit_id'), updated_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT id FROM subtree);
+
+-- name: LockTenantDepartmentHierarchy :exec
+-- Locks the tenant's business units so that department moves of the tenant
+-- run one at a time and each sees the hierarchy the previous one left.
+SELECT id FROM business_units
+WHERE tenant_id = sqlc.arg('tenant_id')
+ORDER BY id
+FOR UPDATE;
+
+-- name: CountDepartmentSubtreeRoleAssignments :one
+-- Counts the live role assignments scoped to a department or its descendants.
+WITH RECURSIVE subtree AS (
+    SELECT d.id, ARRAY[d.id] AS path
+    FROM departments d
+    WHERE d.id = sqlc.arg('id')
+    UNION ALL
+    SELECT d.id, s.path || d.id
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE NOT d.id = ANY(s.path)
+)
+SELECT COUNT(*) FROM role_assignment ra
+WHERE ra.department_id IN (SELECT id FROM subtree) AND ra.deleted_at IS NULL;
+
+-- name: SetDepartmentSubtreeUsersBusinessUnit :execrows
+-- Moves the users of a department and its descendants into the business unit
+-- the department moved to.
+WITH RECURSIVE subtree AS (
+    SELECT d.id, ARRAY[d.id] AS path
+    FROM departments d
+    WHERE d.id = sqlc.arg('id')
+    UNION ALL
+    SELECT d.id, s.path || d.id
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE NOT d.id = ANY(s.path)
+)
+UPDATE users
+SET business_unit_id = sqlc.arg('business_unit_id'), updated_at = CURRENT_TIMESTAMP
+WHERE department_id IN (SELECT id FROM subtree) AND deleted_at IS NULL;
+
+-- name: DeleteDepartment :one
+UPDATE departments
+SET
+    status = 'deleted',
+    deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    status,
+    created_at,
+    updated_at,
+    deleted_at,
+    business_unit_id,
+    parent_id;
+
+-- name: CountDepartmentChildren :one
+SELECT COUNT(*)
+FROM departments
+WHERE parent_id = sqlc.arg('id') AND deleted_at IS NULL;
+
+-- name: ListDepartmentSubtree :many
+-- Walks parent_id downwards from a department: depth 0 is the department
+-- itself. The path guards against cycles and orders the result depth first.
+WITH RECURSIVE subtree AS (
+    SELECT
+        d.id, d.name, d.status, d.created_at, d.updated_at, d.deleted_at,
+        d.business_unit_id, d.parent_id, 0 AS depth,
+        ARRAY[d.id] AS path, ARRAY[d.name::text] AS name_path
+    FROM departments d
+    WHERE d.id = sqlc.arg('id') AND d.deleted_at IS NULL
+    UNION ALL
+    SELECT
+        d.id, d.name, d.status, d.created_at, d.updated_at, d.deleted_at,
+        d.business_unit_id, d.parent_id, s.depth + 1,
+        s.path || d.id, s.name_path || d.name::text
+    FROM subtree s
+    JOIN departments d ON d.parent_id = s.id
+    WHERE d.deleted_at IS NULL
+      AND NOT d.id = ANY(s.path)
+)
+SELECT
     id,
     name,
     status,
     created_at,
     updated_at,
-    deleted_at;
+    deleted_at,
+    business_unit_id,
+    parent_id,
+    depth::int AS depth
+FROM subtree
+ORDER BY name_path;
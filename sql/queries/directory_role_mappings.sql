@@ -0,0 +1,100 @@
+-- name: ListDirectoryRoleMappings :many
+SELECT * FROM directory_role_mappings
+WHERE tenant_id = $1 AND deleted_at IS NULL
+ORDER BY claim_type, claim_value, role_id;
+
+-- name: GetDirectoryRoleMappingByID :one
+SELECT * FROM directory_role_mappings
+WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL;
+
+-- name: CreateDirectoryRoleMapping :one
+INSERT INTO directory_role_mappings (
+    claim_type,
+    claim_value,
+    role_id,
+    business_unit_id,
+    description,
+    created_by,
+    tenant_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: DeleteDirectoryRoleMapping :one
+UPDATE directory_role_mappings
+SET
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetDirectoryRoleGrants :many
+-- GetDirectoryRoleGrants expands the mappings of the token's tenant matched by
+-- its app roles and groups, or by the user's SCIM groups, into the active role
+-- permissions they grant.
+SELECT
+    m.id AS mapping_id,
+    m.claim_type,
+    m.business_unit_id,
+    rp.id AS role_permissions_id,
+    rp.role_id
+FROM directory_role_mappings m
+JOIN role_permissions rp ON rp.role_id = m.role_id
+    AND rp.status = 'active'
+    AND rp.deleted_at IS NULL
+WHERE m.tenant_id = sqlc.arg('tenant_id')
+    AND m.deleted_at IS NULL
+    AND (
+        (m.claim_type = 'app_role' AND m.claim_value = ANY(sqlc.arg('app_roles')::text[]))
+        OR (m.claim_type = 'group' AND m.claim_value = ANY(sqlc.arg('groups')::text[]))
+        OR (m.claim_type = 'scim_group' AND m.claim_value = ANY(sqlc.arg('scim_groups')::text[]))
+    )
+ORDER BY m.created_at, rp.id;
+
+-- name: GetUserDirectorySyncAssignments :many
+-- GetUserDirectorySyncAssignments returns the user's live assignments, with the
+-- claim type of the mapping that owns them when they are directory managed.
+SELECT
+    ra.id,
+    ra.role_permissions_id,
+    ra.business_unit_id,
+    ra.directory_mapping_id,
+    m.claim_type
+FROM role_assignment ra
+LEFT JOIN directory_role_mappings m ON ra.directory_mapping_id = m.id
+WHERE ra.assignee_id = $1
+    AND ra.status = 'active'
+    AND ra.deleted_at IS NULL;
+
+-- name: CreateDirectoryRoleAssignment :one
+INSERT INTO role_assignment (
+    role_permissions_id,
+    assignee_id,
+    business_unit_id,
+    directory_mapping_id,
+    status
+) VALUES (
+    $1, $2, $3, $4, 'active'
+)
+ON CONFLICT ON CONSTRAINT role_assignment_grant_key DO UPDATE
+SET
+    department_id = NULL,
+    assigned_by = NULL,
+    assigned_at = CURRENT_TIMESTAMP,
+    expires_at = NULL,
+    status = 'active',
+    directory_mapping_id = EXCLUDED.directory_mapping_id,
+    elevation_request_id = NULL,
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = NULL
+WHERE role_assignment.deleted_at IS NOT NULL OR role_assignment.status <> 'active'
+RETURNING *;
+
+-- name: RevokeDirectoryMappingAssignments :execrows
+UPDATE role_assignment
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE directory_mapping_id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,86 @@
+-- name: GetDirectorySyncState :one
+SELECT * FROM directory_sync_state
+WHERE tenant_id = sqlc.arg('tenant_id') AND resource = sqlc.arg('resource');
+
+-- name: SaveDirectorySyncState :exec
+INSERT INTO directory_sync_state (
+    tenant_id,
+    resource,
+    delta_link,
+    last_synced_at,
+    last_full_sync_at
+) VALUES (
+    sqlc.arg('tenant_id'), sqlc.arg('resource'), sqlc.narg('delta_link'), CURRENT_TIMESTAMP,
+    CASE WHEN sqlc.arg('full_sync')::boolean THEN CURRENT_TIMESTAMP END
+)
+ON CONFLICT (tenant_id, resource) DO UPDATE
+SET
+    delta_link = EXCLUDED.delta_link,
+    last_synced_at = EXCLUDED.last_synced_at,
+    last_full_sync_at = COALESCE(EXCLUDED.last_full_sync_at, directory_sync_state.last_full_sync_at),
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: GetUserByObjectID :one
+SELECT id, azure_ad_object_id, home_tenant_id, department_id, business_unit_id, manager_id, mail, display_name, given_name, sur_name, job_title, office_location, status, last_login, locked_until, created_at, updated_at, deleted_at
+FROM users
+WHERE azure_ad_object_id = sqlc.arg('azure_ad_object_id')
+  AND home_tenant_id = sqlc.arg('home_tenant_id')
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: UpdateDirectoryUser :one
+-- Overwrites the profile attributes mastered by the directory. Status and
+-- manager are changed separately.
+UPDATE users
+SET
+    department_id = sqlc.narg('department_id'),
+    business_unit_id = COALESCE(sqlc.narg('business_unit_id'), business_unit_id),
+    mail = sqlc.arg('mail'),
+    display_name = sqlc.arg('display_name'),
+    given_name = sqlc.narg('given_name'),
+    sur_name = sqlc.narg('sur_name'),
+    job_title = sqlc.narg('job_title'),
+    office_location = sqlc.narg('office_location'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id')
+RETURNING id, azure_ad_object_id, home_tenant_id, department_id, business_unit_id, manager_id, mail, display_name, given_name, sur_name, job_title, office_location, status, last_login, locked_until, created_at, updated_at, deleted_at;
+
+-- name: SetUserManagerByObjectID :execrows
+-- Links a user to their manager by object IDs; a NULL or unknown manager
+-- object ID clears the link.
+UPDATE users u
+SET
+    manager_id = (
+        SELECT m.id FROM users m
+        WHERE m.azure_ad_object_id = sqlc.narg('manager_object_id')
+          AND m.home_tenant_id = u.home_tenant_id
+          AND m.deleted_at IS NULL
+        LIMIT 1
+    ),
+    updated_at = CURRENT_TIMESTAMP
+WHERE u.azure_ad_object_id = sqlc.arg('azure_ad_object_id')
+  AND u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL;
+
+-- name: DeactivateDirectoryUser :execrows
+UPDATE users
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND (status = 'active' OR status IS NULL);
+
+-- name: ReactivateDirectoryUser :execrows
+-- Only reactivates users deactivated by directory sync; users suspended by an
+-- administrator stay suspended.
+UPDATE users u
+SET
+    status = 'active',
+    updated_at = CURRENT_TIMESTAMP
+WHERE u.id = sqlc.arg('id')
+  AND u.status = 'inactive'
+  AND (
+      SELECT usc.action FROM user_status_changes usc
+      WHERE usc.user_id = u.id
+      ORDER BY usc.created_at DESC
+      LIMIT 1
+  ) = 'deactivate';
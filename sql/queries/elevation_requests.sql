@@ -0,0 +1,139 @@
+-- name: CreateElevationRequest :one
+INSERT INTO elevation_requests (
+    requester_id,
+    role_id,
+    business_unit_id,
+    justification,
+    duration_minutes
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListElevationRequests :many
+SELECT
+    er.id,
+    er.requester_id,
+    er.role_id,
+    er.business_unit_id,
+    er.justification,
+    er.duration_minutes,
+    er.status,
+    er.decided_by,
+    er.decided_at,
+    er.decision_comment,
+    er.expires_at,
+    er.created_at,
+    er.updated_at,
+    r.name AS role_name,
+    requester.display_name AS requester_name,
+    requester.mail AS requester_mail,
+    decider.display_name AS decided_by_name
+FROM elevation_requests er
+JOIN roles r ON er.role_id = r.id
+JOIN users requester ON er.requester_id = requester.id
+LEFT JOIN users decider ON er.decided_by = decider.id
+WHERE requester.home_tenant_id = sqlc.arg('tenant_id')
+    AND (sqlc.narg('id')::uuid IS NULL OR er.id = sqlc.narg('id'))
+    AND (sqlc.narg('status')::elevation_request_status IS NULL OR er.status = sqlc.narg('status'))
+    AND (sqlc.narg('requester_id')::uuid IS NULL OR er.requester_id = sqlc.narg('requester_id'))
+    AND (sqlc.narg('role_id')::text IS NULL OR er.role_id = sqlc.narg('role_id'))
+ORDER BY er.created_at DESC, er.id
+LIMIT sqlc.arg('page_limit') OFFSET sqlc.arg('page_offset');
+
+-- name: CountElevationRequests :one
+SELECT COUNT(*)
+FROM elevation_requests er
+JOIN users requester ON er.requester_id = requester.id
+WHERE requester.home_tenant_id = sqlc.arg('tenant_id')
+    AND (sqlc.narg('status')::elevation_request_status IS NULL OR er.status = sqlc.narg('status'))
+    AND (sqlc.narg('requester_id')::uuid IS NULL OR er.requester_id = sqlc.narg('requester_id'))
+    AND (sqlc.narg('role_id')::text IS NULL OR er.role_id = sqlc.narg('role_id'));
+
+-- name: ApproveElevationRequest :one
+UPDATE elevation_requests
+SET
+    status = 'approved',
+    decided_by = $2,
+    decided_at = CURRENT_TIMESTAMP,
+    decision_comment = $3,
+    expires_at = CURRENT_TIMESTAMP + make_interval(mins => duration_minutes),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: RejectElevationRequest :one
+UPDATE elevation_requests
+SET
+    status = 'rejected',
+    decided_by = $2,
+    decided_at = CURRENT_TIMESTAMP,
+    decision_comment = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: CancelElevationRequest :one
+UPDATE elevation_requests
+SET
+    status = 'cancelled',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND requester_id = $2 AND status = 'pending'
+RETURNING *;
+
+-- name: ExpireElevationRequests :many
+UPDATE elevation_requests
+SET
+    status = 'expired',
+    updated_at = CURRENT_TIMESTAMP
+WHERE status = 'approved'
+    AND expires_at <= CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: CreateElevationRoleAssignments :many
+-- Assigns every active role permission of the approved request's role until the
+-- request expires. Permissions the requester already holds actively are left
+-- untouched, so a standing assignment is never shortened by an elevation.
+INSERT INTO role_assignment (
+    role_permissions_id,
+    assignee_id,
+    business_unit_id,
+    assigned_by,
+    expires_at,
+    status,
+    elevation_request_id
+)
+SELECT
+    rp.id,
+    er.requester_id,
+    er.business_unit_id,
+    er.decided_by,
+    er.expires_at,
+    'active',
+    er.id
+FROM elevation_requests er
+JOIN role_permissions rp ON rp.role_id = er.role_id
+    AND rp.status = 'active'
+    AND rp.deleted_at IS NULL
+WHERE er.id = $1 AND er.status = 'approved'
+ON CONFLICT ON CONSTRAINT role_assignment_grant_key DO UPDATE
+SET
+    department_id = NULL,
+    assigned_by = EXCLUDED.assigned_by,
+    assigned_at = CURRENT_TIMESTAMP,
+    expires_at = EXCLUDED.expires_at,
+    status = 'active',
+    directory_mapping_id = NULL,
+    elevation_request_id = EXCLUDED.elevation_request_id,
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = NULL
+WHERE role_assignment.deleted_at IS NOT NULL OR role_assignment.status <> 'active'
+RETURNING *;
+
+-- name: RevokeElevationRoleAssignments :execrows
+UPDATE role_assignment
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE elevation_request_id = $1 AND deleted_at IS NULL;
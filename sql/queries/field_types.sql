@@ -7,6 +7,10 @@ ORDER BY type_name;
 SELECT * FROM field_types
 WHERE id = $1 AND status = 'active' AND deleted_at IS NULL;
 
+-- name: GetFieldTypeByName :one
+SELECT * FROM field_types
+WHERE type_name = $1 AND status = 'active' AND deleted_at IS NULL;
+
 -- name: CreateFieldType :one
 INSERT INTO field_types (
     type_name, description, validation_schema
@@ -14,18 +18,29 @@ INSERT INTO field_types (
 RETURNING *;
 
 -- name: UpdateFieldType :one
+-- The type name is what form fields reference, so it never changes. Built-in
+-- types are shared by every tenant and cannot be changed.
 UPDATE field_types
 SET 
-    type_name = COALESCE($2, type_name),
-    description = COALESCE($3, description),
-    validation_schema = COALESCE($4, validation_schema),
+    description = $2,
+    validation_schema = $3,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
+WHERE id = $1 AND NOT is_builtin AND status = 'active' AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteFieldType :exec
+-- name: DeleteFieldType :execrows
 UPDATE field_types
 SET 
     status = 'inactive',
     deleted_at = CURRENT_TIMESTAMP
-WHERE id = $1;
+WHERE id = $1 AND NOT is_builtin AND deleted_at IS NULL;
+
+-- name: CountFormFieldsByType :one
+SELECT COUNT(*) FROM form_fields
+WHERE field_type = $1 AND deleted_at IS NULL;
+
+-- name: GetFieldTypesByNames :many
+-- Resolves the types of existing fields, including types deactivated since.
+SELECT * FROM field_types
+WHERE type_name = ANY(sqlc.arg(type_names)::text[]) AND deleted_at IS NULL
+ORDER BY type_name;
@@ -11,7 +11,7 @@ ORDER BY field_order;
 
 -- name: GetFormFieldByID :one
 SELECT * FROM form_fields
-WHERE id = $1 AND status = 'active' AND deleted_at IS NULL;
+WHERE id = $1 AND form_template_id = $2 AND status = 'active' AND deleted_at IS NULL;
 
 -- name: CreateFormField :one
 INSERT INTO form_fields (
@@ -23,18 +23,18 @@ RETURNING *;
 -- name: UpdateFormField :one
 UPDATE form_fields
 SET 
-    field_name = COALESCE($2, field_name),
-    field_type = COALESCE($3, field_type),
-    field_order = COALESCE($4, field_order),
-    config = COALESCE($5, config),
+    form_section_id = $3,
+    field_name = $4,
+    field_type = $5,
+    field_order = $6,
+    config = $7,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
+WHERE id = $1 AND form_template_id = $2 AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteFormField :exec
+-- name: DeleteFormField :execrows
 UPDATE form_fields
 SET 
     status = 'inactive',
     deleted_at = CURRENT_TIMESTAMP
-WHERE id = $1;
-
+WHERE id = $1 AND form_template_id = $2 AND deleted_at IS NULL;
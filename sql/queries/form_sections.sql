@@ -1,7 +1,7 @@
 -- name: GetFormSections :many
 SELECT * FROM form_sections
 WHERE form_template_id = $1 AND status = 'active' AND deleted_at IS NULL
-ORDER BY section_order;
+ORDER BY section_order, id;
 
 -- name: GetFormSectionByID :one
 SELECT * FROM form_sections
@@ -0,0 +1,70 @@
+-- name: CreateFormSubmission :one
+INSERT INTO form_submissions (
+    form_template_id, lineage_id, business_unit_id, department_id,
+    submitted_by, state, answers, submitted_at, tenant_id
+)
+SELECT
+    t.id, t.lineage_id, t.business_unit_id, t.department_id,
+    sqlc.arg(submitted_by), sqlc.arg(state)::form_submission_state, sqlc.arg(answers),
+    CASE WHEN sqlc.arg(state)::form_submission_state = 'submitted' THEN CURRENT_TIMESTAMP END,
+    t.tenant_id
+FROM form_templates t
+WHERE t.id = sqlc.arg(form_template_id)
+RETURNING *;
+
+-- name: GetFormSubmissionByID :one
+SELECT * FROM form_submissions
+WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL;
+
+-- name: UpdateFormSubmissionDraft :one
+-- Replaces the answers of a draft; state 'submitted' submits it.
+UPDATE form_submissions
+SET
+    answers = sqlc.arg(answers),
+    state = sqlc.arg(state)::form_submission_state,
+    submitted_at = CASE WHEN sqlc.arg(state)::form_submission_state = 'submitted' THEN CURRENT_TIMESTAMP END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND state = 'draft' AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteFormSubmissionDraft :execrows
+UPDATE form_submissions
+SET
+    status = 'inactive',
+    deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'draft' AND deleted_at IS NULL;
+
+-- name: ListFormSubmissions :many
+-- Drafts are only listed for their submitter.
+SELECT * FROM form_submissions
+WHERE tenant_id = sqlc.arg(tenant_id) AND deleted_at IS NULL
+    AND (sqlc.narg(lineage_id)::uuid IS NULL OR lineage_id = sqlc.narg(lineage_id))
+    AND (sqlc.narg(submitted_by)::uuid IS NULL OR submitted_by = sqlc.narg(submitted_by))
+    AND (sqlc.narg(state)::form_submission_state IS NULL OR state = sqlc.narg(state))
+    AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from))
+    AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to))
+    AND (state = 'submitted' OR submitted_by = sqlc.arg(caller_id))
+    AND (
+        sqlc.arg(all_access)::boolean
+        OR business_unit_id = ANY(sqlc.arg(business_unit_ids)::uuid[])
+        OR department_id = ANY(sqlc.arg(department_ids)::uuid[])
+        OR submitted_by = sqlc.narg(owner_id)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountFormSubmissions :one
+SELECT COUNT(*) FROM form_submissions
+WHERE tenant_id = sqlc.arg(tenant_id) AND deleted_at IS NULL
+    AND (sqlc.narg(lineage_id)::uuid IS NULL OR lineage_id = sqlc.narg(lineage_id))
+    AND (sqlc.narg(submitted_by)::uuid IS NULL OR submitted_by = sqlc.narg(submitted_by))
+    AND (sqlc.narg(state)::form_submission_state IS NULL OR state = sqlc.narg(state))
+    AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from))
+    AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to))
+    AND (state = 'submitted' OR submitted_by = sqlc.arg(caller_id))
+    AND (
+        sqlc.arg(all_access)::boolean
+        OR business_unit_id = ANY(sqlc.arg(business_unit_ids)::uuid[])
+        OR department_id = ANY(sqlc.arg(department_ids)::uuid[])
+        OR submitted_by = sqlc.narg(owner_id)
+    );
@@ -1,22 +1,40 @@
 -- name: GetFormTemplates :many
 SELECT * FROM form_templates
-WHERE status = 'active' AND deleted_at IS NULL
+WHERE tenant_id = sqlc.arg(tenant_id) AND status = 'active' AND deleted_at IS NULL
+    AND (
+        sqlc.arg(all_access)::boolean
+        OR business_unit_id = ANY(sqlc.arg(business_unit_ids)::uuid[])
+        OR department_id = ANY(sqlc.arg(department_ids)::uuid[])
+        OR created_by = sqlc.narg(owner_id)
+    )
 ORDER BY created_at DESC;
 
 -- name: GetFormTemplateByID :one
 SELECT * FROM form_templates
-WHERE id = $1 AND status = 'active' AND deleted_at IS NULL;
+WHERE id = $1 AND tenant_id = $2 AND status = 'active' AND deleted_at IS NULL;
 
 -- name: GetFormTemplatesByCategory :many
 SELECT * FROM form_templates
-WHERE form_category_id = $1 AND status = 'active' AND deleted_at IS NULL
+WHERE form_category_id = sqlc.arg(form_category_id) AND tenant_id = sqlc.arg(tenant_id) AND status = 'active' AND deleted_at IS NULL
+    AND (
+        sqlc.arg(all_access)::boolean
+        OR business_unit_id = ANY(sqlc.arg(business_unit_ids)::uuid[])
+        OR department_id = ANY(sqlc.arg(department_ids)::uuid[])
+        OR created_by = sqlc.narg(owner_id)
+    )
 ORDER BY created_at DESC;
 
 -- name: CreateFormTemplate :one
+-- The first version of a form starts its own lineage in the creator's tenant.
+WITH new_template AS (
+    SELECT gen_random_uuid() AS id
+)
 INSERT INTO form_templates (
-    name, description, form_category_id, business_unit_id,
-    version, created_by
-) VALUES ($1, $2, $3, $4, $5, $6)
+    id, lineage_id, name, description, form_category_id, business_unit_id,
+    version, created_by, department_id, tenant_id
+)
+SELECT new_template.id, new_template.id, $1, $2, $3, $4, 1, $5, $6, $7
+FROM new_template
 RETURNING *;
 
 -- name: UpdateFormTemplate :one
@@ -26,24 +44,105 @@ SET
     description = COALESCE($3, description),
     form_category_id = COALESCE($4, form_category_id),
     business_unit_id = COALESCE($5, business_unit_id),
-    version = COALESCE($6, version),
+    department_id = COALESCE($6, department_id),
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
+WHERE id = $1 AND state = 'draft' AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetFormTemplateVersions :many
+SELECT * FROM form_templates
+WHERE lineage_id = $1 AND status = 'active' AND deleted_at IS NULL
+ORDER BY version DESC;
+
+-- name: GetFormTemplateVersion :one
+SELECT * FROM form_templates
+WHERE lineage_id = $1 AND version = $2 AND status = 'active' AND deleted_at IS NULL;
+
+-- name: GetPublishedFormTemplate :one
+SELECT * FROM form_templates
+WHERE lineage_id = $1 AND state = 'published' AND status = 'active' AND deleted_at IS NULL;
+
+-- name: CreateFormTemplateVersion :one
+-- Starts the next draft version of a form from one of its versions.
+INSERT INTO form_templates (
+    lineage_id, name, description, form_category_id, business_unit_id,
+    version, created_by, department_id, tenant_id
+)
+SELECT
+    source.lineage_id, source.name, source.description, source.form_category_id, source.business_unit_id,
+    (SELECT MAX(v.version) + 1 FROM form_templates v WHERE v.lineage_id = source.lineage_id),
+    sqlc.arg(created_by), source.department_id, source.tenant_id
+FROM form_templates source
+WHERE source.id = sqlc.arg(source_id)
+RETURNING *;
+
+-- name: CopyFormSections :exec
+INSERT INTO form_sections (form_template_id, section_name, section_order, description)
+SELECT sqlc.arg(target_template_id), section_name, section_order, description
+FROM form_sections
+WHERE form_template_id = sqlc.arg(source_template_id) AND status = 'active' AND deleted_at IS NULL;
+
+-- name: CopyFormFields :exec
+-- Run after CopyFormSections; fields follow their section by name.
+INSERT INTO form_fields (form_template_id, form_section_id, field_name, field_type, field_order, config)
+SELECT sqlc.arg(target_template_id), target_section.id, f.field_name, f.field_type, f.field_order, f.config
+FROM form_fields f
+LEFT JOIN form_sections source_section ON source_section.id = f.form_section_id
+LEFT JOIN form_sections target_section
+    ON target_section.form_template_id = sqlc.arg(target_template_id)
+    AND target_section.section_name = source_section.section_name
+    AND target_section.deleted_at IS NULL
+WHERE f.form_template_id = sqlc.arg(source_template_id) AND f.status = 'active' AND f.deleted_at IS NULL;
+
+-- name: SubmitFormTemplateForReview :one
+UPDATE form_templates
+SET 
+    state = 'in_review',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'draft' AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RejectFormTemplate :one
+UPDATE form_templates
+SET 
+    state = 'draft',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'in_review' AND deleted_at IS NULL
 RETURNING *;
 
 -- name: PublishFormTemplate :one
 UPDATE form_templates
 SET 
+    state = 'published',
     published_at = CURRENT_TIMESTAMP,
+    approved_by = $2,
     approved_at = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
+WHERE id = $1 AND state = 'in_review' AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteFormTemplate :exec
+-- name: RetireFormTemplate :one
+UPDATE form_templates
+SET 
+    state = 'retired',
+    retired_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND state = 'published' AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RetirePublishedFormTemplates :exec
+-- Retires the published version of a lineage ahead of publishing a newer one.
+UPDATE form_templates
+SET 
+    state = 'retired',
+    retired_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE lineage_id = $1 AND state = 'published' AND deleted_at IS NULL;
+
+-- name: DeleteFormTemplate :execrows
+-- Published and retired versions stay for the submissions made against them.
 UPDATE form_templates
 SET 
     status = 'inactive',
     deleted_at = CURRENT_TIMESTAMP
-WHERE id = $1;
-
+WHERE id = $1 AND tenant_id = $2 AND state IN ('draft', 'in_review') AND deleted_at IS NULL;
@@ -11,6 +11,8 @@ SELECT
     ra.status,
     ra.updated_at,
     ra.deleted_at,
+    ra.directory_mapping_id,
+    ra.elevation_request_id,
     r.name as role_name,
     p.name as permission_name,
     p.resource,
@@ -25,8 +27,15 @@ JOIN permissions p ON rp.permission_id = p.id
 LEFT JOIN scopes s ON rp.scope_id = s.id
 LEFT JOIN business_units bu ON ra.business_unit_id = bu.id
 LEFT JOIN departments d ON ra.department_id = d.id
-WHERE ra.assignee_id = $1 AND ra.deleted_at IS NULL
-ORDER BY ra.assigned_at DESC;
+JOIN users u ON ra.assignee_id = u.id
+WHERE ra.assignee_id = $1 AND u.home_tenant_id = $2 AND ra.deleted_at IS NULL
+ORDER BY ra.assigned_at DESC, ra.id
+LIMIT $3 OFFSET $4;
+
+-- name: CountUserRoleAssignments :one
+SELECT COUNT(*) FROM role_assignment ra
+JOIN users u ON ra.assignee_id = u.id
+WHERE ra.assignee_id = $1 AND u.home_tenant_id = $2 AND ra.deleted_at IS NULL;
 
 -- name: CreateRoleAssignment :one
 INSERT INTO role_assignment (
@@ -40,18 +49,98 @@ INSERT INTO role_assignment (
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at;
+ON CONFLICT ON CONSTRAINT role_assignment_grant_key DO UPDATE
+SET
+    department_id = EXCLUDED.department_id,
+    assigned_by = EXCLUDED.assigned_by,
+    assigned_at = CURRENT_TIMESTAMP,
+    expires_at = EXCLUDED.expires_at,
+    status = EXCLUDED.status,
+    directory_mapping_id = NULL,
+    elevation_request_id = NULL,
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = NULL
+WHERE role_assignment.deleted_at IS NOT NULL OR role_assignment.status <> 'active'
+RETURNING id, role_permissions_id, assignee_id, business_unit_id, department_id, assigned_by, assigned_at, expires_at, status, updated_at, deleted_at, directory_mapping_id, elevation_request_id;
 
 -- name: CheckUserPermission :one
 SELECT COUNT(*) > 0 as hasPermission
-FROM role_assignment ra
-JOIN role_permissions rp ON ra.role_permissions_id = rp.id
-JOIN permissions p ON rp.permission_id = p.id
-WHERE ra.assignee_id = $1 
-    AND p.resource = $2 
-    AND p.action = $3
-    AND ra.status = 'active'
-    AND ra.deleted_at IS NULL
-    AND rp.status = 'active'
-    AND rp.deleted_at IS NULL
-    AND (ra.expires_at IS NULL OR ra.expires_at > CURRENT_TIMESTAMP);
\ No newline at end of file
+FROM user_permission_grants(sqlc.arg('assignee_id')) g
+WHERE g.resource = sqlc.arg('resource')
+    AND g.action = sqlc.arg('action');
+
+-- name: GetUserPermissionGrants :many
+-- A grant carries the assignee's tenant, which bounds a tenant-wide scope.
+SELECT
+    g.role_assignment_id,
+    g.role_id,
+    g.role_name,
+    g.scope_id,
+    g.business_unit_id,
+    g.department_id,
+    g.expires_at,
+    g.source_role_id,
+    u.home_tenant_id AS tenant_id
+FROM user_permission_grants(sqlc.arg('assignee_id')) g
+JOIN users u ON u.id = sqlc.arg('assignee_id')
+WHERE g.resource = sqlc.arg('resource')
+    AND g.action = sqlc.arg('action')
+ORDER BY g.depth, g.assigned_at;
+
+-- name: GetRoleAssignmentByID :one
+-- Only assignments of users in the given tenant are found.
+SELECT * FROM role_assignment
+WHERE id = $1 AND deleted_at IS NULL
+    AND assignee_id IN (SELECT u.id FROM users u WHERE u.home_tenant_id = $2);
+
+-- name: GetRoleAssignmentByIDForUpdate :one
+SELECT * FROM role_assignment
+WHERE id = $1 AND deleted_at IS NULL
+    AND assignee_id IN (SELECT u.id FROM users u WHERE u.home_tenant_id = $2)
+FOR UPDATE OF role_assignment;
+
+-- name: UpdateRoleAssignment :one
+UPDATE role_assignment
+SET
+    expires_at = COALESCE(sqlc.narg('expires_at'), expires_at),
+    status = COALESCE(sqlc.narg('status'), status),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RevokeRoleAssignment :one
+UPDATE role_assignment
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ExpireRoleAssignments :many
+UPDATE role_assignment
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP
+WHERE status = 'active'
+    AND deleted_at IS NULL
+    AND expires_at IS NOT NULL
+    AND expires_at <= CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetUserEffectivePermissions :many
+SELECT
+    g.role_assignment_id,
+    g.role_id,
+    g.role_name,
+    g.resource,
+    g.action,
+    g.scope_id,
+    g.business_unit_id,
+    g.department_id,
+    g.expires_at,
+    g.source_role_id,
+    u.home_tenant_id AS tenant_id
+FROM user_permission_grants(sqlc.arg('assignee_id')) g
+JOIN users u ON u.id = sqlc.arg('assignee_id')
+ORDER BY g.resource, g.action, g.depth, g.assigned_at;
@@ -20,6 +20,42 @@ LEFT JOIN scopes s ON rp.scope_id = s.id
 WHERE rp.role_id = $1 AND rp.deleted_at IS NULL
 ORDER BY p.resource, p.action;
 
+-- name: GetResolvedRolePermissions :many
+-- Permissions granted to a role directly (depth 0) or inherited from its ancestors.
+WITH RECURSIVE role_tree AS (
+    SELECT r.id, 0 AS depth, ARRAY[r.id]::VARCHAR[] AS path
+    FROM roles r
+    WHERE r.id = sqlc.arg('role_id')
+    UNION ALL
+    SELECT r.parent_role_id, rt.depth + 1, rt.path || r.parent_role_id
+    FROM role_tree rt
+    JOIN roles r ON r.id = rt.id
+    WHERE r.parent_role_id IS NOT NULL
+        AND NOT r.parent_role_id = ANY(rt.path)
+)
+SELECT 
+    rp.id,
+    rp.role_id,
+    rp.permission_id,
+    rp.scope_id,
+    rp.status,
+    rp.created_at,
+    rp.updated_at,
+    rp.deleted_at,
+    r.name as role_name,
+    p.name as permission_name,
+    p.resource,
+    p.action,
+    s.name as scope_name,
+    rt.depth::INTEGER AS depth
+FROM role_tree rt
+JOIN roles r ON rt.id = r.id AND (rt.depth = 0 OR r.deleted_at IS NULL)
+JOIN role_permissions rp ON rp.role_id = rt.id
+JOIN permissions p ON rp.permission_id = p.id
+LEFT JOIN scopes s ON rp.scope_id = s.id
+WHERE rp.deleted_at IS NULL
+ORDER BY p.resource, p.action, rt.depth;
+
 -- name: GetRolePermissionByID :one
 SELECT 
     rp.id,
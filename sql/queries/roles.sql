@@ -7,9 +7,11 @@ SELECT
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    parent_role_id,
+    tenant_id
 FROM roles 
-WHERE deleted_at IS NULL
+WHERE deleted_at IS NULL AND (tenant_id IS NULL OR tenant_id = $1)
 ORDER BY created_at DESC;
 
 -- name: GetRoleByID :one
@@ -21,9 +23,11 @@ SELECT
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    parent_role_id,
+    tenant_id
 FROM roles 
-WHERE id = $1;
+WHERE id = $1 AND (tenant_id IS NULL OR tenant_id = $2);
 
 -- name: GetSystemRoles :many
 SELECT 
@@ -34,19 +38,49 @@ SELECT
     status,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    parent_role_id,
+    tenant_id
 FROM roles 
-WHERE is_system_role AND deleted_at IS NULL
+WHERE is_system_role AND deleted_at IS NULL AND (tenant_id IS NULL OR tenant_id = $1)
 ORDER BY name ASC;
 
 -- name: CreateRole :one
+-- Roles created through the API belong to the creator's tenant.
 INSERT INTO roles (
     id,
     name,
     description,
     is_system_role,
-    status
+    status,
+    parent_role_id,
+    tenant_id
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, name, description, is_system_role, status, created_at, updated_at, deleted_at, parent_role_id, tenant_id;
+
+-- name: SetRoleParent :one
+-- Shared roles have no tenant and are left alone.
+UPDATE roles
+SET
+    parent_role_id = sqlc.narg('parent_role_id'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND tenant_id = sqlc.arg('tenant_id') AND deleted_at IS NULL
+RETURNING id, name, description, is_system_role, status, created_at, updated_at, deleted_at, parent_role_id, tenant_id;
+
+-- name: GetRoleAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT r.parent_role_id AS id, 1 AS depth, ARRAY[r.id]::VARCHAR[] AS path
+    FROM roles r
+    WHERE r.id = $1 AND r.parent_role_id IS NOT NULL
+    UNION ALL
+    SELECT r.parent_role_id, a.depth + 1, a.path || a.id
+    FROM ancestors a
+    JOIN roles r ON r.id = a.id
+    WHERE r.parent_role_id IS NOT NULL
+        AND NOT r.parent_role_id = ANY(a.path || a.id)
 )
-RETURNING id, name, description, is_system_role, status, created_at, updated_at, deleted_at;
+SELECT id::VARCHAR AS id, depth::INTEGER AS depth
+FROM ancestors
+ORDER BY depth;
@@ -0,0 +1,162 @@
+-- name: GetScimUser :one
+-- Service principals are backed by users rows but are not provisioned over
+-- SCIM, so they are hidden from it.
+SELECT u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id, u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status, u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.id = sqlc.arg('id')
+  AND u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM service_principals sp WHERE sp.user_id = u.id);
+
+-- name: ListScimUsers :many
+SELECT u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id, u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status, u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM service_principals sp WHERE sp.user_id = u.id)
+  AND (sqlc.narg('id')::uuid IS NULL OR u.id = sqlc.narg('id')::uuid)
+  AND (sqlc.narg('user_name')::text IS NULL OR lower(u.mail) = lower(sqlc.narg('user_name')::text))
+  AND (sqlc.narg('external_id')::text IS NULL OR u.azure_ad_object_id = sqlc.narg('external_id')::text)
+  AND (sqlc.narg('display_name')::text IS NULL OR u.display_name = sqlc.narg('display_name')::text)
+ORDER BY u.created_at, u.id
+OFFSET sqlc.arg('page_offset')::int
+LIMIT sqlc.arg('page_limit')::int;
+
+-- name: CountScimUsers :one
+SELECT COUNT(*)
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM service_principals sp WHERE sp.user_id = u.id)
+  AND (sqlc.narg('id')::uuid IS NULL OR u.id = sqlc.narg('id')::uuid)
+  AND (sqlc.narg('user_name')::text IS NULL OR lower(u.mail) = lower(sqlc.narg('user_name')::text))
+  AND (sqlc.narg('external_id')::text IS NULL OR u.azure_ad_object_id = sqlc.narg('external_id')::text)
+  AND (sqlc.narg('display_name')::text IS NULL OR u.display_name = sqlc.narg('display_name')::text);
+
+-- name: ScimUserNameExists :one
+-- Reports whether another live user of the tenant already has the user name,
+-- which SCIM treats as unique and case-insensitive.
+SELECT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+      AND lower(u.mail) = lower(sqlc.arg('user_name'))
+      AND u.deleted_at IS NULL
+      AND (sqlc.narg('exclude_id')::uuid IS NULL OR u.id <> sqlc.narg('exclude_id')::uuid)
+);
+
+-- name: ReplaceScimUser :one
+-- Status is changed separately, so that an administrator's suspension is not
+-- lifted by the next provisioning cycle.
+UPDATE users
+SET
+    azure_ad_object_id = sqlc.arg('azure_ad_object_id'),
+    department_id = sqlc.narg('department_id'),
+    business_unit_id = COALESCE(sqlc.narg('business_unit_id'), business_unit_id),
+    manager_id = sqlc.narg('manager_id'),
+    mail = sqlc.arg('mail'),
+    display_name = sqlc.arg('display_name'),
+    given_name = sqlc.narg('given_name'),
+    sur_name = sqlc.narg('sur_name'),
+    job_title = sqlc.narg('job_title'),
+    office_location = sqlc.narg('office_location'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND home_tenant_id = sqlc.arg('home_tenant_id') AND deleted_at IS NULL
+RETURNING id, azure_ad_object_id, home_tenant_id, department_id, business_unit_id, manager_id, mail, display_name, given_name, sur_name, job_title, office_location, status, last_login, locked_until, created_at, updated_at, deleted_at;
+
+-- name: DeleteScimUser :one
+UPDATE users
+SET
+    status = 'deleted',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND home_tenant_id = sqlc.arg('home_tenant_id') AND deleted_at IS NULL
+RETURNING id, azure_ad_object_id, home_tenant_id, department_id, business_unit_id, manager_id, mail, display_name, given_name, sur_name, job_title, office_location, status, last_login, locked_until, created_at, updated_at, deleted_at;
+
+-- name: GetScimGroup :one
+SELECT * FROM scim_groups
+WHERE id = sqlc.arg('id') AND tenant_id = sqlc.arg('tenant_id');
+
+-- name: ListScimGroups :many
+SELECT g.* FROM scim_groups g
+WHERE g.tenant_id = sqlc.arg('tenant_id')
+  AND (sqlc.narg('id')::uuid IS NULL OR g.id = sqlc.narg('id')::uuid)
+  AND (sqlc.narg('display_name')::text IS NULL OR lower(g.display_name) = lower(sqlc.narg('display_name')::text))
+  AND (sqlc.narg('external_id')::text IS NULL OR g.external_id = sqlc.narg('external_id')::text)
+  AND (sqlc.narg('member_id')::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM scim_group_members m WHERE m.group_id = g.id AND m.user_id = sqlc.narg('member_id')::uuid
+  ))
+ORDER BY g.created_at, g.id
+OFFSET sqlc.arg('page_offset')::int
+LIMIT sqlc.arg('page_limit')::int;
+
+-- name: CountScimGroups :one
+SELECT COUNT(*) FROM scim_groups g
+WHERE g.tenant_id = sqlc.arg('tenant_id')
+  AND (sqlc.narg('id')::uuid IS NULL OR g.id = sqlc.narg('id')::uuid)
+  AND (sqlc.narg('display_name')::text IS NULL OR lower(g.display_name) = lower(sqlc.narg('display_name')::text))
+  AND (sqlc.narg('external_id')::text IS NULL OR g.external_id = sqlc.narg('external_id')::text)
+  AND (sqlc.narg('member_id')::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM scim_group_members m WHERE m.group_id = g.id AND m.user_id = sqlc.narg('member_id')::uuid
+  ));
+
+-- name: ScimGroupNameExists :one
+SELECT EXISTS (
+    SELECT 1 FROM scim_groups g
+    WHERE g.tenant_id = sqlc.arg('tenant_id')
+      AND lower(g.display_name) = lower(sqlc.arg('display_name'))
+      AND (sqlc.narg('exclude_id')::uuid IS NULL OR g.id <> sqlc.narg('exclude_id')::uuid)
+);
+
+-- name: CreateScimGroup :one
+INSERT INTO scim_groups (
+    tenant_id,
+    external_id,
+    display_name
+) VALUES (
+    sqlc.arg('tenant_id'), sqlc.narg('external_id'), sqlc.arg('display_name')
+)
+RETURNING *;
+
+-- name: UpdateScimGroup :one
+UPDATE scim_groups
+SET
+    external_id = sqlc.narg('external_id'),
+    display_name = sqlc.arg('display_name'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND tenant_id = sqlc.arg('tenant_id')
+RETURNING *;
+
+-- name: DeleteScimGroup :one
+DELETE FROM scim_groups
+WHERE id = sqlc.arg('id') AND tenant_id = sqlc.arg('tenant_id')
+RETURNING *;
+
+-- name: ListScimGroupMembers :many
+SELECT u.id, u.display_name
+FROM scim_group_members m
+JOIN users u ON u.id = m.user_id AND u.deleted_at IS NULL
+WHERE m.group_id = sqlc.arg('group_id')
+ORDER BY m.created_at, u.id;
+
+-- name: AddScimGroupMember :exec
+INSERT INTO scim_group_members (group_id, user_id)
+VALUES (sqlc.arg('group_id'), sqlc.arg('user_id'))
+ON CONFLICT (group_id, user_id) DO NOTHING;
+
+-- name: RemoveScimGroupMember :exec
+DELETE FROM scim_group_members
+WHERE group_id = sqlc.arg('group_id') AND user_id = sqlc.arg('user_id');
+
+-- name: RemoveScimGroupMembers :exec
+DELETE FROM scim_group_members
+WHERE group_id = sqlc.arg('group_id');
+
+-- name: RemoveUserScimGroupMemberships :exec
+DELETE FROM scim_group_members
+WHERE user_id = sqlc.arg('user_id');
+
+-- name: ListUserScimGroups :many
+SELECT g.* FROM scim_groups g
+JOIN scim_group_members m ON m.group_id = g.id
+WHERE m.user_id = sqlc.arg('user_id')
+ORDER BY g.display_name, g.id;
@@ -0,0 +1,148 @@
+-- name: ListServicePrincipals :many
+SELECT
+    sp.id,
+    sp.user_id,
+    sp.display_name,
+    sp.description,
+    sp.app_id,
+    sp.created_by,
+    sp.created_at,
+    sp.updated_at,
+    sp.deleted_at,
+    u.azure_ad_object_id AS object_id,
+    (
+        SELECT COUNT(*) FROM api_keys ak
+        WHERE ak.service_principal_id = sp.id
+            AND ak.revoked_at IS NULL
+            AND (ak.expires_at IS NULL OR ak.expires_at > CURRENT_TIMESTAMP)
+    ) AS active_api_keys
+FROM service_principals sp
+JOIN users u ON sp.user_id = u.id
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+    AND sp.deleted_at IS NULL
+    AND (sqlc.narg('id')::uuid IS NULL OR sp.id = sqlc.narg('id'))
+ORDER BY sp.display_name;
+
+-- name: CreateServicePrincipal :one
+INSERT INTO service_principals (
+    user_id,
+    display_name,
+    description,
+    app_id,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: DeleteServicePrincipal :one
+UPDATE service_principals sp
+SET
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+FROM users u
+WHERE sp.id = $1
+    AND sp.user_id = u.id
+    AND u.home_tenant_id = $2
+    AND sp.deleted_at IS NULL
+RETURNING sp.*;
+
+-- name: DeactivateServicePrincipalUser :exec
+-- Removes the backing users row from authentication and drops its role
+-- assignments once the service principal is deleted.
+WITH revoked AS (
+    UPDATE role_assignment
+    SET
+        status = 'inactive',
+        updated_at = CURRENT_TIMESTAMP,
+        deleted_at = CURRENT_TIMESTAMP
+    WHERE assignee_id = sqlc.arg('user_id') AND deleted_at IS NULL
+)
+UPDATE users
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('user_id');
+
+-- name: ListAPIKeys :many
+SELECT ak.* FROM api_keys ak
+JOIN service_principals sp ON ak.service_principal_id = sp.id
+JOIN users u ON sp.user_id = u.id
+WHERE ak.service_principal_id = $1 AND u.home_tenant_id = $2
+ORDER BY ak.created_at DESC;
+
+-- name: GetAPIKeyByID :one
+SELECT ak.* FROM api_keys ak
+JOIN service_principals sp ON ak.service_principal_id = sp.id
+JOIN users u ON sp.user_id = u.id
+WHERE ak.id = $1 AND ak.service_principal_id = $2 AND u.home_tenant_id = $3;
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    service_principal_id,
+    name,
+    key_prefix,
+    key_hash,
+    expires_at,
+    rotated_from,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: RevokeAPIKey :one
+UPDATE api_keys ak
+SET
+    revoked_at = CURRENT_TIMESTAMP,
+    revoked_by = $3
+FROM service_principals sp
+JOIN users u ON sp.user_id = u.id
+WHERE ak.id = $1
+    AND ak.service_principal_id = $2
+    AND sp.id = ak.service_principal_id
+    AND u.home_tenant_id = $4
+    AND ak.revoked_at IS NULL
+RETURNING ak.*;
+
+-- name: ShortenAPIKeyExpiry :exec
+-- Lets a rotated key keep working for a grace period; never extends its expiry.
+UPDATE api_keys
+SET expires_at = LEAST(COALESCE(expires_at, sqlc.arg('expires_at')::timestamptz), sqlc.arg('expires_at')::timestamptz)
+WHERE id = sqlc.arg('id') AND revoked_at IS NULL;
+
+-- name: RevokeServicePrincipalAPIKeys :execrows
+UPDATE api_keys
+SET
+    revoked_at = CURRENT_TIMESTAMP,
+    revoked_by = $2
+WHERE service_principal_id = $1 AND revoked_at IS NULL;
+
+-- name: GetActiveAPIKeyByPrefix :one
+-- Resolves an API key to the identity of its service principal. Revoked and
+-- expired keys, and keys of deleted principals, are not returned.
+SELECT
+    ak.id,
+    ak.key_hash,
+    sp.id AS service_principal_id,
+    u.azure_ad_object_id,
+    u.home_tenant_id,
+    u.display_name
+FROM api_keys ak
+JOIN service_principals sp ON ak.service_principal_id = sp.id
+JOIN users u ON sp.user_id = u.id
+WHERE ak.key_prefix = $1
+    AND ak.revoked_at IS NULL
+    AND (ak.expires_at IS NULL OR ak.expires_at > CURRENT_TIMESTAMP)
+    AND sp.deleted_at IS NULL
+    AND u.deleted_at IS NULL;
+
+-- name: TouchAPIKeyLastUsed :exec
+-- Records key usage at most once a minute to keep authentication read-mostly.
+UPDATE api_keys
+SET
+    last_used_at = CURRENT_TIMESTAMP,
+    last_used_ip = $2
+WHERE id = $1
+    AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute');
@@ -0,0 +1,103 @@
+-- name: ListSodConstraints :many
+SELECT * FROM sod_constraints
+WHERE deleted_at IS NULL
+ORDER BY name;
+
+-- name: GetSodConstraintByID :one
+SELECT * FROM sod_constraints
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: CreateSodConstraint :one
+INSERT INTO sod_constraints (
+    name,
+    description,
+    role_a_id,
+    role_b_id,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: DeleteSodConstraint :one
+UPDATE sod_constraints
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP,
+    deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: LockSodAssignee :exec
+-- Serializes separation-of-duties checks for one assignee until the end of the
+-- transaction, so concurrent grants cannot each miss the other.
+SELECT id FROM users
+WHERE id = $1
+FOR NO KEY UPDATE;
+
+-- name: GetSodConflicts :many
+-- Active constraints that assigning role_id to the assignee would violate, given
+-- the roles the assignee already holds. Both sides include inherited roles.
+WITH RECURSIVE held AS (
+    SELECT rp.role_id, ARRAY[rp.role_id]::VARCHAR[] AS path
+    FROM role_assignment ra
+    JOIN role_permissions rp ON ra.role_permissions_id = rp.id
+    WHERE ra.assignee_id = sqlc.arg('assignee_id')
+        AND ra.status = 'active'
+        AND ra.deleted_at IS NULL
+        AND (ra.expires_at IS NULL OR ra.expires_at > CURRENT_TIMESTAMP)
+    UNION ALL
+    SELECT r.parent_role_id, h.path || r.parent_role_id
+    FROM held h
+    JOIN roles r ON r.id = h.role_id
+    WHERE r.parent_role_id IS NOT NULL
+        AND NOT r.parent_role_id = ANY(h.path)
+),
+requested AS (
+    SELECT sqlc.arg('role_id')::VARCHAR AS role_id, ARRAY[sqlc.arg('role_id')::VARCHAR] AS path
+    UNION ALL
+    SELECT r.parent_role_id, rq.path || r.parent_role_id
+    FROM requested rq
+    JOIN roles r ON r.id = rq.role_id
+    WHERE r.parent_role_id IS NOT NULL
+        AND NOT r.parent_role_id = ANY(rq.path)
+)
+SELECT DISTINCT c.id, c.name, c.description, c.role_a_id, c.role_b_id, c.created_by, c.status, c.created_at, c.updated_at, c.deleted_at
+FROM sod_constraints c
+JOIN requested rq ON rq.role_id IN (c.role_a_id, c.role_b_id)
+JOIN held h ON h.role_id IN (c.role_a_id, c.role_b_id) AND h.role_id <> rq.role_id
+WHERE c.status = 'active' AND c.deleted_at IS NULL
+ORDER BY c.name;
+
+-- name: GetSodViolations :many
+-- Users whose current assignments hold both roles of an active constraint.
+WITH RECURSIVE held AS (
+    SELECT ra.assignee_id, ra.id AS role_assignment_id, rp.role_id, ARRAY[rp.role_id]::VARCHAR[] AS path
+    FROM role_assignment ra
+    JOIN role_permissions rp ON ra.role_permissions_id = rp.id
+    WHERE ra.status = 'active'
+        AND ra.deleted_at IS NULL
+        AND (ra.expires_at IS NULL OR ra.expires_at > CURRENT_TIMESTAMP)
+    UNION ALL
+    SELECT h.assignee_id, h.role_assignment_id, r.parent_role_id, h.path || r.parent_role_id
+    FROM held h
+    JOIN roles r ON r.id = h.role_id
+    WHERE r.parent_role_id IS NOT NULL
+        AND NOT r.parent_role_id = ANY(h.path)
+)
+SELECT DISTINCT
+    c.id AS constraint_id,
+    c.name AS constraint_name,
+    c.role_a_id,
+    c.role_b_id,
+    u.id AS user_id,
+    u.display_name,
+    u.mail,
+    ha.role_assignment_id AS role_a_assignment_id,
+    hb.role_assignment_id AS role_b_assignment_id
+FROM sod_constraints c
+JOIN held ha ON ha.role_id = c.role_a_id
+JOIN held hb ON hb.role_id = c.role_b_id AND hb.assignee_id = ha.assignee_id
+JOIN users u ON u.id = ha.assignee_id
+WHERE c.status = 'active' AND c.deleted_at IS NULL
+ORDER BY c.name, u.display_name;
@@ -19,8 +19,13 @@ SELECT
     updated_at,
     deleted_at
 FROM users 
-WHERE department_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC;
+WHERE department_id = $1 AND home_tenant_id = $2 AND deleted_at IS NULL
+ORDER BY created_at DESC, id
+LIMIT $3 OFFSET $4;
+
+-- name: CountUsersInDepartment :one
+SELECT COUNT(*) FROM users
+WHERE department_id = $1 AND home_tenant_id = $2 AND deleted_at IS NULL;
 
 -- name: GetUserByID :one
 SELECT 
@@ -43,7 +48,7 @@ SELECT
     updated_at,
     deleted_at
 FROM users 
-WHERE id = $1;
+WHERE id = $1 AND home_tenant_id = $2;
 
 -- name: GetUserByEmail :one
 SELECT 
@@ -66,7 +71,31 @@ SELECT
     updated_at,
     deleted_at
 FROM users 
-WHERE mail = $1;
+WHERE mail = $1 AND home_tenant_id = $2;
+
+-- name: GetUserByAzureADObjectID :one
+SELECT 
+    id,
+    azure_ad_object_id,
+    home_tenant_id,
+    department_id,
+    business_unit_id,
+    manager_id,
+    mail,
+    display_name,
+    given_name,
+    sur_name,
+    job_title,
+    office_location,
+    status,
+    last_login,
+    locked_until,
+    created_at,
+    updated_at,
+    deleted_at
+FROM users 
+WHERE azure_ad_object_id = $1 AND deleted_at IS NULL
+LIMIT 1;
 
 -- name: CreateUser :one
 INSERT INTO users (
@@ -92,4 +121,4 @@ UPDATE users
 SET 
     last_login = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP
-WHERE mail = $1;
+WHERE mail = $1 AND home_tenant_id = $2;
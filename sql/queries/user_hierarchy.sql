@@ -0,0 +1,83 @@
+-- name: GetManagementChain :many
+-- Walks manager_id upwards from a user: depth 1 is the direct manager. The
+-- path guards against cycles in manager links.
+WITH RECURSIVE chain AS (
+    SELECT
+        m.id, m.manager_id, m.department_id, m.business_unit_id, m.display_name,
+        m.mail, m.job_title, m.status, 1 AS depth, ARRAY[u.id, m.id] AS path
+    FROM users u
+    JOIN users m ON m.id = u.manager_id
+    WHERE u.id = sqlc.arg('id')
+      AND u.home_tenant_id = sqlc.arg('home_tenant_id')
+      AND u.deleted_at IS NULL
+      AND m.home_tenant_id = sqlc.arg('home_tenant_id')
+      AND m.deleted_at IS NULL
+    UNION ALL
+    SELECT
+        m.id, m.manager_id, m.department_id, m.business_unit_id, m.display_name,
+        m.mail, m.job_title, m.status, c.depth + 1, c.path || m.id
+    FROM chain c
+    JOIN users m ON m.id = c.manager_id
+    WHERE m.home_tenant_id = sqlc.arg('home_tenant_id')
+      AND m.deleted_at IS NULL
+      AND c.depth < sqlc.arg('max_depth')::int
+      AND NOT m.id = ANY(c.path)
+)
+SELECT id, manager_id, department_id, business_unit_id, display_name, mail, job_title, status, depth::int AS depth
+FROM chain
+ORDER BY depth;
+
+-- name: ListReports :many
+-- Walks manager_id downwards from a manager: depth 1 are the direct reports.
+WITH RECURSIVE reports AS (
+    SELECT
+        u.id, u.manager_id, u.department_id, u.business_unit_id, u.display_name,
+        u.mail, u.job_title, u.status, 1 AS depth, ARRAY[sqlc.arg('manager_id')::uuid, u.id] AS path
+    FROM users u
+    WHERE u.manager_id = sqlc.arg('manager_id')
+      AND u.home_tenant_id = sqlc.arg('home_tenant_id')
+      AND u.deleted_at IS NULL
+    UNION ALL
+    SELECT
+        u.id, u.manager_id, u.department_id, u.business_unit_id, u.display_name,
+        u.mail, u.job_title, u.status, r.depth + 1, r.path || u.id
+    FROM reports r
+    JOIN users u ON u.manager_id = r.id
+    WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+      AND u.deleted_at IS NULL
+      AND r.depth < sqlc.arg('max_depth')::int
+      AND NOT u.id = ANY(r.path)
+)
+SELECT id, manager_id, department_id, business_unit_id, display_name, mail, job_title, status, depth::int AS depth
+FROM reports
+ORDER BY depth, display_name;
+
+-- name: ListOrgChartUsers :many
+-- Builds the org chart of a department and/or business unit. Roots are the
+-- members whose manager is not a member; depth 1 are the roots.
+WITH RECURSIVE members AS (
+    SELECT id, manager_id, department_id, business_unit_id, display_name, mail, job_title, status
+    FROM users
+    WHERE home_tenant_id = sqlc.arg('home_tenant_id')
+      AND deleted_at IS NULL
+      AND (sqlc.narg('department_id')::uuid IS NULL OR department_id = sqlc.narg('department_id')::uuid)
+      AND (sqlc.narg('business_unit_id')::uuid IS NULL OR business_unit_id = sqlc.narg('business_unit_id')::uuid)
+), chart AS (
+    SELECT
+        m.id, m.manager_id, m.department_id, m.business_unit_id, m.display_name,
+        m.mail, m.job_title, m.status, 1 AS depth, ARRAY[m.id] AS path
+    FROM members m
+    WHERE m.manager_id IS NULL
+       OR NOT EXISTS (SELECT 1 FROM members p WHERE p.id = m.manager_id)
+    UNION ALL
+    SELECT
+        m.id, m.manager_id, m.department_id, m.business_unit_id, m.display_name,
+        m.mail, m.job_title, m.status, c.depth + 1, c.path || m.id
+    FROM chart c
+    JOIN members m ON m.manager_id = c.id
+    WHERE c.depth < sqlc.arg('max_depth')::int
+      AND NOT m.id = ANY(c.path)
+)
+SELECT id, manager_id, department_id, business_unit_id, display_name, mail, job_title, status, depth::int AS depth
+FROM chart
+ORDER BY depth, display_name;
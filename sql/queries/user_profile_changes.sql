@@ -0,0 +1,37 @@
+-- name: UpdateUserProfile :one
+-- Applies a profile refreshed from the identity provider. Status is left
+-- alone so suspensions survive the next login.
+UPDATE users
+SET
+    department_id = sqlc.narg('department_id'),
+    business_unit_id = sqlc.narg('business_unit_id'),
+    manager_id = sqlc.narg('manager_id'),
+    mail = sqlc.arg('mail'),
+    display_name = sqlc.arg('display_name'),
+    given_name = sqlc.narg('given_name'),
+    sur_name = sqlc.narg('sur_name'),
+    job_title = sqlc.narg('job_title'),
+    office_location = sqlc.narg('office_location'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND home_tenant_id = sqlc.arg('home_tenant_id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CreateUserProfileChange :exec
+INSERT INTO user_profile_changes (
+    user_id,
+    field,
+    old_value,
+    new_value
+) VALUES (
+    sqlc.arg('user_id'), sqlc.arg('field'), sqlc.narg('old_value'), sqlc.narg('new_value')
+);
+
+-- name: ListUserProfileChanges :many
+SELECT * FROM user_profile_changes
+WHERE user_id = sqlc.arg('user_id')
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountUserProfileChanges :one
+SELECT COUNT(*) FROM user_profile_changes
+WHERE user_id = sqlc.arg('user_id');
@@ -0,0 +1,173 @@
+-- The search queries return one keyset page of the tenant's users, one query
+-- per sort so each ORDER BY matches an index from the user_search and
+-- user_search_sort migrations. after_key and after_id are the sort value and
+-- id of the previous page's last user; id breaks ties. search is a to_tsquery
+-- expression over display name, mail and job title.
+
+-- name: SearchUsersByDisplayName :many
+-- Orders by display name.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (lower(u.display_name), u.id) > (lower(sqlc.narg('after_key')::text), sqlc.narg('after_id')::uuid))
+ORDER BY lower(u.display_name), u.id
+LIMIT sqlc.arg('page_limit');
+
+-- name: SearchUsersByDisplayNameDesc :many
+-- Orders by display name, descending.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (lower(u.display_name), u.id) < (lower(sqlc.narg('after_key')::text), sqlc.narg('after_id')::uuid))
+ORDER BY lower(u.display_name) DESC, u.id DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: SearchUsersByMail :many
+-- Orders by mail.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (lower(u.mail), u.id) > (lower(sqlc.narg('after_key')::text), sqlc.narg('after_id')::uuid))
+ORDER BY lower(u.mail), u.id
+LIMIT sqlc.arg('page_limit');
+
+-- name: SearchUsersByMailDesc :many
+-- Orders by mail, descending.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (lower(u.mail), u.id) < (lower(sqlc.narg('after_key')::text), sqlc.narg('after_id')::uuid))
+ORDER BY lower(u.mail) DESC, u.id DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: SearchUsersByCreatedAt :many
+-- Orders by creation time.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (u.created_at, u.id) > (sqlc.narg('after_key')::text::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY u.created_at, u.id
+LIMIT sqlc.arg('page_limit');
+
+-- name: SearchUsersByCreatedAtDesc :many
+-- Orders by creation time, descending.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (u.created_at, u.id) < (sqlc.narg('after_key')::text::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY u.created_at DESC, u.id DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: SearchUsersByLastLogin :many
+-- Orders by last login; users who never logged in come first.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (COALESCE(u.last_login, '-infinity'::timestamptz), u.id) > (sqlc.narg('after_key')::text::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY COALESCE(u.last_login, '-infinity'::timestamptz), u.id
+LIMIT sqlc.arg('page_limit');
+
+-- name: SearchUsersByLastLoginDesc :many
+-- Orders by last login, descending; users who never logged in come last.
+SELECT
+    u.id, u.azure_ad_object_id, u.home_tenant_id, u.department_id, u.business_unit_id, u.manager_id,
+    u.mail, u.display_name, u.given_name, u.sur_name, u.job_title, u.office_location, u.status,
+    u.last_login, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+FROM users u
+WHERE u.home_tenant_id = sqlc.arg('home_tenant_id')
+  AND u.deleted_at IS NULL
+  AND (sqlc.narg('search')::text IS NULL
+       OR to_tsvector('simple', u.display_name || ' ' || u.mail || ' ' || COALESCE(u.job_title, ''))
+          @@ to_tsquery('simple', sqlc.narg('search')::text))
+  AND (sqlc.narg('department_id')::uuid IS NULL OR u.department_id = sqlc.narg('department_id')::uuid)
+  AND (sqlc.narg('business_unit_id')::uuid IS NULL OR u.business_unit_id = sqlc.narg('business_unit_id')::uuid)
+  AND (sqlc.narg('status')::status_enum IS NULL OR u.status = sqlc.narg('status')::status_enum)
+  AND (sqlc.narg('manager_id')::uuid IS NULL OR u.manager_id = sqlc.narg('manager_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR (COALESCE(u.last_login, '-infinity'::timestamptz), u.id) < (sqlc.narg('after_key')::text::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY COALESCE(u.last_login, '-infinity'::timestamptz) DESC, u.id DESC
+LIMIT sqlc.arg('page_limit');
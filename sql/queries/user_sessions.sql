@@ -0,0 +1,46 @@
+-- name: CreateUserSession :one
+INSERT INTO user_sessions (
+    object_id,
+    tenant_id,
+    access_token,
+    access_token_expires_at,
+    refresh_token,
+    csrf_token,
+    ip_address,
+    user_agent,
+    expires_at
+) VALUES (
+    sqlc.arg('object_id'), sqlc.arg('tenant_id'), sqlc.arg('access_token'), sqlc.arg('access_token_expires_at'),
+    sqlc.arg('refresh_token'), sqlc.arg('csrf_token'), sqlc.narg('ip_address'), sqlc.narg('user_agent'), sqlc.arg('expires_at')
+)
+RETURNING *;
+
+-- name: GetActiveUserSession :one
+SELECT * FROM user_sessions
+WHERE id = sqlc.arg('id')
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: UpdateUserSessionTokens :exec
+-- Stores the tokens obtained with the refresh token. Entra may or may not
+-- rotate the refresh token, so a NULL keeps the current one.
+UPDATE user_sessions
+SET
+    access_token = sqlc.arg('access_token'),
+    access_token_expires_at = sqlc.arg('access_token_expires_at'),
+    refresh_token = COALESCE(sqlc.narg('refresh_token'), refresh_token),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id');
+
+-- name: RevokeUserSession :exec
+UPDATE user_sessions
+SET
+    revoked_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND revoked_at IS NULL;
+
+-- name: DeleteStaleUserSessions :execrows
+-- Removes sessions that ended more than a day ago.
+DELETE FROM user_sessions
+WHERE expires_at < CURRENT_TIMESTAMP - INTERVAL '1 day'
+   OR revoked_at < CURRENT_TIMESTAMP - INTERVAL '1 day';
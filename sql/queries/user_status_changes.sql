@@ -0,0 +1,67 @@
+-- name: GetUserAccountStatus :one
+-- Resolves the account state checked by authentication. Users that are not
+-- provisioned yet have no row; deleted users are returned so they can be
+-- blocked, unless a live row exists for the same object ID.
+SELECT id, status, locked_until, deleted_at
+FROM users
+WHERE azure_ad_object_id = sqlc.arg('azure_ad_object_id')
+  AND home_tenant_id = sqlc.arg('home_tenant_id')
+ORDER BY deleted_at IS NOT NULL, deleted_at DESC
+LIMIT 1;
+
+-- name: SuspendUser :one
+UPDATE users
+SET
+    status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND home_tenant_id = sqlc.arg('home_tenant_id') AND deleted_at IS NULL
+RETURNING id, azure_ad_object_id, home_tenant_id, department_id, business_unit_id, manager_id, mail, display_name, given_name, sur_name, job_title, office_location, status, last_login, locked_until, created_at, updated_at, deleted_at;
+
+-- name: LockUser :one
+UPDATE users
+SET
+    locked_until = sqlc.arg('locked_until'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND home_tenant_id = sqlc.arg('home_tenant_id') AND deleted_at IS NULL
+RETURNING id, azure_ad_object_id, home_tenant_id, department_id, business_unit_id, manager_id, mail, display_name, given_name, sur_name, job_title, office_location, status, last_login, locked_until, created_at, updated_at, deleted_at;
+
+-- name: UnlockUser :one
+UPDATE users
+SET
+    status = 'active',
+    locked_until = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND home_tenant_id = sqlc.arg('home_tenant_id') AND deleted_at IS NULL
+RETURNING id, azure_ad_object_id, home_tenant_id, department_id, business_unit_id, manager_id, mail, display_name, given_name, sur_name, job_title, office_location, status, last_login, locked_until, created_at, updated_at, deleted_at;
+
+-- name: CreateUserStatusChange :one
+INSERT INTO user_status_changes (
+    user_id,
+    action,
+    reason,
+    locked_until,
+    changed_by
+) VALUES (
+    sqlc.arg('user_id'), sqlc.arg('action'), sqlc.arg('reason'), sqlc.narg('locked_until'), sqlc.narg('changed_by')
+)
+RETURNING *;
+
+-- name: ListUserStatusChanges :many
+SELECT
+    usc.id,
+    usc.user_id,
+    usc.action,
+    usc.reason,
+    usc.locked_until,
+    usc.changed_by,
+    cb.display_name AS changed_by_name,
+    usc.created_at
+FROM user_status_changes usc
+LEFT JOIN users cb ON cb.id = usc.changed_by
+WHERE usc.user_id = sqlc.arg('user_id')
+ORDER BY usc.created_at DESC, usc.id
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountUserStatusChanges :one
+SELECT COUNT(*) FROM user_status_changes
+WHERE user_id = sqlc.arg('user_id');